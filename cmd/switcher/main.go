@@ -1,49 +1,128 @@
 package main
 
 import (
+	"context"
+	"crypto/rand"
 	"database/sql"
-	"log"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/NachoGz/switcher-backend-go/internal/asyncapi"
 	"github.com/NachoGz/switcher-backend-go/internal/board"
+	"github.com/NachoGz/switcher-backend-go/internal/bot"
+	"github.com/NachoGz/switcher-backend-go/internal/chat"
+	"github.com/NachoGz/switcher-backend-go/internal/config"
 	"github.com/NachoGz/switcher-backend-go/internal/database"
 	"github.com/NachoGz/switcher-backend-go/internal/figureCard"
 	"github.com/NachoGz/switcher-backend-go/internal/game"
+	"github.com/NachoGz/switcher-backend-go/internal/gameEvent"
 	gameState "github.com/NachoGz/switcher-backend-go/internal/game_state"
+	"github.com/NachoGz/switcher-backend-go/internal/gameplay"
 	"github.com/NachoGz/switcher-backend-go/internal/handlers"
+	"github.com/NachoGz/switcher-backend-go/internal/health"
+	"github.com/NachoGz/switcher-backend-go/internal/invite"
+	"github.com/NachoGz/switcher-backend-go/internal/janitor"
+	"github.com/NachoGz/switcher-backend-go/internal/lobby"
+	"github.com/NachoGz/switcher-backend-go/internal/lobbyFeed"
+	"github.com/NachoGz/switcher-backend-go/internal/logging"
+	"github.com/NachoGz/switcher-backend-go/internal/matchmaking"
+	"github.com/NachoGz/switcher-backend-go/internal/metrics"
 	"github.com/NachoGz/switcher-backend-go/internal/middleware"
 	"github.com/NachoGz/switcher-backend-go/internal/movementCard"
+	"github.com/NachoGz/switcher-backend-go/internal/openapi"
+	"github.com/NachoGz/switcher-backend-go/internal/partialMovements"
 	"github.com/NachoGz/switcher-backend-go/internal/player"
+	"github.com/NachoGz/switcher-backend-go/internal/rating"
+	"github.com/NachoGz/switcher-backend-go/internal/ruleSet"
+	"github.com/NachoGz/switcher-backend-go/internal/stats"
+	"github.com/NachoGz/switcher-backend-go/internal/user"
 	"github.com/NachoGz/switcher-backend-go/internal/websocket"
-	"github.com/joho/godotenv"
+	"github.com/NachoGz/switcher-backend-go/sql/schema"
 	_ "github.com/lib/pq"
 )
 
 func main() {
-	err := godotenv.Load(".env")
+	// Subcommands go before the flags, as in "switcher config print"
+	args := os.Args[1:]
+	printConfig := false
+	if len(args) > 0 && args[0] == "config" {
+		if len(args) < 2 || args[1] != "print" {
+			fmt.Fprintln(os.Stderr, "usage: switcher config print [-config file.yaml]")
+			os.Exit(2)
+		}
+		printConfig = true
+		args = args[2:]
+	}
+
+	flags := flag.NewFlagSet("switcher", flag.ExitOnError)
+	configFile := flags.String("config", os.Getenv("CONFIG_FILE"), "optional YAML configuration file, overridden by the environment")
+	flags.Parse(args)
+
+	cfg, err := config.Load(config.Sources{EnvFile: ".env", File: *configFile})
 	if err != nil {
-		log.Printf("warning: assuming default configuration. .env unreadable: %v", err)
+		fatal("Error loading configuration", "error", err)
 	}
 
-	port := os.Getenv("PORT")
-	if port == "" {
-		log.Fatal("PORT environment variable is not set")
+	if printConfig {
+		if err := config.Print(os.Stdout, cfg); err != nil {
+			fatal("Error printing configuration", "error", err)
+		}
+		if err := cfg.Validate(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
 	}
 
-	dbURL := os.Getenv("DB_URL")
-	if dbURL == "" {
-		log.Fatal("DB_URL environment variable is not set")
+	if err := cfg.Validate(); err != nil {
+		fatal("Invalid configuration", "error", err)
 	}
 
-	dbConn, err := sql.Open("postgres", dbURL)
+	// JSON logs at the configured level
+	logLevel, _ := logging.ParseLevel(cfg.LogLevel)
+	slog.SetDefault(logging.New(os.Stdout, logLevel))
+
+	// Invites are signed with this secret. A random one means invites
+	// stop working when the server restarts
+	tokenSecret := []byte(cfg.TokenSecret)
+	if len(tokenSecret) == 0 {
+		slog.Warn("TOKEN_SECRET is not set, using a random secret")
+		tokenSecret = make([]byte, 32)
+		if _, err := rand.Read(tokenSecret); err != nil {
+			fatal("Error generating token secret", "error", err)
+		}
+	}
+
+	allowedOrigins, err := cfg.Origins()
 	if err != nil {
-		log.Fatalf("error opening database connection: %v", err)
+		fatal("Invalid allowed origins", "error", err)
+	}
+	websocket.SetLimits(cfg.WebsocketLimits())
+	websocket.SetAllowedOrigins(allowedOrigins)
+	if err := ruleSet.SetClassicTurnDuration(int(cfg.TurnDuration / time.Second)); err != nil {
+		fatal("Invalid turn duration", "error", err)
+	}
+
+	dbConn, err := sql.Open("postgres", cfg.DBURL)
+	if err != nil {
+		fatal("Error opening database connection", "error", err)
 	}
 	defer dbConn.Close()
+	dbConn.SetMaxOpenConns(cfg.Database.MaxOpenConns)
+	dbConn.SetMaxIdleConns(cfg.Database.MaxIdleConns)
+	dbConn.SetConnMaxLifetime(cfg.Database.ConnMaxLifetime)
+	dbConn.SetConnMaxIdleTime(cfg.Database.ConnMaxIdleTime)
 
-	// Create database queries
-	dbQueries := database.New(dbConn)
+	// Create database queries, timed by query name. Queries made inside
+	// database.InTx run in its transaction
+	dbQueries := database.New(metrics.InstrumentDB(database.ContextDB(dbConn)))
 
 	// Create repositories
 	gameRepo := game.NewGameRepository(dbQueries)
@@ -52,6 +131,11 @@ func main() {
 	boardRepo := board.NewBoardRepository(dbQueries, dbConn)
 	movementCardRepo := movementCard.NewMovementCardRepository(dbQueries)
 	figureCardRepo := figureCard.NewFigureCardRepository(dbQueries)
+	chatRepo := chat.NewChatRepository(dbQueries)
+	gameEventRepo := gameEvent.NewGameEventRepository(dbQueries)
+	partialMovRepo := partialMovements.NewPartialMovementRepository(dbQueries)
+	userRepo := user.NewUserRepository(dbQueries)
+	statsRepo := stats.NewStatsRepository(dbQueries, dbConn)
 
 	// Create services
 	gameStateService := gameState.NewService(gameStateRepo, playerRepo)
@@ -60,47 +144,226 @@ func main() {
 	boardService := board.NewService(boardRepo)
 	movementCardService := movementCard.NewService(movementCardRepo, playerRepo)
 	figureCardService := figureCard.NewService(figureCardRepo, playerRepo)
+	gameEventService := gameEvent.NewService(gameEventRepo, boardRepo, movementCardRepo, figureCardRepo)
+	chatService := chat.NewService(chatRepo, playerRepo,
+		chat.NewProfanityFilter(cfg.ChatBlockedWords),
+		chat.LinkFilter{},
+	)
+	partialMovementService := partialMovements.NewService(partialMovRepo, boardRepo, movementCardRepo, gameEventService)
+	userService := user.NewService(userRepo)
+	inviteService := invite.NewService(gameService, gameStateService, playerService, tokenSecret, cfg.InviteBaseURL)
+	statsService := stats.NewService(statsRepo, gameService, playerService, gameEventService, userService)
+	ratingService := rating.NewService(playerService, userService, dbConn)
+
+	// Stop on SIGINT or SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
 	// Create WebSocket server
 	wsHub := websocket.NewHub()
 	go wsHub.Run()
 
+	// Readiness fails when any of these do
+	healthService := health.NewService(health.ReadBuildInfo())
+	healthService.AddCheck("database", health.DatabaseCheck(dbConn))
+	healthService.AddCheck("migrations", health.MigrationsCheck(dbConn, schema.Migrations))
+	healthService.AddCheck("hub", wsHub.Ping)
+
+	// Services that broadcast to the games
+	lobbyFeedService := lobbyFeed.NewService(gameService, wsHub)
+	gameplayService := gameplay.NewService(gameService, gameStateService, playerService, boardRepo,
+		movementCardService, movementCardRepo, figureCardService, figureCardRepo,
+		partialMovRepo, partialMovementService, gameEventService, wsHub, dbConn)
+	botService := bot.NewService(gameService, gameStateService, playerService, gameplayService, bot.THINK_DELAY)
+	gameplayService.OnGameFinished(statsService.HandleGameFinished)
+	gameplayService.OnGameFinished(ratingService.HandleGameFinished)
+	lobbyService := lobby.NewService(gameService, gameStateService, playerService, boardService,
+		movementCardService, figureCardService, gameEventService, gameplayService, lobbyFeedService, wsHub, dbConn, lobby.AUTO_START_COUNTDOWN)
+	matchmakingService := matchmaking.NewService(gameService, playerService, lobbyService, ratingService, wsHub)
+	go matchmakingService.Run(ctx)
+	janitorService := janitor.NewService(gameService, gameplayService, lobbyFeedService, wsHub, userService, cfg.JanitorConfig(), time.Now)
+	gameplayService.OnGameFinished(janitorService.HandleGameFinished)
+	go janitorService.Run(ctx)
+
 	// Create handlers
-	gameHandlers := handlers.NewGameHandlers(gameService, playerService, wsHub)
-	gameStateHandlers := handlers.NewGameStateHandlers(gameStateService, playerService, boardService, movementCardService, figureCardService, wsHub)
-	playerHandlers := handlers.NewPlayerHandlers(playerService, gameService, gameStateService, wsHub)
+	gameHandlers := handlers.NewGameHandlers(gameService, playerService, lobbyFeedService, wsHub)
+	gameStateHandlers := handlers.NewGameStateHandlers(lobbyService)
+	playerHandlers := handlers.NewPlayerHandlers(playerService, gameService, gameStateService, inviteService, lobbyFeedService, wsHub)
+	inviteHandlers := handlers.NewInviteHandlers(inviteService)
 	wsHandlers := handlers.NewWSHandlers(wsHub, gameService, playerService)
+	spectatorHandlers := handlers.NewSpectatorHandlers(gameService, gameStateService, playerService, boardService, figureCardService, wsHub)
+	chatHandlers := handlers.NewChatHandlers(chatService, wsHub)
+	replayHandlers := handlers.NewReplayHandlers(gameEventService, gameService, gameStateService)
+	gameplayHandlers := handlers.NewGameplayHandlers(gameplayService)
+	botHandlers := handlers.NewBotHandlers(botService, lobbyFeedService, wsHub)
+	userHandlers := handlers.NewUserHandlers(userService)
+	statsHandlers := handlers.NewStatsHandlers(statsService)
+	matchmakingHandlers := handlers.NewMatchmakingHandlers(matchmakingService, wsHub)
+	healthHandlers := handlers.NewHealthHandlers(healthService)
 
-	// Configure routes
-	mux := http.NewServeMux()
+	// Websocket commands and hooks
+	wsHub.RegisterCommand(websocket.CHAT_MESSAGE, chatHandlers.HandleChatCommand)
+	wsHub.OnRegister(chatHandlers.SendChatHistory)
+	wsHub.OnRegister(lobbyFeedService.SendSnapshot)
+	wsHub.OnRegister(janitorService.HandleClient)
+	wsHub.OnUnregister(janitorService.HandleClient)
+	lobbyService.OnPlayerRemoved(chatHandlers.AnnouncePlayerRemoved)
+
+	// Configure routes. Documented routes make up the OpenAPI document
+	router := openapi.NewRouter(openapi.Info{
+		Title:   "Switcher API",
+		Version: "1.0.0",
+	})
+	router.Use(middleware.CorrelationMiddleware)
 
 	// Game routes
-	mux.HandleFunc("POST /games", gameHandlers.HandleCreateGame)
-	mux.HandleFunc("GET /games", gameHandlers.HandleGetGames)
-	mux.HandleFunc("GET /games/{gameID}", gameHandlers.HandleGetGameByID)
-	mux.HandleFunc("DELETE /games/{gameID}", gameHandlers.HandleDeleteGame)
-	mux.HandleFunc("GET /games/{gameID}/winner", gameHandlers.HandlerGetWinner)
+	router.HandleFunc(handlers.CreateGameOp, gameHandlers.HandleCreateGame)
+	router.HandleFunc(handlers.GetGamesOp, gameHandlers.HandleGetGames)
+	router.HandleFunc(handlers.GetGameByIDOp, gameHandlers.HandleGetGameByID)
+	router.HandleFunc(handlers.DeleteGameOp, gameHandlers.HandleDeleteGame)
+	router.HandleFunc(handlers.GetWinnerOp, gameHandlers.HandlerGetWinner)
+	router.HandleFunc(handlers.SpectateGameOp, spectatorHandlers.HandleSpectateGame)
+	router.HandleFunc(handlers.SendChatMessageOp, chatHandlers.HandleSendChatMessage)
+	router.HandleFunc(handlers.GetReplayOp, replayHandlers.HandleGetReplay)
+	router.HandleFunc(handlers.AddBotOp, botHandlers.HandleAddBot)
+	router.HandleFunc(handlers.GetGameByCodeOp, inviteHandlers.HandleGetGameByCode)
+
+	// Gameplay routes
+	router.HandleFunc(handlers.PlayMovementOp, gameplayHandlers.HandlePlayMovement)
+	router.HandleFunc(handlers.PlayFigureOp, gameplayHandlers.HandlePlayFigure)
+	router.HandleFunc(handlers.BlockFigureOp, gameplayHandlers.HandleBlockFigure)
+	router.HandleFunc(handlers.EndTurnOp, gameplayHandlers.HandleEndTurn)
+	router.HandleFunc(handlers.GetMovesOp, gameplayHandlers.HandleGetMoves)
 
 	// Game State routes
-	mux.HandleFunc("PATCH /game_state/start/{gameID}", gameStateHandlers.HandleStartGame)
+	router.HandleFunc(handlers.StartGameOp, gameStateHandlers.HandleStartGame)
+	router.HandleFunc(handlers.GetLobbyOp, gameStateHandlers.HandleGetLobby)
+	router.HandleFunc(handlers.KickPlayerOp, gameStateHandlers.HandleKickPlayer)
+	router.HandleFunc(handlers.LeaveGameOp, gameStateHandlers.HandleLeaveGame)
+
+	// Invite routes
+	router.HandleFunc(handlers.CreateInviteOp, inviteHandlers.HandleCreateInvite)
 
 	// Player routes
-	mux.HandleFunc("POST /players/join/{gameID}", playerHandlers.HandleJoinGame)
-	mux.HandleFunc("GET /players/{gameID}", playerHandlers.HandleGetPlayers)
-	mux.HandleFunc("GET /players/{gameID}/{playerID}", playerHandlers.HandleGetPlayer)
+	router.HandleFunc(handlers.JoinGameOp, playerHandlers.HandleJoinGame)
+	router.HandleFunc(handlers.GetPlayersOp, playerHandlers.HandleGetPlayers)
+	router.HandleFunc(handlers.GetPlayerOp, playerHandlers.HandleGetPlayer)
+	router.HandleFunc(handlers.SetReadyOp, gameStateHandlers.HandleSetReady)
+
+	// User routes
+	router.HandleFunc(handlers.RegisterOp, userHandlers.HandleRegister)
+	router.HandleFunc(handlers.LoginOp, userHandlers.HandleLogin)
+	router.HandleFunc(handlers.LogoutOp, userHandlers.HandleLogout)
+	router.HandleFunc(handlers.GetMeOp, userHandlers.HandleGetMe)
 
-	// Websocket route
-	mux.HandleFunc("/ws", wsHandlers.HandleWebSocket)
+	// Stats routes
+	router.HandleFunc(handlers.GetPlayerStatsOp, statsHandlers.HandleGetPlayerStats)
+	router.HandleFunc(handlers.GetLeaderboardOp, statsHandlers.HandleGetLeaderboard)
 
-	// Add middleware
-	handler := middleware.CORSMiddleware(mux)
+	// Matchmaking routes
+	router.HandleFunc(handlers.JoinQueueOp, matchmakingHandlers.HandleJoinQueue)
+	router.HandleFunc(handlers.GetQueueStatusOp, matchmakingHandlers.HandleGetQueueStatus)
+	router.HandleFunc(handlers.LeaveQueueOp, matchmakingHandlers.HandleLeaveQueue)
+
+	// Health routes
+	router.HandleFunc(handlers.HealthzOp, healthHandlers.HandleHealthz)
+	router.HandleFunc(handlers.ReadyzOp, healthHandlers.HandleReadyz)
+	router.HandleFunc(handlers.VersionOp, healthHandlers.HandleVersion)
+
+	// Websocket routes, documented in the AsyncAPI document
+	router.HandleUndocumentedFunc(handlers.LOBBY_CHANNEL, wsHandlers.HandleWebSocket)
+	router.HandleUndocumentedFunc(handlers.GAME_CHANNEL, wsHandlers.HandleWebSocket)
+	router.HandleUndocumentedFunc(handlers.MATCHMAKING_CHANNEL, matchmakingHandlers.HandleQueueWebSocket)
+
+	// API specifications
+	asyncAPIDoc := asyncapi.NewDocument(openapi.Info{
+		Title:   "Switcher websocket API",
+		Version: "1.0.0",
+	}, handlers.Channels, handlers.Events)
+	router.HandleUndocumentedFunc("GET /openapi.json", router.HandleSpec)
+	router.HandleUndocumentedFunc("GET /asyncapi.json", asyncAPIDoc.ServeHTTP)
+
+	// Prometheus metrics
+	registerGauges(wsHub, gameStateService)
+	router.HandleUndocumentedFunc("GET /metrics", metrics.Default.ServeHTTP)
+
+	// Add middleware. Metrics go right around the router to see the
+	// pattern of the route that served the request
+	handler := middleware.RequestIDMiddleware(
+		middleware.CORSMiddleware(middleware.CORSOptions{Origins: allowedOrigins, AllowCredentials: cfg.CORS.AllowCredentials}, middleware.AuthMiddleware(userService, middleware.MetricsMiddleware(router))),
+	)
 
 	// Start server
 	srv := &http.Server{
-		Addr:    ":" + port,
+		Addr:    ":" + cfg.Port,
 		Handler: handler,
 	}
 
-	log.Printf("Starting server on port %s", port)
-	log.Fatal(srv.ListenAndServe())
+	go func() {
+		slog.Info("Starting server", "port", cfg.Port, "log_level", logLevel.String())
+		if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			fatal("Server stopped", "error", err)
+		}
+	}()
+
+	<-ctx.Done()
+	stop()
+
+	// Fail readiness first, so load balancers stop routing here before the
+	// server stops taking connections
+	slog.Info("Shutting down", "drain_delay", cfg.Shutdown.DrainDelay)
+	healthService.Drain()
+	time.Sleep(cfg.Shutdown.DrainDelay)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Shutdown.Timeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		slog.Error("Error shutting down server", "error", err)
+	}
+	slog.Info("Server stopped")
+}
+
+// registerGauges adds the metrics read from the hub and the database on
+// every scrape
+func registerGauges(wsHub *websocket.Hub, gameStateService gameState.GameStateService) {
+	metrics.Default.NewGaugeFunc("switcher_websocket_clients",
+		"Websocket clients connected to each game, the lobby being the nil game",
+		[]string{"game_id"}, func() []metrics.Sample {
+			var samples []metrics.Sample
+			for gameID, clients := range wsHub.ClientsByGame() {
+				samples = append(samples, metrics.Sample{Labels: []string{gameID.String()}, Value: float64(clients)})
+			}
+			return samples
+		})
+
+	metrics.Default.NewGaugeFunc("switcher_websocket_broadcast_queue_depth",
+		"Broadcasts waiting for the hub to deliver them",
+		nil, func() []metrics.Sample {
+			return []metrics.Sample{{Value: float64(wsHub.QueueDepth())}}
+		})
+
+	metrics.Default.NewGaugeFunc("switcher_games",
+		"Games by state",
+		[]string{"state"}, func() []metrics.Sample {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+
+			counts, err := gameStateService.CountGamesByState(ctx)
+			if err != nil {
+				slog.Error("Error counting games by state", "error", err)
+				return nil
+			}
+
+			var samples []metrics.Sample
+			for _, state := range []gameState.State{gameState.WAITING, gameState.PLAYING, gameState.FINISHED} {
+				samples = append(samples, metrics.Sample{Labels: []string{string(state)}, Value: float64(counts[state])})
+			}
+			return samples
+		})
+}
+
+// fatal logs an error that keeps the server from running and exits
+func fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
 }
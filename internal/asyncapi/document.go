@@ -0,0 +1,118 @@
+package asyncapi
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"github.com/NachoGz/switcher-backend-go/internal/openapi"
+)
+
+const messagesPrefix = "#/components/messages/"
+
+// NewDocument documents the messages of the channels. Every message is
+// wrapped in the envelope the hub sends, {type, game_id, payload}
+func NewDocument(info openapi.Info, channels []Channel, messages []Message) *Document {
+	g := openapi.NewSchemaGenerator()
+
+	doc := &Document{
+		AsyncAPI:           ASYNCAPI_VERSION,
+		Info:               info,
+		DefaultContentType: JSON_CONTENT_TYPE,
+		Channels:           map[string]ChannelDoc{},
+		Components:         Components{Messages: map[string]MessageDoc{}},
+	}
+
+	for _, channel := range channels {
+		channelDoc := ChannelDoc{Description: channel.Description}
+		for _, name := range channel.params() {
+			if channelDoc.Parameters == nil {
+				channelDoc.Parameters = map[string]ParameterDoc{}
+			}
+			channelDoc.Parameters[name] = ParameterDoc{Schema: &openapi.Schema{Type: "string", Format: "uuid"}}
+		}
+		doc.Channels[channel.Path] = channelDoc
+	}
+
+	for _, message := range messages {
+		channelDoc, ok := doc.Channels[message.Channel]
+		if !ok {
+			slog.Warn("Message belongs to an unknown channel", "type", message.Type, "channel", message.Channel)
+			continue
+		}
+
+		doc.Components.Messages[message.key()] = MessageDoc{
+			Name:    message.Type,
+			Summary: message.Summary,
+			Payload: envelope(g, message),
+		}
+
+		ref := Ref{Ref: messagesPrefix + message.key()}
+		if message.Command {
+			if channelDoc.Publish == nil {
+				channelDoc.Publish = &OperationDoc{OperationID: operationID("send", message.Channel)}
+			}
+			channelDoc.Publish.Message.OneOf = append(channelDoc.Publish.Message.OneOf, ref)
+		} else {
+			if channelDoc.Subscribe == nil {
+				channelDoc.Subscribe = &OperationDoc{OperationID: operationID("receive", message.Channel)}
+			}
+			channelDoc.Subscribe.Message.OneOf = append(channelDoc.Subscribe.Message.OneOf, ref)
+		}
+		doc.Channels[message.Channel] = channelDoc
+	}
+	doc.Components.Schemas = g.Components()
+
+	return doc
+}
+
+// envelope is the schema of the whole websocket message. Commands only carry
+// their type and payload
+func envelope(g *openapi.SchemaGenerator, message Message) *openapi.Schema {
+	schema := &openapi.Schema{
+		Type: "object",
+		Properties: map[string]*openapi.Schema{
+			"type": {Type: "string", Enum: []string{message.Type}},
+		},
+		Required: []string{"type"},
+	}
+	if !message.Command {
+		schema.Properties["game_id"] = &openapi.Schema{Type: "string", Format: "uuid"}
+	}
+	if message.Payload != nil {
+		schema.Properties["payload"] = g.Schema(message.Payload)
+		schema.Required = append(schema.Required, "payload")
+	}
+	return schema
+}
+
+// operationID names an operation after its channel, such as receiveWsGameID
+// for /ws/{gameID}
+func operationID(verb string, path string) string {
+	var b strings.Builder
+	b.WriteString(verb)
+	for _, part := range strings.FieldsFunc(path, func(r rune) bool {
+		return r == '/' || r == '{' || r == '}'
+	}) {
+		runes := []rune(part)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+	return b.String()
+}
+
+// ServeHTTP serves the document as JSON
+func (d *Document) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	spec, err := json.Marshal(d)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error marshalling AsyncAPI document", "error", err)
+		http.Error(w, "Couldn't build the AsyncAPI document", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", JSON_CONTENT_TYPE)
+	w.WriteHeader(http.StatusOK)
+	w.Write(spec)
+}
@@ -0,0 +1,95 @@
+package asyncapi
+
+import (
+	"strings"
+
+	"github.com/NachoGz/switcher-backend-go/internal/openapi"
+)
+
+const ASYNCAPI_VERSION = "2.6.0"
+
+const JSON_CONTENT_TYPE = "application/json"
+
+// Channel is a websocket route clients connect to
+type Channel struct {
+	Path        string
+	Description string
+}
+
+// params lists the {name} segments of the path
+func (c Channel) params() []string {
+	var params []string
+	for _, segment := range strings.Split(c.Path, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			params = append(params, strings.Trim(segment, "{}"))
+		}
+	}
+	return params
+}
+
+// Message is a websocket message of a channel. Payload is an example value,
+// its schema is generated from its type. A nil payload means the message
+// has none
+type Message struct {
+	Type    string
+	Channel string
+	Summary string
+	Payload any
+	// Commands are sent by clients instead of the server
+	Command bool
+}
+
+// key names the message in the components. A command may share its type with
+// the message the server answers with
+func (m Message) key() string {
+	if m.Command {
+		return m.Type + "_COMMAND"
+	}
+	return m.Type
+}
+
+// Document is an AsyncAPI 2 document
+type Document struct {
+	AsyncAPI           string                `json:"asyncapi"`
+	Info               openapi.Info          `json:"info"`
+	DefaultContentType string                `json:"defaultContentType"`
+	Channels           map[string]ChannelDoc `json:"channels"`
+	Components         Components            `json:"components"`
+}
+
+type ChannelDoc struct {
+	Description string                  `json:"description,omitempty"`
+	Parameters  map[string]ParameterDoc `json:"parameters,omitempty"`
+	// Messages the clients receive
+	Subscribe *OperationDoc `json:"subscribe,omitempty"`
+	// Messages the clients send
+	Publish *OperationDoc `json:"publish,omitempty"`
+}
+
+type ParameterDoc struct {
+	Schema *openapi.Schema `json:"schema"`
+}
+
+type OperationDoc struct {
+	OperationID string       `json:"operationId"`
+	Message     MessageOneOf `json:"message"`
+}
+
+type MessageOneOf struct {
+	OneOf []Ref `json:"oneOf"`
+}
+
+type Ref struct {
+	Ref string `json:"$ref"`
+}
+
+type MessageDoc struct {
+	Name    string          `json:"name"`
+	Summary string          `json:"summary,omitempty"`
+	Payload *openapi.Schema `json:"payload"`
+}
+
+type Components struct {
+	Messages map[string]MessageDoc      `json:"messages"`
+	Schemas  map[string]*openapi.Schema `json:"schemas"`
+}
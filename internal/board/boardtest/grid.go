@@ -0,0 +1,22 @@
+// Package boardtest has helpers for the tests that work with boards
+package boardtest
+
+import "github.com/NachoGz/switcher-backend-go/internal/board"
+
+// ParseGrid builds a board from rows of color initials
+func ParseGrid(rows ...string) [][]board.ColorEnum {
+	colors := map[rune]board.ColorEnum{
+		'R': board.RED,
+		'G': board.GREEN,
+		'B': board.BLUE,
+		'Y': board.YELLOW,
+	}
+
+	grid := make([][]board.ColorEnum, len(rows))
+	for y, row := range rows {
+		for _, initial := range row {
+			grid[y] = append(grid[y], colors[initial])
+		}
+	}
+	return grid
+}
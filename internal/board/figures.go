@@ -0,0 +1,186 @@
+package board
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/NachoGz/switcher-backend-go/internal/figureCard"
+)
+
+// FormedFigure is a figure found on the board
+type FormedFigure struct {
+	Type  figureCard.TypeEnum `json:"type"`
+	Color ColorEnum           `json:"color"`
+	Boxes []BoardPosition     `json:"boxes"`
+}
+
+// Contains reports whether the figure covers the given position
+func (f FormedFigure) Contains(pos BoardPosition) bool {
+	for _, box := range f.Boxes {
+		if box == pos {
+			return true
+		}
+	}
+	return false
+}
+
+// figuresByShape maps the normalized boxes of every rotation of every figure
+// to the figure
+var figuresByShape = buildFiguresByShape()
+
+func buildFiguresByShape() map[string]figureCard.TypeEnum {
+	figures := map[string]figureCard.TypeEnum{}
+	for _, figureType := range figureCard.GetAllCardTypes() {
+		boxes := figureCard.Shape(figureType)
+		for rotation := 0; rotation < 4; rotation++ {
+			figures[shapeKey(boxes)] = figureType
+			boxes = rotate(boxes)
+		}
+	}
+	return figures
+}
+
+// rotate turns the boxes 90 degrees clockwise
+func rotate(boxes [][2]int) [][2]int {
+	rotated := make([][2]int, len(boxes))
+	for i, box := range boxes {
+		rotated[i] = [2]int{-box[1], box[0]}
+	}
+	return rotated
+}
+
+// shapeKey moves the boxes to the origin and returns them sorted, so that
+// equal shapes in different places have the same key
+func shapeKey(boxes [][2]int) string {
+	minX, minY := boxes[0][0], boxes[0][1]
+	for _, box := range boxes {
+		minX = min(minX, box[0])
+		minY = min(minY, box[1])
+	}
+
+	cells := make([]string, len(boxes))
+	for i, box := range boxes {
+		cells[i] = fmt.Sprintf("%d,%d", box[0]-minX, box[1]-minY)
+	}
+	sort.Strings(cells)
+	return strings.Join(cells, ";")
+}
+
+// FindFormedFigures returns the figures formed on a board indexed by
+// [posY][posX]. A figure is formed when a group of boxes of the same color,
+// not touching other boxes of that color, has the shape of a figure
+func FindFormedFigures(grid [][]ColorEnum) []FormedFigure {
+	figures := []FormedFigure{}
+	visited := newVisited(grid)
+
+	for y := range grid {
+		for x := range grid[y] {
+			if figure, ok := figureAt(grid, visited, BoardPosition{PosX: x, PosY: y}); ok {
+				figures = append(figures, figure)
+			}
+		}
+	}
+	return figures
+}
+
+// FindFormedFiguresAround returns the figures that contain or touch any of the
+// given positions. After swapping two boxes, only these figures can have
+// changed, so it's a cheaper way to look for new figures than checking the
+// whole board
+func FindFormedFiguresAround(grid [][]ColorEnum, positions []BoardPosition) []FormedFigure {
+	figures := []FormedFigure{}
+	visited := newVisited(grid)
+
+	for _, pos := range positions {
+		for _, seed := range append(neighbours(pos), pos) {
+			if !insideGrid(grid, seed) {
+				continue
+			}
+			if figure, ok := figureAt(grid, visited, seed); ok {
+				figures = append(figures, figure)
+			}
+		}
+	}
+	return figures
+}
+
+func newVisited(grid [][]ColorEnum) [][]bool {
+	visited := make([][]bool, len(grid))
+	for y := range grid {
+		visited[y] = make([]bool, len(grid[y]))
+	}
+	return visited
+}
+
+// figureAt returns the figure formed by the color group of pos, unless the
+// group was already visited
+func figureAt(grid [][]ColorEnum, visited [][]bool, pos BoardPosition) (FormedFigure, bool) {
+	if visited[pos.PosY][pos.PosX] {
+		return FormedFigure{}, false
+	}
+	group := colorGroup(grid, visited, pos)
+	if len(group) < 4 || len(group) > 5 {
+		return FormedFigure{}, false
+	}
+
+	boxes := make([][2]int, len(group))
+	for i, box := range group {
+		boxes[i] = [2]int{box.PosX, box.PosY}
+	}
+	figureType, ok := figuresByShape[shapeKey(boxes)]
+	if !ok {
+		return FormedFigure{}, false
+	}
+	return FormedFigure{
+		Type:  figureType,
+		Color: grid[pos.PosY][pos.PosX],
+		Boxes: group,
+	}, true
+}
+
+func neighbours(pos BoardPosition) []BoardPosition {
+	return []BoardPosition{
+		{PosX: pos.PosX + 1, PosY: pos.PosY},
+		{PosX: pos.PosX - 1, PosY: pos.PosY},
+		{PosX: pos.PosX, PosY: pos.PosY + 1},
+		{PosX: pos.PosX, PosY: pos.PosY - 1},
+	}
+}
+
+func insideGrid(grid [][]ColorEnum, pos BoardPosition) bool {
+	return pos.PosY >= 0 && pos.PosY < len(grid) && pos.PosX >= 0 && pos.PosX < len(grid[pos.PosY])
+}
+
+// colorGroup flood fills the boxes connected to start with its color
+func colorGroup(grid [][]ColorEnum, visited [][]bool, start BoardPosition) []BoardPosition {
+	color := grid[start.PosY][start.PosX]
+	group := []BoardPosition{}
+	stack := []BoardPosition{start}
+	visited[start.PosY][start.PosX] = true
+
+	for len(stack) > 0 {
+		pos := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		group = append(group, pos)
+
+		for _, next := range neighbours(pos) {
+			if !insideGrid(grid, next) {
+				continue
+			}
+			if visited[next.PosY][next.PosX] || grid[next.PosY][next.PosX] != color {
+				continue
+			}
+			visited[next.PosY][next.PosX] = true
+			stack = append(stack, next)
+		}
+	}
+
+	sort.Slice(group, func(i, j int) bool {
+		if group[i].PosY != group[j].PosY {
+			return group[i].PosY < group[j].PosY
+		}
+		return group[i].PosX < group[j].PosX
+	})
+	return group
+}
@@ -0,0 +1,84 @@
+package board_test
+
+import (
+	"testing"
+
+	"github.com/NachoGz/switcher-backend-go/internal/board"
+	"github.com/NachoGz/switcher-backend-go/internal/board/boardtest"
+	"github.com/NachoGz/switcher-backend-go/internal/figureCard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindFormedFigures_Line(t *testing.T) {
+	grid := boardtest.ParseGrid(
+		"RRRRRG",
+		"GBGBGB",
+		"BGBGBG",
+		"GBGBGB",
+		"BGBGBG",
+		"GBGBGB",
+	)
+
+	figures := board.FindFormedFigures(grid)
+	require.Len(t, figures, 1)
+	assert.Equal(t, figureCard.FIG03, figures[0].Type)
+	assert.Equal(t, board.RED, figures[0].Color)
+	assert.True(t, figures[0].Contains(board.BoardPosition{PosX: 4, PosY: 0}))
+	assert.False(t, figures[0].Contains(board.BoardPosition{PosX: 5, PosY: 0}))
+}
+
+func TestFindFormedFigures_Rotated(t *testing.T) {
+	// FIGE01 standing up instead of lying down
+	grid := boardtest.ParseGrid(
+		"YGBGBG",
+		"YBGBGB",
+		"YGBGBG",
+		"YBGBGB",
+		"BGBGBG",
+		"GBGBGB",
+	)
+
+	figures := board.FindFormedFigures(grid)
+	require.Len(t, figures, 1)
+	assert.Equal(t, figureCard.FIGE01, figures[0].Type)
+	assert.Equal(t, []board.BoardPosition{
+		{PosX: 0, PosY: 0}, {PosX: 0, PosY: 1}, {PosX: 0, PosY: 2}, {PosX: 0, PosY: 3},
+	}, figures[0].Boxes)
+}
+
+func TestFindFormedFigures_TouchingSameColor(t *testing.T) {
+	// The square touches more red boxes, so it isn't a figure
+	grid := boardtest.ParseGrid(
+		"RRGBGB",
+		"RRBGBG",
+		"RRGBGB",
+		"BGBGBG",
+		"GBGBGB",
+		"BGBGBG",
+	)
+
+	for _, figure := range board.FindFormedFigures(grid) {
+		assert.NotEqual(t, board.RED, figure.Color)
+	}
+}
+
+func TestFindFormedFigures_EveryShape(t *testing.T) {
+	for _, figureType := range figureCard.GetAllCardTypes() {
+		grid := boardtest.ParseGrid(
+			"BGBGBG",
+			"GBGBGB",
+			"BGBGBG",
+			"GBGBGB",
+			"BGBGBG",
+			"GBGBGB",
+		)
+		for _, box := range figureCard.Shape(figureType) {
+			grid[box[1]][box[0]] = board.RED
+		}
+
+		figures := board.FindFormedFigures(grid)
+		require.Len(t, figures, 1, "figure %s", figureType)
+		assert.Equal(t, figureType, figures[0].Type)
+	}
+}
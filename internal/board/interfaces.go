@@ -4,11 +4,14 @@ import (
 	"context"
 
 	"github.com/NachoGz/switcher-backend-go/internal/database"
+	"github.com/NachoGz/switcher-backend-go/internal/ruleSet"
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
 	"github.com/google/uuid"
 )
 
 type BoardService interface {
-	ConfigureBoard(ctx context.Context, gameID uuid.UUID) error
+	ConfigureBoard(ctx context.Context, gameID uuid.UUID, rules ruleSet.RuleSet, rng utils.Randomizer) error
+	GetBoard(ctx context.Context, gameID uuid.UUID) (*BoardAndBoxesOut, error)
 }
 
 type BoardRepository interface {
@@ -16,6 +19,7 @@ type BoardRepository interface {
 	GetBoard(ctx context.Context, gameID uuid.UUID) (database.Board, error)
 	AddBoxToBoard(ctx context.Context, params database.AddBoxToBoardParams) (database.Box, error)
 	GetBox(ctx context.Context, params database.GetBoxParams) (database.Box, error)
+	GetBoxesByGame(ctx context.Context, gameID uuid.UUID) ([]database.Box, error)
 	ChangeBoxColor(ctx context.Context, params database.ChangeBoxColorParams) error
 	SwapColors(ctx context.Context, gameID uuid.UUID, posFrom, posTo BoardPosition) error
 }
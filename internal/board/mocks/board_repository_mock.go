@@ -3,6 +3,7 @@ package board_mock
 import (
 	"context"
 
+	"github.com/NachoGz/switcher-backend-go/internal/board"
 	"github.com/NachoGz/switcher-backend-go/internal/database"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
@@ -26,3 +27,23 @@ func (m *MockBoardRepository) AddBoxToBoard(ctx context.Context, params database
 	args := m.Called(ctx, params)
 	return args.Get(0).(database.Box), args.Error(1)
 }
+
+func (m *MockBoardRepository) GetBox(ctx context.Context, params database.GetBoxParams) (database.Box, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).(database.Box), args.Error(1)
+}
+
+func (m *MockBoardRepository) GetBoxesByGame(ctx context.Context, gameID uuid.UUID) ([]database.Box, error) {
+	args := m.Called(ctx, gameID)
+	return args.Get(0).([]database.Box), args.Error(1)
+}
+
+func (m *MockBoardRepository) ChangeBoxColor(ctx context.Context, params database.ChangeBoxColorParams) error {
+	args := m.Called(ctx, params)
+	return args.Error(0)
+}
+
+func (m *MockBoardRepository) SwapColors(ctx context.Context, gameID uuid.UUID, posFrom, posTo board.BoardPosition) error {
+	args := m.Called(ctx, gameID, posFrom, posTo)
+	return args.Error(0)
+}
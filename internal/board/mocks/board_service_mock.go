@@ -3,6 +3,9 @@ package board_mock
 import (
 	"context"
 
+	"github.com/NachoGz/switcher-backend-go/internal/board"
+	"github.com/NachoGz/switcher-backend-go/internal/ruleSet"
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 )
@@ -11,7 +14,15 @@ type MockBoardService struct {
 	mock.Mock
 }
 
-func (m *MockBoardService) ConfigureBoard(ctx context.Context, gameID uuid.UUID) error {
-	args := m.Called(ctx, gameID)
+func (m *MockBoardService) ConfigureBoard(ctx context.Context, gameID uuid.UUID, rules ruleSet.RuleSet, rng utils.Randomizer) error {
+	args := m.Called(ctx, gameID, rules, rng)
 	return args.Error(0)
 }
+
+func (m *MockBoardService) GetBoard(ctx context.Context, gameID uuid.UUID) (*board.BoardAndBoxesOut, error) {
+	args := m.Called(ctx, gameID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*board.BoardAndBoxesOut), args.Error(1)
+}
@@ -1,6 +1,7 @@
 package board
 
 import (
+	"github.com/NachoGz/switcher-backend-go/internal/database"
 	"github.com/NachoGz/switcher-backend-go/internal/figureCard"
 	"github.com/google/uuid"
 )
@@ -47,3 +48,37 @@ type BoardPosition struct {
 	PosX int `json:"pos_x"`
 	PosY int `json:"pos_y"`
 }
+
+// BoxToOut converts a database box to its output representation
+func (s *Service) BoxToOut(dbBox database.Box) BoxOut {
+	box := BoxOut{
+		Color:       ColorEnum(dbBox.Color),
+		PosX:        int(dbBox.PosX),
+		PosY:        int(dbBox.PosY),
+		Highlighted: dbBox.Highlight,
+	}
+	if dbBox.FigureID.Valid {
+		box.FigureID = &dbBox.FigureID.UUID
+	}
+	if dbBox.FigureType.Valid {
+		figureType := figureCard.TypeEnum(dbBox.FigureType.String)
+		box.FigureType = &figureType
+	}
+	return box
+}
+
+// ColorGrid returns the colors of the boxes indexed by [posY][posX]
+func ColorGrid(dbBoxes []database.Box) [][]ColorEnum {
+	grid := [][]ColorEnum{}
+	for _, dbBox := range dbBoxes {
+		row := int(dbBox.PosY)
+		for len(grid) <= row {
+			grid = append(grid, []ColorEnum{})
+		}
+		for len(grid[row]) <= int(dbBox.PosX) {
+			grid[row] = append(grid[row], "")
+		}
+		grid[row][dbBox.PosX] = ColorEnum(dbBox.Color)
+	}
+	return grid
+}
@@ -42,6 +42,11 @@ func (r *PostgresBoardRepository) GetBox(ctx context.Context, params database.Ge
 	return r.queries.GetBox(ctx, params)
 }
 
+// GetBoxesByGame fetches every box of the game's board ordered by row and column
+func (r *PostgresBoardRepository) GetBoxesByGame(ctx context.Context, gameID uuid.UUID) ([]database.Box, error) {
+	return r.queries.GetBoxesByGame(ctx, gameID)
+}
+
 // ChangeBoxColor changes the color of a box
 func (r *PostgresBoardRepository) ChangeBoxColor(ctx context.Context, params database.ChangeBoxColorParams) error {
 	return r.queries.ChangeBoxColor(ctx, params)
@@ -49,44 +54,36 @@ func (r *PostgresBoardRepository) ChangeBoxColor(ctx context.Context, params dat
 
 // SwapColors swaps the colors between two boxes
 func (r *PostgresBoardRepository) SwapColors(ctx context.Context, gameID uuid.UUID, posFrom, posTo BoardPosition) error {
-	tx, err := r.db.BeginTx(ctx, nil)
-	if err != nil {
-		return err
-	}
-	defer tx.Rollback()
-
-	boxFrom, err := r.queries.GetBox(ctx, database.GetBoxParams{
-		GameID: gameID,
-		PosX:   int32(posFrom.PosX),
-		PosY:   int32(posFrom.PosY),
-	})
-	if err != nil {
-		return err
-	}
+	return database.InTx(ctx, r.db, func(ctx context.Context) error {
+		boxFrom, err := r.queries.GetBox(ctx, database.GetBoxParams{
+			GameID: gameID,
+			PosX:   int32(posFrom.PosX),
+			PosY:   int32(posFrom.PosY),
+		})
+		if err != nil {
+			return err
+		}
 
-	boxTo, err := r.queries.GetBox(ctx, database.GetBoxParams{
-		GameID: gameID,
-		PosX:   int32(posTo.PosX),
-		PosY:   int32(posTo.PosY),
-	})
-	if err != nil {
-		return err
-	}
-
-	// Switch colors
-	if err := r.queries.WithTx(tx).ChangeBoxColor(ctx, database.ChangeBoxColorParams{
-		ID:    boxFrom.ID,
-		Color: boxTo.Color,
-	}); err != nil {
-		return err
-	}
+		boxTo, err := r.queries.GetBox(ctx, database.GetBoxParams{
+			GameID: gameID,
+			PosX:   int32(posTo.PosX),
+			PosY:   int32(posTo.PosY),
+		})
+		if err != nil {
+			return err
+		}
 
-	if err := r.queries.WithTx(tx).ChangeBoxColor(ctx, database.ChangeBoxColorParams{
-		ID:    boxTo.ID,
-		Color: boxFrom.Color,
-	}); err != nil {
-		return err
-	}
+		// Switch colors
+		if err := r.queries.ChangeBoxColor(ctx, database.ChangeBoxColorParams{
+			ID:    boxFrom.ID,
+			Color: boxTo.Color,
+		}); err != nil {
+			return err
+		}
 
-	return tx.Commit()
+		return r.queries.ChangeBoxColor(ctx, database.ChangeBoxColorParams{
+			ID:    boxTo.ID,
+			Color: boxFrom.Color,
+		})
+	})
 }
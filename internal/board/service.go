@@ -5,9 +5,10 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
-	"math/rand/v2"
 
 	"github.com/NachoGz/switcher-backend-go/internal/database"
+	"github.com/NachoGz/switcher-backend-go/internal/ruleSet"
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
 	"github.com/google/uuid"
 )
 
@@ -29,7 +30,7 @@ func NewService(
 // Ensure Service implements BoardService
 var _ BoardService = (*Service)(nil)
 
-func (s *Service) ConfigureBoard(ctx context.Context, gameID uuid.UUID) error {
+func (s *Service) ConfigureBoard(ctx context.Context, gameID uuid.UUID, rules ruleSet.RuleSet, rng utils.Randomizer) error {
 	// Check if a board hasn't been created for this game
 	existingBoard, err := s.boardRepo.GetBoard(ctx, gameID)
 	if err != nil && existingBoard.ID != uuid.Nil {
@@ -48,24 +49,24 @@ func (s *Service) ConfigureBoard(ctx context.Context, gameID uuid.UUID) error {
 		return fmt.Errorf("error creating new board: %v", err)
 	}
 
-	// Create a list with the colors of the boxes (9 of each)
-	colors := make([]ColorEnum, 0, 36)
-	for i := 0; i < 9; i++ {
-		colors = append(colors, RED)
-		colors = append(colors, GREEN)
-		colors = append(colors, BLUE)
-		colors = append(colors, YELLOW)
+	// Create a list with the colors of the boxes, as evenly split as the
+	// board size allows (9 of each in the classic rules)
+	boxesAmount := rules.BoardSize * rules.BoardSize
+	availableColors := []ColorEnum{RED, GREEN, BLUE, YELLOW}[:rules.Colors]
+	colors := make([]ColorEnum, 0, boxesAmount)
+	for i := 0; i < boxesAmount; i++ {
+		colors = append(colors, availableColors[i%len(availableColors)])
 	}
 
 	// Shuffle the colors
-	rand.Shuffle(len(colors), func(i, j int) {
+	rng.Shuffle(len(colors), func(i, j int) {
 		colors[i], colors[j] = colors[j], colors[i]
 	})
 
 	// Create each box
 	for i, color := range colors {
-		posX := i % 6
-		posY := i / 6
+		posX := i % rules.BoardSize
+		posY := i / rules.BoardSize
 
 		_, err := s.boardRepo.AddBoxToBoard(ctx, database.AddBoxToBoardParams{
 			ID:        uuid.New(),
@@ -82,3 +83,41 @@ func (s *Service) ConfigureBoard(ctx context.Context, gameID uuid.UUID) error {
 	}
 	return nil
 }
+
+// GetBoard fetches the board of a game as a grid of boxes indexed by [posY][posX]
+func (s *Service) GetBoard(ctx context.Context, gameID uuid.UUID) (*BoardAndBoxesOut, error) {
+	dbBoard, err := s.boardRepo.GetBoard(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	dbBoxes, err := s.boardRepo.GetBoxesByGame(ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching boxes: %w", err)
+	}
+
+	boxes := [][]BoxOut{}
+	for _, dbBox := range dbBoxes {
+		row := int(dbBox.PosY)
+		for len(boxes) <= row {
+			boxes = append(boxes, []BoxOut{})
+		}
+		boxes[row] = append(boxes[row], s.BoxToOut(dbBox))
+	}
+
+	formedFigures := [][]BoxOut{}
+	for _, figure := range FindFormedFigures(ColorGrid(dbBoxes)) {
+		figureBoxes := []BoxOut{}
+		for _, pos := range figure.Boxes {
+			figureBoxes = append(figureBoxes, boxes[pos.PosY][pos.PosX])
+		}
+		formedFigures = append(formedFigures, figureBoxes)
+	}
+
+	return &BoardAndBoxesOut{
+		GameID:        gameID,
+		BoardID:       dbBoard.ID,
+		Boxes:         boxes,
+		FormedFigures: formedFigures,
+	}, nil
+}
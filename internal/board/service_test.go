@@ -0,0 +1,87 @@
+package board_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/NachoGz/switcher-backend-go/internal/board"
+	board_mock "github.com/NachoGz/switcher-backend-go/internal/board/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/database"
+	"github.com/NachoGz/switcher-backend-go/internal/ruleSet"
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// configureBoard sets up a board with the given seed and returns the colors
+// of the created boxes in creation order
+func configureBoard(t *testing.T, rules ruleSet.RuleSet, seed int64) []string {
+	t.Helper()
+
+	mockBoardRepo := new(board_mock.MockBoardRepository)
+	service := board.NewService(mockBoardRepo)
+
+	gameID := uuid.New()
+	colors := []string{}
+
+	mockBoardRepo.On("GetBoard", mock.Anything, gameID).
+		Return(database.Board{}, sql.ErrNoRows)
+	mockBoardRepo.On("CreateBoard", mock.Anything, mock.Anything).
+		Return(database.Board{ID: uuid.New(), GameID: gameID}, nil)
+	mockBoardRepo.On("AddBoxToBoard", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			params := args.Get(1).(database.AddBoxToBoardParams)
+			colors = append(colors, params.Color)
+		}).
+		Return(database.Box{}, nil)
+
+	err := service.ConfigureBoard(context.Background(), gameID, rules, utils.NewRandomizer(seed))
+	assert.NoError(t, err)
+	mockBoardRepo.AssertExpectations(t)
+
+	return colors
+}
+
+func TestConfigureBoard_SameSeedSameLayout(t *testing.T) {
+	first := configureBoard(t, ruleSet.Classic(), 42)
+	second := configureBoard(t, ruleSet.Classic(), 42)
+
+	assert.Len(t, first, 36)
+	assert.Equal(t, first, second)
+}
+
+func TestConfigureBoard_DifferentSeedDifferentLayout(t *testing.T) {
+	assert.NotEqual(t, configureBoard(t, ruleSet.Classic(), 1), configureBoard(t, ruleSet.Classic(), 2))
+}
+
+func TestConfigureBoard_ColorDistribution(t *testing.T) {
+	counts := map[string]int{}
+	for _, color := range configureBoard(t, ruleSet.Classic(), 7) {
+		counts[color]++
+	}
+
+	assert.Equal(t, map[string]int{
+		string(board.RED):    9,
+		string(board.GREEN):  9,
+		string(board.BLUE):   9,
+		string(board.YELLOW): 9,
+	}, counts)
+}
+
+func TestConfigureBoard_RuleSet(t *testing.T) {
+	rules := ruleSet.Blitz()
+	rules.Colors = 2
+
+	counts := map[string]int{}
+	for _, color := range configureBoard(t, rules, 7) {
+		counts[color]++
+	}
+
+	// 25 boxes of the first two colors
+	assert.Equal(t, map[string]int{
+		string(board.RED):   13,
+		string(board.GREEN): 12,
+	}, counts)
+}
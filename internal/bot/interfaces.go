@@ -0,0 +1,13 @@
+package bot
+
+import (
+	"context"
+
+	"github.com/NachoGz/switcher-backend-go/internal/player"
+	"github.com/google/uuid"
+)
+
+type BotService interface {
+	AddBot(ctx context.Context, gameID uuid.UUID, hostID uuid.UUID, level Level) (*player.Player, error)
+	PlayTurn(ctx context.Context, gameID uuid.UUID, botID uuid.UUID) error
+}
@@ -0,0 +1,27 @@
+package bot_mock
+
+import (
+	"context"
+
+	"github.com/NachoGz/switcher-backend-go/internal/bot"
+	"github.com/NachoGz/switcher-backend-go/internal/player"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockBotService struct {
+	mock.Mock
+}
+
+func (m *MockBotService) AddBot(ctx context.Context, gameID uuid.UUID, hostID uuid.UUID, level bot.Level) (*player.Player, error) {
+	args := m.Called(ctx, gameID, hostID, level)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*player.Player), args.Error(1)
+}
+
+func (m *MockBotService) PlayTurn(ctx context.Context, gameID uuid.UUID, botID uuid.UUID) error {
+	args := m.Called(ctx, gameID, botID)
+	return args.Error(0)
+}
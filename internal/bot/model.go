@@ -0,0 +1,68 @@
+package bot
+
+import (
+	"time"
+
+	"github.com/NachoGz/switcher-backend-go/internal/board"
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
+	"github.com/google/uuid"
+)
+
+// Level enum
+type Level string
+
+const (
+	EASY Level = "easy"
+	HARD Level = "hard"
+)
+
+const (
+	// Time a bot waits before playing, so the other players can follow its turn
+	THINK_DELAY = 1500 * time.Millisecond
+
+	// Times a bot tries to play its turn before passing it, and how long
+	// each try may take
+	TURN_ATTEMPTS = 3
+	TURN_TIMEOUT  = 10 * time.Second
+
+	// Amount of movements the hard bot looks ahead
+	HARD_DEPTH = 2
+)
+
+var (
+	ErrUnknownLevel = utils.NewDomainError(utils.ErrInvalidInput, "UNKNOWN_BOT_LEVEL", "unknown bot level")
+	ErrNotHost      = utils.NewDomainError(utils.ErrForbidden, "NOT_HOST", "only the host can add bots")
+	ErrGameStarted  = utils.NewDomainError(utils.ErrConflict, "GAME_STARTED", "bots can only be added before the game starts")
+	ErrGameFull     = utils.NewDomainError(utils.ErrGameFull, "GAME_FULL", "the game is full")
+)
+
+// ParseLevel returns the level with the given name. An empty name is easy
+func ParseLevel(name string) (Level, error) {
+	switch Level(name) {
+	case "", EASY:
+		return EASY, nil
+	case HARD:
+		return HARD, nil
+	default:
+		return "", ErrUnknownLevel
+	}
+}
+
+// ActionKind enum
+type ActionKind string
+
+const (
+	MOVE         ActionKind = "move"
+	PLAY_FIGURE  ActionKind = "play_figure"
+	BLOCK_FIGURE ActionKind = "block_figure"
+)
+
+// Action is a single step of a bot's turn
+type Action struct {
+	Kind           ActionKind
+	MovementCardID uuid.UUID
+	From           board.BoardPosition
+	To             board.BoardPosition
+	FigureCardID   uuid.UUID
+	Position       board.BoardPosition
+}
@@ -0,0 +1,155 @@
+package bot
+
+import (
+	"sort"
+
+	"github.com/NachoGz/switcher-backend-go/internal/board"
+	"github.com/NachoGz/switcher-backend-go/internal/figureCard"
+	"github.com/NachoGz/switcher-backend-go/internal/gameplay"
+	"github.com/NachoGz/switcher-backend-go/internal/movementCard"
+	"github.com/google/uuid"
+)
+
+// target is a figure card the bot would like to form
+type target struct {
+	card  figureCard.FigureCard
+	block bool
+}
+
+// PlanTurn picks the actions a bot plays in its turn. The plan is a list of
+// movements followed by the figure card they form, or empty when the bot
+// can't form any figure and just ends its turn.
+//
+// The easy bot only looks one movement ahead and plays the first figure it
+// finds. The hard bot looks HARD_DEPTH movements ahead, prefers the shortest
+// plan and hard figures, and blocks other players when it can't play its own
+// cards.
+func PlanTurn(state *gameplay.TurnState, level Level) []Action {
+	depth := 1
+	if level == HARD {
+		depth = HARD_DEPTH
+	}
+
+	targets := targetsFor(state, level)
+	if len(targets) == 0 {
+		return []Action{}
+	}
+
+	hand := []movementCard.MovementCard{}
+	for _, card := range state.Hand {
+		if !card.Used {
+			hand = append(hand, card)
+		}
+	}
+
+	grid := make([][]board.ColorEnum, len(state.Board))
+	for y := range state.Board {
+		grid[y] = append([]board.ColorEnum(nil), state.Board[y]...)
+	}
+
+	// Iterative deepening, so the first plan found is one of the shortest
+	for maxDepth := 0; maxDepth <= depth; maxDepth++ {
+		if plan, ok := search(grid, hand, targets, state, maxDepth); ok {
+			return plan
+		}
+	}
+	return []Action{}
+}
+
+// targetsFor returns the figure cards the bot can use, in the order it
+// prefers them
+func targetsFor(state *gameplay.TurnState, level Level) []target {
+	own := []target{}
+	blockable := []target{}
+	blockedPlayers := map[uuid.UUID]bool{}
+	shownByPlayer := map[uuid.UUID]int{}
+
+	for _, card := range state.FigureCards {
+		if card.Blocked {
+			blockedPlayers[card.PlayerID] = true
+		}
+		shownByPlayer[card.PlayerID]++
+	}
+
+	for _, card := range state.FigureCards {
+		switch {
+		case card.PlayerID == state.PlayerID:
+			if !card.Blocked {
+				own = append(own, target{card: card})
+			}
+		case level == HARD && state.Rules.BlockingEnabled:
+			if !blockedPlayers[card.PlayerID] && shownByPlayer[card.PlayerID] > 1 {
+				blockable = append(blockable, target{card: card, block: true})
+			}
+		}
+	}
+
+	if level == HARD {
+		sort.SliceStable(own, func(i, j int) bool {
+			return own[i].card.Difficulty == string(figureCard.HARD) && own[j].card.Difficulty != string(figureCard.HARD)
+		})
+	}
+	return append(own, blockable...)
+}
+
+// search tries every sequence of up to depth movements with the cards of the
+// hand, returning the first one that leaves a target formed on the board
+func search(grid [][]board.ColorEnum, hand []movementCard.MovementCard, targets []target, state *gameplay.TurnState, depth int) ([]Action, bool) {
+	if depth == 0 {
+		action, ok := formedTarget(grid, targets, state.ForbiddenColor)
+		if !ok {
+			return nil, false
+		}
+		return []Action{action}, true
+	}
+
+	for i, card := range hand {
+		rest := append(append([]movementCard.MovementCard{}, hand[:i]...), hand[i+1:]...)
+
+		for y := range grid {
+			for x := range grid[y] {
+				from := board.BoardPosition{PosX: x, PosY: y}
+				for _, to := range movementCard.Destinations(card.Type, from, len(grid)) {
+					// Swapping boxes of the same color doesn't change the board
+					if grid[from.PosY][from.PosX] == grid[to.PosY][to.PosX] {
+						continue
+					}
+
+					swap(grid, from, to)
+					plan, ok := search(grid, rest, targets, state, depth-1)
+					swap(grid, from, to)
+
+					if ok {
+						move := Action{Kind: MOVE, MovementCardID: card.ID, From: from, To: to}
+						return append([]Action{move}, plan...), true
+					}
+				}
+			}
+		}
+	}
+	return nil, false
+}
+
+// formedTarget returns the action that plays the first target formed on the
+// board with a color other than the forbidden one
+func formedTarget(grid [][]board.ColorEnum, targets []target, forbiddenColor board.ColorEnum) (Action, bool) {
+	figures := board.FindFormedFigures(grid)
+	for _, t := range targets {
+		for _, figure := range figures {
+			if figure.Type != t.card.Type || figure.Color == forbiddenColor {
+				continue
+			}
+
+			kind := PLAY_FIGURE
+			if t.block {
+				kind = BLOCK_FIGURE
+			}
+			return Action{Kind: kind, FigureCardID: t.card.ID, Position: figure.Boxes[0]}, true
+		}
+	}
+	return Action{}, false
+}
+
+func swap(grid [][]board.ColorEnum, from, to board.BoardPosition) {
+	grid[from.PosY][from.PosX], grid[to.PosY][to.PosX] = grid[to.PosY][to.PosX], grid[from.PosY][from.PosX]
+}
@@ -0,0 +1,166 @@
+package bot_test
+
+import (
+	"testing"
+
+	"github.com/NachoGz/switcher-backend-go/internal/board"
+	"github.com/NachoGz/switcher-backend-go/internal/board/boardtest"
+	"github.com/NachoGz/switcher-backend-go/internal/bot"
+	"github.com/NachoGz/switcher-backend-go/internal/figureCard"
+	gameState "github.com/NachoGz/switcher-backend-go/internal/game_state"
+	"github.com/NachoGz/switcher-backend-go/internal/gameplay"
+	"github.com/NachoGz/switcher-backend-go/internal/movementCard"
+	"github.com/NachoGz/switcher-backend-go/internal/ruleSet"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTurnState returns the state of a bot holding a square figure card and
+// two linear movement cards
+func newTurnState(grid [][]board.ColorEnum) *gameplay.TurnState {
+	botID := uuid.New()
+	return &gameplay.TurnState{
+		GameID:          uuid.New(),
+		PlayerID:        botID,
+		State:           gameState.PLAYING,
+		CurrentPlayerID: botID,
+		Rules:           ruleSet.Classic(),
+		Board:           grid,
+		Hand: []movementCard.MovementCard{
+			{ID: uuid.New(), Type: movementCard.LINEAR_CONT, PlayerID: botID},
+			{ID: uuid.New(), Type: movementCard.LINEAR_CONT, PlayerID: botID},
+		},
+		FigureCards: []figureCard.FigureCard{
+			{ID: uuid.New(), Type: figureCard.FIGE02, Show: true, PlayerID: botID},
+		},
+	}
+}
+
+// play applies the movements of a plan to the board
+func play(grid [][]board.ColorEnum, plan []bot.Action) {
+	for _, action := range plan {
+		if action.Kind != bot.MOVE {
+			continue
+		}
+		from, to := action.From, action.To
+		grid[from.PosY][from.PosX], grid[to.PosY][to.PosX] = grid[to.PosY][to.PosX], grid[from.PosY][from.PosX]
+	}
+}
+
+// oneMoveGrid needs a single swap to form a red square
+func oneMoveGrid() [][]board.ColorEnum {
+	return boardtest.ParseGrid(
+		"RRBGBG",
+		"RGRBGB",
+		"GBGBGB",
+		"BGBGBG",
+		"GBGBGB",
+		"BGBGBG",
+	)
+}
+
+// twoMovesGrid needs two swaps to form a red square
+func twoMovesGrid() [][]board.ColorEnum {
+	return boardtest.ParseGrid(
+		"RRBGBG",
+		"GBRBGB",
+		"RGBGBG",
+		"GBGBGB",
+		"BGBGBG",
+		"GBGBGB",
+	)
+}
+
+func TestPlanTurn_EasyOneMove(t *testing.T) {
+	state := newTurnState(oneMoveGrid())
+
+	plan := bot.PlanTurn(state, bot.EASY)
+	require.Len(t, plan, 2)
+	assert.Equal(t, bot.MOVE, plan[0].Kind)
+	assert.Equal(t, bot.PLAY_FIGURE, plan[1].Kind)
+	assert.Equal(t, state.FigureCards[0].ID, plan[1].FigureCardID)
+
+	// The planned movement is legal and forms the figure where the bot plays it
+	assert.True(t, movementCard.IsValidMovement(movementCard.LINEAR_CONT, plan[0].From, plan[0].To, 6))
+	grid := oneMoveGrid()
+	play(grid, plan)
+	figures := board.FindFormedFigures(grid)
+	found := false
+	for _, figure := range figures {
+		if figure.Type == figureCard.FIGE02 && figure.Contains(plan[1].Position) {
+			found = true
+		}
+	}
+	assert.True(t, found)
+
+	// Planning doesn't change the state
+	assert.Equal(t, oneMoveGrid(), state.Board)
+}
+
+func TestPlanTurn_EasyGivesUpOnTwoMoves(t *testing.T) {
+	plan := bot.PlanTurn(newTurnState(twoMovesGrid()), bot.EASY)
+	assert.Empty(t, plan)
+}
+
+func TestPlanTurn_HardTwoMoves(t *testing.T) {
+	state := newTurnState(twoMovesGrid())
+
+	plan := bot.PlanTurn(state, bot.HARD)
+	require.Len(t, plan, 3)
+	assert.Equal(t, bot.MOVE, plan[0].Kind)
+	assert.Equal(t, bot.MOVE, plan[1].Kind)
+	assert.Equal(t, bot.PLAY_FIGURE, plan[2].Kind)
+
+	// Each card is used once
+	assert.NotEqual(t, plan[0].MovementCardID, plan[1].MovementCardID)
+}
+
+func TestPlanTurn_ForbiddenColor(t *testing.T) {
+	state := newTurnState(oneMoveGrid())
+	state.ForbiddenColor = board.RED
+
+	assert.Empty(t, bot.PlanTurn(state, bot.EASY))
+}
+
+func TestPlanTurn_UsedCardsAreSkipped(t *testing.T) {
+	state := newTurnState(oneMoveGrid())
+	for i := range state.Hand {
+		state.Hand[i].Used = true
+	}
+
+	assert.Empty(t, bot.PlanTurn(state, bot.HARD))
+}
+
+func TestPlanTurn_HardBlocks(t *testing.T) {
+	state := newTurnState(oneMoveGrid())
+	opponentID := uuid.New()
+
+	// The bot's own card can't be formed, but another player's can
+	state.FigureCards = []figureCard.FigureCard{
+		{ID: uuid.New(), Type: figureCard.FIG03, Show: true, PlayerID: state.PlayerID},
+		{ID: uuid.New(), Type: figureCard.FIGE02, Show: true, PlayerID: opponentID},
+		{ID: uuid.New(), Type: figureCard.FIG01, Show: true, PlayerID: opponentID},
+	}
+
+	plan := bot.PlanTurn(state, bot.HARD)
+	require.Len(t, plan, 2)
+	assert.Equal(t, bot.BLOCK_FIGURE, plan[1].Kind)
+	assert.Equal(t, state.FigureCards[1].ID, plan[1].FigureCardID)
+
+	// The easy bot never blocks
+	assert.Empty(t, bot.PlanTurn(state, bot.EASY))
+}
+
+func TestParseLevel(t *testing.T) {
+	level, err := bot.ParseLevel("")
+	assert.NoError(t, err)
+	assert.Equal(t, bot.EASY, level)
+
+	level, err = bot.ParseLevel("hard")
+	assert.NoError(t, err)
+	assert.Equal(t, bot.HARD, level)
+
+	_, err = bot.ParseLevel("impossible")
+	assert.ErrorIs(t, err, bot.ErrUnknownLevel)
+}
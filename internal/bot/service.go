@@ -0,0 +1,179 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/NachoGz/switcher-backend-go/internal/game"
+	gameState "github.com/NachoGz/switcher-backend-go/internal/game_state"
+	"github.com/NachoGz/switcher-backend-go/internal/gameplay"
+	"github.com/NachoGz/switcher-backend-go/internal/logging"
+	"github.com/NachoGz/switcher-backend-go/internal/player"
+	"github.com/google/uuid"
+)
+
+// Service adds bots to games and plays their turns through the gameplay
+// service, the same way players do
+type Service struct {
+	gameService      game.GameService
+	gameStateService gameState.GameStateService
+	playerService    player.PlayerService
+	gameplayService  gameplay.GameplayService
+	thinkDelay       time.Duration
+}
+
+// NewService creates a new bot service and registers it to play the turns of
+// the bots
+func NewService(
+	gameService game.GameService,
+	gameStateService gameState.GameStateService,
+	playerService player.PlayerService,
+	gameplayService gameplay.GameplayService,
+	thinkDelay time.Duration,
+) *Service {
+	s := &Service{
+		gameService:      gameService,
+		gameStateService: gameStateService,
+		playerService:    playerService,
+		gameplayService:  gameplayService,
+		thinkDelay:       thinkDelay,
+	}
+	gameplayService.OnTurnStart(s.handleTurnStart)
+	return s
+}
+
+// Ensure Service implements BotService
+var _ BotService = (*Service)(nil)
+
+// AddBot adds a bot of the given level to a game that hasn't started yet.
+// Only the host of the game can add bots
+func (s *Service) AddBot(ctx context.Context, gameID uuid.UUID, hostID uuid.UUID, level Level) (*player.Player, error) {
+	state, err := s.gameStateService.GetGameStateByGameID(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	if state.State != gameState.WAITING {
+		return nil, ErrGameStarted
+	}
+
+	host, err := s.playerService.GetPlayerByID(ctx, hostID, gameID)
+	if err != nil {
+		return nil, err
+	}
+	if !host.Host {
+		return nil, ErrNotHost
+	}
+
+	currentGame, err := s.gameService.GetGameByID(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	players, err := s.playerService.GetPlayersInGame(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	if len(players) >= currentGame.MaxPlayers {
+		return nil, ErrGameFull
+	}
+
+	bots := 0
+	for _, p := range players {
+		if p.Bot {
+			bots++
+		}
+	}
+
+	return s.playerService.CreatePlayer(ctx, player.Player{
+		Name:        fmt.Sprintf("Bot %d", bots+1),
+		GameID:      gameID,
+		GameStateID: state.ID,
+		Bot:         true,
+		BotLevel:    string(level),
+	})
+}
+
+// PlayTurn plans and plays the turn of a bot, ending it afterwards
+func (s *Service) PlayTurn(ctx context.Context, gameID uuid.UUID, botID uuid.UUID) error {
+	botPlayer, err := s.playerService.GetPlayerByID(ctx, botID, gameID)
+	if err != nil {
+		return err
+	}
+
+	state, err := s.gameplayService.GetTurnState(ctx, gameID, botID)
+	if err != nil {
+		return err
+	}
+	if state.State != gameState.PLAYING || state.CurrentPlayerID != botID {
+		return nil
+	}
+
+	for _, action := range PlanTurn(state, Level(botPlayer.BotLevel)) {
+		switch action.Kind {
+		case MOVE:
+			err = s.gameplayService.PlayMovement(ctx, gameID, botID, action.MovementCardID, action.From, action.To)
+		case PLAY_FIGURE:
+			err = s.gameplayService.PlayFigure(ctx, gameID, botID, action.FigureCardID, action.Position)
+		case BLOCK_FIGURE:
+			err = s.gameplayService.BlockFigure(ctx, gameID, botID, action.FigureCardID, action.Position)
+		}
+		if err != nil {
+			// Ending the turn reverts whatever part of the plan was played
+			slog.WarnContext(ctx, "Bot couldn't play its action", logging.GameID(gameID), logging.PlayerID(botID), "action", action.Kind, "error", err)
+			break
+		}
+	}
+
+	err = s.gameplayService.EndTurn(ctx, gameID, botID)
+	if errors.Is(err, gameplay.ErrGameNotPlaying) {
+		// The bot won with its last figure
+		return nil
+	}
+	return err
+}
+
+// handleTurnStart plays the turn in the background when it belongs to a bot
+func (s *Service) handleTurnStart(gameID uuid.UUID, playerID uuid.UUID) {
+	go func() {
+		ctx := logging.WithPlayerID(logging.WithGameID(context.Background(), gameID), playerID)
+
+		p, err := s.playerService.GetPlayerByID(ctx, playerID, gameID)
+		if err != nil || !p.Bot {
+			return
+		}
+
+		for attempt := 1; attempt <= TURN_ATTEMPTS; attempt++ {
+			time.Sleep(s.thinkDelay)
+			err = s.playTurnWithTimeout(ctx, gameID, playerID)
+			if err == nil {
+				return
+			}
+			slog.WarnContext(ctx, "Bot couldn't play its turn", "attempt", attempt, "error", err)
+		}
+
+		// Passing the turn keeps the game going for the other players
+		if err := s.endTurnWithTimeout(ctx, gameID, playerID); err != nil {
+			slog.ErrorContext(ctx, "Bot couldn't pass its turn", "error", err)
+		}
+	}()
+}
+
+func (s *Service) playTurnWithTimeout(ctx context.Context, gameID uuid.UUID, botID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, TURN_TIMEOUT)
+	defer cancel()
+	return s.PlayTurn(ctx, gameID, botID)
+}
+
+func (s *Service) endTurnWithTimeout(ctx context.Context, gameID uuid.UUID, botID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, TURN_TIMEOUT)
+	defer cancel()
+
+	err := s.gameplayService.EndTurn(ctx, gameID, botID)
+	if errors.Is(err, gameplay.ErrGameNotPlaying) || errors.Is(err, gameplay.ErrNotYourTurn) {
+		// The turn moved on in the meantime
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,52 @@
+package bot_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NachoGz/switcher-backend-go/internal/bot"
+	game_mock "github.com/NachoGz/switcher-backend-go/internal/game/mocks"
+	gameState_mock "github.com/NachoGz/switcher-backend-go/internal/game_state/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/gameplay"
+	gameplay_mock "github.com/NachoGz/switcher-backend-go/internal/gameplay/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/player"
+	player_mock "github.com/NachoGz/switcher-backend-go/internal/player/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTurnStart_PassesTurnAfterFailedAttempts(t *testing.T) {
+	mockGameService := new(game_mock.MockGameService)
+	mockGameStateService := new(gameState_mock.MockGameStateService)
+	mockPlayerService := new(player_mock.MockPlayerService)
+	mockGameplayService := new(gameplay_mock.MockGameplayService)
+
+	var onTurnStart gameplay.TurnListener
+	mockGameplayService.On("OnTurnStart", mock.Anything).Run(func(args mock.Arguments) {
+		onTurnStart = args.Get(0).(gameplay.TurnListener)
+	}).Return()
+
+	bot.NewService(mockGameService, mockGameStateService, mockPlayerService, mockGameplayService, 0)
+
+	gameID := uuid.New()
+	botID := uuid.New()
+	mockPlayerService.On("GetPlayerByID", mock.Anything, botID, gameID).
+		Return(player.Player{ID: botID, GameID: gameID, Bot: true, BotLevel: string(bot.EASY)}, nil)
+	mockGameplayService.On("GetTurnState", mock.Anything, gameID, botID).
+		Return(nil, errors.New("database unavailable"))
+
+	passed := make(chan struct{})
+	mockGameplayService.On("EndTurn", mock.Anything, gameID, botID).Run(func(args mock.Arguments) {
+		close(passed)
+	}).Return(nil).Once()
+
+	onTurnStart(gameID, botID)
+
+	select {
+	case <-passed:
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the bot to pass its turn")
+	}
+	mockGameplayService.AssertNumberOfCalls(t, "GetTurnState", bot.TURN_ATTEMPTS)
+}
@@ -0,0 +1,56 @@
+package chat
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ProfanityFilter masks the listed words with asterisks
+type ProfanityFilter struct {
+	pattern *regexp.Regexp
+}
+
+// NewProfanityFilter creates a filter that masks the given words, matching
+// whole words regardless of case
+func NewProfanityFilter(words []string) *ProfanityFilter {
+	quoted := make([]string, 0, len(words))
+	for _, word := range words {
+		if word = strings.TrimSpace(word); word != "" {
+			quoted = append(quoted, regexp.QuoteMeta(word))
+		}
+	}
+	if len(quoted) == 0 {
+		return &ProfanityFilter{}
+	}
+
+	return &ProfanityFilter{
+		pattern: regexp.MustCompile(`(?i)\b(` + strings.Join(quoted, "|") + `)\b`),
+	}
+}
+
+func (f *ProfanityFilter) Filter(content string) (string, error) {
+	if f.pattern == nil {
+		return content, nil
+	}
+	return f.pattern.ReplaceAllStringFunc(content, func(word string) string {
+		return strings.Repeat("*", len([]rune(word)))
+	}), nil
+}
+
+var linkPattern = regexp.MustCompile(`(?i)(https?://|www\.)\S+|\b[a-z0-9-]+\.(com|net|org|io|gg|ly|xyz|ar)\b`)
+
+// LinkFilter rejects messages containing links
+type LinkFilter struct{}
+
+func (f LinkFilter) Filter(content string) (string, error) {
+	if linkPattern.MatchString(content) {
+		return "", ErrMessageBlocked
+	}
+	return content, nil
+}
+
+// Ensure the filters implement ChatFilter
+var (
+	_ ChatFilter = (*ProfanityFilter)(nil)
+	_ ChatFilter = LinkFilter{}
+)
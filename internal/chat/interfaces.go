@@ -0,0 +1,25 @@
+package chat
+
+import (
+	"context"
+
+	"github.com/NachoGz/switcher-backend-go/internal/database"
+	"github.com/google/uuid"
+)
+
+type ChatService interface {
+	SendMessage(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID, content string) (*ChatMessage, error)
+	PostSystemMessage(ctx context.Context, gameID uuid.UUID, content string) (*ChatMessage, error)
+	GetHistory(ctx context.Context, gameID uuid.UUID) ([]ChatMessage, error)
+}
+
+type ChatRepository interface {
+	CreateChatMessage(ctx context.Context, params database.CreateChatMessageParams) (database.ChatMessage, error)
+	GetRecentChatMessages(ctx context.Context, params database.GetRecentChatMessagesParams) ([]database.ChatMessage, error)
+}
+
+// ChatFilter inspects a message before it is stored. It returns the content
+// to store, which may be rewritten, or ErrMessageBlocked to reject it
+type ChatFilter interface {
+	Filter(content string) (string, error)
+}
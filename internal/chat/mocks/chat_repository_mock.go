@@ -0,0 +1,22 @@
+package chat_mock
+
+import (
+	"context"
+
+	"github.com/NachoGz/switcher-backend-go/internal/database"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockChatRepository struct {
+	mock.Mock
+}
+
+func (m *MockChatRepository) CreateChatMessage(ctx context.Context, params database.CreateChatMessageParams) (database.ChatMessage, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).(database.ChatMessage), args.Error(1)
+}
+
+func (m *MockChatRepository) GetRecentChatMessages(ctx context.Context, params database.GetRecentChatMessagesParams) ([]database.ChatMessage, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).([]database.ChatMessage), args.Error(1)
+}
@@ -0,0 +1,34 @@
+package chat_mock
+
+import (
+	"context"
+
+	"github.com/NachoGz/switcher-backend-go/internal/chat"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockChatService struct {
+	mock.Mock
+}
+
+func (m *MockChatService) SendMessage(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID, content string) (*chat.ChatMessage, error) {
+	args := m.Called(ctx, gameID, playerID, content)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*chat.ChatMessage), args.Error(1)
+}
+
+func (m *MockChatService) PostSystemMessage(ctx context.Context, gameID uuid.UUID, content string) (*chat.ChatMessage, error) {
+	args := m.Called(ctx, gameID, content)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*chat.ChatMessage), args.Error(1)
+}
+
+func (m *MockChatService) GetHistory(ctx context.Context, gameID uuid.UUID) ([]chat.ChatMessage, error) {
+	args := m.Called(ctx, gameID)
+	return args.Get(0).([]chat.ChatMessage), args.Error(1)
+}
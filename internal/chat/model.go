@@ -0,0 +1,51 @@
+package chat
+
+import (
+	"time"
+
+	"github.com/NachoGz/switcher-backend-go/internal/database"
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
+	"github.com/google/uuid"
+)
+
+const (
+	// Maximum amount of characters in a message
+	MAX_MESSAGE_LENGTH = 200
+
+	// Amount of messages a player can send within RATE_LIMIT_WINDOW
+	RATE_LIMIT_MESSAGES = 5
+	RATE_LIMIT_WINDOW   = 10 * time.Second
+
+	// Amount of messages sent to a client when it joins a game
+	HISTORY_SIZE = 50
+)
+
+var (
+	ErrEmptyMessage   = utils.NewDomainError(utils.ErrInvalidInput, "EMPTY_MESSAGE", "message is empty")
+	ErrMessageTooLong = utils.NewDomainError(utils.ErrInvalidInput, "MESSAGE_TOO_LONG", "message is too long")
+	ErrRateLimited    = utils.NewDomainError(utils.ErrRateLimited, "CHAT_RATE_LIMITED", "too many messages, slow down")
+	ErrMessageBlocked = utils.NewDomainError(utils.ErrRejected, "MESSAGE_BLOCKED", "message was blocked by the chat filter")
+)
+
+type ChatMessage struct {
+	ID         uuid.UUID `json:"id"`
+	GameID     uuid.UUID `json:"game_id"`
+	PlayerID   uuid.UUID `json:"player_id"`
+	PlayerName string    `json:"player_name"`
+	Content    string    `json:"content"`
+	System     bool      `json:"system"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// DBToModel converts a database chat message to a model chat message
+func (s *Service) DBToModel(dbMessage database.ChatMessage) ChatMessage {
+	return ChatMessage{
+		ID:         dbMessage.ID,
+		GameID:     dbMessage.GameID,
+		PlayerID:   dbMessage.PlayerID.UUID,
+		PlayerName: dbMessage.PlayerName,
+		Content:    dbMessage.Content,
+		System:     dbMessage.System,
+		CreatedAt:  dbMessage.CreatedAt,
+	}
+}
@@ -0,0 +1,29 @@
+package chat
+
+import (
+	"context"
+
+	"github.com/NachoGz/switcher-backend-go/internal/database"
+)
+
+// PostgresChatRepository implements ChatRepository for Postgres
+type PostgresChatRepository struct {
+	queries *database.Queries
+}
+
+// NewChatRepository creates a new chat repository
+func NewChatRepository(queries *database.Queries) ChatRepository {
+	return &PostgresChatRepository{
+		queries: queries,
+	}
+}
+
+// CreateChatMessage stores a new chat message
+func (r *PostgresChatRepository) CreateChatMessage(ctx context.Context, params database.CreateChatMessageParams) (database.ChatMessage, error) {
+	return r.queries.CreateChatMessage(ctx, params)
+}
+
+// GetRecentChatMessages fetches the latest messages of a game, newest first
+func (r *PostgresChatRepository) GetRecentChatMessages(ctx context.Context, params database.GetRecentChatMessagesParams) ([]database.ChatMessage, error) {
+	return r.queries.GetRecentChatMessages(ctx, params)
+}
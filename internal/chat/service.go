@@ -0,0 +1,171 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/NachoGz/switcher-backend-go/internal/database"
+	"github.com/NachoGz/switcher-backend-go/internal/player"
+	"github.com/google/uuid"
+)
+
+// Name shown as author of system messages
+const SYSTEM_AUTHOR = "system"
+
+// Service handles all chat related operations
+type Service struct {
+	chatRepo   ChatRepository
+	playerRepo player.PlayerRepository
+	filters    []ChatFilter
+
+	// Timestamps of the recent messages of each player, used for rate limiting
+	mu     sync.Mutex
+	recent map[uuid.UUID][]time.Time
+	// Last time the players that stopped chatting were forgotten
+	lastSweep time.Time
+}
+
+// NewService creates a new chat service. Filters are applied in order
+func NewService(
+	chatRepo ChatRepository,
+	playerRepo player.PlayerRepository,
+	filters ...ChatFilter,
+) *Service {
+	return &Service{
+		chatRepo:   chatRepo,
+		playerRepo: playerRepo,
+		filters:    filters,
+		recent:     make(map[uuid.UUID][]time.Time),
+	}
+}
+
+// Ensure Service implements ChatService
+var _ ChatService = (*Service)(nil)
+
+// SendMessage validates, filters and stores a message from a player
+func (s *Service) SendMessage(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID, content string) (*ChatMessage, error) {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return nil, ErrEmptyMessage
+	}
+	if utf8.RuneCountInString(content) > MAX_MESSAGE_LENGTH {
+		return nil, ErrMessageTooLong
+	}
+
+	author, err := s.playerRepo.GetPlayerByID(ctx, database.GetPlayerByIDParams{
+		GameID: gameID,
+		ID:     playerID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get player %s: %w", playerID, err)
+	}
+
+	for _, filter := range s.filters {
+		content, err = filter.Filter(content)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !s.allow(playerID, time.Now()) {
+		return nil, ErrRateLimited
+	}
+
+	message, err := s.chatRepo.CreateChatMessage(ctx, database.CreateChatMessageParams{
+		ID:         uuid.New(),
+		GameID:     gameID,
+		PlayerID:   uuid.NullUUID{UUID: playerID, Valid: true},
+		PlayerName: author.Name,
+		Content:    content,
+		System:     false,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := s.DBToModel(message)
+	return &result, nil
+}
+
+// PostSystemMessage stores a message generated by the server, such as a
+// player leaving the game. System messages skip filters and rate limits
+func (s *Service) PostSystemMessage(ctx context.Context, gameID uuid.UUID, content string) (*ChatMessage, error) {
+	message, err := s.chatRepo.CreateChatMessage(ctx, database.CreateChatMessageParams{
+		ID:         uuid.New(),
+		GameID:     gameID,
+		PlayerID:   uuid.NullUUID{Valid: false},
+		PlayerName: SYSTEM_AUTHOR,
+		Content:    content,
+		System:     true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := s.DBToModel(message)
+	return &result, nil
+}
+
+// GetHistory fetches the last HISTORY_SIZE messages of a game, oldest first
+func (s *Service) GetHistory(ctx context.Context, gameID uuid.UUID) ([]ChatMessage, error) {
+	dbMessages, err := s.chatRepo.GetRecentChatMessages(ctx, database.GetRecentChatMessagesParams{
+		GameID: gameID,
+		Limit:  HISTORY_SIZE,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The query returns the newest first
+	messages := make([]ChatMessage, 0, len(dbMessages))
+	for i := len(dbMessages) - 1; i >= 0; i-- {
+		messages = append(messages, s.DBToModel(dbMessages[i]))
+	}
+
+	return messages, nil
+}
+
+// allow records a message from the player and reports whether it is within
+// the rate limit
+func (s *Service) allow(playerID uuid.UUID, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Drop the timestamps that fell out of the window
+	windowStart := now.Add(-RATE_LIMIT_WINDOW)
+	s.sweep(now, windowStart)
+	recent := s.recent[playerID][:0]
+	for _, sentAt := range s.recent[playerID] {
+		if sentAt.After(windowStart) {
+			recent = append(recent, sentAt)
+		}
+	}
+
+	if len(recent) >= RATE_LIMIT_MESSAGES {
+		s.recent[playerID] = recent
+		return false
+	}
+
+	s.recent[playerID] = append(recent, now)
+	return true
+}
+
+// sweep forgets the players without messages in the window, at most once
+// per window, so players don't stay in memory once they stop chatting. The
+// caller must hold the lock
+func (s *Service) sweep(now time.Time, windowStart time.Time) {
+	if now.Sub(s.lastSweep) < RATE_LIMIT_WINDOW {
+		return
+	}
+	s.lastSweep = now
+
+	for playerID, recent := range s.recent {
+		if len(recent) == 0 || !recent[len(recent)-1].After(windowStart) {
+			delete(s.recent, playerID)
+		}
+	}
+}
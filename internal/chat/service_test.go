@@ -0,0 +1,140 @@
+package chat_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NachoGz/switcher-backend-go/internal/chat"
+	chat_mock "github.com/NachoGz/switcher-backend-go/internal/chat/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/database"
+	player_mock "github.com/NachoGz/switcher-backend-go/internal/player/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSendMessage(t *testing.T) {
+	// Create mock repositories
+	mockChatRepo := new(chat_mock.MockChatRepository)
+	mockPlayerRepo := new(player_mock.MockPlayerRepository)
+
+	service := chat.NewService(mockChatRepo, mockPlayerRepo,
+		chat.NewProfanityFilter([]string{"darn"}),
+		chat.LinkFilter{},
+	)
+
+	// Test data
+	gameID := uuid.New()
+	playerID := uuid.New()
+
+	mockPlayerRepo.On("GetPlayerByID", mock.Anything, database.GetPlayerByIDParams{GameID: gameID, ID: playerID}).
+		Return(database.Player{ID: playerID, Name: "Player1", GameID: gameID}, nil)
+
+	// The profanity is masked before storing the message
+	mockChatRepo.On("CreateChatMessage", mock.Anything, mock.MatchedBy(func(params database.CreateChatMessageParams) bool {
+		return params.GameID == gameID &&
+			params.PlayerID.UUID == playerID &&
+			params.PlayerName == "Player1" &&
+			params.Content == "well **** it" &&
+			!params.System
+	})).Return(database.ChatMessage{
+		ID:         uuid.New(),
+		GameID:     gameID,
+		PlayerID:   uuid.NullUUID{UUID: playerID, Valid: true},
+		PlayerName: "Player1",
+		Content:    "well **** it",
+		CreatedAt:  time.Now(),
+	}, nil)
+
+	message, err := service.SendMessage(context.Background(), gameID, playerID, "  well DARN it ")
+
+	assert.NoError(t, err)
+	assert.NotNil(t, message)
+	assert.Equal(t, "well **** it", message.Content)
+	assert.Equal(t, playerID, message.PlayerID)
+
+	mockChatRepo.AssertExpectations(t)
+	mockPlayerRepo.AssertExpectations(t)
+}
+
+func TestSendMessage_Invalid(t *testing.T) {
+	mockChatRepo := new(chat_mock.MockChatRepository)
+	mockPlayerRepo := new(player_mock.MockPlayerRepository)
+
+	service := chat.NewService(mockChatRepo, mockPlayerRepo, chat.LinkFilter{})
+
+	gameID := uuid.New()
+	playerID := uuid.New()
+
+	mockPlayerRepo.On("GetPlayerByID", mock.Anything, mock.Anything).
+		Return(database.Player{ID: playerID, Name: "Player1", GameID: gameID}, nil)
+
+	_, err := service.SendMessage(context.Background(), gameID, playerID, "   ")
+	assert.ErrorIs(t, err, chat.ErrEmptyMessage)
+
+	_, err = service.SendMessage(context.Background(), gameID, playerID, strings.Repeat("a", chat.MAX_MESSAGE_LENGTH+1))
+	assert.ErrorIs(t, err, chat.ErrMessageTooLong)
+
+	_, err = service.SendMessage(context.Background(), gameID, playerID, "join my game at https://example.com")
+	assert.ErrorIs(t, err, chat.ErrMessageBlocked)
+
+	// Nothing was stored
+	mockChatRepo.AssertNotCalled(t, "CreateChatMessage")
+}
+
+func TestSendMessage_RateLimited(t *testing.T) {
+	mockChatRepo := new(chat_mock.MockChatRepository)
+	mockPlayerRepo := new(player_mock.MockPlayerRepository)
+
+	service := chat.NewService(mockChatRepo, mockPlayerRepo)
+
+	gameID := uuid.New()
+	playerID := uuid.New()
+
+	mockPlayerRepo.On("GetPlayerByID", mock.Anything, mock.Anything).
+		Return(database.Player{ID: playerID, Name: "Player1", GameID: gameID}, nil)
+	mockChatRepo.On("CreateChatMessage", mock.Anything, mock.Anything).
+		Return(database.ChatMessage{ID: uuid.New(), GameID: gameID, Content: "hi"}, nil)
+
+	for i := 0; i < chat.RATE_LIMIT_MESSAGES; i++ {
+		_, err := service.SendMessage(context.Background(), gameID, playerID, "hi")
+		assert.NoError(t, err)
+	}
+
+	_, err := service.SendMessage(context.Background(), gameID, playerID, "hi")
+	assert.ErrorIs(t, err, chat.ErrRateLimited)
+
+	// Other players are not affected
+	_, err = service.SendMessage(context.Background(), gameID, uuid.New(), "hi")
+	assert.NoError(t, err)
+
+	mockChatRepo.AssertNumberOfCalls(t, "CreateChatMessage", chat.RATE_LIMIT_MESSAGES+1)
+}
+
+func TestGetHistory(t *testing.T) {
+	mockChatRepo := new(chat_mock.MockChatRepository)
+	mockPlayerRepo := new(player_mock.MockPlayerRepository)
+
+	service := chat.NewService(mockChatRepo, mockPlayerRepo)
+
+	gameID := uuid.New()
+	now := time.Now()
+
+	// The repository returns the newest first
+	mockChatRepo.On("GetRecentChatMessages", mock.Anything, database.GetRecentChatMessagesParams{
+		GameID: gameID,
+		Limit:  chat.HISTORY_SIZE,
+	}).Return([]database.ChatMessage{
+		{ID: uuid.New(), GameID: gameID, Content: "second", CreatedAt: now},
+		{ID: uuid.New(), GameID: gameID, Content: "first", CreatedAt: now.Add(-time.Minute)},
+	}, nil)
+
+	history, err := service.GetHistory(context.Background(), gameID)
+
+	assert.NoError(t, err)
+	assert.Len(t, history, 2)
+	assert.Equal(t, "first", history[0].Content)
+	assert.Equal(t, "second", history[1].Content)
+}
@@ -0,0 +1,231 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NachoGz/switcher-backend-go/internal/janitor"
+	"github.com/NachoGz/switcher-backend-go/internal/logging"
+	"github.com/NachoGz/switcher-backend-go/internal/origins"
+	"github.com/NachoGz/switcher-backend-go/internal/ruleSet"
+	"github.com/NachoGz/switcher-backend-go/internal/websocket"
+)
+
+const (
+	DEFAULT_PORT             = "8080"
+	DEFAULT_DRAIN_DELAY      = 5 * time.Second
+	DEFAULT_SHUTDOWN_TIMEOUT = 15 * time.Second
+)
+
+var ErrInvalidConfig = errors.New("invalid configuration")
+
+// Config is everything the server can be configured with. Each field is
+// read from the YAML key and the environment variable in its tags, the
+// environment winning
+type Config struct {
+	Port  string `yaml:"port" env:"PORT"`
+	DBURL string `yaml:"db_url" env:"DB_URL"`
+	// Debug, info, warn or error
+	LogLevel string `yaml:"log_level" env:"LOG_LEVEL"`
+	// Secret used to sign invites, random on every start if empty
+	TokenSecret string `yaml:"token_secret" env:"TOKEN_SECRET"`
+	// Address the invite links point to, usually the frontend
+	InviteBaseURL string `yaml:"invite_base_url" env:"INVITE_BASE_URL"`
+	// Words masked in the in-game chat
+	ChatBlockedWords []string `yaml:"chat_blocked_words" env:"CHAT_BLOCKED_WORDS"`
+	// Time a player has to play a turn in games on the classic rules, after
+	// which the turn passes to the next player
+	TurnDuration time.Duration `yaml:"turn_duration" env:"TURN_DURATION"`
+
+	Database  DatabaseConfig  `yaml:"database"`
+	CORS      CORSConfig      `yaml:"cors"`
+	Websocket WebsocketConfig `yaml:"websocket"`
+	Janitor   JanitorConfig   `yaml:"janitor"`
+	Shutdown  ShutdownConfig  `yaml:"shutdown"`
+}
+
+// DatabaseConfig sizes the connection pool. Zero means no limit
+type DatabaseConfig struct {
+	MaxOpenConns    int           `yaml:"max_open_conns" env:"DB_MAX_OPEN_CONNS"`
+	MaxIdleConns    int           `yaml:"max_idle_conns" env:"DB_MAX_IDLE_CONNS"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" env:"DB_CONN_MAX_LIFETIME"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time" env:"DB_CONN_MAX_IDLE_TIME"`
+}
+
+type CORSConfig struct {
+	// Origins allowed to call the API and open websockets, "*" allowing
+	// any and https://*.example.com the subdomains of example.com
+	AllowedOrigins []string `yaml:"allowed_origins" env:"CORS_ALLOWED_ORIGINS"`
+	// Whether browsers send cookies and authorization headers. Not allowed
+	// with "*"
+	AllowCredentials bool `yaml:"allow_credentials" env:"CORS_ALLOW_CREDENTIALS"`
+}
+
+type WebsocketConfig struct {
+	WriteWait       time.Duration `yaml:"write_wait" env:"WS_WRITE_WAIT"`
+	PongWait        time.Duration `yaml:"pong_wait" env:"WS_PONG_WAIT"`
+	MaxMessageSize  int64         `yaml:"max_message_size" env:"WS_MAX_MESSAGE_SIZE"`
+	ReadBufferSize  int           `yaml:"read_buffer_size" env:"WS_READ_BUFFER_SIZE"`
+	WriteBufferSize int           `yaml:"write_buffer_size" env:"WS_WRITE_BUFFER_SIZE"`
+	SendBufferSize  int           `yaml:"send_buffer_size" env:"WS_SEND_BUFFER_SIZE"`
+}
+
+// JanitorConfig is how long idle games are kept
+type JanitorConfig struct {
+	Interval     time.Duration `yaml:"interval" env:"JANITOR_INTERVAL"`
+	LobbyTimeout time.Duration `yaml:"lobby_timeout" env:"LOBBY_TIMEOUT"`
+	MatchTimeout time.Duration `yaml:"match_timeout" env:"MATCH_TIMEOUT"`
+	Retention    time.Duration `yaml:"retention" env:"GAME_RETENTION"`
+}
+
+// ShutdownConfig is how long readiness fails before the server stops, then
+// how long requests in flight have to finish
+type ShutdownConfig struct {
+	DrainDelay time.Duration `yaml:"drain_delay" env:"SHUTDOWN_DRAIN_DELAY"`
+	Timeout    time.Duration `yaml:"timeout" env:"SHUTDOWN_TIMEOUT"`
+}
+
+// Default is the configuration used for whatever isn't set
+func Default() Config {
+	limits := websocket.DefaultLimits()
+	janitorConfig := janitor.DefaultConfig()
+
+	return Config{
+		Port:         DEFAULT_PORT,
+		LogLevel:     strings.ToLower(logging.DEFAULT_LEVEL.String()),
+		TurnDuration: ruleSet.DEFAULT_TURN_DURATION * time.Second,
+		CORS: CORSConfig{
+			AllowedOrigins: []string{"*"},
+		},
+		Websocket: WebsocketConfig{
+			WriteWait:       limits.WriteWait,
+			PongWait:        limits.PongWait,
+			MaxMessageSize:  limits.MaxMessageSize,
+			ReadBufferSize:  limits.ReadBufferSize,
+			WriteBufferSize: limits.WriteBufferSize,
+			SendBufferSize:  limits.SendBufferSize,
+		},
+		Janitor: JanitorConfig{
+			Interval:     janitorConfig.Interval,
+			LobbyTimeout: janitorConfig.LobbyTimeout,
+			MatchTimeout: janitorConfig.MatchTimeout,
+			Retention:    janitorConfig.Retention,
+		},
+		Shutdown: ShutdownConfig{
+			DrainDelay: DEFAULT_DRAIN_DELAY,
+			Timeout:    DEFAULT_SHUTDOWN_TIMEOUT,
+		},
+	}
+}
+
+// Validate reports every problem of the configuration at once
+func (c Config) Validate() error {
+	var errs []error
+	invalid := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	if port, err := strconv.Atoi(c.Port); err != nil || port < 1 || port > 65535 {
+		invalid("port %q is not a port number", c.Port)
+	}
+
+	if c.DBURL == "" {
+		invalid("db_url is not set")
+	} else if u, err := url.Parse(c.DBURL); err != nil || (u.Scheme != "postgres" && u.Scheme != "postgresql") {
+		invalid("db_url must be a postgres:// URL")
+	}
+
+	if _, err := logging.ParseLevel(c.LogLevel); err != nil {
+		invalid("%v", err)
+	}
+
+	if c.InviteBaseURL != "" {
+		if u, err := url.Parse(c.InviteBaseURL); err != nil || u.Scheme == "" || u.Host == "" {
+			invalid("invite_base_url %q is not an absolute URL", c.InviteBaseURL)
+		}
+	}
+
+	if c.TurnDuration%time.Second != 0 {
+		invalid("turn_duration must be whole seconds")
+	} else if c.TurnDuration < ruleSet.MIN_TURN_DURATION*time.Second || c.TurnDuration > ruleSet.MAX_TURN_DURATION*time.Second {
+		invalid("turn_duration must be between %ds and %ds", ruleSet.MIN_TURN_DURATION, ruleSet.MAX_TURN_DURATION)
+	}
+
+	db := c.Database
+	if db.MaxOpenConns < 0 || db.MaxIdleConns < 0 || db.ConnMaxLifetime < 0 || db.ConnMaxIdleTime < 0 {
+		invalid("database pool settings can't be negative")
+	} else if db.MaxOpenConns > 0 && db.MaxIdleConns > db.MaxOpenConns {
+		invalid("database.max_idle_conns can't be more than database.max_open_conns")
+	}
+
+	if len(c.CORS.AllowedOrigins) == 0 {
+		invalid("cors.allowed_origins is empty, no browser could call the API")
+	}
+	if allowed, err := c.Origins(); err != nil {
+		invalid("cors.allowed_origins: %w", err)
+	} else if allowed.AllowsAny() && c.CORS.AllowCredentials {
+		invalid("cors.allow_credentials can't be used with the origin *, any site could act for its visitors")
+	}
+
+	if err := c.WebsocketLimits().Validate(); err != nil {
+		invalid("%v", err)
+	}
+	if err := c.JanitorConfig().Validate(); err != nil {
+		invalid("janitor durations must be positive")
+	}
+	if c.Shutdown.DrainDelay < 0 || c.Shutdown.Timeout <= 0 {
+		invalid("shutdown.drain_delay can't be negative and shutdown.timeout must be positive")
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: %w", ErrInvalidConfig, errors.Join(errs...))
+	}
+	return nil
+}
+
+// Origins are the origins allowed to call the API and open websockets
+func (c Config) Origins() (*origins.AllowList, error) {
+	return origins.NewAllowList(c.CORS.AllowedOrigins)
+}
+
+// WebsocketLimits are the limits of the websocket connections
+func (c Config) WebsocketLimits() websocket.Limits {
+	return websocket.Limits{
+		WriteWait:       c.Websocket.WriteWait,
+		PongWait:        c.Websocket.PongWait,
+		MaxMessageSize:  c.Websocket.MaxMessageSize,
+		ReadBufferSize:  c.Websocket.ReadBufferSize,
+		WriteBufferSize: c.Websocket.WriteBufferSize,
+		SendBufferSize:  c.Websocket.SendBufferSize,
+	}
+}
+
+// JanitorConfig is the configuration of the janitor service
+func (c Config) JanitorConfig() janitor.Config {
+	return janitor.Config{
+		Interval:     c.Janitor.Interval,
+		LobbyTimeout: c.Janitor.LobbyTimeout,
+		MatchTimeout: c.Janitor.MatchTimeout,
+		Retention:    c.Janitor.Retention,
+	}
+}
+
+// Redacted is a copy of the configuration that is safe to print, without
+// the token secret nor the database password
+func (c Config) Redacted() Config {
+	if c.TokenSecret != "" {
+		c.TokenSecret = logging.REDACTED
+	}
+	if u, err := url.Parse(c.DBURL); err != nil {
+		c.DBURL = logging.REDACTED
+	} else {
+		c.DBURL = u.Redacted()
+	}
+	c.ChatBlockedWords = append([]string(nil), c.ChatBlockedWords...)
+	c.CORS.AllowedOrigins = append([]string(nil), c.CORS.AllowedOrigins...)
+	return c
+}
@@ -0,0 +1,132 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// Sources are the files the configuration is read from
+type Sources struct {
+	// Variables loaded into the environment unless already set. Missing
+	// files are skipped
+	EnvFile string
+	// Optional YAML file, overridden by the environment
+	File string
+}
+
+// Load reads the configuration over the defaults, first from the YAML file
+// and then from the environment. It doesn't validate it
+func Load(sources Sources) (Config, error) {
+	cfg := Default()
+
+	if sources.EnvFile != "" {
+		if err := godotenv.Load(sources.EnvFile); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return cfg, fmt.Errorf("error reading %s: %w", sources.EnvFile, err)
+		}
+	}
+
+	if sources.File != "" {
+		data, err := os.ReadFile(sources.File)
+		if err != nil {
+			return cfg, fmt.Errorf("error reading config file: %w", err)
+		}
+		if err := decodeYAML(data, &cfg); err != nil {
+			return cfg, fmt.Errorf("error parsing %s: %w", sources.File, err)
+		}
+	}
+
+	if err := loadEnv(reflect.ValueOf(&cfg).Elem(), os.LookupEnv); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// decodeYAML rejects unknown keys, which are most likely typos
+func decodeYAML(data []byte, cfg *Config) error {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
+
+// loadEnv sets the fields of a struct from the variables of their env tags,
+// going into nested structs
+func loadEnv(v reflect.Value, lookup func(string) (string, bool)) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() == reflect.Struct {
+			if err := loadEnv(field, lookup); err != nil {
+				return err
+			}
+			continue
+		}
+
+		key := t.Field(i).Tag.Get("env")
+		if key == "" {
+			continue
+		}
+		value, ok := lookup(key)
+		if !ok {
+			continue
+		}
+		if err := setField(field, value); err != nil {
+			return fmt.Errorf("invalid %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+func setField(field reflect.Value, value string) error {
+	value = strings.TrimSpace(value)
+
+	switch {
+	case field.Type() == durationType:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+	case field.Kind() == reflect.String:
+		field.SetString(value)
+	case field.Kind() == reflect.Int || field.Kind() == reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case field.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.String:
+		// Comma separated, empty items are dropped
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		field.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+	return nil
+}
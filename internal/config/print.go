@@ -0,0 +1,18 @@
+package config
+
+import (
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Print writes the configuration as YAML, with its secrets redacted. The
+// output can be used as a config file once the secrets are filled in
+func Print(w io.Writer, cfg Config) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(cfg.Redacted()); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
@@ -139,3 +139,43 @@ func (q *Queries) GetBox(ctx context.Context, arg GetBoxParams) (Box, error) {
 	)
 	return i, err
 }
+
+const getBoxesByGame = `-- name: GetBoxesByGame :many
+SELECT id, color, pos_x, pos_y, game_id, board_id, highlight, figure_id, figure_type
+FROM boxes
+WHERE game_id = $1
+ORDER BY pos_y, pos_x
+`
+
+func (q *Queries) GetBoxesByGame(ctx context.Context, gameID uuid.UUID) ([]Box, error) {
+	rows, err := q.db.QueryContext(ctx, getBoxesByGame, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Box
+	for rows.Next() {
+		var i Box
+		if err := rows.Scan(
+			&i.ID,
+			&i.Color,
+			&i.PosX,
+			&i.PosY,
+			&i.GameID,
+			&i.BoardID,
+			&i.Highlight,
+			&i.FigureID,
+			&i.FigureType,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,104 @@
+// Package databasetest opens databases for tests of the services that run
+// their work in transactions, without a real Postgres behind them
+package databasetest
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync"
+	"testing"
+)
+
+// Transactions counts the transactions begun on a test database and how
+// each of them ended
+type Transactions struct {
+	mu        sync.Mutex
+	begun     int
+	committed int
+	rolled    int
+}
+
+// Begun returns how many transactions were started
+func (t *Transactions) Begun() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.begun
+}
+
+// Committed returns how many transactions were committed
+func (t *Transactions) Committed() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.committed
+}
+
+// RolledBack returns how many transactions were rolled back
+func (t *Transactions) RolledBack() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.rolled
+}
+
+// NewDB opens a database that only supports transactions. The queries
+// themselves are meant to go through mocked repositories, running one fails
+func NewDB(t *testing.T) (*sql.DB, *Transactions) {
+	t.Helper()
+
+	txs := &Transactions{}
+	db := sql.OpenDB(connector{txs: txs})
+	t.Cleanup(func() { db.Close() })
+	return db, txs
+}
+
+var errNoQueries = errors.New("databasetest: queries aren't supported")
+
+type connector struct {
+	txs *Transactions
+}
+
+func (c connector) Connect(context.Context) (driver.Conn, error) {
+	return &conn{txs: c.txs}, nil
+}
+
+func (c connector) Driver() driver.Driver {
+	return nil
+}
+
+type conn struct {
+	txs *Transactions
+}
+
+func (c *conn) Prepare(string) (driver.Stmt, error) {
+	return nil, errNoQueries
+}
+
+func (c *conn) Close() error {
+	return nil
+}
+
+func (c *conn) Begin() (driver.Tx, error) {
+	c.txs.mu.Lock()
+	defer c.txs.mu.Unlock()
+	c.txs.begun++
+	return &tx{txs: c.txs}, nil
+}
+
+type tx struct {
+	txs *Transactions
+}
+
+func (t *tx) Commit() error {
+	t.txs.mu.Lock()
+	defer t.txs.mu.Unlock()
+	t.txs.committed++
+	return nil
+}
+
+func (t *tx) Rollback() error {
+	t.txs.mu.Lock()
+	defer t.txs.mu.Unlock()
+	t.txs.rolled++
+	return nil
+}
@@ -14,10 +14,10 @@ import (
 
 const createFigureCard = `-- name: CreateFigureCard :one
 INSERT INTO
-	figure_cards (id, show, player_id, game_id, type, blocked, soft_blocked, difficulty)
+	figure_cards (id, show, player_id, game_id, type, blocked, soft_blocked, difficulty, position)
 VALUES
-	($1, $2, $3, $4, $5, $6 , $7, $8)
-RETURNING id, show, difficulty, player_id, game_id, type, blocked, soft_blocked
+	($1, $2, $3, $4, $5, $6 , $7, $8, $9)
+RETURNING id, show, difficulty, player_id, game_id, type, blocked, soft_blocked, position
 `
 
 type CreateFigureCardParams struct {
@@ -29,6 +29,7 @@ type CreateFigureCardParams struct {
 	Blocked     bool
 	SoftBlocked bool
 	Difficulty  sql.NullString
+	Position    sql.NullInt32
 }
 
 func (q *Queries) CreateFigureCard(ctx context.Context, arg CreateFigureCardParams) (FigureCard, error) {
@@ -41,6 +42,7 @@ func (q *Queries) CreateFigureCard(ctx context.Context, arg CreateFigureCardPara
 		arg.Blocked,
 		arg.SoftBlocked,
 		arg.Difficulty,
+		arg.Position,
 	)
 	var i FigureCard
 	err := row.Scan(
@@ -52,6 +54,162 @@ func (q *Queries) CreateFigureCard(ctx context.Context, arg CreateFigureCardPara
 		&i.Type,
 		&i.Blocked,
 		&i.SoftBlocked,
+		&i.Position,
 	)
 	return i, err
 }
+
+const deleteFigureCard = `-- name: DeleteFigureCard :exec
+DELETE FROM figure_cards
+WHERE id = $1
+`
+
+func (q *Queries) DeleteFigureCard(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteFigureCard, id)
+	return err
+}
+
+const getFigureCardsByGame = `-- name: GetFigureCardsByGame :many
+SELECT id, show, difficulty, player_id, game_id, type, blocked, soft_blocked, position
+FROM figure_cards
+WHERE game_id = $1
+`
+
+func (q *Queries) GetFigureCardsByGame(ctx context.Context, gameID uuid.UUID) ([]FigureCard, error) {
+	rows, err := q.db.QueryContext(ctx, getFigureCardsByGame, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FigureCard
+	for rows.Next() {
+		var i FigureCard
+		if err := rows.Scan(
+			&i.ID,
+			&i.Show,
+			&i.Difficulty,
+			&i.PlayerID,
+			&i.GameID,
+			&i.Type,
+			&i.Blocked,
+			&i.SoftBlocked,
+			&i.Position,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getFigureCardsByPlayer = `-- name: GetFigureCardsByPlayer :many
+SELECT id, show, difficulty, player_id, game_id, type, blocked, soft_blocked, position
+FROM figure_cards
+WHERE player_id = $1
+ORDER BY position
+`
+
+func (q *Queries) GetFigureCardsByPlayer(ctx context.Context, playerID uuid.UUID) ([]FigureCard, error) {
+	rows, err := q.db.QueryContext(ctx, getFigureCardsByPlayer, playerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FigureCard
+	for rows.Next() {
+		var i FigureCard
+		if err := rows.Scan(
+			&i.ID,
+			&i.Show,
+			&i.Difficulty,
+			&i.PlayerID,
+			&i.GameID,
+			&i.Type,
+			&i.Blocked,
+			&i.SoftBlocked,
+			&i.Position,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getShownFigureCardsByGame = `-- name: GetShownFigureCardsByGame :many
+SELECT id, show, difficulty, player_id, game_id, type, blocked, soft_blocked, position
+FROM figure_cards
+WHERE game_id = $1 AND show = true
+`
+
+func (q *Queries) GetShownFigureCardsByGame(ctx context.Context, gameID uuid.UUID) ([]FigureCard, error) {
+	rows, err := q.db.QueryContext(ctx, getShownFigureCardsByGame, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FigureCard
+	for rows.Next() {
+		var i FigureCard
+		if err := rows.Scan(
+			&i.ID,
+			&i.Show,
+			&i.Difficulty,
+			&i.PlayerID,
+			&i.GameID,
+			&i.Type,
+			&i.Blocked,
+			&i.SoftBlocked,
+			&i.Position,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setFigureCardBlocked = `-- name: SetFigureCardBlocked :exec
+UPDATE figure_cards
+SET blocked = $2
+WHERE id = $1
+`
+
+type SetFigureCardBlockedParams struct {
+	ID      uuid.UUID
+	Blocked bool
+}
+
+func (q *Queries) SetFigureCardBlocked(ctx context.Context, arg SetFigureCardBlockedParams) error {
+	_, err := q.db.ExecContext(ctx, setFigureCardBlocked, arg.ID, arg.Blocked)
+	return err
+}
+
+const showFigureCard = `-- name: ShowFigureCard :exec
+UPDATE figure_cards
+SET show = true
+WHERE id = $1
+`
+
+func (q *Queries) ShowFigureCard(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, showFigureCard, id)
+	return err
+}
@@ -12,6 +12,40 @@ import (
 	"github.com/google/uuid"
 )
 
+const countGamesByState = `-- name: CountGamesByState :many
+SELECT state, COUNT(*) AS games
+FROM game_state
+GROUP BY state
+`
+
+type CountGamesByStateRow struct {
+	State string
+	Games int64
+}
+
+func (q *Queries) CountGamesByState(ctx context.Context) ([]CountGamesByStateRow, error) {
+	rows, err := q.db.QueryContext(ctx, countGamesByState)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountGamesByStateRow
+	for rows.Next() {
+		var i CountGamesByStateRow
+		if err := rows.Scan(&i.State, &i.Games); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const createGameState = `-- name: CreateGameState :one
 INSERT INTO game_state (id, state, game_id, current_player_id, forbidden_color)
 VALUES ($1, $2, $3, $4, $5)
@@ -68,6 +102,26 @@ func (q *Queries) GetGameStateByGameID(ctx context.Context, gameID uuid.UUID) (G
 	return i, err
 }
 
+const transitionGameState = `-- name: TransitionGameState :execrows
+UPDATE game_state
+SET state=$1
+WHERE game_id=$2 AND state=$3
+`
+
+type TransitionGameStateParams struct {
+	ToState   string
+	GameID    uuid.UUID
+	FromState string
+}
+
+func (q *Queries) TransitionGameState(ctx context.Context, arg TransitionGameStateParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, transitionGameState, arg.ToState, arg.GameID, arg.FromState)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const updateCurrentPlayer = `-- name: UpdateCurrentPlayer :exec
 UPDATE game_state
 SET current_player_id=$2
@@ -84,6 +138,22 @@ func (q *Queries) UpdateCurrentPlayer(ctx context.Context, arg UpdateCurrentPlay
 	return err
 }
 
+const updateForbiddenColor = `-- name: UpdateForbiddenColor :exec
+UPDATE game_state
+SET forbidden_color=$2
+WHERE game_id=$1
+`
+
+type UpdateForbiddenColorParams struct {
+	GameID         uuid.UUID
+	ForbiddenColor sql.NullString
+}
+
+func (q *Queries) UpdateForbiddenColor(ctx context.Context, arg UpdateForbiddenColorParams) error {
+	_, err := q.db.ExecContext(ctx, updateForbiddenColor, arg.GameID, arg.ForbiddenColor)
+	return err
+}
+
 const updateGameState = `-- name: UpdateGameState :exec
 UPDATE game_state
 SET state=$2
@@ -8,23 +8,70 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+const countSearchGames = `-- name: CountSearchGames :one
+WITH lobby AS (
+	SELECT games.id, games.name, games.max_players, games.min_players, games.is_private, games.password, games.created_at, games.updated_at, games.max_spectators, games.seed, games.rules, games.join_code, games.last_activity_at, COUNT(players.id)::INTEGER AS players_count
+	FROM games
+	JOIN game_state ON games.id = game_state.game_id
+	LEFT JOIN players ON games.id = players.game_id
+	WHERE game_state.state = 'waiting'
+	GROUP BY games.id
+)
+SELECT COUNT(*)
+FROM lobby
+WHERE ($1::TEXT IS NULL OR name ILIKE '%' || $1::TEXT || '%')
+	AND ($2::BOOLEAN IS NULL OR is_private = $2::BOOLEAN)
+	AND ($3::INTEGER IS NULL OR players_count = $3::INTEGER)
+	AND max_players - players_count >= $4::INTEGER
+	AND ($5::INTEGER IS NULL OR min_players >= $5::INTEGER)
+	AND ($6::INTEGER IS NULL OR max_players <= $6::INTEGER)
+`
+
+type CountSearchGamesParams struct {
+	Name         sql.NullString
+	IsPrivate    sql.NullBool
+	PlayersCount sql.NullInt32
+	MinFreeSlots int32
+	MinPlayers   sql.NullInt32
+	MaxPlayers   sql.NullInt32
+}
+
+func (q *Queries) CountSearchGames(ctx context.Context, arg CountSearchGamesParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countSearchGames,
+		arg.Name,
+		arg.IsPrivate,
+		arg.PlayersCount,
+		arg.MinFreeSlots,
+		arg.MinPlayers,
+		arg.MaxPlayers,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createGame = `-- name: CreateGame :one
-INSERT INTO games (id, name, max_players, min_players, is_private, password)
-VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, name, max_players, min_players, is_private, password, created_at, updated_at
+INSERT INTO games (id, name, max_players, min_players, is_private, password, max_spectators, seed, rules)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, name, max_players, min_players, is_private, password, created_at, updated_at, max_spectators, seed, rules, join_code, last_activity_at
 `
 
 type CreateGameParams struct {
-	ID         uuid.UUID
-	Name       string
-	MaxPlayers int32
-	MinPlayers int32
-	IsPrivate  bool
-	Password   sql.NullString
+	ID            uuid.UUID
+	Name          string
+	MaxPlayers    int32
+	MinPlayers    int32
+	IsPrivate     bool
+	Password      sql.NullString
+	MaxSpectators sql.NullInt32
+	Seed          int64
+	Rules         json.RawMessage
 }
 
 func (q *Queries) CreateGame(ctx context.Context, arg CreateGameParams) (Game, error) {
@@ -35,6 +82,9 @@ func (q *Queries) CreateGame(ctx context.Context, arg CreateGameParams) (Game, e
 		arg.MinPlayers,
 		arg.IsPrivate,
 		arg.Password,
+		arg.MaxSpectators,
+		arg.Seed,
+		arg.Rules,
 	)
 	var i Game
 	err := row.Scan(
@@ -46,6 +96,11 @@ func (q *Queries) CreateGame(ctx context.Context, arg CreateGameParams) (Game, e
 		&i.Password,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.MaxSpectators,
+		&i.Seed,
+		&i.Rules,
+		&i.JoinCode,
+		&i.LastActivityAt,
 	)
 	return i, err
 }
@@ -61,22 +116,199 @@ func (q *Queries) DeleteGame(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
-const getAvailableGames = `-- name: GetAvailableGames :many
-SELECT games.id, games.name, games.max_players, games.min_players, games.is_private, games.password, games.created_at, games.updated_at
+const getGameById = `-- name: GetGameById :one
+SELECT id, name, max_players, min_players, is_private, password, created_at, updated_at, max_spectators, seed, rules, join_code, last_activity_at
+FROM games
+WHERE id = $1
+`
+
+func (q *Queries) GetGameById(ctx context.Context, id uuid.UUID) (Game, error) {
+	row := q.db.QueryRowContext(ctx, getGameById, id)
+	var i Game
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.MaxPlayers,
+		&i.MinPlayers,
+		&i.IsPrivate,
+		&i.Password,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.MaxSpectators,
+		&i.Seed,
+		&i.Rules,
+		&i.JoinCode,
+		&i.LastActivityAt,
+	)
+	return i, err
+}
+
+const getGameByJoinCode = `-- name: GetGameByJoinCode :one
+SELECT id, name, max_players, min_players, is_private, password, created_at, updated_at, max_spectators, seed, rules, join_code, last_activity_at
 FROM games
-JOIN game_state ON games.id = game_state.game_id
-WHERE game_state.state = 'waiting'
+WHERE join_code = $1
+`
+
+func (q *Queries) GetGameByJoinCode(ctx context.Context, joinCode sql.NullString) (Game, error) {
+	row := q.db.QueryRowContext(ctx, getGameByJoinCode, joinCode)
+	var i Game
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.MaxPlayers,
+		&i.MinPlayers,
+		&i.IsPrivate,
+		&i.Password,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.MaxSpectators,
+		&i.Seed,
+		&i.Rules,
+		&i.JoinCode,
+		&i.LastActivityAt,
+	)
+	return i, err
+}
+
+const listInactiveGames = `-- name: ListInactiveGames :many
+SELECT id, state, last_activity_at
+FROM (
+	SELECT games.id, game_state.state, GREATEST(
+		games.last_activity_at,
+		(SELECT MAX(created_at) FROM game_events WHERE game_events.game_id = games.id),
+		(SELECT MAX(created_at) FROM chat_messages WHERE chat_messages.game_id = games.id),
+		(SELECT MAX(created_at) FROM players WHERE players.game_id = games.id)
+	)::TIMESTAMP AS last_activity_at
+	FROM games
+	JOIN game_state ON games.id = game_state.game_id
+	WHERE games.last_activity_at < $1
+) AS activity
+WHERE last_activity_at < $1
+ORDER BY last_activity_at
+`
+
+type ListInactiveGamesRow struct {
+	ID             uuid.UUID
+	State          string
+	LastActivityAt time.Time
+}
+
+// Moves, chat messages and players joining count as activity, along with
+// what was recorded with TouchGame
+func (q *Queries) ListInactiveGames(ctx context.Context, lastActivityAt time.Time) ([]ListInactiveGamesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listInactiveGames, lastActivityAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListInactiveGamesRow
+	for rows.Next() {
+		var i ListInactiveGamesRow
+		if err := rows.Scan(&i.ID, &i.State, &i.LastActivityAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchGames = `-- name: SearchGames :many
+WITH lobby AS (
+	SELECT games.id, games.name, games.max_players, games.min_players, games.is_private, games.password, games.created_at, games.updated_at, games.max_spectators, games.seed, games.rules, games.join_code, games.last_activity_at, COUNT(players.id)::INTEGER AS players_count
+	FROM games
+	JOIN game_state ON games.id = game_state.game_id
+	LEFT JOIN players ON games.id = players.game_id
+	WHERE game_state.state = 'waiting'
+	GROUP BY games.id
+)
+SELECT id, name, max_players, min_players, is_private, password, created_at, updated_at, max_spectators, seed, rules, join_code, last_activity_at, players_count
+FROM lobby
+WHERE ($1::TEXT IS NULL OR name ILIKE '%' || $1::TEXT || '%')
+	AND ($2::BOOLEAN IS NULL OR is_private = $2::BOOLEAN)
+	AND ($3::INTEGER IS NULL OR players_count = $3::INTEGER)
+	AND max_players - players_count >= $4::INTEGER
+	AND ($5::INTEGER IS NULL OR min_players >= $5::INTEGER)
+	AND ($6::INTEGER IS NULL OR max_players <= $6::INTEGER)
+	AND ($7::UUID IS NULL OR CASE $8::TEXT
+		WHEN 'oldest' THEN (created_at, id) > ($9::TIMESTAMP, $7::UUID)
+		WHEN 'name' THEN (LOWER(name), id) > ($10::TEXT, $7::UUID)
+		WHEN 'players' THEN (players_count, id) < ($11::INTEGER, $7::UUID)
+		WHEN 'free_slots' THEN (max_players - players_count, id) < ($11::INTEGER, $7::UUID)
+		ELSE (created_at, id) < ($9::TIMESTAMP, $7::UUID)
+	END)
+ORDER BY
+	CASE WHEN $8::TEXT = 'oldest' THEN created_at END ASC,
+	CASE WHEN $8::TEXT = 'name' THEN LOWER(name) END ASC,
+	CASE WHEN $8::TEXT = 'players' THEN players_count END DESC,
+	CASE WHEN $8::TEXT = 'free_slots' THEN max_players - players_count END DESC,
+	CASE WHEN $8::TEXT = 'newest' THEN created_at END DESC,
+	CASE WHEN $8::TEXT IN ('oldest', 'name') THEN id END ASC,
+	CASE WHEN $8::TEXT NOT IN ('oldest', 'name') THEN id END DESC
+LIMIT $12 OFFSET $13
 `
 
-func (q *Queries) GetAvailableGames(ctx context.Context) ([]Game, error) {
-	rows, err := q.db.QueryContext(ctx, getAvailableGames)
+type SearchGamesParams struct {
+	Name         sql.NullString
+	IsPrivate    sql.NullBool
+	PlayersCount sql.NullInt32
+	MinFreeSlots int32
+	MinPlayers   sql.NullInt32
+	MaxPlayers   sql.NullInt32
+	CursorID     uuid.NullUUID
+	Sort         string
+	CursorTime   sql.NullTime
+	CursorName   sql.NullString
+	CursorCount  sql.NullInt32
+	PageSize     int32
+	PageOffset   int32
+}
+
+type SearchGamesRow struct {
+	ID             uuid.UUID
+	Name           string
+	MaxPlayers     int32
+	MinPlayers     int32
+	IsPrivate      bool
+	Password       sql.NullString
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	MaxSpectators  sql.NullInt32
+	Seed           int64
+	Rules          json.RawMessage
+	JoinCode       sql.NullString
+	LastActivityAt time.Time
+	PlayersCount   int32
+}
+
+func (q *Queries) SearchGames(ctx context.Context, arg SearchGamesParams) ([]SearchGamesRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchGames,
+		arg.Name,
+		arg.IsPrivate,
+		arg.PlayersCount,
+		arg.MinFreeSlots,
+		arg.MinPlayers,
+		arg.MaxPlayers,
+		arg.CursorID,
+		arg.Sort,
+		arg.CursorTime,
+		arg.CursorName,
+		arg.CursorCount,
+		arg.PageSize,
+		arg.PageOffset,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Game
+	var items []SearchGamesRow
 	for rows.Next() {
-		var i Game
+		var i SearchGamesRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.Name,
@@ -86,6 +318,12 @@ func (q *Queries) GetAvailableGames(ctx context.Context) ([]Game, error) {
 			&i.Password,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.MaxSpectators,
+			&i.Seed,
+			&i.Rules,
+			&i.JoinCode,
+			&i.LastActivityAt,
+			&i.PlayersCount,
 		); err != nil {
 			return nil, err
 		}
@@ -100,24 +338,34 @@ func (q *Queries) GetAvailableGames(ctx context.Context) ([]Game, error) {
 	return items, nil
 }
 
-const getGameById = `-- name: GetGameById :one
-SELECT id, name, max_players, min_players, is_private, password, created_at, updated_at
-FROM games
+const setGameJoinCode = `-- name: SetGameJoinCode :exec
+UPDATE games
+SET join_code = $2, updated_at = NOW()
 WHERE id = $1
 `
 
-func (q *Queries) GetGameById(ctx context.Context, id uuid.UUID) (Game, error) {
-	row := q.db.QueryRowContext(ctx, getGameById, id)
-	var i Game
-	err := row.Scan(
-		&i.ID,
-		&i.Name,
-		&i.MaxPlayers,
-		&i.MinPlayers,
-		&i.IsPrivate,
-		&i.Password,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-	)
-	return i, err
+type SetGameJoinCodeParams struct {
+	ID       uuid.UUID
+	JoinCode sql.NullString
+}
+
+func (q *Queries) SetGameJoinCode(ctx context.Context, arg SetGameJoinCodeParams) error {
+	_, err := q.db.ExecContext(ctx, setGameJoinCode, arg.ID, arg.JoinCode)
+	return err
+}
+
+const touchGame = `-- name: TouchGame :exec
+UPDATE games
+SET last_activity_at = $2
+WHERE id = $1
+`
+
+type TouchGameParams struct {
+	ID             uuid.UUID
+	LastActivityAt time.Time
+}
+
+func (q *Queries) TouchGame(ctx context.Context, arg TouchGameParams) error {
+	_, err := q.db.ExecContext(ctx, touchGame, arg.ID, arg.LastActivityAt)
+	return err
 }
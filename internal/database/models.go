@@ -6,6 +6,7 @@ package database
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -30,6 +31,16 @@ type Box struct {
 	FigureType sql.NullString
 }
 
+type ChatMessage struct {
+	ID         uuid.UUID
+	GameID     uuid.UUID
+	PlayerID   uuid.NullUUID
+	PlayerName string
+	Content    string
+	System     bool
+	CreatedAt  time.Time
+}
+
 type FigureCard struct {
 	ID          uuid.UUID
 	Show        bool
@@ -39,17 +50,46 @@ type FigureCard struct {
 	Type        string
 	Blocked     bool
 	SoftBlocked bool
+	Position    sql.NullInt32
 }
 
 type Game struct {
-	ID         uuid.UUID
-	Name       string
-	MaxPlayers int32
-	MinPlayers int32
-	IsPrivate  bool
-	Password   sql.NullString
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
+	ID             uuid.UUID
+	Name           string
+	MaxPlayers     int32
+	MinPlayers     int32
+	IsPrivate      bool
+	Password       sql.NullString
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	MaxSpectators  sql.NullInt32
+	Seed           int64
+	Rules          json.RawMessage
+	JoinCode       sql.NullString
+	LastActivityAt time.Time
+}
+
+type GameBan struct {
+	ID        uuid.UUID
+	GameID    uuid.UUID
+	Name      string
+	UserID    uuid.NullUUID
+	CreatedAt time.Time
+}
+
+type GameEvent struct {
+	ID        uuid.UUID
+	GameID    uuid.UUID
+	Seq       int32
+	Type      string
+	PlayerID  uuid.NullUUID
+	Payload   json.RawMessage
+	CreatedAt time.Time
+}
+
+type GameEventCounter struct {
+	GameID uuid.UUID
+	Seq    int32
 }
 
 type GameState struct {
@@ -62,6 +102,30 @@ type GameState struct {
 	UpdatedAt       time.Time
 }
 
+type MatchParticipant struct {
+	ID               uuid.UUID
+	MatchID          uuid.UUID
+	PlayerID         uuid.UUID
+	UserID           uuid.NullUUID
+	Name             string
+	Bot              bool
+	Winner           bool
+	FiguresCompleted int32
+	Blocks           int32
+}
+
+type MatchResult struct {
+	ID              uuid.UUID
+	GameID          uuid.UUID
+	GameName        string
+	PlayerCount     int32
+	WinnerName      string
+	Turns           int32
+	DurationSeconds int32
+	StartedAt       time.Time
+	FinishedAt      time.Time
+}
+
 type MovementCard struct {
 	ID          uuid.UUID
 	Description string
@@ -95,4 +159,26 @@ type Player struct {
 	Winner      bool
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+	Bot         bool
+	BotLevel    sql.NullString
+	UserID      uuid.NullUUID
+	Ready       bool
+}
+
+type Session struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	TokenHash string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+type User struct {
+	ID           uuid.UUID
+	Username     string
+	PasswordHash string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	Rating       int32
+	RatedGames   int32
 }
@@ -85,10 +85,31 @@ func (q *Queries) CreateMovementCard(ctx context.Context, arg CreateMovementCard
 	return i, err
 }
 
+const discardMovementCard = `-- name: DiscardMovementCard :exec
+UPDATE movement_cards
+SET player_id = NULL, used = false, position = (
+	SELECT COALESCE(MAX(position), 0) + 1
+	FROM movement_cards
+	WHERE game_id = $2
+)
+WHERE id = $1
+`
+
+type DiscardMovementCardParams struct {
+	ID     uuid.UUID
+	GameID uuid.UUID
+}
+
+func (q *Queries) DiscardMovementCard(ctx context.Context, arg DiscardMovementCardParams) error {
+	_, err := q.db.ExecContext(ctx, discardMovementCard, arg.ID, arg.GameID)
+	return err
+}
+
 const getMovementCardDeck = `-- name: GetMovementCardDeck :many
 SELECT id, description, used, player_id, game_id, type, position
 FROM movement_cards
 WHERE game_id = $1 AND player_id IS NULL
+ORDER BY position
 `
 
 func (q *Queries) GetMovementCardDeck(ctx context.Context, gameID uuid.UUID) ([]MovementCard, error) {
@@ -122,6 +143,82 @@ func (q *Queries) GetMovementCardDeck(ctx context.Context, gameID uuid.UUID) ([]
 	return items, nil
 }
 
+const getMovementCardsByGame = `-- name: GetMovementCardsByGame :many
+SELECT id, description, used, player_id, game_id, type, position
+FROM movement_cards
+WHERE game_id = $1
+ORDER BY position
+`
+
+func (q *Queries) GetMovementCardsByGame(ctx context.Context, gameID uuid.UUID) ([]MovementCard, error) {
+	rows, err := q.db.QueryContext(ctx, getMovementCardsByGame, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []MovementCard
+	for rows.Next() {
+		var i MovementCard
+		if err := rows.Scan(
+			&i.ID,
+			&i.Description,
+			&i.Used,
+			&i.PlayerID,
+			&i.GameID,
+			&i.Type,
+			&i.Position,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMovementCardsByPlayer = `-- name: GetMovementCardsByPlayer :many
+SELECT id, description, used, player_id, game_id, type, position
+FROM movement_cards
+WHERE player_id = $1
+ORDER BY position
+`
+
+func (q *Queries) GetMovementCardsByPlayer(ctx context.Context, playerID uuid.NullUUID) ([]MovementCard, error) {
+	rows, err := q.db.QueryContext(ctx, getMovementCardsByPlayer, playerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []MovementCard
+	for rows.Next() {
+		var i MovementCard
+		if err := rows.Scan(
+			&i.ID,
+			&i.Description,
+			&i.Used,
+			&i.PlayerID,
+			&i.GameID,
+			&i.Type,
+			&i.Position,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const markCardInPlayerHand = `-- name: MarkCardInPlayerHand :exec
 UPDATE movement_cards
 SET used = false
@@ -132,3 +229,14 @@ func (q *Queries) MarkCardInPlayerHand(ctx context.Context, id uuid.UUID) error
 	_, err := q.db.ExecContext(ctx, markCardInPlayerHand, id)
 	return err
 }
+
+const markCardUsed = `-- name: MarkCardUsed :exec
+UPDATE movement_cards
+SET used = true
+WHERE id = $1
+`
+
+func (q *Queries) MarkCardUsed(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, markCardUsed, id)
+	return err
+}
@@ -71,6 +71,7 @@ const getPartialMovementsByPlayer = `-- name: GetPartialMovementsByPlayer :many
 SELECT id, pos_from_x, pos_from_y, pos_to_x, pos_to_y, game_id, player_id, movement_card_id, created_at, updated_at 
 FROM partial_movements
 WHERE game_id = $1 AND player_id = $2
+ORDER BY created_at DESC
 `
 
 type GetPartialMovementsByPlayerParams struct {
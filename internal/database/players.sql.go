@@ -40,9 +40,9 @@ func (q *Queries) CountPlayers(ctx context.Context, gameID uuid.UUID) (int64, er
 }
 
 const createPlayer = `-- name: CreatePlayer :one
-INSERT INTO players (id, name, turn, game_id, game_state_id, host)
-VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, name, turn, game_id, game_state_id, host, winner, created_at, updated_at
+INSERT INTO players (id, name, turn, game_id, game_state_id, host, bot, bot_level, user_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, name, turn, game_id, game_state_id, host, winner, created_at, updated_at, bot, bot_level, user_id, ready
 `
 
 type CreatePlayerParams struct {
@@ -52,6 +52,9 @@ type CreatePlayerParams struct {
 	GameID      uuid.UUID
 	GameStateID uuid.UUID
 	Host        bool
+	Bot         bool
+	BotLevel    sql.NullString
+	UserID      uuid.NullUUID
 }
 
 func (q *Queries) CreatePlayer(ctx context.Context, arg CreatePlayerParams) (Player, error) {
@@ -62,6 +65,9 @@ func (q *Queries) CreatePlayer(ctx context.Context, arg CreatePlayerParams) (Pla
 		arg.GameID,
 		arg.GameStateID,
 		arg.Host,
+		arg.Bot,
+		arg.BotLevel,
+		arg.UserID,
 	)
 	var i Player
 	err := row.Scan(
@@ -74,12 +80,32 @@ func (q *Queries) CreatePlayer(ctx context.Context, arg CreatePlayerParams) (Pla
 		&i.Winner,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Bot,
+		&i.BotLevel,
+		&i.UserID,
+		&i.Ready,
 	)
 	return i, err
 }
 
+const deletePlayer = `-- name: DeletePlayer :exec
+DELETE
+FROM players
+WHERE id = $1 AND game_id = $2
+`
+
+type DeletePlayerParams struct {
+	ID     uuid.UUID
+	GameID uuid.UUID
+}
+
+func (q *Queries) DeletePlayer(ctx context.Context, arg DeletePlayerParams) error {
+	_, err := q.db.ExecContext(ctx, deletePlayer, arg.ID, arg.GameID)
+	return err
+}
+
 const getPlayerByID = `-- name: GetPlayerByID :one
-SELECT id, name, turn, game_id, game_state_id, host, winner, created_at, updated_at
+SELECT id, name, turn, game_id, game_state_id, host, winner, created_at, updated_at, bot, bot_level, user_id, ready
 FROM players
 WHERE game_id=$1 AND id=$2
 `
@@ -102,14 +128,19 @@ func (q *Queries) GetPlayerByID(ctx context.Context, arg GetPlayerByIDParams) (P
 		&i.Winner,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Bot,
+		&i.BotLevel,
+		&i.UserID,
+		&i.Ready,
 	)
 	return i, err
 }
 
 const getPlayersInGame = `-- name: GetPlayersInGame :many
-SELECT id, name, turn, game_id, game_state_id, host, winner, created_at, updated_at
+SELECT id, name, turn, game_id, game_state_id, host, winner, created_at, updated_at, bot, bot_level, user_id, ready
 FROM players
 WHERE game_id=$1
+ORDER BY created_at, id
 `
 
 func (q *Queries) GetPlayersInGame(ctx context.Context, gameID uuid.UUID) ([]Player, error) {
@@ -131,6 +162,10 @@ func (q *Queries) GetPlayersInGame(ctx context.Context, gameID uuid.UUID) ([]Pla
 			&i.Winner,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Bot,
+			&i.BotLevel,
+			&i.UserID,
+			&i.Ready,
 		); err != nil {
 			return nil, err
 		}
@@ -146,7 +181,7 @@ func (q *Queries) GetPlayersInGame(ctx context.Context, gameID uuid.UUID) ([]Pla
 }
 
 const getWinner = `-- name: GetWinner :one
-SELECT id, name, turn, game_id, game_state_id, host, winner, created_at, updated_at
+SELECT id, name, turn, game_id, game_state_id, host, winner, created_at, updated_at, bot, bot_level, user_id, ready
 FROM players
 WHERE game_id = $1 AND winner = true limit 1
 `
@@ -164,6 +199,37 @@ func (q *Queries) GetWinner(ctx context.Context, gameID uuid.UUID) (Player, erro
 		&i.Winner,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Bot,
+		&i.BotLevel,
+		&i.UserID,
+		&i.Ready,
 	)
 	return i, err
 }
+
+const setPlayerReady = `-- name: SetPlayerReady :exec
+UPDATE players
+SET ready = $2, updated_at = NOW()
+WHERE id = $1
+`
+
+type SetPlayerReadyParams struct {
+	ID    uuid.UUID
+	Ready bool
+}
+
+func (q *Queries) SetPlayerReady(ctx context.Context, arg SetPlayerReadyParams) error {
+	_, err := q.db.ExecContext(ctx, setPlayerReady, arg.ID, arg.Ready)
+	return err
+}
+
+const setWinner = `-- name: SetWinner :exec
+UPDATE players
+SET winner = true, updated_at = NOW()
+WHERE id = $1
+`
+
+func (q *Queries) SetWinner(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, setWinner, id)
+	return err
+}
@@ -0,0 +1,63 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+)
+
+type txKey struct{}
+
+// contextDB runs each query in the transaction carried by its context, if any
+type contextDB struct {
+	db *sql.DB
+}
+
+// ContextDB wraps db so the queries made with a context returned by InTx run
+// in its transaction. Repositories sharing the same Queries join it without
+// knowing about it
+func ContextDB(db *sql.DB) DBTX {
+	return &contextDB{db: db}
+}
+
+func (c *contextDB) conn(ctx context.Context) DBTX {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return c.db
+}
+
+func (c *contextDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return c.conn(ctx).ExecContext(ctx, query, args...)
+}
+
+func (c *contextDB) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return c.conn(ctx).PrepareContext(ctx, query)
+}
+
+func (c *contextDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return c.conn(ctx).QueryContext(ctx, query, args...)
+}
+
+func (c *contextDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return c.conn(ctx).QueryRowContext(ctx, query, args...)
+}
+
+// InTx runs fn in a transaction, committed when fn returns no error and
+// rolled back otherwise. Called with a context already in a transaction, fn
+// joins it instead of starting another
+func InTx(ctx context.Context, db *sql.DB, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
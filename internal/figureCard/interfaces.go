@@ -4,14 +4,23 @@ import (
 	"context"
 
 	"github.com/NachoGz/switcher-backend-go/internal/database"
+	"github.com/NachoGz/switcher-backend-go/internal/ruleSet"
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
 	"github.com/google/uuid"
 )
 
 type FigureCardService interface {
-	CreateFigureCardDeck(ctx context.Context, gameID uuid.UUID) error
+	CreateFigureCardDeck(ctx context.Context, gameID uuid.UUID, rules ruleSet.RuleSet, rng utils.Randomizer) error
 	DBToModel(ctx context.Context, dbFigureCard database.FigureCard) FigureCard
+	GetShownFigureCards(ctx context.Context, gameID uuid.UUID) ([]FigureCard, error)
 }
 
 type FigureCardRepository interface {
 	CreateFigureCard(ctx context.Context, params database.CreateFigureCardParams) (database.FigureCard, error)
+	GetShownFigureCardsByGame(ctx context.Context, gameID uuid.UUID) ([]database.FigureCard, error)
+	GetFigureCardsByGame(ctx context.Context, gameID uuid.UUID) ([]database.FigureCard, error)
+	GetFigureCardsByPlayer(ctx context.Context, playerID uuid.UUID) ([]database.FigureCard, error)
+	ShowFigureCard(ctx context.Context, cardID uuid.UUID) error
+	DeleteFigureCard(ctx context.Context, cardID uuid.UUID) error
+	SetFigureCardBlocked(ctx context.Context, params database.SetFigureCardBlockedParams) error
 }
@@ -5,6 +5,8 @@ import (
 
 	"github.com/NachoGz/switcher-backend-go/internal/database"
 	"github.com/NachoGz/switcher-backend-go/internal/figureCard"
+	"github.com/NachoGz/switcher-backend-go/internal/ruleSet"
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 )
@@ -13,8 +15,8 @@ type MockFigureCardService struct {
 	mock.Mock
 }
 
-func (m *MockFigureCardService) CreateFigureCardDeck(ctx context.Context, gameID uuid.UUID) error {
-	args := m.Called(ctx, gameID)
+func (m *MockFigureCardService) CreateFigureCardDeck(ctx context.Context, gameID uuid.UUID, rules ruleSet.RuleSet, rng utils.Randomizer) error {
+	args := m.Called(ctx, gameID, rules, rng)
 	return args.Error(0)
 }
 
@@ -22,3 +24,8 @@ func (m *MockFigureCardService) DBToModel(ctx context.Context, dbFigureCard data
 	args := m.Called(ctx, dbFigureCard)
 	return args.Get(0).(figureCard.FigureCard)
 }
+
+func (m *MockFigureCardService) GetShownFigureCards(ctx context.Context, gameID uuid.UUID) ([]figureCard.FigureCard, error) {
+	args := m.Called(ctx, gameID)
+	return args.Get(0).([]figureCard.FigureCard), args.Error(1)
+}
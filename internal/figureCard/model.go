@@ -52,7 +52,6 @@ const (
 	HARD DifficultyEnum = "hard"
 )
 
-const SHOW_LIMIT = 3
 const AMOUNT_HARD_CARDS = 18
 const AMOUNT_EASY_CARDS = 7
 
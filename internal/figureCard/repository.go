@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/NachoGz/switcher-backend-go/internal/database"
+	"github.com/google/uuid"
 )
 
 // PostgresFigureCardRepository implements FigureCardRepository for Postgres
@@ -22,3 +23,33 @@ func NewFigureCardRepository(queries *database.Queries) FigureCardRepository {
 func (r *PostgresFigureCardRepository) CreateFigureCard(ctx context.Context, params database.CreateFigureCardParams) (database.FigureCard, error) {
 	return r.queries.CreateFigureCard(ctx, params)
 }
+
+// GetShownFigureCardsByGame fetches the figure cards that are face up in a game
+func (r *PostgresFigureCardRepository) GetShownFigureCardsByGame(ctx context.Context, gameID uuid.UUID) ([]database.FigureCard, error) {
+	return r.queries.GetShownFigureCardsByGame(ctx, gameID)
+}
+
+// GetFigureCardsByGame fetches every figure card of a game, shown or hidden
+func (r *PostgresFigureCardRepository) GetFigureCardsByGame(ctx context.Context, gameID uuid.UUID) ([]database.FigureCard, error) {
+	return r.queries.GetFigureCardsByGame(ctx, gameID)
+}
+
+// GetFigureCardsByPlayer fetches the figure cards of a player in deck order
+func (r *PostgresFigureCardRepository) GetFigureCardsByPlayer(ctx context.Context, playerID uuid.UUID) ([]database.FigureCard, error) {
+	return r.queries.GetFigureCardsByPlayer(ctx, playerID)
+}
+
+// ShowFigureCard turns a figure card face up
+func (r *PostgresFigureCardRepository) ShowFigureCard(ctx context.Context, cardID uuid.UUID) error {
+	return r.queries.ShowFigureCard(ctx, cardID)
+}
+
+// DeleteFigureCard removes a played figure card
+func (r *PostgresFigureCardRepository) DeleteFigureCard(ctx context.Context, cardID uuid.UUID) error {
+	return r.queries.DeleteFigureCard(ctx, cardID)
+}
+
+// SetFigureCardBlocked blocks or unblocks a figure card
+func (r *PostgresFigureCardRepository) SetFigureCardBlocked(ctx context.Context, params database.SetFigureCardBlockedParams) error {
+	return r.queries.SetFigureCardBlocked(ctx, params)
+}
@@ -5,11 +5,12 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
-	"math/rand"
 	"strings"
 
 	"github.com/NachoGz/switcher-backend-go/internal/database"
 	"github.com/NachoGz/switcher-backend-go/internal/player"
+	"github.com/NachoGz/switcher-backend-go/internal/ruleSet"
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
 	"github.com/google/uuid"
 )
 
@@ -33,7 +34,7 @@ func NewService(
 // Ensure Service implements FigureCardService
 var _ FigureCardService = (*Service)(nil)
 
-func (s *Service) CreateFigureCardDeck(ctx context.Context, gameID uuid.UUID) error {
+func (s *Service) CreateFigureCardDeck(ctx context.Context, gameID uuid.UUID, rules ruleSet.RuleSet, rng utils.Randomizer) error {
 	typesList := GetAllCardTypes()
 
 	// Create a list with card types
@@ -64,8 +65,8 @@ func (s *Service) CreateFigureCardDeck(ctx context.Context, gameID uuid.UUID) er
 		return errors.New("there are no players")
 	}
 
-	hardCardsPerPlayer := 36 / len(players)
-	easyCardsPerPlayer := 14 / len(players)
+	hardCardsPerPlayer := rules.HardFigures / len(players)
+	easyCardsPerPlayer := rules.EasyFigures / len(players)
 
 	// Check bounds
 	if hardCardsPerPlayer > len(hardCards) {
@@ -77,23 +78,23 @@ func (s *Service) CreateFigureCardDeck(ctx context.Context, gameID uuid.UUID) er
 
 	for _, player := range players {
 		// Shuffle the lists
-		rand.Shuffle(len(hardCards), func(i, j int) {
+		rng.Shuffle(len(hardCards), func(i, j int) {
 			hardCards[i], hardCards[j] = hardCards[j], hardCards[i]
 		})
-		rand.Shuffle(len(easyCards), func(i, j int) {
+		rng.Shuffle(len(easyCards), func(i, j int) {
 			easyCards[i], easyCards[j] = easyCards[j], easyCards[i]
 		})
 
 		playerCards := hardCards[:hardCardsPerPlayer]
 		playerCards = append(playerCards, easyCards[:easyCardsPerPlayer]...)
 
-		rand.Shuffle(len(playerCards), func(i, j int) {
+		rng.Shuffle(len(playerCards), func(i, j int) {
 			playerCards[i], playerCards[j] = playerCards[j], playerCards[i]
 		})
 
 		show := true
 		for i, figure := range playerCards {
-			if i == SHOW_LIMIT {
+			if i == rules.ShowLimit {
 				show = false
 			}
 			difficulty := HARD
@@ -110,6 +111,7 @@ func (s *Service) CreateFigureCardDeck(ctx context.Context, gameID uuid.UUID) er
 				Blocked:     false,
 				SoftBlocked: false,
 				Difficulty:  sql.NullString{String: string(difficulty), Valid: true},
+				Position:    sql.NullInt32{Int32: int32(i), Valid: true},
 			})
 			if err != nil {
 				return fmt.Errorf("failed to create figure card: %w", err)
@@ -118,3 +120,19 @@ func (s *Service) CreateFigureCardDeck(ctx context.Context, gameID uuid.UUID) er
 	}
 	return nil
 }
+
+// GetShownFigureCards fetches the face up figure cards of every player in the game.
+// Hidden cards never leave the database through this method.
+func (s *Service) GetShownFigureCards(ctx context.Context, gameID uuid.UUID) ([]FigureCard, error) {
+	dbCards, err := s.figureCardRepo.GetShownFigureCardsByGame(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	cards := []FigureCard{}
+	for _, card := range dbCards {
+		cards = append(cards, s.DBToModel(ctx, card))
+	}
+
+	return cards, nil
+}
@@ -0,0 +1,37 @@
+package figureCard
+
+// shapes holds the boxes of each figure in one orientation, as {x, y}.
+// Hard figures are the 18 one-sided pentominoes and easy figures the 7
+// one-sided tetrominoes, so no two figures match after rotating them
+var shapes = map[TypeEnum][][2]int{
+	FIG01:  {{1, 0}, {2, 0}, {0, 1}, {1, 1}, {1, 2}},
+	FIG02:  {{0, 0}, {1, 0}, {1, 1}, {2, 1}, {1, 2}},
+	FIG03:  {{0, 0}, {1, 0}, {2, 0}, {3, 0}, {4, 0}},
+	FIG04:  {{0, 0}, {0, 1}, {0, 2}, {0, 3}, {1, 3}},
+	FIG05:  {{1, 0}, {1, 1}, {1, 2}, {1, 3}, {0, 3}},
+	FIG06:  {{1, 0}, {1, 1}, {1, 2}, {0, 2}, {0, 3}},
+	FIG07:  {{0, 0}, {0, 1}, {0, 2}, {1, 2}, {1, 3}},
+	FIG08:  {{0, 0}, {1, 0}, {0, 1}, {1, 1}, {0, 2}},
+	FIG09:  {{0, 0}, {1, 0}, {0, 1}, {1, 1}, {1, 2}},
+	FIG10:  {{0, 0}, {1, 0}, {2, 0}, {1, 1}, {1, 2}},
+	FIG11:  {{0, 0}, {2, 0}, {0, 1}, {1, 1}, {2, 1}},
+	FIG12:  {{0, 0}, {0, 1}, {0, 2}, {1, 2}, {2, 2}},
+	FIG13:  {{0, 0}, {0, 1}, {1, 1}, {1, 2}, {2, 2}},
+	FIG14:  {{1, 0}, {0, 1}, {1, 1}, {2, 1}, {1, 2}},
+	FIG15:  {{1, 0}, {0, 1}, {1, 1}, {1, 2}, {1, 3}},
+	FIG16:  {{0, 0}, {0, 1}, {1, 1}, {0, 2}, {0, 3}},
+	FIG17:  {{0, 0}, {1, 0}, {1, 1}, {1, 2}, {2, 2}},
+	FIG18:  {{1, 0}, {2, 0}, {1, 1}, {0, 2}, {1, 2}},
+	FIGE01: {{0, 0}, {1, 0}, {2, 0}, {3, 0}},
+	FIGE02: {{0, 0}, {1, 0}, {0, 1}, {1, 1}},
+	FIGE03: {{0, 0}, {1, 0}, {2, 0}, {1, 1}},
+	FIGE04: {{1, 0}, {2, 0}, {0, 1}, {1, 1}},
+	FIGE05: {{0, 0}, {1, 0}, {1, 1}, {2, 1}},
+	FIGE06: {{0, 0}, {0, 1}, {0, 2}, {1, 2}},
+	FIGE07: {{1, 0}, {1, 1}, {1, 2}, {0, 2}},
+}
+
+// Shape returns the boxes of a figure in its card orientation, as {x, y}
+func Shape(figureType TypeEnum) [][2]int {
+	return shapes[figureType]
+}
@@ -0,0 +1,73 @@
+package game
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/NachoGz/switcher-backend-go/internal/database"
+	"github.com/google/uuid"
+)
+
+// cursor points right after the last game of a page. It holds the key the
+// page was sorted by and the game ID to break ties
+type cursor struct {
+	Sort  string    `json:"s"`
+	ID    uuid.UUID `json:"id"`
+	Time  time.Time `json:"t,omitempty"`
+	Name  string    `json:"n,omitempty"`
+	Count int       `json:"c,omitempty"`
+}
+
+// newCursor builds the cursor of the page ending with the given game
+func newCursor(sort string, row database.SearchGamesRow) cursor {
+	c := cursor{Sort: sort, ID: row.ID}
+	switch sort {
+	case SORT_NAME:
+		c.Name = row.Name
+	case SORT_PLAYERS:
+		c.Count = int(row.PlayersCount)
+	case SORT_FREE_SLOTS:
+		c.Count = int(row.MaxPlayers - row.PlayersCount)
+	default:
+		c.Time = row.CreatedAt
+	}
+	return c
+}
+
+// encode turns the cursor into an opaque string clients hand back as is
+func (c cursor) encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor parses a cursor, which has to come from a search with the
+// same sort order
+func decodeCursor(encoded string, sort string) (cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return cursor{}, ErrInvalidCursor
+	}
+
+	var c cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.Sort != sort || c.ID == uuid.Nil {
+		return cursor{}, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+// apply sets the keyset parameters so the search resumes after the cursor
+func (c cursor) apply(params *database.SearchGamesParams) {
+	params.CursorID = uuid.NullUUID{UUID: c.ID, Valid: true}
+	switch c.Sort {
+	case SORT_NAME:
+		// The query compares names in lower case
+		params.CursorName = sql.NullString{String: strings.ToLower(c.Name), Valid: true}
+	case SORT_PLAYERS, SORT_FREE_SLOTS:
+		params.CursorCount = sql.NullInt32{Int32: int32(c.Count), Valid: true}
+	default:
+		params.CursorTime = sql.NullTime{Time: c.Time, Valid: true}
+	}
+}
@@ -2,6 +2,8 @@ package game
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/NachoGz/switcher-backend-go/internal/database"
 	gameState "github.com/NachoGz/switcher-backend-go/internal/game_state"
@@ -11,14 +13,27 @@ import (
 
 type GameService interface {
 	CreateGame(ctx context.Context, gameData Game, playerData player.Player) (*Game, *gameState.GameState, *player.Player, error)
-	GetAvailableGames(ctx context.Context, numPlayers int, page int, limit int, name string) ([]Game, int, error)
+	SearchGames(ctx context.Context, filter GameFilter) (*GamePage, error)
 	GetGameByID(ctx context.Context, id uuid.UUID) (*Game, error)
 	DeleteGame(ctx context.Context, id uuid.UUID) error
+	BanPlayer(ctx context.Context, gameID uuid.UUID, name string, userID *uuid.UUID) error
+	IsBanned(ctx context.Context, gameID uuid.UUID, name string, userID *uuid.UUID) (bool, error)
+	GetGameByJoinCode(ctx context.Context, code string) (*Game, error)
+	SetJoinCode(ctx context.Context, gameID uuid.UUID, code string) error
+	TouchGame(ctx context.Context, gameID uuid.UUID, at time.Time) error
+	ListInactiveGames(ctx context.Context, before time.Time) ([]InactiveGame, error)
 }
 
 type GameRepository interface {
 	CreateGame(ctx context.Context, params database.CreateGameParams) (database.Game, error)
-	GetAvailableGames(ctx context.Context) ([]database.Game, error)
+	SearchGames(ctx context.Context, params database.SearchGamesParams) ([]database.SearchGamesRow, error)
+	CountSearchGames(ctx context.Context, params database.CountSearchGamesParams) (int64, error)
 	GetGameById(ctx context.Context, id uuid.UUID) (database.Game, error)
 	DeleteGame(ctx context.Context, id uuid.UUID) error
+	CreateGameBan(ctx context.Context, params database.CreateGameBanParams) error
+	IsPlayerBanned(ctx context.Context, params database.IsPlayerBannedParams) (bool, error)
+	GetGameByJoinCode(ctx context.Context, joinCode sql.NullString) (database.Game, error)
+	SetGameJoinCode(ctx context.Context, params database.SetGameJoinCodeParams) error
+	TouchGame(ctx context.Context, params database.TouchGameParams) error
+	ListInactiveGames(ctx context.Context, before time.Time) ([]database.ListInactiveGamesRow, error)
 }
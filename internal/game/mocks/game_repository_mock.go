@@ -2,6 +2,8 @@ package game_mock
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/NachoGz/switcher-backend-go/internal/database"
 	"github.com/google/uuid"
@@ -17,9 +19,14 @@ func (m *MockGameRepository) CreateGame(ctx context.Context, params database.Cre
 	return args.Get(0).(database.Game), args.Error(1)
 }
 
-func (m *MockGameRepository) GetAvailableGames(ctx context.Context) ([]database.Game, error) {
-	args := m.Called(ctx)
-	return args.Get(0).([]database.Game), args.Error(1)
+func (m *MockGameRepository) SearchGames(ctx context.Context, params database.SearchGamesParams) ([]database.SearchGamesRow, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).([]database.SearchGamesRow), args.Error(1)
+}
+
+func (m *MockGameRepository) CountSearchGames(ctx context.Context, params database.CountSearchGamesParams) (int64, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).(int64), args.Error(1)
 }
 
 func (m *MockGameRepository) GetGameById(ctx context.Context, id uuid.UUID) (database.Game, error) {
@@ -31,3 +38,33 @@ func (m *MockGameRepository) DeleteGame(ctx context.Context, id uuid.UUID) error
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
+
+func (m *MockGameRepository) CreateGameBan(ctx context.Context, params database.CreateGameBanParams) error {
+	args := m.Called(ctx, params)
+	return args.Error(0)
+}
+
+func (m *MockGameRepository) IsPlayerBanned(ctx context.Context, params database.IsPlayerBannedParams) (bool, error) {
+	args := m.Called(ctx, params)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockGameRepository) GetGameByJoinCode(ctx context.Context, joinCode sql.NullString) (database.Game, error) {
+	args := m.Called(ctx, joinCode)
+	return args.Get(0).(database.Game), args.Error(1)
+}
+
+func (m *MockGameRepository) SetGameJoinCode(ctx context.Context, params database.SetGameJoinCodeParams) error {
+	args := m.Called(ctx, params)
+	return args.Error(0)
+}
+
+func (m *MockGameRepository) TouchGame(ctx context.Context, params database.TouchGameParams) error {
+	args := m.Called(ctx, params)
+	return args.Error(0)
+}
+
+func (m *MockGameRepository) ListInactiveGames(ctx context.Context, before time.Time) ([]database.ListInactiveGamesRow, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).([]database.ListInactiveGamesRow), args.Error(1)
+}
@@ -2,6 +2,7 @@ package game_mock
 
 import (
 	"context"
+	"time"
 
 	"github.com/NachoGz/switcher-backend-go/internal/game"
 	gameState "github.com/NachoGz/switcher-backend-go/internal/game_state"
@@ -19,9 +20,12 @@ func (m *MockGameService) CreateGame(ctx context.Context, gameData game.Game, pl
 	return args.Get(0).(*game.Game), args.Get(1).(*gameState.GameState), args.Get(2).(*player.Player), args.Error(3)
 }
 
-func (m *MockGameService) GetAvailableGames(ctx context.Context, numPlayers int, page int, limit int, name string) ([]game.Game, int, error) {
-	args := m.Called(ctx, numPlayers, page, limit, name)
-	return args.Get(0).([]game.Game), args.Int(1), args.Error(2)
+func (m *MockGameService) SearchGames(ctx context.Context, filter game.GameFilter) (*game.GamePage, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*game.GamePage), args.Error(1)
 }
 
 func (m *MockGameService) GetGameByID(ctx context.Context, id uuid.UUID) (*game.Game, error) {
@@ -33,3 +37,36 @@ func (m *MockGameService) DeleteGame(ctx context.Context, id uuid.UUID) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
+
+func (m *MockGameService) BanPlayer(ctx context.Context, gameID uuid.UUID, name string, userID *uuid.UUID) error {
+	args := m.Called(ctx, gameID, name, userID)
+	return args.Error(0)
+}
+
+func (m *MockGameService) IsBanned(ctx context.Context, gameID uuid.UUID, name string, userID *uuid.UUID) (bool, error) {
+	args := m.Called(ctx, gameID, name, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockGameService) GetGameByJoinCode(ctx context.Context, code string) (*game.Game, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*game.Game), args.Error(1)
+}
+
+func (m *MockGameService) SetJoinCode(ctx context.Context, gameID uuid.UUID, code string) error {
+	args := m.Called(ctx, gameID, code)
+	return args.Error(0)
+}
+
+func (m *MockGameService) TouchGame(ctx context.Context, gameID uuid.UUID, at time.Time) error {
+	args := m.Called(ctx, gameID, at)
+	return args.Error(0)
+}
+
+func (m *MockGameService) ListInactiveGames(ctx context.Context, before time.Time) ([]game.InactiveGame, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).([]game.InactiveGame), args.Error(1)
+}
@@ -2,11 +2,40 @@ package game
 
 import (
 	"context"
+	"log/slog"
+	"time"
 
 	"github.com/NachoGz/switcher-backend-go/internal/database"
+	gameState "github.com/NachoGz/switcher-backend-go/internal/game_state"
+	"github.com/NachoGz/switcher-backend-go/internal/logging"
+	"github.com/NachoGz/switcher-backend-go/internal/ruleSet"
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
 	"github.com/google/uuid"
 )
 
+// Orders the lobby can be sorted by
+const (
+	SORT_NEWEST     = "newest"
+	SORT_OLDEST     = "oldest"
+	SORT_NAME       = "name"
+	SORT_PLAYERS    = "players"
+	SORT_FREE_SLOTS = "free_slots"
+
+	DEFAULT_PAGE_SIZE = 5
+	MAX_PAGE_SIZE     = 50
+)
+
+var (
+	ErrNotFound         = utils.NewDomainError(utils.ErrNotFound, "GAME_NOT_FOUND", "game not found")
+	ErrGameFull         = utils.NewDomainError(utils.ErrGameFull, "GAME_FULL", "the game is full")
+	ErrPasswordRequired = utils.NewDomainError(utils.ErrForbidden, "PASSWORD_REQUIRED", "password required for private games")
+	ErrWrongPassword    = utils.NewDomainError(utils.ErrForbidden, "WRONG_PASSWORD", "incorrect password")
+	ErrBanned           = utils.NewDomainError(utils.ErrForbidden, "BANNED", "you were banned from this game")
+	ErrInvalidSort      = utils.NewDomainError(utils.ErrInvalidInput, "INVALID_SORT", "invalid sort order")
+	ErrInvalidCursor    = utils.NewDomainError(utils.ErrInvalidInput, "INVALID_CURSOR", "invalid cursor")
+	ErrInvalidPage      = utils.NewDomainError(utils.ErrInvalidInput, "INVALID_PAGE", "invalid page")
+)
+
 type Game struct {
 	ID           uuid.UUID `json:"id"`
 	Name         string    `json:"name"`
@@ -14,7 +43,58 @@ type Game struct {
 	MinPlayers   int       `json:"min_players"`
 	PlayersCount int       `json:"players_count"`
 	IsPrivate    bool      `json:"is_private"`
-	Password     *string   `json:"password"`
+	// Hash of the password, nil if the game has none. Never sent to clients
+	Password      *string         `json:"-"`
+	MaxSpectators *int            `json:"max_spectators"`
+	Seed          int64           `json:"-"`
+	Rules         ruleSet.RuleSet `json:"rules"`
+	// Only handed out by the host through invites
+	JoinCode *string `json:"-"`
+}
+
+// SpectatingAllowed reports whether one more spectator can watch the game
+// given the amount already connected. A nil MaxSpectators means there is no
+// limit and 0 means spectating is disabled
+func (g Game) SpectatingAllowed(connected int) bool {
+	if g.MaxSpectators == nil {
+		return true
+	}
+	return connected < *g.MaxSpectators
+}
+
+// GameFilter narrows down the games listed in the lobby. Zero values leave
+// a filter out
+type GameFilter struct {
+	// Case insensitive substring of the game name
+	Name string
+	// Exact amount of players already in the game
+	NumPlayers int
+	IsPrivate  *bool
+	// Games with at least this many seats left
+	MinFreeSlots int
+	// Games that need at least this many players to start
+	MinPlayers int
+	// Games that allow at most this many players
+	MaxPlayers int
+	Sort       string
+	// Cursor returned with the previous page, takes precedence over Page
+	Cursor string
+	Page   int
+	Limit  int
+}
+
+// GamePage is one page of the lobby. NextCursor is empty on the last page
+type GamePage struct {
+	Games      []Game
+	Total      int
+	NextCursor string
+}
+
+// InactiveGame is a game nobody has acted on since LastActivityAt
+type InactiveGame struct {
+	ID             uuid.UUID
+	State          gameState.State
+	LastActivityAt time.Time
 }
 
 // DBToModel converts a database game to a model game with player count
@@ -24,13 +104,44 @@ func (s *Service) DBToModel(ctx context.Context, dbGame database.Game) Game {
 		playersCount = int(count)
 	}
 
+	return toModel(dbGame, playersCount)
+}
+
+// toModel converts a database game to a model game when the player count is
+// already known
+func toModel(dbGame database.Game, playersCount int) Game {
+	var maxSpectators *int
+	if dbGame.MaxSpectators.Valid {
+		limit := int(dbGame.MaxSpectators.Int32)
+		maxSpectators = &limit
+	}
+
+	rules, err := ruleSet.FromJSON(dbGame.Rules)
+	if err != nil {
+		slog.Warn("Invalid rules stored for game, using the classic ones", logging.GameID(dbGame.ID), "error", err)
+		rules = ruleSet.Classic()
+	}
+
+	var joinCode *string
+	if dbGame.JoinCode.Valid {
+		joinCode = &dbGame.JoinCode.String
+	}
+	var password *string
+	if dbGame.Password.Valid {
+		password = &dbGame.Password.String
+	}
+
 	return Game{
-		ID:           dbGame.ID,
-		Name:         dbGame.Name,
-		MaxPlayers:   int(dbGame.MaxPlayers),
-		MinPlayers:   int(dbGame.MinPlayers),
-		PlayersCount: playersCount,
-		IsPrivate:    dbGame.IsPrivate,
-		Password:     &dbGame.Password.String,
+		ID:            dbGame.ID,
+		Name:          dbGame.Name,
+		MaxPlayers:    int(dbGame.MaxPlayers),
+		MinPlayers:    int(dbGame.MinPlayers),
+		PlayersCount:  playersCount,
+		IsPrivate:     dbGame.IsPrivate,
+		Password:      password,
+		MaxSpectators: maxSpectators,
+		Seed:          dbGame.Seed,
+		Rules:         rules,
+		JoinCode:      joinCode,
 	}
 }
@@ -2,6 +2,8 @@ package game
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/NachoGz/switcher-backend-go/internal/database"
 	"github.com/google/uuid"
@@ -24,9 +26,14 @@ func (r *PostgresGameRepository) CreateGame(ctx context.Context, params database
 	return r.queries.CreateGame(ctx, params)
 }
 
-// GetAvailableGames gets all available games
-func (r *PostgresGameRepository) GetAvailableGames(ctx context.Context) ([]database.Game, error) {
-	return r.queries.GetAvailableGames(ctx)
+// SearchGames gets one page of the games waiting for players
+func (r *PostgresGameRepository) SearchGames(ctx context.Context, params database.SearchGamesParams) ([]database.SearchGamesRow, error) {
+	return r.queries.SearchGames(ctx, params)
+}
+
+// CountSearchGames counts the games waiting for players that match a search
+func (r *PostgresGameRepository) CountSearchGames(ctx context.Context, params database.CountSearchGamesParams) (int64, error) {
+	return r.queries.CountSearchGames(ctx, params)
 }
 
 func (r PostgresGameRepository) GetGameById(ctx context.Context, id uuid.UUID) (database.Game, error) {
@@ -36,3 +43,33 @@ func (r PostgresGameRepository) GetGameById(ctx context.Context, id uuid.UUID) (
 func (r PostgresGameRepository) DeleteGame(ctx context.Context, id uuid.UUID) error {
 	return r.queries.DeleteGame(ctx, id)
 }
+
+// CreateGameBan adds a name or user to the ban list of a game
+func (r *PostgresGameRepository) CreateGameBan(ctx context.Context, params database.CreateGameBanParams) error {
+	return r.queries.CreateGameBan(ctx, params)
+}
+
+// IsPlayerBanned reports whether a name or user is in the ban list of a game
+func (r *PostgresGameRepository) IsPlayerBanned(ctx context.Context, params database.IsPlayerBannedParams) (bool, error) {
+	return r.queries.IsPlayerBanned(ctx, params)
+}
+
+// GetGameByJoinCode gets the game with the given join code
+func (r *PostgresGameRepository) GetGameByJoinCode(ctx context.Context, joinCode sql.NullString) (database.Game, error) {
+	return r.queries.GetGameByJoinCode(ctx, joinCode)
+}
+
+// SetGameJoinCode sets the join code of a game
+func (r *PostgresGameRepository) SetGameJoinCode(ctx context.Context, params database.SetGameJoinCodeParams) error {
+	return r.queries.SetGameJoinCode(ctx, params)
+}
+
+// TouchGame sets the last activity of a game
+func (r *PostgresGameRepository) TouchGame(ctx context.Context, params database.TouchGameParams) error {
+	return r.queries.TouchGame(ctx, params)
+}
+
+// ListInactiveGames gets the games with no activity since the given time
+func (r *PostgresGameRepository) ListInactiveGames(ctx context.Context, before time.Time) ([]database.ListInactiveGamesRow, error) {
+	return r.queries.ListInactiveGames(ctx, before)
+}
@@ -3,12 +3,16 @@ package game
 import (
 	"context"
 	"database/sql"
-	"log"
+	"encoding/json"
+	"errors"
+	"math"
 	"strings"
+	"time"
 
 	"github.com/NachoGz/switcher-backend-go/internal/database"
 	gameState "github.com/NachoGz/switcher-backend-go/internal/game_state"
 	"github.com/NachoGz/switcher-backend-go/internal/player"
+	"github.com/NachoGz/switcher-backend-go/internal/ruleSet"
 	"github.com/NachoGz/switcher-backend-go/internal/utils"
 	"github.com/google/uuid"
 )
@@ -42,57 +46,115 @@ func NewService(
 // Ensure Service implements GameService
 var _ GameService = (*Service)(nil)
 
-// GetAvailableGames gets all available games with player counts
-func (s *Service) GetAvailableGames(ctx context.Context, numPlayers, page, limit int, name string) ([]Game, int, error) {
-	dbGames, err := s.gameRepo.GetAvailableGames(ctx)
-	if err != nil {
-		return nil, 0, err
+// SearchGames lists the games waiting for players that match the filter,
+// one page at a time. Filtering, sorting and paging all happen in the
+// database
+func (s *Service) SearchGames(ctx context.Context, filter GameFilter) (*GamePage, error) {
+	if filter.Sort == "" {
+		filter.Sort = SORT_NEWEST
+	}
+	switch filter.Sort {
+	case SORT_NEWEST, SORT_OLDEST, SORT_NAME, SORT_PLAYERS, SORT_FREE_SLOTS:
+	default:
+		return nil, ErrInvalidSort
+	}
+	if filter.Limit < 1 {
+		filter.Limit = DEFAULT_PAGE_SIZE
+	}
+	filter.Limit = min(filter.Limit, MAX_PAGE_SIZE)
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	// The offset of the page has to fit in the query
+	if filter.Page-1 > math.MaxInt32/filter.Limit {
+		return nil, ErrInvalidPage
 	}
 
-	var filteredGames []Game
-
-	// Convert and filter games
-	for _, dbGame := range dbGames {
-		game := s.DBToModel(ctx, dbGame)
+	countParams := database.CountSearchGamesParams{
+		MinFreeSlots: int32(filter.MinFreeSlots),
+	}
+	if filter.Name != "" {
+		countParams.Name = sql.NullString{String: escapeLike(filter.Name), Valid: true}
+	}
+	if filter.IsPrivate != nil {
+		countParams.IsPrivate = sql.NullBool{Bool: *filter.IsPrivate, Valid: true}
+	}
+	if filter.NumPlayers != 0 {
+		countParams.PlayersCount = sql.NullInt32{Int32: int32(filter.NumPlayers), Valid: true}
+	}
+	if filter.MinPlayers != 0 {
+		countParams.MinPlayers = sql.NullInt32{Int32: int32(filter.MinPlayers), Valid: true}
+	}
+	if filter.MaxPlayers != 0 {
+		countParams.MaxPlayers = sql.NullInt32{Int32: int32(filter.MaxPlayers), Valid: true}
+	}
 
-		// Filter by number of players if needed
-		log.Println(numPlayers)
-		if numPlayers != 0 {
-			if game.PlayersCount == numPlayers && strings.Contains(game.Name, name) {
-				filteredGames = append(filteredGames, game)
-			}
-		} else {
-			if strings.Contains(game.Name, name) {
-				filteredGames = append(filteredGames, game)
-			}
+	// One extra row tells whether there is a next page
+	params := database.SearchGamesParams{
+		Name:         countParams.Name,
+		IsPrivate:    countParams.IsPrivate,
+		PlayersCount: countParams.PlayersCount,
+		MinFreeSlots: countParams.MinFreeSlots,
+		MinPlayers:   countParams.MinPlayers,
+		MaxPlayers:   countParams.MaxPlayers,
+		Sort:         filter.Sort,
+		PageSize:     int32(filter.Limit + 1),
+	}
+	if filter.Cursor != "" {
+		c, err := decodeCursor(filter.Cursor, filter.Sort)
+		if err != nil {
+			return nil, err
 		}
+		c.apply(&params)
+	} else {
+		params.PageOffset = int32((filter.Page - 1) * filter.Limit)
 	}
 
-	// Calculate total count for pagination
-	totalCount := len(filteredGames)
-
-	// Apply pagination
-	startIndex := (page - 1) * limit
-	endIndex := startIndex + limit
-
-	// Check bounds
-	if startIndex >= len(filteredGames) {
-		return []Game{}, totalCount, nil
+	rows, err := s.gameRepo.SearchGames(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	total, err := s.gameRepo.CountSearchGames(ctx, countParams)
+	if err != nil {
+		return nil, err
 	}
 
-	if endIndex > len(filteredGames) {
-		endIndex = len(filteredGames)
+	page := &GamePage{Games: []Game{}, Total: int(total)}
+	if len(rows) > filter.Limit {
+		rows = rows[:filter.Limit]
+		page.NextCursor = newCursor(filter.Sort, rows[len(rows)-1]).encode()
 	}
+	for _, row := range rows {
+		page.Games = append(page.Games, toModel(database.Game{
+			ID:             row.ID,
+			Name:           row.Name,
+			MaxPlayers:     row.MaxPlayers,
+			MinPlayers:     row.MinPlayers,
+			IsPrivate:      row.IsPrivate,
+			Password:       row.Password,
+			CreatedAt:      row.CreatedAt,
+			UpdatedAt:      row.UpdatedAt,
+			MaxSpectators:  row.MaxSpectators,
+			Seed:           row.Seed,
+			Rules:          row.Rules,
+			JoinCode:       row.JoinCode,
+			LastActivityAt: row.LastActivityAt,
+		}, int(row.PlayersCount)))
+	}
+
+	return page, nil
+}
 
-	// Return paginated results
-	return filteredGames[startIndex:endIndex], totalCount, nil
+// escapeLike makes the wildcards of a search term match literally
+func escapeLike(term string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(term)
 }
 
 // CreateGame creates a new game with game state and first player (creator)
 func (s *Service) CreateGame(ctx context.Context, gameData Game, playerData player.Player) (*Game, *gameState.GameState, *player.Player, error) {
 	// Hash password if provided
 	var passwordSQL sql.NullString
-	if *gameData.Password != "" {
+	if gameData.Password != nil && *gameData.Password != "" {
 		// Set game to private
 		gameData.IsPrivate = true
 		hashedPassword, err := utils.HashPassword(*gameData.Password)
@@ -103,14 +165,40 @@ func (s *Service) CreateGame(ctx context.Context, gameData Game, playerData play
 	} else {
 		passwordSQL = sql.NullString{Valid: false}
 	}
+
+	// Spectators are unlimited unless the host sets a cap
+	var maxSpectatorsSQL sql.NullInt32
+	if gameData.MaxSpectators != nil {
+		maxSpectatorsSQL = sql.NullInt32{Int32: int32(*gameData.MaxSpectators), Valid: true}
+	}
+
+	// The seed drives the board, decks and turns of the game
+	seed := gameData.Seed
+	if seed == 0 {
+		seed = utils.NewSeed()
+	}
+
+	// Games are played with the classic rules unless others were chosen
+	rules := gameData.Rules
+	if rules.Name == "" {
+		rules = ruleSet.Classic()
+	}
+	rulesJSON, err := json.Marshal(rules)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
 	// Create game using repository
 	game, err := s.gameRepo.CreateGame(ctx, database.CreateGameParams{
-		ID:         uuid.New(),
-		Name:       gameData.Name,
-		MaxPlayers: int32(gameData.MaxPlayers),
-		MinPlayers: int32(gameData.MinPlayers),
-		IsPrivate:  gameData.IsPrivate,
-		Password:   passwordSQL,
+		ID:            uuid.New(),
+		Name:          gameData.Name,
+		MaxPlayers:    int32(gameData.MaxPlayers),
+		MinPlayers:    int32(gameData.MinPlayers),
+		IsPrivate:     gameData.IsPrivate,
+		Password:      passwordSQL,
+		MaxSpectators: maxSpectatorsSQL,
+		Seed:          seed,
+		Rules:         rulesJSON,
 	})
 	if err != nil {
 		return nil, nil, nil, err
@@ -136,6 +224,7 @@ func (s *Service) CreateGame(ctx context.Context, gameData Game, playerData play
 		GameID:      game.ID,
 		GameStateID: gameStateDb.ID,
 		Host:        playerData.Host,
+		UserID:      player.NullUserID(playerData.UserID),
 	})
 	if err != nil {
 		return nil, nil, nil, err
@@ -155,6 +244,9 @@ func (s *Service) CreateGame(ctx context.Context, gameData Game, playerData play
 func (s *Service) GetGameByID(ctx context.Context, id uuid.UUID) (*Game, error) {
 	dbGame, err := s.gameRepo.GetGameById(ctx, id)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
 		return nil, err
 	}
 
@@ -172,3 +264,72 @@ func (s *Service) DeleteGame(ctx context.Context, id uuid.UUID) error {
 
 	return nil
 }
+
+// BanPlayer keeps a name, and the user behind it if any, from joining the
+// game again
+func (s *Service) BanPlayer(ctx context.Context, gameID uuid.UUID, name string, userID *uuid.UUID) error {
+	return s.gameRepo.CreateGameBan(ctx, database.CreateGameBanParams{
+		ID:     uuid.New(),
+		GameID: gameID,
+		Name:   name,
+		UserID: player.NullUserID(userID),
+	})
+}
+
+// IsBanned reports whether the name or the user was banned from the game.
+// Names are compared ignoring case
+func (s *Service) IsBanned(ctx context.Context, gameID uuid.UUID, name string, userID *uuid.UUID) (bool, error) {
+	return s.gameRepo.IsPlayerBanned(ctx, database.IsPlayerBannedParams{
+		GameID: gameID,
+		Lower:  name,
+		UserID: player.NullUserID(userID),
+	})
+}
+
+// GetGameByJoinCode gets the game with the given join code
+func (s *Service) GetGameByJoinCode(ctx context.Context, code string) (*Game, error) {
+	dbGame, err := s.gameRepo.GetGameByJoinCode(ctx, sql.NullString{String: code, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	game := s.DBToModel(ctx, dbGame)
+
+	return &game, nil
+}
+
+// SetJoinCode sets the join code of a game. Codes are unique among games
+func (s *Service) SetJoinCode(ctx context.Context, gameID uuid.UUID, code string) error {
+	return s.gameRepo.SetGameJoinCode(ctx, database.SetGameJoinCodeParams{
+		ID:       gameID,
+		JoinCode: sql.NullString{String: code, Valid: true},
+	})
+}
+
+// TouchGame records that something happened in the game at the given time
+func (s *Service) TouchGame(ctx context.Context, gameID uuid.UUID, at time.Time) error {
+	return s.gameRepo.TouchGame(ctx, database.TouchGameParams{
+		ID:             gameID,
+		LastActivityAt: at,
+	})
+}
+
+// ListInactiveGames lists the games with no activity since the given time,
+// the longest inactive first. Besides what TouchGame records, moves, chat
+// messages and players joining count as activity
+func (s *Service) ListInactiveGames(ctx context.Context, before time.Time) ([]InactiveGame, error) {
+	rows, err := s.gameRepo.ListInactiveGames(ctx, before)
+	if err != nil {
+		return nil, err
+	}
+
+	games := make([]InactiveGame, 0, len(rows))
+	for _, row := range rows {
+		games = append(games, InactiveGame{
+			ID:             row.ID,
+			State:          gameState.State(row.State),
+			LastActivityAt: row.LastActivityAt,
+		})
+	}
+	return games, nil
+}
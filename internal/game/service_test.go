@@ -2,7 +2,9 @@ package game_test
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/NachoGz/switcher-backend-go/internal/database"
 	"github.com/NachoGz/switcher-backend-go/internal/game"
@@ -73,7 +75,8 @@ func TestCreateGame(t *testing.T) {
 	mockGameRepo.On("CreateGame", mock.Anything, mock.MatchedBy(func(params database.CreateGameParams) bool {
 		return params.Name == testGame.Name &&
 			params.MaxPlayers == int32(testGame.MaxPlayers) &&
-			params.MinPlayers == int32(testGame.MinPlayers)
+			params.MinPlayers == int32(testGame.MinPlayers) &&
+			params.Seed != 0
 	})).Return(dbGame, nil)
 
 	// Setup expectations for game state
@@ -130,3 +133,198 @@ func TestCreateGame(t *testing.T) {
 	mockGameStateService.AssertExpectations(t)
 	mockPlayerService.AssertExpectations(t)
 }
+
+func TestCreateGame_NilPassword(t *testing.T) {
+	// Create the service with all the mocks
+	mockGameRepo := new(game_mock.MockGameRepository)
+	service := game.NewService(
+		mockGameRepo,
+		new(gameState_mock.MockGameStateRepository),
+		new(player_mock.MockPlayerRepository),
+		new(gameState_mock.MockGameStateService),
+		new(player_mock.MockPlayerService),
+	)
+
+	// A game without password is public
+	mockGameRepo.On("CreateGame", mock.Anything, mock.MatchedBy(func(params database.CreateGameParams) bool {
+		return !params.IsPrivate && !params.Password.Valid
+	})).Return(database.Game{}, errors.New("database error"))
+
+	// Execute the function being tested
+	testGame := game.Game{Name: "Test Game", MaxPlayers: 4, MinPlayers: 2}
+	_, _, _, err := service.CreateGame(context.Background(), testGame, player.Player{Name: "Player1"})
+
+	// Assertions
+	assert.EqualError(t, err, "database error")
+	mockGameRepo.AssertExpectations(t)
+}
+
+func newSearchService() (*game.Service, *game_mock.MockGameRepository) {
+	mockGameRepo := new(game_mock.MockGameRepository)
+	service := game.NewService(
+		mockGameRepo,
+		new(gameState_mock.MockGameStateRepository),
+		new(player_mock.MockPlayerRepository),
+		new(gameState_mock.MockGameStateService),
+		new(player_mock.MockPlayerService),
+	)
+	return service, mockGameRepo
+}
+
+func TestSearchGames(t *testing.T) {
+	service, mockGameRepo := newSearchService()
+
+	// Test data
+	now := time.Now()
+	rows := []database.SearchGamesRow{
+		{ID: uuid.New(), Name: "50% off", MaxPlayers: 4, MinPlayers: 2, PlayersCount: 2, CreatedAt: now},
+		{ID: uuid.New(), Name: "50% off again", MaxPlayers: 4, MinPlayers: 2, PlayersCount: 2, CreatedAt: now.Add(-time.Minute)},
+		{ID: uuid.New(), Name: "50% off forever", MaxPlayers: 3, MinPlayers: 2, PlayersCount: 2, CreatedAt: now.Add(-time.Hour)},
+	}
+
+	// Setup expectations, the wildcard in the name has to match literally
+	mockGameRepo.On("SearchGames", mock.Anything, mock.MatchedBy(func(params database.SearchGamesParams) bool {
+		return params.Name.String == `50\% off` &&
+			params.PlayersCount.Int32 == 2 && params.PlayersCount.Valid &&
+			!params.IsPrivate.Valid &&
+			params.Sort == game.SORT_NEWEST &&
+			params.PageSize == 3 &&
+			params.PageOffset == 0 &&
+			!params.CursorID.Valid
+	})).Return(rows, nil).Once()
+	mockGameRepo.On("CountSearchGames", mock.Anything, mock.MatchedBy(func(params database.CountSearchGamesParams) bool {
+		return params.Name.String == `50\% off` && params.PlayersCount.Int32 == 2
+	})).Return(int64(3), nil)
+
+	// Call service
+	page, err := service.SearchGames(context.Background(), game.GameFilter{Name: "50% off", NumPlayers: 2, Limit: 2})
+
+	// Check results
+	assert.NoError(t, err)
+	assert.Len(t, page.Games, 2)
+	assert.Equal(t, rows[0].ID, page.Games[0].ID)
+	assert.Equal(t, 2, page.Games[0].PlayersCount)
+	assert.Equal(t, 3, page.Total)
+	assert.NotEmpty(t, page.NextCursor)
+
+	// The next page resumes after the last game of this one
+	mockGameRepo.On("SearchGames", mock.Anything, mock.MatchedBy(func(params database.SearchGamesParams) bool {
+		return params.CursorID.Valid && params.CursorID.UUID == rows[1].ID &&
+			params.CursorTime.Valid && params.CursorTime.Time.Equal(rows[1].CreatedAt) &&
+			params.PageOffset == 0
+	})).Return(rows[2:], nil).Once()
+
+	next, err := service.SearchGames(context.Background(), game.GameFilter{
+		Name:       "50% off",
+		NumPlayers: 2,
+		Cursor:     page.NextCursor,
+		Limit:      2,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, next.Games, 1)
+	assert.Equal(t, rows[2].ID, next.Games[0].ID)
+	assert.Empty(t, next.NextCursor)
+
+	// Verify mocks are called
+	mockGameRepo.AssertExpectations(t)
+}
+
+func TestSearchGames_PageOffset(t *testing.T) {
+	service, mockGameRepo := newSearchService()
+
+	// Setup expectations
+	private := false
+	mockGameRepo.On("SearchGames", mock.Anything, mock.MatchedBy(func(params database.SearchGamesParams) bool {
+		return params.PageOffset == 10 && params.PageSize == 6 &&
+			params.IsPrivate.Valid && !params.IsPrivate.Bool &&
+			params.MinFreeSlots == 1 &&
+			params.MaxPlayers.Int32 == 3 && params.MaxPlayers.Valid &&
+			!params.MinPlayers.Valid &&
+			params.Sort == game.SORT_FREE_SLOTS
+	})).Return([]database.SearchGamesRow{}, nil)
+	mockGameRepo.On("CountSearchGames", mock.Anything, mock.Anything).Return(int64(10), nil)
+
+	// Call service
+	page, err := service.SearchGames(context.Background(), game.GameFilter{
+		IsPrivate:    &private,
+		MinFreeSlots: 1,
+		MaxPlayers:   3,
+		Sort:         game.SORT_FREE_SLOTS,
+		Page:         3,
+		Limit:        5,
+	})
+
+	// Check results
+	assert.NoError(t, err)
+	assert.Empty(t, page.Games)
+	assert.NotNil(t, page.Games)
+	assert.Equal(t, 10, page.Total)
+	assert.Empty(t, page.NextCursor)
+
+	// Verify mocks are called
+	mockGameRepo.AssertExpectations(t)
+}
+
+func TestSearchGames_PageLimits(t *testing.T) {
+	service, mockGameRepo := newSearchService()
+
+	// Setup expectations
+	mockGameRepo.On("SearchGames", mock.Anything, mock.MatchedBy(func(params database.SearchGamesParams) bool {
+		return params.PageSize == game.MAX_PAGE_SIZE+1 && params.PageOffset == game.MAX_PAGE_SIZE
+	})).Return([]database.SearchGamesRow{}, nil)
+	mockGameRepo.On("CountSearchGames", mock.Anything, mock.Anything).Return(int64(0), nil)
+
+	// Huge pages are cut down to the maximum size
+	_, err := service.SearchGames(context.Background(), game.GameFilter{Page: 2, Limit: 1 << 40})
+	assert.NoError(t, err)
+	mockGameRepo.AssertExpectations(t)
+
+	// Pages whose offset overflows are rejected
+	page, err := service.SearchGames(context.Background(), game.GameFilter{Page: 1 << 40, Limit: game.MAX_PAGE_SIZE})
+	assert.ErrorIs(t, err, game.ErrInvalidPage)
+	assert.Nil(t, page)
+	mockGameRepo.AssertNumberOfCalls(t, "SearchGames", 1)
+}
+
+func TestSearchGames_InvalidSort(t *testing.T) {
+	service, mockGameRepo := newSearchService()
+
+	// Call service
+	page, err := service.SearchGames(context.Background(), game.GameFilter{Sort: "random"})
+
+	// Check results
+	assert.ErrorIs(t, err, game.ErrInvalidSort)
+	assert.Nil(t, page)
+	mockGameRepo.AssertNotCalled(t, "SearchGames", mock.Anything, mock.Anything)
+}
+
+func TestSearchGames_InvalidCursor(t *testing.T) {
+	service, mockGameRepo := newSearchService()
+
+	// Build a cursor sorting by name
+	row := database.SearchGamesRow{ID: uuid.New(), Name: "Game"}
+	mockGameRepo.On("SearchGames", mock.Anything, mock.Anything).
+		Return([]database.SearchGamesRow{row, row}, nil).Once()
+	mockGameRepo.On("CountSearchGames", mock.Anything, mock.Anything).Return(int64(2), nil)
+
+	page, err := service.SearchGames(context.Background(), game.GameFilter{Sort: game.SORT_NAME, Limit: 1})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, page.NextCursor)
+
+	for _, tc := range []struct {
+		name   string
+		filter game.GameFilter
+	}{
+		{"Garbage", game.GameFilter{Sort: game.SORT_NAME, Cursor: "not a cursor"}},
+		{"Different sort", game.GameFilter{Sort: game.SORT_PLAYERS, Cursor: page.NextCursor}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := service.SearchGames(context.Background(), tc.filter)
+			assert.ErrorIs(t, err, game.ErrInvalidCursor)
+			assert.Nil(t, result)
+		})
+	}
+
+	// Verify mocks are called
+	mockGameRepo.AssertNumberOfCalls(t, "SearchGames", 1)
+}
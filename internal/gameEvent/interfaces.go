@@ -0,0 +1,19 @@
+package gameEvent
+
+import (
+	"context"
+
+	"github.com/NachoGz/switcher-backend-go/internal/database"
+	"github.com/google/uuid"
+)
+
+type GameEventService interface {
+	Record(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID, eventType EventType, payload interface{}) (*GameEvent, error)
+	RecordGameStarted(ctx context.Context, gameID uuid.UUID) error
+	GetEvents(ctx context.Context, gameID uuid.UUID) ([]GameEvent, error)
+}
+
+type GameEventRepository interface {
+	CreateGameEvent(ctx context.Context, params database.CreateGameEventParams) (database.GameEvent, error)
+	GetGameEvents(ctx context.Context, gameID uuid.UUID) ([]database.GameEvent, error)
+}
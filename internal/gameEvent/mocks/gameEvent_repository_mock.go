@@ -0,0 +1,23 @@
+package gameEvent_mock
+
+import (
+	"context"
+
+	"github.com/NachoGz/switcher-backend-go/internal/database"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockGameEventRepository struct {
+	mock.Mock
+}
+
+func (m *MockGameEventRepository) CreateGameEvent(ctx context.Context, params database.CreateGameEventParams) (database.GameEvent, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).(database.GameEvent), args.Error(1)
+}
+
+func (m *MockGameEventRepository) GetGameEvents(ctx context.Context, gameID uuid.UUID) ([]database.GameEvent, error) {
+	args := m.Called(ctx, gameID)
+	return args.Get(0).([]database.GameEvent), args.Error(1)
+}
@@ -0,0 +1,31 @@
+package gameEvent_mock
+
+import (
+	"context"
+
+	"github.com/NachoGz/switcher-backend-go/internal/gameEvent"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockGameEventService struct {
+	mock.Mock
+}
+
+func (m *MockGameEventService) Record(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID, eventType gameEvent.EventType, payload interface{}) (*gameEvent.GameEvent, error) {
+	args := m.Called(ctx, gameID, playerID, eventType, payload)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*gameEvent.GameEvent), args.Error(1)
+}
+
+func (m *MockGameEventService) RecordGameStarted(ctx context.Context, gameID uuid.UUID) error {
+	args := m.Called(ctx, gameID)
+	return args.Error(0)
+}
+
+func (m *MockGameEventService) GetEvents(ctx context.Context, gameID uuid.UUID) ([]gameEvent.GameEvent, error) {
+	args := m.Called(ctx, gameID)
+	return args.Get(0).([]gameEvent.GameEvent), args.Error(1)
+}
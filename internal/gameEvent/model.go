@@ -0,0 +1,95 @@
+package gameEvent
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/NachoGz/switcher-backend-go/internal/board"
+	"github.com/NachoGz/switcher-backend-go/internal/database"
+	"github.com/NachoGz/switcher-backend-go/internal/figureCard"
+	"github.com/NachoGz/switcher-backend-go/internal/movementCard"
+	"github.com/google/uuid"
+)
+
+// EventType enum
+type EventType string
+
+const (
+	GAME_STARTED    EventType = "GAME_STARTED"
+	MOVEMENT_PLAYED EventType = "MOVEMENT_PLAYED"
+	MOVEMENT_UNDONE EventType = "MOVEMENT_UNDONE"
+	FIGURE_PLAYED   EventType = "FIGURE_PLAYED"
+	FIGURE_BLOCKED  EventType = "FIGURE_BLOCKED"
+	TURN_ENDED      EventType = "TURN_ENDED"
+	GAME_WON        EventType = "GAME_WON"
+)
+
+type GameEvent struct {
+	ID        uuid.UUID       `json:"id"`
+	GameID    uuid.UUID       `json:"game_id"`
+	Seq       int             `json:"seq"`
+	Type      EventType       `json:"type"`
+	PlayerID  uuid.UUID       `json:"player_id"`
+	Payload   json.RawMessage `json:"payload"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type DealtMovementCard struct {
+	ID       uuid.UUID             `json:"id"`
+	Type     movementCard.TypeEnum `json:"type"`
+	PlayerID uuid.UUID             `json:"player_id"`
+	Position int                   `json:"position"`
+}
+
+type DealtFigureCard struct {
+	ID       uuid.UUID           `json:"id"`
+	Type     figureCard.TypeEnum `json:"type"`
+	PlayerID uuid.UUID           `json:"player_id"`
+	Show     bool                `json:"show"`
+}
+
+// GameStartedPayload holds the initial board colors, indexed by [posY][posX],
+// and the dealt decks
+type GameStartedPayload struct {
+	Board         [][]board.ColorEnum `json:"board"`
+	MovementCards []DealtMovementCard `json:"movement_cards"`
+	FigureCards   []DealtFigureCard   `json:"figure_cards"`
+}
+
+// MovementPayload describes a swap of two boxes, either played or undone
+type MovementPayload struct {
+	MovementCardID uuid.UUID             `json:"movement_card_id"`
+	CardType       movementCard.TypeEnum `json:"card_type"`
+	From           board.BoardPosition   `json:"from"`
+	To             board.BoardPosition   `json:"to"`
+}
+
+// FigurePayload describes a figure formed on the board, either played by its
+// owner or used to block another player's card
+type FigurePayload struct {
+	FigureCardID   uuid.UUID             `json:"figure_card_id"`
+	FigureType     figureCard.TypeEnum   `json:"figure_type"`
+	TargetPlayerID uuid.UUID             `json:"target_player_id"`
+	Boxes          []board.BoardPosition `json:"boxes"`
+}
+
+type TurnEndedPayload struct {
+	NextPlayerID uuid.UUID `json:"next_player_id"`
+}
+
+type GameWonPayload struct {
+	WinnerID uuid.UUID `json:"winner_id"`
+}
+
+// DBToModel converts a database game event to a model game event
+func (s *Service) DBToModel(dbEvent database.GameEvent) GameEvent {
+	return GameEvent{
+		ID:        dbEvent.ID,
+		GameID:    dbEvent.GameID,
+		Seq:       int(dbEvent.Seq),
+		Type:      EventType(dbEvent.Type),
+		PlayerID:  dbEvent.PlayerID.UUID,
+		Payload:   dbEvent.Payload,
+		CreatedAt: dbEvent.CreatedAt,
+	}
+}
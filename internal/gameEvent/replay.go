@@ -0,0 +1,121 @@
+package gameEvent
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/NachoGz/switcher-backend-go/internal/board"
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
+)
+
+var (
+	ErrNoStartEvent    = utils.NewDomainError(utils.ErrNotFound, "NO_REPLAY", "the log doesn't start with a GAME_STARTED event")
+	ErrStepOutOfRange  = utils.NewDomainError(utils.ErrInvalidInput, "STEP_OUT_OF_RANGE", "step out of range")
+	ErrInvalidPosition = errors.New("movement outside of the board")
+)
+
+// Replayer rebuilds the board of a game from its event log
+type Replayer struct {
+	events  []GameEvent
+	initial [][]board.ColorEnum
+}
+
+// NewReplayer creates a replayer for a log that starts with GAME_STARTED
+func NewReplayer(events []GameEvent) (*Replayer, error) {
+	if len(events) == 0 || events[0].Type != GAME_STARTED {
+		return nil, ErrNoStartEvent
+	}
+
+	var started GameStartedPayload
+	if err := json.Unmarshal(events[0].Payload, &started); err != nil {
+		return nil, fmt.Errorf("invalid GAME_STARTED payload: %w", err)
+	}
+
+	return &Replayer{
+		events:  events,
+		initial: started.Board,
+	}, nil
+}
+
+// Steps returns the index of the last step. Step 0 is the initial board
+func (r *Replayer) Steps() int {
+	return len(r.events) - 1
+}
+
+// BoardAt returns the board colors, indexed by [posY][posX], after applying
+// the events up to the given step
+func (r *Replayer) BoardAt(step int) ([][]board.ColorEnum, error) {
+	if step < 0 || step > r.Steps() {
+		return nil, fmt.Errorf("%w: %d not in [0, %d]", ErrStepOutOfRange, step, r.Steps())
+	}
+
+	grid := make([][]board.ColorEnum, len(r.initial))
+	for i, row := range r.initial {
+		grid[i] = append([]board.ColorEnum(nil), row...)
+	}
+
+	for _, event := range r.events[1 : step+1] {
+		switch event.Type {
+		case MOVEMENT_PLAYED, MOVEMENT_UNDONE:
+			// Undoing a swap is swapping the same boxes again
+			var movement MovementPayload
+			if err := json.Unmarshal(event.Payload, &movement); err != nil {
+				return nil, fmt.Errorf("invalid %s payload at seq %d: %w", event.Type, event.Seq, err)
+			}
+			if err := swap(grid, movement.From, movement.To); err != nil {
+				return nil, fmt.Errorf("seq %d: %w", event.Seq, err)
+			}
+		}
+	}
+
+	return grid, nil
+}
+
+// WithoutHiddenCards leaves out of GAME_STARTED what players can't see while
+// the game runs: every hand of movement cards and the figure cards still in
+// the decks
+func WithoutHiddenCards(events []GameEvent) ([]GameEvent, error) {
+	result := append([]GameEvent(nil), events...)
+	for i, event := range result {
+		if event.Type != GAME_STARTED {
+			continue
+		}
+
+		var started GameStartedPayload
+		if err := json.Unmarshal(event.Payload, &started); err != nil {
+			return nil, fmt.Errorf("invalid GAME_STARTED payload: %w", err)
+		}
+		started.MovementCards = []DealtMovementCard{}
+		shown := []DealtFigureCard{}
+		for _, card := range started.FigureCards {
+			if card.Show {
+				shown = append(shown, card)
+			}
+		}
+		started.FigureCards = shown
+
+		payload, err := json.Marshal(started)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling GAME_STARTED payload: %w", err)
+		}
+		result[i].Payload = payload
+	}
+	return result, nil
+}
+
+// FinalBoard returns the board after applying every event
+func (r *Replayer) FinalBoard() ([][]board.ColorEnum, error) {
+	return r.BoardAt(r.Steps())
+}
+
+func swap(grid [][]board.ColorEnum, from, to board.BoardPosition) error {
+	for _, pos := range []board.BoardPosition{from, to} {
+		if pos.PosY < 0 || pos.PosY >= len(grid) || pos.PosX < 0 || pos.PosX >= len(grid[pos.PosY]) {
+			return fmt.Errorf("%w: (%d, %d)", ErrInvalidPosition, pos.PosX, pos.PosY)
+		}
+	}
+
+	grid[from.PosY][from.PosX], grid[to.PosY][to.PosX] = grid[to.PosY][to.PosX], grid[from.PosY][from.PosX]
+	return nil
+}
@@ -0,0 +1,244 @@
+package gameEvent_test
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/NachoGz/switcher-backend-go/internal/board"
+	"github.com/NachoGz/switcher-backend-go/internal/database"
+	"github.com/NachoGz/switcher-backend-go/internal/figureCard"
+	"github.com/NachoGz/switcher-backend-go/internal/gameEvent"
+	"github.com/NachoGz/switcher-backend-go/internal/movementCard"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBoardRepo keeps the boxes of a single game in memory
+type fakeBoardRepo struct {
+	board.BoardRepository
+	boxes []database.Box
+}
+
+func (r *fakeBoardRepo) GetBoxesByGame(ctx context.Context, gameID uuid.UUID) ([]database.Box, error) {
+	return append([]database.Box(nil), r.boxes...), nil
+}
+
+func (r *fakeBoardRepo) SwapColors(ctx context.Context, gameID uuid.UUID, posFrom, posTo board.BoardPosition) error {
+	from := &r.boxes[posFrom.PosY*6+posFrom.PosX]
+	to := &r.boxes[posTo.PosY*6+posTo.PosX]
+	from.Color, to.Color = to.Color, from.Color
+	return nil
+}
+
+type fakeMovementCardRepo struct {
+	movementCard.MovementCardRepository
+}
+
+func (r *fakeMovementCardRepo) GetMovementCardsByGame(ctx context.Context, gameID uuid.UUID) ([]database.MovementCard, error) {
+	return []database.MovementCard{}, nil
+}
+
+type fakeFigureCardRepo struct {
+	figureCard.FigureCardRepository
+}
+
+func (r *fakeFigureCardRepo) GetFigureCardsByGame(ctx context.Context, gameID uuid.UUID) ([]database.FigureCard, error) {
+	return []database.FigureCard{}, nil
+}
+
+// fakeEventRepo is an in-memory append-only log
+type fakeEventRepo struct {
+	events []database.GameEvent
+}
+
+func (r *fakeEventRepo) CreateGameEvent(ctx context.Context, params database.CreateGameEventParams) (database.GameEvent, error) {
+	event := database.GameEvent{
+		ID:        params.ID,
+		GameID:    params.GameID,
+		Seq:       int32(len(r.events) + 1),
+		Type:      params.Type,
+		PlayerID:  params.PlayerID,
+		Payload:   params.Payload,
+		CreatedAt: time.Now(),
+	}
+	r.events = append(r.events, event)
+	return event, nil
+}
+
+func (r *fakeEventRepo) GetGameEvents(ctx context.Context, gameID uuid.UUID) ([]database.GameEvent, error) {
+	return r.events, nil
+}
+
+func newBoxes(rng *rand.Rand) []database.Box {
+	colors := []board.ColorEnum{board.RED, board.GREEN, board.BLUE, board.YELLOW}
+	boxes := make([]database.Box, 0, 36)
+	for i := 0; i < 36; i++ {
+		boxes = append(boxes, database.Box{
+			ID:    uuid.New(),
+			Color: string(colors[rng.IntN(len(colors))]),
+			PosX:  int32(i % 6),
+			PosY:  int32(i / 6),
+		})
+	}
+	return boxes
+}
+
+func boxesToGrid(boxes []database.Box) [][]board.ColorEnum {
+	grid := make([][]board.ColorEnum, 6)
+	for _, box := range boxes {
+		grid[box.PosY] = append(grid[box.PosY], board.ColorEnum(box.Color))
+	}
+	return grid
+}
+
+func randomPosition(rng *rand.Rand) board.BoardPosition {
+	return board.BoardPosition{PosX: rng.IntN(6), PosY: rng.IntN(6)}
+}
+
+func TestReplay_FinalBoardMatchesBoxes(t *testing.T) {
+	ctx := context.Background()
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	gameID := uuid.New()
+	playerID := uuid.New()
+
+	boardRepo := &fakeBoardRepo{boxes: newBoxes(rng)}
+	eventRepo := &fakeEventRepo{}
+	service := gameEvent.NewService(eventRepo, boardRepo, &fakeMovementCardRepo{}, &fakeFigureCardRepo{})
+
+	initial := boxesToGrid(boardRepo.boxes)
+	require.NoError(t, service.RecordGameStarted(ctx, gameID))
+
+	// Play random movements, undoing some of them, the same way the
+	// services change the boxes table
+	var played []gameEvent.MovementPayload
+	for i := 0; i < 50; i++ {
+		if len(played) > 0 && rng.IntN(4) == 0 {
+			last := played[len(played)-1]
+			played = played[:len(played)-1]
+			require.NoError(t, boardRepo.SwapColors(ctx, gameID, last.From, last.To))
+			_, err := service.Record(ctx, gameID, playerID, gameEvent.MOVEMENT_UNDONE, last)
+			require.NoError(t, err)
+			continue
+		}
+
+		movement := gameEvent.MovementPayload{
+			MovementCardID: uuid.New(),
+			CardType:       movementCard.LINEAR_CONT,
+			From:           randomPosition(rng),
+			To:             randomPosition(rng),
+		}
+		require.NoError(t, boardRepo.SwapColors(ctx, gameID, movement.From, movement.To))
+		_, err := service.Record(ctx, gameID, playerID, gameEvent.MOVEMENT_PLAYED, movement)
+		require.NoError(t, err)
+		played = append(played, movement)
+
+		if i%10 == 9 {
+			_, err := service.Record(ctx, gameID, playerID, gameEvent.TURN_ENDED, gameEvent.TurnEndedPayload{NextPlayerID: playerID})
+			require.NoError(t, err)
+		}
+	}
+
+	events, err := service.GetEvents(ctx, gameID)
+	require.NoError(t, err)
+
+	replayer, err := gameEvent.NewReplayer(events)
+	require.NoError(t, err)
+	assert.Equal(t, len(events)-1, replayer.Steps())
+
+	// Step 0 is the dealt board
+	start, err := replayer.BoardAt(0)
+	require.NoError(t, err)
+	assert.Equal(t, initial, start)
+
+	// The last step is the current boxes state
+	final, err := replayer.FinalBoard()
+	require.NoError(t, err)
+	assert.Equal(t, boxesToGrid(boardRepo.boxes), final)
+}
+
+func TestReplay_BoardAtEachStep(t *testing.T) {
+	ctx := context.Background()
+	rng := rand.New(rand.NewPCG(3, 4))
+
+	gameID := uuid.New()
+	boardRepo := &fakeBoardRepo{boxes: newBoxes(rng)}
+	eventRepo := &fakeEventRepo{}
+	service := gameEvent.NewService(eventRepo, boardRepo, &fakeMovementCardRepo{}, &fakeFigureCardRepo{})
+
+	require.NoError(t, service.RecordGameStarted(ctx, gameID))
+
+	snapshots := [][][]board.ColorEnum{boxesToGrid(boardRepo.boxes)}
+	for i := 0; i < 10; i++ {
+		movement := gameEvent.MovementPayload{From: randomPosition(rng), To: randomPosition(rng)}
+		require.NoError(t, boardRepo.SwapColors(ctx, gameID, movement.From, movement.To))
+		_, err := service.Record(ctx, gameID, uuid.Nil, gameEvent.MOVEMENT_PLAYED, movement)
+		require.NoError(t, err)
+		snapshots = append(snapshots, boxesToGrid(boardRepo.boxes))
+	}
+
+	events, err := service.GetEvents(ctx, gameID)
+	require.NoError(t, err)
+	replayer, err := gameEvent.NewReplayer(events)
+	require.NoError(t, err)
+
+	for step, expected := range snapshots {
+		grid, err := replayer.BoardAt(step)
+		require.NoError(t, err)
+		assert.Equal(t, expected, grid, "step %d", step)
+	}
+
+	_, err = replayer.BoardAt(len(snapshots))
+	assert.ErrorIs(t, err, gameEvent.ErrStepOutOfRange)
+}
+
+func TestReplay_RequiresStartEvent(t *testing.T) {
+	payload, _ := json.Marshal(gameEvent.TurnEndedPayload{})
+	_, err := gameEvent.NewReplayer([]gameEvent.GameEvent{{Type: gameEvent.TURN_ENDED, Payload: payload}})
+	assert.ErrorIs(t, err, gameEvent.ErrNoStartEvent)
+
+	_, err = gameEvent.NewReplayer(nil)
+	assert.ErrorIs(t, err, gameEvent.ErrNoStartEvent)
+}
+
+func TestWithoutHiddenCards(t *testing.T) {
+	playerID := uuid.New()
+	shown := gameEvent.DealtFigureCard{ID: uuid.New(), Type: figureCard.FIG01, PlayerID: playerID, Show: true}
+	started := gameEvent.GameStartedPayload{
+		Board: [][]board.ColorEnum{{board.RED, board.BLUE}},
+		MovementCards: []gameEvent.DealtMovementCard{
+			{ID: uuid.New(), Type: movementCard.DIAGONAL_CONT, PlayerID: playerID},
+		},
+		FigureCards: []gameEvent.DealtFigureCard{
+			shown,
+			{ID: uuid.New(), Type: figureCard.FIG02, PlayerID: playerID, Show: false},
+		},
+	}
+	payload, err := json.Marshal(started)
+	require.NoError(t, err)
+	turnEnded, err := json.Marshal(gameEvent.TurnEndedPayload{})
+	require.NoError(t, err)
+
+	events := []gameEvent.GameEvent{
+		{Seq: 1, Type: gameEvent.GAME_STARTED, Payload: payload},
+		{Seq: 2, Type: gameEvent.TURN_ENDED, Payload: turnEnded},
+	}
+
+	visible, err := gameEvent.WithoutHiddenCards(events)
+	require.NoError(t, err)
+	require.Len(t, visible, 2)
+
+	var visibleStart gameEvent.GameStartedPayload
+	require.NoError(t, json.Unmarshal(visible[0].Payload, &visibleStart))
+	assert.Equal(t, started.Board, visibleStart.Board)
+	assert.Empty(t, visibleStart.MovementCards)
+	assert.Equal(t, []gameEvent.DealtFigureCard{shown}, visibleStart.FigureCards)
+	assert.Equal(t, events[1], visible[1])
+
+	// The log itself is left as it was
+	assert.Equal(t, payload, []byte(events[0].Payload))
+}
@@ -0,0 +1,30 @@
+package gameEvent
+
+import (
+	"context"
+
+	"github.com/NachoGz/switcher-backend-go/internal/database"
+	"github.com/google/uuid"
+)
+
+// PostgresGameEventRepository implements GameEventRepository for Postgres
+type PostgresGameEventRepository struct {
+	queries *database.Queries
+}
+
+// NewGameEventRepository creates a new game event repository
+func NewGameEventRepository(queries *database.Queries) GameEventRepository {
+	return &PostgresGameEventRepository{
+		queries: queries,
+	}
+}
+
+// CreateGameEvent appends an event to the log of a game
+func (r *PostgresGameEventRepository) CreateGameEvent(ctx context.Context, params database.CreateGameEventParams) (database.GameEvent, error) {
+	return r.queries.CreateGameEvent(ctx, params)
+}
+
+// GetGameEvents fetches the log of a game in order
+func (r *PostgresGameEventRepository) GetGameEvents(ctx context.Context, gameID uuid.UUID) ([]database.GameEvent, error) {
+	return r.queries.GetGameEvents(ctx, gameID)
+}
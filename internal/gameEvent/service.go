@@ -0,0 +1,129 @@
+package gameEvent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/NachoGz/switcher-backend-go/internal/board"
+	"github.com/NachoGz/switcher-backend-go/internal/database"
+	"github.com/NachoGz/switcher-backend-go/internal/figureCard"
+	"github.com/NachoGz/switcher-backend-go/internal/movementCard"
+	"github.com/google/uuid"
+)
+
+// Service handles the append-only log of game events
+type Service struct {
+	eventRepo        GameEventRepository
+	boardRepo        board.BoardRepository
+	movementCardRepo movementCard.MovementCardRepository
+	figureCardRepo   figureCard.FigureCardRepository
+}
+
+// NewService creates a new game event service
+func NewService(
+	eventRepo GameEventRepository,
+	boardRepo board.BoardRepository,
+	movementCardRepo movementCard.MovementCardRepository,
+	figureCardRepo figureCard.FigureCardRepository,
+) *Service {
+	return &Service{
+		eventRepo:        eventRepo,
+		boardRepo:        boardRepo,
+		movementCardRepo: movementCardRepo,
+		figureCardRepo:   figureCardRepo,
+	}
+}
+
+// Ensure Service implements GameEventService
+var _ GameEventService = (*Service)(nil)
+
+// Record appends an event to the log of a game. playerID may be uuid.Nil for
+// events that aren't caused by a player
+func (s *Service) Record(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID, eventType EventType, payload interface{}) (*GameEvent, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling %s payload: %w", eventType, err)
+	}
+
+	dbEvent, err := s.eventRepo.CreateGameEvent(ctx, database.CreateGameEventParams{
+		ID:       uuid.New(),
+		GameID:   gameID,
+		Type:     string(eventType),
+		PlayerID: uuid.NullUUID{UUID: playerID, Valid: playerID != uuid.Nil},
+		Payload:  data,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error recording %s event: %w", eventType, err)
+	}
+
+	event := s.DBToModel(dbEvent)
+	return &event, nil
+}
+
+// RecordGameStarted records the initial board colors and the dealt decks.
+// It must be called once the board and the decks have been created
+func (s *Service) RecordGameStarted(ctx context.Context, gameID uuid.UUID) error {
+	boxes, err := s.boardRepo.GetBoxesByGame(ctx, gameID)
+	if err != nil {
+		return fmt.Errorf("error fetching boxes: %w", err)
+	}
+
+	payload := GameStartedPayload{
+		Board:         [][]board.ColorEnum{},
+		MovementCards: []DealtMovementCard{},
+		FigureCards:   []DealtFigureCard{},
+	}
+
+	for _, box := range boxes {
+		row := int(box.PosY)
+		for len(payload.Board) <= row {
+			payload.Board = append(payload.Board, []board.ColorEnum{})
+		}
+		payload.Board[row] = append(payload.Board[row], board.ColorEnum(box.Color))
+	}
+
+	movementCards, err := s.movementCardRepo.GetMovementCardsByGame(ctx, gameID)
+	if err != nil {
+		return fmt.Errorf("error fetching movement cards: %w", err)
+	}
+	for _, card := range movementCards {
+		payload.MovementCards = append(payload.MovementCards, DealtMovementCard{
+			ID:       card.ID,
+			Type:     movementCard.TypeEnum(card.Type),
+			PlayerID: card.PlayerID.UUID,
+			Position: int(card.Position.Int32),
+		})
+	}
+
+	figureCards, err := s.figureCardRepo.GetFigureCardsByGame(ctx, gameID)
+	if err != nil {
+		return fmt.Errorf("error fetching figure cards: %w", err)
+	}
+	for _, card := range figureCards {
+		payload.FigureCards = append(payload.FigureCards, DealtFigureCard{
+			ID:       card.ID,
+			Type:     figureCard.TypeEnum(card.Type),
+			PlayerID: card.PlayerID,
+			Show:     card.Show,
+		})
+	}
+
+	_, err = s.Record(ctx, gameID, uuid.Nil, GAME_STARTED, payload)
+	return err
+}
+
+// GetEvents fetches the whole log of a game in order
+func (s *Service) GetEvents(ctx context.Context, gameID uuid.UUID) ([]GameEvent, error) {
+	dbEvents, err := s.eventRepo.GetGameEvents(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]GameEvent, 0, len(dbEvents))
+	for _, dbEvent := range dbEvents {
+		events = append(events, s.DBToModel(dbEvent))
+	}
+
+	return events, nil
+}
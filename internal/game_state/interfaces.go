@@ -11,13 +11,19 @@ type GameStateService interface {
 	CreateGameState(ctx context.Context, gameStateData GameState) (*GameState, error)
 	DBToModel(ctx context.Context, dbGameState database.GameState) GameState
 	UpdateGameState(ctx context.Context, gameID uuid.UUID, state State) error
+	TransitionGameState(ctx context.Context, gameID uuid.UUID, from State, to State) (bool, error)
 	UpdateCurrentPlayer(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID) error
 	GetGameStateByGameID(ctx context.Context, gameID uuid.UUID) (*GameState, error)
+	UpdateForbiddenColor(ctx context.Context, gameID uuid.UUID, color string) error
+	CountGamesByState(ctx context.Context) (map[State]int, error)
 }
 
 type GameStateRepository interface {
 	CreateGameState(ctx context.Context, params database.CreateGameStateParams) (database.GameState, error)
 	UpdateGameState(ctx context.Context, params database.UpdateGameStateParams) error
+	TransitionGameState(ctx context.Context, params database.TransitionGameStateParams) (int64, error)
 	UpdateCurrentPlayer(ctx context.Context, params database.UpdateCurrentPlayerParams) error
 	GetGameStateByGameID(ctx context.Context, gameID uuid.UUID) (database.GameState, error)
+	UpdateForbiddenColor(ctx context.Context, params database.UpdateForbiddenColorParams) error
+	CountGamesByState(ctx context.Context) ([]database.CountGamesByStateRow, error)
 }
@@ -22,6 +22,11 @@ func (m *MockGameStateRepository) UpdateGameState(ctx context.Context, params da
 	return args.Error(0)
 }
 
+func (m *MockGameStateRepository) TransitionGameState(ctx context.Context, params database.TransitionGameStateParams) (int64, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *MockGameStateRepository) UpdateCurrentPlayer(ctx context.Context, params database.UpdateCurrentPlayerParams) error {
 	args := m.Called(ctx, params)
 	return args.Error(0)
@@ -31,3 +36,13 @@ func (m *MockGameStateRepository) GetGameStateByGameID(ctx context.Context, game
 	args := m.Called(ctx, gameID)
 	return args.Get(0).(database.GameState), args.Error(1)
 }
+
+func (m *MockGameStateRepository) UpdateForbiddenColor(ctx context.Context, params database.UpdateForbiddenColorParams) error {
+	args := m.Called(ctx, params)
+	return args.Error(0)
+}
+
+func (m *MockGameStateRepository) CountGamesByState(ctx context.Context) ([]database.CountGamesByStateRow, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]database.CountGamesByStateRow), args.Error(1)
+}
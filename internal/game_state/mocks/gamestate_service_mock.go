@@ -31,6 +31,11 @@ func (m *MockGameStateService) UpdateGameState(ctx context.Context, gameID uuid.
 	return args.Error(0)
 }
 
+func (m *MockGameStateService) TransitionGameState(ctx context.Context, gameID uuid.UUID, from gameState.State, to gameState.State) (bool, error) {
+	args := m.Called(ctx, gameID, from, to)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *MockGameStateService) UpdateCurrentPlayer(ctx context.Context, gameID uuid.UUID, currentPlayerID uuid.UUID) error {
 	args := m.Called(ctx, gameID, currentPlayerID)
 	return args.Error(0)
@@ -40,3 +45,13 @@ func (m *MockGameStateService) GetGameStateByGameID(ctx context.Context, gameID
 	args := m.Called(ctx, gameID)
 	return args.Get(0).(*gameState.GameState), args.Error(1)
 }
+
+func (m *MockGameStateService) UpdateForbiddenColor(ctx context.Context, gameID uuid.UUID, color string) error {
+	args := m.Called(ctx, gameID, color)
+	return args.Error(0)
+}
+
+func (m *MockGameStateService) CountGamesByState(ctx context.Context) (map[gameState.State]int, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(map[gameState.State]int), args.Error(1)
+}
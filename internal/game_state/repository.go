@@ -28,6 +28,12 @@ func (r *PostgresGameStateRepository) UpdateGameState(ctx context.Context, param
 	return r.queries.UpdateGameState(ctx, params)
 }
 
+// TransitionGameState moves the game to the new state only if it is still in the
+// expected one and returns how many rows changed
+func (r *PostgresGameStateRepository) TransitionGameState(ctx context.Context, params database.TransitionGameStateParams) (int64, error) {
+	return r.queries.TransitionGameState(ctx, params)
+}
+
 func (r *PostgresGameStateRepository) UpdateCurrentPlayer(ctx context.Context, params database.UpdateCurrentPlayerParams) error {
 	return r.queries.UpdateCurrentPlayer(ctx, params)
 }
@@ -35,3 +41,13 @@ func (r *PostgresGameStateRepository) UpdateCurrentPlayer(ctx context.Context, p
 func (r *PostgresGameStateRepository) GetGameStateByGameID(ctx context.Context, gameID uuid.UUID) (database.GameState, error) {
 	return r.queries.GetGameStateByGameID(ctx, gameID)
 }
+
+// UpdateForbiddenColor sets the forbidden color of the game
+func (r *PostgresGameStateRepository) UpdateForbiddenColor(ctx context.Context, params database.UpdateForbiddenColorParams) error {
+	return r.queries.UpdateForbiddenColor(ctx, params)
+}
+
+// CountGamesByState counts the games in each state
+func (r *PostgresGameStateRepository) CountGamesByState(ctx context.Context) ([]database.CountGamesByStateRow, error) {
+	return r.queries.CountGamesByState(ctx)
+}
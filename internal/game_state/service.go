@@ -2,6 +2,7 @@ package gameState
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/NachoGz/switcher-backend-go/internal/database"
 	"github.com/NachoGz/switcher-backend-go/internal/player"
@@ -58,10 +59,25 @@ func (s *Service) UpdateGameState(ctx context.Context, gameID uuid.UUID, state S
 	return nil
 }
 
+// TransitionGameState moves the game from one state to another, reporting false
+// when the game was no longer in the state it was expected to leave
+func (s *Service) TransitionGameState(ctx context.Context, gameID uuid.UUID, from State, to State) (bool, error) {
+	changed, err := s.gameStateRepo.TransitionGameState(ctx, database.TransitionGameStateParams{
+		ToState:   string(to),
+		GameID:    gameID,
+		FromState: string(from),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return changed > 0, nil
+}
+
 func (s *Service) UpdateCurrentPlayer(ctx context.Context, gameID uuid.UUID, currentPlayerID uuid.UUID) error {
 	err := s.gameStateRepo.UpdateCurrentPlayer(ctx, database.UpdateCurrentPlayerParams{
 		GameID:          gameID,
-		CurrentPlayerID: uuid.NullUUID{UUID: currentPlayerID},
+		CurrentPlayerID: uuid.NullUUID{UUID: currentPlayerID, Valid: true},
 	})
 	if err != nil {
 		return err
@@ -80,3 +96,27 @@ func (s *Service) GetGameStateByGameID(ctx context.Context, gameID uuid.UUID) (*
 
 	return &gameState, nil
 }
+
+// UpdateForbiddenColor sets the color no figure can be played with, an empty
+// color lifts the restriction
+func (s *Service) UpdateForbiddenColor(ctx context.Context, gameID uuid.UUID, color string) error {
+	return s.gameStateRepo.UpdateForbiddenColor(ctx, database.UpdateForbiddenColorParams{
+		GameID:         gameID,
+		ForbiddenColor: sql.NullString{String: color, Valid: color != ""},
+	})
+}
+
+// CountGamesByState counts the games in each state. States without games
+// are left out
+func (s *Service) CountGamesByState(ctx context.Context) (map[State]int, error) {
+	rows, err := s.gameStateRepo.CountGamesByState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[State]int, len(rows))
+	for _, row := range rows {
+		counts[State(row.State)] = int(row.Games)
+	}
+	return counts, nil
+}
@@ -0,0 +1,146 @@
+package gameplay
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/NachoGz/switcher-backend-go/internal/board"
+	"github.com/NachoGz/switcher-backend-go/internal/movementCard"
+)
+
+// Amount of movements looked ahead when searching where figures can be formed
+const HINT_DEPTH = 2
+
+// EnumerateMoves lists the legal swaps of every movement card in the player's
+// hand, and where every shown figure card is formed now or after up to
+// HINT_DEPTH movements with that hand. Each place is listed once, with the
+// shortest list of movements found. Figures of the forbidden color are left
+// out, since they can't be played
+func EnumerateMoves(state *TurnState) *MoveHints {
+	hints := &MoveHints{
+		GameID:    state.GameID,
+		PlayerID:  state.PlayerID,
+		Movements: []MovementOption{},
+		Figures:   []FigureOption{},
+	}
+
+	grid := make([][]board.ColorEnum, len(state.Board))
+	for y := range state.Board {
+		grid[y] = append([]board.ColorEnum(nil), state.Board[y]...)
+	}
+	boardSize := len(grid)
+
+	// Legal swaps of each card
+	available := []Movement{}
+	for _, card := range state.Hand {
+		option := MovementOption{
+			MovementCardID: card.ID,
+			Type:           card.Type,
+			Used:           card.Used,
+			Swaps:          []Swap{},
+		}
+		if !card.Used {
+			for y := 0; y < boardSize; y++ {
+				for x := 0; x < boardSize; x++ {
+					from := board.BoardPosition{PosX: x, PosY: y}
+					for _, to := range movementCard.Destinations(card.Type, from, boardSize) {
+						option.Swaps = append(option.Swaps, Swap{From: from, To: to})
+						available = append(available, Movement{MovementCardID: card.ID, From: from, To: to})
+					}
+				}
+			}
+		}
+		hints.Movements = append(hints.Movements, option)
+	}
+
+	for _, card := range state.FigureCards {
+		hints.Figures = append(hints.Figures, FigureOption{
+			FigureCardID: card.ID,
+			Type:         card.Type,
+			PlayerID:     card.PlayerID,
+			Blocked:      card.Blocked,
+			Placements:   []FigurePlacement{},
+		})
+	}
+
+	// Places already listed for each figure option
+	seen := make([]map[string]bool, len(hints.Figures))
+	for i := range seen {
+		seen[i] = map[string]bool{}
+	}
+
+	record := func(figures []board.FormedFigure, movements []Movement) {
+		for _, figure := range figures {
+			if figure.Color == state.ForbiddenColor {
+				continue
+			}
+			key := placementKey(figure.Boxes)
+			for i := range hints.Figures {
+				option := &hints.Figures[i]
+				if option.Type != figure.Type || seen[i][key] {
+					continue
+				}
+				seen[i][key] = true
+				option.Placements = append(option.Placements, FigurePlacement{
+					Color:     figure.Color,
+					Boxes:     figure.Boxes,
+					Movements: append([]Movement{}, movements...),
+				})
+			}
+		}
+	}
+
+	record(board.FindFormedFigures(grid), nil)
+
+	// Breadth first, so places are first found with the fewest movements
+	for depth := 1; depth <= HINT_DEPTH; depth++ {
+		explore(grid, available, []Movement{}, depth, record)
+	}
+
+	return hints
+}
+
+// explore plays every sequence of exactly depth movements with different
+// cards, recording the figures formed around the last swap
+func explore(grid [][]board.ColorEnum, available []Movement, played []Movement, depth int, record func([]board.FormedFigure, []Movement)) {
+	for _, movement := range available {
+		if usesCard(played, movement) {
+			continue
+		}
+		// Swapping boxes of the same color doesn't change the board
+		if grid[movement.From.PosY][movement.From.PosX] == grid[movement.To.PosY][movement.To.PosX] {
+			continue
+		}
+
+		swapBoxes(grid, movement.From, movement.To)
+		sequence := append(played, movement)
+		if depth == 1 {
+			record(board.FindFormedFiguresAround(grid, []board.BoardPosition{movement.From, movement.To}), sequence)
+		} else {
+			explore(grid, available, sequence, depth-1, record)
+		}
+		swapBoxes(grid, movement.From, movement.To)
+	}
+}
+
+func usesCard(played []Movement, movement Movement) bool {
+	for _, p := range played {
+		if p.MovementCardID == movement.MovementCardID {
+			return true
+		}
+	}
+	return false
+}
+
+func swapBoxes(grid [][]board.ColorEnum, from, to board.BoardPosition) {
+	grid[from.PosY][from.PosX], grid[to.PosY][to.PosX] = grid[to.PosY][to.PosX], grid[from.PosY][from.PosX]
+}
+
+// placementKey identifies the boxes of a figure, which come sorted
+func placementKey(boxes []board.BoardPosition) string {
+	cells := make([]string, len(boxes))
+	for i, box := range boxes {
+		cells[i] = fmt.Sprintf("%d,%d", box.PosX, box.PosY)
+	}
+	return strings.Join(cells, ";")
+}
@@ -0,0 +1,224 @@
+package gameplay_test
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/NachoGz/switcher-backend-go/internal/board"
+	"github.com/NachoGz/switcher-backend-go/internal/board/boardtest"
+	"github.com/NachoGz/switcher-backend-go/internal/figureCard"
+	gameState "github.com/NachoGz/switcher-backend-go/internal/game_state"
+	"github.com/NachoGz/switcher-backend-go/internal/gameplay"
+	"github.com/NachoGz/switcher-backend-go/internal/movementCard"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTurnState(grid [][]board.ColorEnum, figureTypes ...figureCard.TypeEnum) *gameplay.TurnState {
+	playerID := uuid.New()
+	state := &gameplay.TurnState{
+		GameID:          uuid.New(),
+		PlayerID:        playerID,
+		State:           gameState.PLAYING,
+		CurrentPlayerID: playerID,
+		Board:           grid,
+		Hand: []movementCard.MovementCard{
+			{ID: uuid.New(), Type: movementCard.LINEAR_CONT, PlayerID: playerID},
+			{ID: uuid.New(), Type: movementCard.LINEAR_CONT, PlayerID: playerID},
+			{ID: uuid.New(), Type: movementCard.DIAGONAL_SPA, PlayerID: playerID},
+		},
+		FigureCards: []figureCard.FigureCard{},
+	}
+	for _, figureType := range figureTypes {
+		state.FigureCards = append(state.FigureCards, figureCard.FigureCard{
+			ID: uuid.New(), Type: figureType, Show: true, PlayerID: playerID,
+		})
+	}
+	return state
+}
+
+// applyMovements plays the movements of a placement on a copy of the board
+func applyMovements(grid [][]board.ColorEnum, movements []gameplay.Movement) [][]board.ColorEnum {
+	result := make([][]board.ColorEnum, len(grid))
+	for y := range grid {
+		result[y] = append([]board.ColorEnum(nil), grid[y]...)
+	}
+	for _, m := range movements {
+		result[m.From.PosY][m.From.PosX], result[m.To.PosY][m.To.PosX] = result[m.To.PosY][m.To.PosX], result[m.From.PosY][m.From.PosX]
+	}
+	return result
+}
+
+func TestEnumerateMoves_Swaps(t *testing.T) {
+	state := newTurnState(boardtest.ParseGrid(
+		"BGBGBG",
+		"GBGBGB",
+		"BGBGBG",
+		"GBGBGB",
+		"BGBGBG",
+		"GBGBGB",
+	))
+	state.Hand[1].Used = true
+
+	hints := gameplay.EnumerateMoves(state)
+	require.Len(t, hints.Movements, 3)
+
+	// 60 ordered pairs of neighbours on a 6x6 board
+	assert.Len(t, hints.Movements[0].Swaps, 120)
+	for _, swap := range hints.Movements[0].Swaps {
+		assert.True(t, movementCard.IsValidMovement(movementCard.LINEAR_CONT, swap.From, swap.To, 6))
+	}
+
+	// Used cards have no swaps left
+	assert.True(t, hints.Movements[1].Used)
+	assert.Empty(t, hints.Movements[1].Swaps)
+
+	// 16 corners of 2x2 diagonals, both ways, in both directions
+	assert.Len(t, hints.Movements[2].Swaps, 64)
+}
+
+func TestEnumerateMoves_FormedNow(t *testing.T) {
+	state := newTurnState(boardtest.ParseGrid(
+		"RRBGBG",
+		"RRGBGB",
+		"BGBGBG",
+		"GBGBGB",
+		"BGBGBG",
+		"GBGBGB",
+	), figureCard.FIGE02)
+
+	hints := gameplay.EnumerateMoves(state)
+	require.Len(t, hints.Figures, 1)
+	require.NotEmpty(t, hints.Figures[0].Placements)
+
+	placement := hints.Figures[0].Placements[0]
+	assert.Empty(t, placement.Movements)
+	assert.Equal(t, board.RED, placement.Color)
+	assert.Equal(t, []board.BoardPosition{
+		{PosX: 0, PosY: 0}, {PosX: 1, PosY: 0}, {PosX: 0, PosY: 1}, {PosX: 1, PosY: 1},
+	}, placement.Boxes)
+}
+
+func TestEnumerateMoves_FormedAfterMovements(t *testing.T) {
+	grid := boardtest.ParseGrid(
+		"RRBGBG",
+		"GBRBGB",
+		"RGBGBG",
+		"GBGBGB",
+		"BGBGBG",
+		"GBGBGB",
+	)
+	state := newTurnState(grid, figureCard.FIGE02)
+
+	hints := gameplay.EnumerateMoves(state)
+	require.Len(t, hints.Figures, 1)
+
+	square := []board.BoardPosition{
+		{PosX: 0, PosY: 0}, {PosX: 1, PosY: 0}, {PosX: 0, PosY: 1}, {PosX: 1, PosY: 1},
+	}
+	var found *gameplay.FigurePlacement
+	for i, placement := range hints.Figures[0].Placements {
+		if assert.ObjectsAreEqual(square, placement.Boxes) {
+			found = &hints.Figures[0].Placements[i]
+		}
+	}
+	require.NotNil(t, found)
+
+	// The square needs two movements, with different cards
+	require.Len(t, found.Movements, 2)
+	assert.NotEqual(t, found.Movements[0].MovementCardID, found.Movements[1].MovementCardID)
+
+	// Every placement is really formed after its movements
+	for _, placement := range hints.Figures[0].Placements {
+		after := applyMovements(grid, placement.Movements)
+		formed := false
+		for _, figure := range board.FindFormedFigures(after) {
+			if figure.Type == figureCard.FIGE02 && assert.ObjectsAreEqual(placement.Boxes, figure.Boxes) {
+				formed = true
+			}
+		}
+		assert.True(t, formed, "placement %v after %v", placement.Boxes, placement.Movements)
+		assert.LessOrEqual(t, len(placement.Movements), gameplay.HINT_DEPTH)
+	}
+}
+
+func TestEnumerateMoves_ForbiddenColor(t *testing.T) {
+	state := newTurnState(boardtest.ParseGrid(
+		"RRBGBG",
+		"RRGBGB",
+		"BGBGBG",
+		"GBGBGB",
+		"BGBGBG",
+		"GBGBGB",
+	), figureCard.FIGE02)
+	state.ForbiddenColor = board.RED
+
+	hints := gameplay.EnumerateMoves(state)
+	for _, placement := range hints.Figures[0].Placements {
+		assert.NotEqual(t, board.RED, placement.Color)
+	}
+}
+
+// TestEnumerateMoves_MatchesFullSearch checks the placements found one
+// movement away against looking at the whole board after every swap
+func TestEnumerateMoves_MatchesFullSearch(t *testing.T) {
+	rng := rand.New(rand.NewPCG(7, 8))
+	colors := []board.ColorEnum{board.RED, board.GREEN, board.BLUE, board.YELLOW}
+
+	for round := 0; round < 5; round++ {
+		grid := make([][]board.ColorEnum, 6)
+		for y := range grid {
+			for x := 0; x < 6; x++ {
+				grid[y] = append(grid[y], colors[rng.IntN(len(colors))])
+			}
+		}
+
+		state := newTurnState(grid, figureCard.GetAllCardTypes()...)
+		state.Hand = state.Hand[:1]
+		hints := gameplay.EnumerateMoves(state)
+
+		// Every figure formed now or after one swap, by type
+		expected := map[figureCard.TypeEnum]int{}
+		seen := map[string]bool{}
+		add := func(figures []board.FormedFigure) {
+			for _, figure := range figures {
+				key := string(figure.Type)
+				for _, box := range figure.Boxes {
+					key += string(rune('a'+box.PosX)) + string(rune('a'+box.PosY))
+				}
+				if !seen[key] {
+					seen[key] = true
+					expected[figure.Type]++
+				}
+			}
+		}
+		add(board.FindFormedFigures(grid))
+		for _, swap := range hints.Movements[0].Swaps {
+			add(board.FindFormedFigures(applyMovements(grid, []gameplay.Movement{{From: swap.From, To: swap.To}})))
+		}
+
+		for _, option := range hints.Figures {
+			assert.Equal(t, expected[option.Type], len(option.Placements), "round %d figure %s", round, option.Type)
+		}
+	}
+}
+
+func TestEnumerateMoves_Fast(t *testing.T) {
+	rng := rand.New(rand.NewPCG(9, 10))
+	colors := []board.ColorEnum{board.RED, board.GREEN, board.BLUE, board.YELLOW}
+	grid := make([][]board.ColorEnum, 6)
+	for y := range grid {
+		for x := 0; x < 6; x++ {
+			grid[y] = append(grid[y], colors[rng.IntN(len(colors))])
+		}
+	}
+
+	state := newTurnState(grid, figureCard.FIG01, figureCard.FIG14, figureCard.FIGE03)
+	state.Hand[1].Type = movementCard.L_LEFT
+
+	start := time.Now()
+	gameplay.EnumerateMoves(state)
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
@@ -0,0 +1,27 @@
+package gameplay
+
+import (
+	"context"
+
+	"github.com/NachoGz/switcher-backend-go/internal/board"
+	"github.com/google/uuid"
+)
+
+// TurnListener is called every time a player's turn begins
+type TurnListener func(gameID uuid.UUID, playerID uuid.UUID)
+
+// FinishListener is called once a game has a winner
+type FinishListener func(ctx context.Context, gameID uuid.UUID, winnerID uuid.UUID)
+
+type GameplayService interface {
+	PlayMovement(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID, movementCardID uuid.UUID, from, to board.BoardPosition) error
+	PlayFigure(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID, figureCardID uuid.UUID, pos board.BoardPosition) error
+	BlockFigure(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID, figureCardID uuid.UUID, pos board.BoardPosition) error
+	EndTurn(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID) error
+	AbandonGame(ctx context.Context, gameID uuid.UUID) error
+	BeginTurn(gameID uuid.UUID, playerID uuid.UUID)
+	OnTurnStart(listener TurnListener)
+	OnGameFinished(listener FinishListener)
+	GetTurnState(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID) (*TurnState, error)
+	GetMoveHints(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID) (*MoveHints, error)
+}
@@ -0,0 +1,67 @@
+package gameplay_mock
+
+import (
+	"context"
+
+	"github.com/NachoGz/switcher-backend-go/internal/board"
+	"github.com/NachoGz/switcher-backend-go/internal/gameplay"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockGameplayService struct {
+	mock.Mock
+}
+
+func (m *MockGameplayService) PlayMovement(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID, movementCardID uuid.UUID, from, to board.BoardPosition) error {
+	args := m.Called(ctx, gameID, playerID, movementCardID, from, to)
+	return args.Error(0)
+}
+
+func (m *MockGameplayService) PlayFigure(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID, figureCardID uuid.UUID, pos board.BoardPosition) error {
+	args := m.Called(ctx, gameID, playerID, figureCardID, pos)
+	return args.Error(0)
+}
+
+func (m *MockGameplayService) BlockFigure(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID, figureCardID uuid.UUID, pos board.BoardPosition) error {
+	args := m.Called(ctx, gameID, playerID, figureCardID, pos)
+	return args.Error(0)
+}
+
+func (m *MockGameplayService) EndTurn(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID) error {
+	args := m.Called(ctx, gameID, playerID)
+	return args.Error(0)
+}
+
+func (m *MockGameplayService) AbandonGame(ctx context.Context, gameID uuid.UUID) error {
+	args := m.Called(ctx, gameID)
+	return args.Error(0)
+}
+
+func (m *MockGameplayService) BeginTurn(gameID uuid.UUID, playerID uuid.UUID) {
+	m.Called(gameID, playerID)
+}
+
+func (m *MockGameplayService) OnTurnStart(listener gameplay.TurnListener) {
+	m.Called(listener)
+}
+
+func (m *MockGameplayService) OnGameFinished(listener gameplay.FinishListener) {
+	m.Called(listener)
+}
+
+func (m *MockGameplayService) GetTurnState(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID) (*gameplay.TurnState, error) {
+	args := m.Called(ctx, gameID, playerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*gameplay.TurnState), args.Error(1)
+}
+
+func (m *MockGameplayService) GetMoveHints(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID) (*gameplay.MoveHints, error) {
+	args := m.Called(ctx, gameID, playerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*gameplay.MoveHints), args.Error(1)
+}
@@ -0,0 +1,94 @@
+package gameplay
+
+import (
+	"github.com/NachoGz/switcher-backend-go/internal/board"
+	"github.com/NachoGz/switcher-backend-go/internal/figureCard"
+	gameState "github.com/NachoGz/switcher-backend-go/internal/game_state"
+	"github.com/NachoGz/switcher-backend-go/internal/movementCard"
+	"github.com/NachoGz/switcher-backend-go/internal/player"
+	"github.com/NachoGz/switcher-backend-go/internal/ruleSet"
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrGameNotPlaying   = utils.NewDomainError(utils.ErrConflict, "GAME_NOT_PLAYING", "the game is not being played")
+	ErrNotYourTurn      = utils.NewDomainError(utils.ErrNotYourTurn, "NOT_YOUR_TURN", "it's not your turn")
+	ErrCardNotInHand    = utils.NewDomainError(utils.ErrNotFound, "CARD_NOT_IN_HAND", "the card is not in your hand")
+	ErrCardAlreadyUsed  = utils.NewDomainError(utils.ErrInvalidMove, "CARD_ALREADY_USED", "the card was already used this turn")
+	ErrInvalidMove      = utils.NewDomainError(utils.ErrInvalidMove, "INVALID_MOVE", "the card doesn't allow that movement")
+	ErrFigureNotFormed  = utils.NewDomainError(utils.ErrInvalidMove, "FIGURE_NOT_FORMED", "the figure is not formed on the board")
+	ErrForbiddenColor   = utils.NewDomainError(utils.ErrInvalidMove, "FORBIDDEN_COLOR", "figures of that color can't be played this turn")
+	ErrFigureBlocked    = utils.NewDomainError(utils.ErrInvalidMove, "FIGURE_BLOCKED", "the figure card is blocked")
+	ErrBlockingDisabled = utils.NewDomainError(utils.ErrInvalidMove, "BLOCKING_DISABLED", "blocking is disabled in this game")
+	ErrCannotBlock      = utils.NewDomainError(utils.ErrInvalidMove, "CANNOT_BLOCK", "that figure card can't be blocked")
+	ErrPlayerNotInGame  = utils.NewDomainError(utils.ErrNotFound, "PLAYER_NOT_IN_GAME", "the player is not in the game")
+)
+
+// TurnState is what a player can see of a running game
+type TurnState struct {
+	GameID          uuid.UUID                   `json:"game_id"`
+	PlayerID        uuid.UUID                   `json:"player_id"`
+	State           gameState.State             `json:"state"`
+	CurrentPlayerID uuid.UUID                   `json:"current_player_id"`
+	ForbiddenColor  board.ColorEnum             `json:"forbidden_color,omitempty"`
+	Rules           ruleSet.RuleSet             `json:"rules"`
+	Board           [][]board.ColorEnum         `json:"board"`
+	Hand            []movementCard.MovementCard `json:"hand"`
+	FigureCards     []figureCard.FigureCard     `json:"figure_cards"`
+}
+
+// Swap is a pair of boxes a movement card can swap
+type Swap struct {
+	From board.BoardPosition `json:"from"`
+	To   board.BoardPosition `json:"to"`
+}
+
+// Movement is a swap made with a specific card of the hand
+type Movement struct {
+	MovementCardID uuid.UUID           `json:"movement_card_id"`
+	From           board.BoardPosition `json:"from"`
+	To             board.BoardPosition `json:"to"`
+}
+
+// MovementOption lists the legal swaps of a movement card in hand. Cards
+// already used this turn have none
+type MovementOption struct {
+	MovementCardID uuid.UUID             `json:"movement_card_id"`
+	Type           movementCard.TypeEnum `json:"type"`
+	Used           bool                  `json:"used"`
+	Swaps          []Swap                `json:"swaps"`
+}
+
+// FigurePlacement is a place of the board where a figure is formed, after
+// playing the given movements
+type FigurePlacement struct {
+	Color     board.ColorEnum       `json:"color"`
+	Boxes     []board.BoardPosition `json:"boxes"`
+	Movements []Movement            `json:"movements"`
+}
+
+// FigureOption lists where a shown figure card can be formed
+type FigureOption struct {
+	FigureCardID uuid.UUID           `json:"figure_card_id"`
+	Type         figureCard.TypeEnum `json:"type"`
+	PlayerID     uuid.UUID           `json:"player_id"`
+	Blocked      bool                `json:"blocked"`
+	Placements   []FigurePlacement   `json:"placements"`
+}
+
+// MoveHints is everything a player can do with its hand
+type MoveHints struct {
+	GameID    uuid.UUID        `json:"game_id"`
+	PlayerID  uuid.UUID        `json:"player_id"`
+	Movements []MovementOption `json:"movements"`
+	Figures   []FigureOption   `json:"figures"`
+}
+
+// turnOrder is the order in which the players play
+var turnOrder = map[player.TurnEnum]int{
+	player.FIRST:  0,
+	player.SECOND: 1,
+	player.THIRD:  2,
+	player.FOURTH: 3,
+}
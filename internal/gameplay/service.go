@@ -0,0 +1,773 @@
+package gameplay
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/NachoGz/switcher-backend-go/internal/board"
+	"github.com/NachoGz/switcher-backend-go/internal/database"
+	"github.com/NachoGz/switcher-backend-go/internal/figureCard"
+	"github.com/NachoGz/switcher-backend-go/internal/game"
+	"github.com/NachoGz/switcher-backend-go/internal/gameEvent"
+	gameState "github.com/NachoGz/switcher-backend-go/internal/game_state"
+	"github.com/NachoGz/switcher-backend-go/internal/logging"
+	"github.com/NachoGz/switcher-backend-go/internal/movementCard"
+	"github.com/NachoGz/switcher-backend-go/internal/partialMovements"
+	"github.com/NachoGz/switcher-backend-go/internal/player"
+	"github.com/NachoGz/switcher-backend-go/internal/websocket"
+	"github.com/google/uuid"
+)
+
+// Service plays the turns of a game. Players and bots both go through it, so
+// every action is checked against the same rules
+type Service struct {
+	gameService            game.GameService
+	gameStateService       gameState.GameStateService
+	playerService          player.PlayerService
+	boardRepo              board.BoardRepository
+	movementCardService    movementCard.MovementCardService
+	movementCardRepo       movementCard.MovementCardRepository
+	figureCardService      figureCard.FigureCardService
+	figureCardRepo         figureCard.FigureCardRepository
+	partialMovRepo         partialMovements.PartialMovementRepository
+	partialMovementService partialMovements.PartialMovementService
+	gameEventService       gameEvent.GameEventService
+	wsHub                  websocket.WebSocketHub
+	db                     *sql.DB
+
+	listenersMu     sync.RWMutex
+	listeners       []TurnListener
+	finishListeners []FinishListener
+
+	// One lock per game, so that the actions of a game are applied one at a
+	// time. A lock is dropped once no action holds or waits for it
+	locksMu sync.Mutex
+	locks   map[uuid.UUID]*gameLock
+
+	// Timer of the turn being played, by game
+	timersMu sync.Mutex
+	timers   map[uuid.UUID]*turnTimer
+}
+
+type gameLock struct {
+	mu    sync.Mutex
+	users int
+}
+
+type turnTimer struct {
+	timer *time.Timer
+}
+
+// NewService creates a new gameplay service
+func NewService(
+	gameService game.GameService,
+	gameStateService gameState.GameStateService,
+	playerService player.PlayerService,
+	boardRepo board.BoardRepository,
+	movementCardService movementCard.MovementCardService,
+	movementCardRepo movementCard.MovementCardRepository,
+	figureCardService figureCard.FigureCardService,
+	figureCardRepo figureCard.FigureCardRepository,
+	partialMovRepo partialMovements.PartialMovementRepository,
+	partialMovementService partialMovements.PartialMovementService,
+	gameEventService gameEvent.GameEventService,
+	wsHub websocket.WebSocketHub,
+	db *sql.DB,
+) *Service {
+	return &Service{
+		gameService:            gameService,
+		gameStateService:       gameStateService,
+		playerService:          playerService,
+		boardRepo:              boardRepo,
+		movementCardService:    movementCardService,
+		movementCardRepo:       movementCardRepo,
+		figureCardService:      figureCardService,
+		figureCardRepo:         figureCardRepo,
+		partialMovRepo:         partialMovRepo,
+		partialMovementService: partialMovementService,
+		gameEventService:       gameEventService,
+		wsHub:                  wsHub,
+		db:                     db,
+		locks:                  make(map[uuid.UUID]*gameLock),
+		timers:                 make(map[uuid.UUID]*turnTimer),
+	}
+}
+
+// Ensure Service implements GameplayService
+var _ GameplayService = (*Service)(nil)
+
+// lock blocks until no other action is being applied to the game and returns
+// the function that releases it
+func (s *Service) lock(gameID uuid.UUID) func() {
+	s.locksMu.Lock()
+	l, ok := s.locks[gameID]
+	if !ok {
+		l = &gameLock{}
+		s.locks[gameID] = l
+	}
+	l.users++
+	s.locksMu.Unlock()
+
+	l.mu.Lock()
+	return func() {
+		l.mu.Unlock()
+
+		s.locksMu.Lock()
+		defer s.locksMu.Unlock()
+		l.users--
+		if l.users == 0 {
+			delete(s.locks, gameID)
+		}
+	}
+}
+
+// currentTurn fetches the game and its state, checking that it's the turn of
+// the given player
+func (s *Service) currentTurn(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID) (*game.Game, *gameState.GameState, error) {
+	state, err := s.gameStateService.GetGameStateByGameID(ctx, gameID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if state.State != gameState.PLAYING {
+		return nil, nil, ErrGameNotPlaying
+	}
+	if state.CurrentPlayerID != playerID {
+		return nil, nil, ErrNotYourTurn
+	}
+
+	currentGame, err := s.gameService.GetGameByID(ctx, gameID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return currentGame, state, nil
+}
+
+// PlayMovement swaps two boxes using a movement card from the player's hand.
+// The movement is partial until a figure is played or the turn ends
+func (s *Service) PlayMovement(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID, movementCardID uuid.UUID, from, to board.BoardPosition) error {
+	defer s.lock(gameID)()
+
+	var payload gameEvent.MovementPayload
+	err := database.InTx(ctx, s.db, func(ctx context.Context) (err error) {
+		payload, err = s.playMovement(ctx, gameID, playerID, movementCardID, from, to)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	s.wsHub.BroadcastToGame(gameID, websocket.MOVEMENT_PLAYED, payload)
+	return nil
+}
+
+func (s *Service) playMovement(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID, movementCardID uuid.UUID, from, to board.BoardPosition) (gameEvent.MovementPayload, error) {
+	currentGame, _, err := s.currentTurn(ctx, gameID, playerID)
+	if err != nil {
+		return gameEvent.MovementPayload{}, err
+	}
+
+	hand, err := s.movementCardRepo.GetMovementCardsByPlayer(ctx, playerID)
+	if err != nil {
+		return gameEvent.MovementPayload{}, err
+	}
+
+	var card *database.MovementCard
+	for i := range hand {
+		if hand[i].ID == movementCardID {
+			card = &hand[i]
+			break
+		}
+	}
+	if card == nil {
+		return gameEvent.MovementPayload{}, ErrCardNotInHand
+	}
+	if card.Used {
+		return gameEvent.MovementPayload{}, ErrCardAlreadyUsed
+	}
+
+	cardType := movementCard.TypeEnum(card.Type)
+	if !movementCard.IsValidMovement(cardType, from, to, currentGame.Rules.BoardSize) {
+		return gameEvent.MovementPayload{}, ErrInvalidMove
+	}
+
+	if err := s.boardRepo.SwapColors(ctx, gameID, from, to); err != nil {
+		return gameEvent.MovementPayload{}, err
+	}
+	if err := s.movementCardRepo.MarkCardUsed(ctx, card.ID); err != nil {
+		return gameEvent.MovementPayload{}, err
+	}
+	if _, err := s.partialMovRepo.CreatePartialMovement(ctx, database.CreatePartialMovementParams{
+		ID:             uuid.New(),
+		PosFromX:       int32(from.PosX),
+		PosFromY:       int32(from.PosY),
+		PosToX:         int32(to.PosX),
+		PosToY:         int32(to.PosY),
+		GameID:         gameID,
+		PlayerID:       playerID,
+		MovementCardID: card.ID,
+	}); err != nil {
+		return gameEvent.MovementPayload{}, err
+	}
+
+	payload := gameEvent.MovementPayload{
+		MovementCardID: card.ID,
+		CardType:       cardType,
+		From:           from,
+		To:             to,
+	}
+	if _, err := s.gameEventService.Record(ctx, gameID, playerID, gameEvent.MOVEMENT_PLAYED, payload); err != nil {
+		return gameEvent.MovementPayload{}, err
+	}
+	return payload, nil
+}
+
+// findFigure looks for a figure of the given type formed on the board over pos
+func (s *Service) findFigure(ctx context.Context, gameID uuid.UUID, figureType figureCard.TypeEnum, pos board.BoardPosition, forbiddenColor sql.NullString) (*board.FormedFigure, error) {
+	boxes, err := s.boardRepo.GetBoxesByGame(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, figure := range board.FindFormedFigures(board.ColorGrid(boxes)) {
+		if figure.Type != figureType || !figure.Contains(pos) {
+			continue
+		}
+		if forbiddenColor.Valid && string(figure.Color) == forbiddenColor.String {
+			return nil, ErrForbiddenColor
+		}
+		return &figure, nil
+	}
+	return nil, ErrFigureNotFormed
+}
+
+// commitMovements makes the partial movements of the player permanent and
+// sends the cards used for them back to the deck
+func (s *Service) commitMovements(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID) error {
+	movements, err := s.partialMovRepo.GetPartialMovementsByPlayer(ctx, database.GetPartialMovementsByPlayerParams{
+		GameID:   gameID,
+		PlayerID: playerID,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, movement := range movements {
+		if err := s.movementCardRepo.DiscardMovementCard(ctx, database.DiscardMovementCardParams{
+			ID:     movement.MovementCardID,
+			GameID: gameID,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return s.partialMovRepo.DeleteAllPartialMovementsByPlayer(ctx, playerID)
+}
+
+// PlayFigure uses one of the player's shown figure cards with a figure formed
+// on the board. The player wins when it has no figure cards left
+func (s *Service) PlayFigure(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID, figureCardID uuid.UUID, pos board.BoardPosition) error {
+	defer s.lock(gameID)()
+
+	var payload gameEvent.FigurePayload
+	var won bool
+	err := database.InTx(ctx, s.db, func(ctx context.Context) (err error) {
+		payload, won, err = s.playFigure(ctx, gameID, playerID, figureCardID, pos)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	s.wsHub.BroadcastToGame(gameID, websocket.FIGURE_PLAYED, payload)
+	if won {
+		s.announceWinner(ctx, gameID, playerID)
+	}
+	return nil
+}
+
+// playFigure applies a played figure and reports whether it won the game
+func (s *Service) playFigure(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID, figureCardID uuid.UUID, pos board.BoardPosition) (gameEvent.FigurePayload, bool, error) {
+	_, state, err := s.currentTurn(ctx, gameID, playerID)
+	if err != nil {
+		return gameEvent.FigurePayload{}, false, err
+	}
+
+	cards, err := s.figureCardRepo.GetFigureCardsByPlayer(ctx, playerID)
+	if err != nil {
+		return gameEvent.FigurePayload{}, false, err
+	}
+
+	var card *database.FigureCard
+	for i := range cards {
+		if cards[i].ID == figureCardID && cards[i].Show {
+			card = &cards[i]
+			break
+		}
+	}
+	if card == nil {
+		return gameEvent.FigurePayload{}, false, ErrCardNotInHand
+	}
+	if card.Blocked {
+		return gameEvent.FigurePayload{}, false, ErrFigureBlocked
+	}
+
+	figure, err := s.findFigure(ctx, gameID, figureCard.TypeEnum(card.Type), pos, state.ForbiddenColor)
+	if err != nil {
+		return gameEvent.FigurePayload{}, false, err
+	}
+
+	if err := s.commitMovements(ctx, gameID, playerID); err != nil {
+		return gameEvent.FigurePayload{}, false, err
+	}
+	if err := s.figureCardRepo.DeleteFigureCard(ctx, card.ID); err != nil {
+		return gameEvent.FigurePayload{}, false, err
+	}
+	if err := s.gameStateService.UpdateForbiddenColor(ctx, gameID, string(figure.Color)); err != nil {
+		return gameEvent.FigurePayload{}, false, err
+	}
+
+	payload := gameEvent.FigurePayload{
+		FigureCardID:   card.ID,
+		FigureType:     figure.Type,
+		TargetPlayerID: playerID,
+		Boxes:          figure.Boxes,
+	}
+	if _, err := s.gameEventService.Record(ctx, gameID, playerID, gameEvent.FIGURE_PLAYED, payload); err != nil {
+		return gameEvent.FigurePayload{}, false, err
+	}
+
+	remaining, err := s.figureCardRepo.GetFigureCardsByPlayer(ctx, playerID)
+	if err != nil {
+		return gameEvent.FigurePayload{}, false, err
+	}
+	if len(remaining) == 0 {
+		return payload, true, s.finishGame(ctx, gameID, playerID)
+	}
+
+	// A blocked card is released once it's the only shown card left
+	shown := []database.FigureCard{}
+	for _, remainingCard := range remaining {
+		if remainingCard.Show {
+			shown = append(shown, remainingCard)
+		}
+	}
+	if len(shown) == 1 && shown[0].Blocked {
+		if err := s.figureCardRepo.SetFigureCardBlocked(ctx, database.SetFigureCardBlockedParams{
+			ID:      shown[0].ID,
+			Blocked: false,
+		}); err != nil {
+			return gameEvent.FigurePayload{}, false, err
+		}
+	}
+
+	return payload, false, nil
+}
+
+// BlockFigure uses a figure formed on the board to block a shown figure card
+// of another player
+func (s *Service) BlockFigure(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID, figureCardID uuid.UUID, pos board.BoardPosition) error {
+	defer s.lock(gameID)()
+
+	var payload gameEvent.FigurePayload
+	err := database.InTx(ctx, s.db, func(ctx context.Context) (err error) {
+		payload, err = s.blockFigure(ctx, gameID, playerID, figureCardID, pos)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	s.wsHub.BroadcastToGame(gameID, websocket.FIGURE_BLOCKED, payload)
+	return nil
+}
+
+func (s *Service) blockFigure(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID, figureCardID uuid.UUID, pos board.BoardPosition) (gameEvent.FigurePayload, error) {
+	currentGame, state, err := s.currentTurn(ctx, gameID, playerID)
+	if err != nil {
+		return gameEvent.FigurePayload{}, err
+	}
+	if !currentGame.Rules.BlockingEnabled {
+		return gameEvent.FigurePayload{}, ErrBlockingDisabled
+	}
+
+	shownCards, err := s.figureCardRepo.GetShownFigureCardsByGame(ctx, gameID)
+	if err != nil {
+		return gameEvent.FigurePayload{}, err
+	}
+
+	var card *database.FigureCard
+	for i := range shownCards {
+		if shownCards[i].ID == figureCardID {
+			card = &shownCards[i]
+			break
+		}
+	}
+	if card == nil || card.PlayerID == playerID {
+		return gameEvent.FigurePayload{}, ErrCannotBlock
+	}
+
+	// Only one card per player can be blocked, and never the last shown one
+	targetShown := 0
+	for _, shownCard := range shownCards {
+		if shownCard.PlayerID != card.PlayerID {
+			continue
+		}
+		if shownCard.Blocked {
+			return gameEvent.FigurePayload{}, ErrCannotBlock
+		}
+		targetShown++
+	}
+	if targetShown <= 1 {
+		return gameEvent.FigurePayload{}, ErrCannotBlock
+	}
+
+	figure, err := s.findFigure(ctx, gameID, figureCard.TypeEnum(card.Type), pos, state.ForbiddenColor)
+	if err != nil {
+		return gameEvent.FigurePayload{}, err
+	}
+
+	if err := s.commitMovements(ctx, gameID, playerID); err != nil {
+		return gameEvent.FigurePayload{}, err
+	}
+	if err := s.figureCardRepo.SetFigureCardBlocked(ctx, database.SetFigureCardBlockedParams{
+		ID:      card.ID,
+		Blocked: true,
+	}); err != nil {
+		return gameEvent.FigurePayload{}, err
+	}
+	if err := s.gameStateService.UpdateForbiddenColor(ctx, gameID, string(figure.Color)); err != nil {
+		return gameEvent.FigurePayload{}, err
+	}
+
+	payload := gameEvent.FigurePayload{
+		FigureCardID:   card.ID,
+		FigureType:     figure.Type,
+		TargetPlayerID: card.PlayerID,
+		Boxes:          figure.Boxes,
+	}
+	if _, err := s.gameEventService.Record(ctx, gameID, playerID, gameEvent.FIGURE_BLOCKED, payload); err != nil {
+		return gameEvent.FigurePayload{}, err
+	}
+	return payload, nil
+}
+
+// finishGame marks the player as the winner and ends the game
+func (s *Service) finishGame(ctx context.Context, gameID uuid.UUID, winnerID uuid.UUID) error {
+	if err := s.playerService.SetWinner(ctx, winnerID); err != nil {
+		return err
+	}
+	if err := s.gameStateService.UpdateGameState(ctx, gameID, gameState.FINISHED); err != nil {
+		return err
+	}
+
+	payload := gameEvent.GameWonPayload{WinnerID: winnerID}
+	_, err := s.gameEventService.Record(ctx, gameID, winnerID, gameEvent.GAME_WON, payload)
+	return err
+}
+
+// announceWinner tells the game and the finish listeners who won, once the
+// game is stored as finished
+func (s *Service) announceWinner(ctx context.Context, gameID uuid.UUID, winnerID uuid.UUID) {
+	s.stopTurnTimer(gameID)
+	s.wsHub.BroadcastToGame(gameID, websocket.GAME_WON, gameEvent.GameWonPayload{WinnerID: winnerID})
+
+	s.listenersMu.RLock()
+	listeners := append([]FinishListener(nil), s.finishListeners...)
+	s.listenersMu.RUnlock()
+
+	for _, listener := range listeners {
+		listener(ctx, gameID, winnerID)
+	}
+}
+
+// EndTurn undoes the partial movements of the player, refills its hand and
+// shown figure cards and passes the turn to the next player
+func (s *Service) EndTurn(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID) error {
+	unlock := s.lock(gameID)
+
+	var nextPlayerID uuid.UUID
+	err := database.InTx(ctx, s.db, func(ctx context.Context) (err error) {
+		nextPlayerID, err = s.endTurn(ctx, gameID, playerID)
+		return err
+	})
+	unlock()
+	if err != nil {
+		return err
+	}
+
+	s.wsHub.BroadcastToGame(gameID, websocket.TURN_ENDED, gameEvent.TurnEndedPayload{NextPlayerID: nextPlayerID})
+	s.BeginTurn(gameID, nextPlayerID)
+	return nil
+}
+
+func (s *Service) endTurn(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID) (uuid.UUID, error) {
+	currentGame, _, err := s.currentTurn(ctx, gameID, playerID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if err := s.partialMovementService.RevertPartialMovements(ctx, gameID, playerID); err != nil {
+		return uuid.Nil, err
+	}
+	if err := s.refillHand(ctx, gameID, playerID, currentGame.Rules.HandSize); err != nil {
+		return uuid.Nil, err
+	}
+	if err := s.refillFigureCards(ctx, playerID, currentGame.Rules.ShowLimit); err != nil {
+		return uuid.Nil, err
+	}
+
+	players, err := s.playerService.GetPlayersInGame(ctx, gameID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	sort.SliceStable(players, func(i, j int) bool {
+		return turnOrder[players[i].Turn] < turnOrder[players[j].Turn]
+	})
+
+	nextPlayerID := playerID
+	for i, p := range players {
+		if p.ID == playerID {
+			nextPlayerID = players[(i+1)%len(players)].ID
+			break
+		}
+	}
+
+	if err := s.gameStateService.UpdateCurrentPlayer(ctx, gameID, nextPlayerID); err != nil {
+		return uuid.Nil, err
+	}
+
+	payload := gameEvent.TurnEndedPayload{NextPlayerID: nextPlayerID}
+	if _, err := s.gameEventService.Record(ctx, gameID, playerID, gameEvent.TURN_ENDED, payload); err != nil {
+		return uuid.Nil, err
+	}
+	return nextPlayerID, nil
+}
+
+// refillHand deals movement cards from the deck until the player has handSize
+func (s *Service) refillHand(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID, handSize int) error {
+	hand, err := s.movementCardRepo.GetMovementCardsByPlayer(ctx, playerID)
+	if err != nil {
+		return err
+	}
+	deck, err := s.movementCardRepo.GetMovementCardDeck(ctx, gameID)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; len(hand)+i < handSize && i < len(deck); i++ {
+		if err := s.movementCardRepo.AssignMovementCard(ctx, database.AssignMovementCardParams{
+			ID:       deck[i].ID,
+			PlayerID: uuid.NullUUID{UUID: playerID, Valid: true},
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// refillFigureCards shows hidden figure cards until the player has showLimit
+// shown. Players with a blocked card don't get new ones
+func (s *Service) refillFigureCards(ctx context.Context, playerID uuid.UUID, showLimit int) error {
+	cards, err := s.figureCardRepo.GetFigureCardsByPlayer(ctx, playerID)
+	if err != nil {
+		return err
+	}
+
+	shown := 0
+	for _, card := range cards {
+		if card.Blocked {
+			return nil
+		}
+		if card.Show {
+			shown++
+		}
+	}
+
+	for _, card := range cards {
+		if shown >= showLimit {
+			break
+		}
+		if card.Show {
+			continue
+		}
+		if err := s.figureCardRepo.ShowFigureCard(ctx, card.ID); err != nil {
+			return err
+		}
+		shown++
+	}
+	return nil
+}
+
+// OnTurnStart registers a listener called every time a turn begins
+func (s *Service) OnTurnStart(listener TurnListener) {
+	s.listenersMu.Lock()
+	defer s.listenersMu.Unlock()
+	s.listeners = append(s.listeners, listener)
+}
+
+// OnGameFinished registers a listener called when a game is won
+func (s *Service) OnGameFinished(listener FinishListener) {
+	s.listenersMu.Lock()
+	defer s.listenersMu.Unlock()
+	s.finishListeners = append(s.finishListeners, listener)
+}
+
+// BeginTurn notifies the listeners that it's the turn of the given player and
+// starts the time the player has to play it
+func (s *Service) BeginTurn(gameID uuid.UUID, playerID uuid.UUID) {
+	s.startTurnTimer(gameID, playerID)
+
+	s.listenersMu.RLock()
+	listeners := append([]TurnListener(nil), s.listeners...)
+	s.listenersMu.RUnlock()
+
+	for _, listener := range listeners {
+		listener(gameID, playerID)
+	}
+}
+
+// AbandonGame finishes a game being played without a winner. Its turn timer
+// is stopped and whoever is still connected is told
+func (s *Service) AbandonGame(ctx context.Context, gameID uuid.UUID) error {
+	unlock := s.lock(gameID)
+	finished, err := s.gameStateService.TransitionGameState(ctx, gameID, gameState.PLAYING, gameState.FINISHED)
+	unlock()
+	if err != nil {
+		return err
+	}
+	if !finished {
+		return ErrGameNotPlaying
+	}
+
+	s.stopTurnTimer(gameID)
+	s.wsHub.BroadcastEvent(gameID, websocket.GAME_ABANDONED)
+	return nil
+}
+
+// startTurnTimer ends the turn of the player once the turn duration of the
+// game has passed, replacing the timer of the previous turn
+func (s *Service) startTurnTimer(gameID uuid.UUID, playerID uuid.UUID) {
+	ctx := logging.WithPlayerID(logging.WithGameID(context.Background(), gameID), playerID)
+
+	currentGame, err := s.gameService.GetGameByID(ctx, gameID)
+	if err != nil {
+		slog.WarnContext(ctx, "Couldn't start the turn timer", "error", err)
+		return
+	}
+	duration := time.Duration(currentGame.Rules.TurnDuration) * time.Second
+
+	s.timersMu.Lock()
+	defer s.timersMu.Unlock()
+
+	if previous, ok := s.timers[gameID]; ok {
+		previous.timer.Stop()
+	}
+	t := &turnTimer{}
+	t.timer = time.AfterFunc(duration, func() {
+		s.expireTurn(ctx, gameID, playerID, t)
+	})
+	s.timers[gameID] = t
+}
+
+// stopTurnTimer stops the timer of the game, if any
+func (s *Service) stopTurnTimer(gameID uuid.UUID) {
+	s.timersMu.Lock()
+	defer s.timersMu.Unlock()
+
+	if t, ok := s.timers[gameID]; ok {
+		t.timer.Stop()
+		delete(s.timers, gameID)
+	}
+}
+
+// expireTurn ends the turn of a player who ran out of time. A timer replaced
+// or stopped in the meantime does nothing
+func (s *Service) expireTurn(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID, t *turnTimer) {
+	s.timersMu.Lock()
+	current := s.timers[gameID] == t
+	if current {
+		delete(s.timers, gameID)
+	}
+	s.timersMu.Unlock()
+	if !current {
+		return
+	}
+
+	slog.InfoContext(ctx, "Turn timed out")
+	err := s.EndTurn(ctx, gameID, playerID)
+	if err != nil && !errors.Is(err, ErrNotYourTurn) && !errors.Is(err, ErrGameNotPlaying) {
+		slog.WarnContext(ctx, "Couldn't end the timed out turn", "error", err)
+	}
+}
+
+// GetTurnState returns the game as seen by the given player
+func (s *Service) GetTurnState(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID) (*TurnState, error) {
+	state, err := s.gameStateService.GetGameStateByGameID(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	currentGame, err := s.gameService.GetGameByID(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	boxes, err := s.boardRepo.GetBoxesByGame(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	dbHand, err := s.movementCardRepo.GetMovementCardsByPlayer(ctx, playerID)
+	if err != nil {
+		return nil, err
+	}
+	hand := make([]movementCard.MovementCard, 0, len(dbHand))
+	for _, dbCard := range dbHand {
+		hand = append(hand, s.movementCardService.DBToModel(ctx, dbCard))
+	}
+
+	figureCards, err := s.figureCardService.GetShownFigureCards(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	turnState := &TurnState{
+		GameID:          gameID,
+		PlayerID:        playerID,
+		State:           state.State,
+		CurrentPlayerID: state.CurrentPlayerID,
+		Rules:           currentGame.Rules,
+		Board:           board.ColorGrid(boxes),
+		Hand:            hand,
+		FigureCards:     figureCards,
+	}
+	if state.ForbiddenColor.Valid {
+		turnState.ForbiddenColor = board.ColorEnum(state.ForbiddenColor.String)
+	}
+	return turnState, nil
+}
+
+// GetMoveHints lists what the player can do with its hand. It can be asked at
+// any time while the game is being played, not only during the player's turn
+func (s *Service) GetMoveHints(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID) (*MoveHints, error) {
+	if _, err := s.playerService.GetPlayerByID(ctx, playerID, gameID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPlayerNotInGame
+		}
+		return nil, err
+	}
+
+	state, err := s.GetTurnState(ctx, gameID, playerID)
+	if err != nil {
+		return nil, err
+	}
+	if state.State != gameState.PLAYING {
+		return nil, ErrGameNotPlaying
+	}
+
+	return EnumerateMoves(state), nil
+}
@@ -0,0 +1,677 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NachoGz/switcher-backend-go/internal/board"
+	board_mock "github.com/NachoGz/switcher-backend-go/internal/board/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/bot"
+	bot_mock "github.com/NachoGz/switcher-backend-go/internal/bot/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/chat"
+	chat_mock "github.com/NachoGz/switcher-backend-go/internal/chat/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/figureCard"
+	figureCard_mock "github.com/NachoGz/switcher-backend-go/internal/figureCard/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/game"
+	game_mock "github.com/NachoGz/switcher-backend-go/internal/game/mocks"
+	gameEvent_mock "github.com/NachoGz/switcher-backend-go/internal/gameEvent/mocks"
+	gameState "github.com/NachoGz/switcher-backend-go/internal/game_state"
+	gameState_mock "github.com/NachoGz/switcher-backend-go/internal/game_state/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/gameplay"
+	gameplay_mock "github.com/NachoGz/switcher-backend-go/internal/gameplay/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/handlers"
+	"github.com/NachoGz/switcher-backend-go/internal/health"
+	health_mock "github.com/NachoGz/switcher-backend-go/internal/health/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/invite"
+	invite_mock "github.com/NachoGz/switcher-backend-go/internal/invite/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/lobby"
+	lobby_mock "github.com/NachoGz/switcher-backend-go/internal/lobby/mocks"
+	lobbyFeed_mock "github.com/NachoGz/switcher-backend-go/internal/lobbyFeed/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/matchmaking"
+	matchmaking_mock "github.com/NachoGz/switcher-backend-go/internal/matchmaking/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/movementCard"
+	"github.com/NachoGz/switcher-backend-go/internal/openapi"
+	"github.com/NachoGz/switcher-backend-go/internal/player"
+	player_mock "github.com/NachoGz/switcher-backend-go/internal/player/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/ruleSet"
+	"github.com/NachoGz/switcher-backend-go/internal/stats"
+	stats_mock "github.com/NachoGz/switcher-backend-go/internal/stats/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/user"
+	user_mock "github.com/NachoGz/switcher-backend-go/internal/user/mocks"
+	websocket_mock "github.com/NachoGz/switcher-backend-go/internal/websocket/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var contractDoc = openapi.NewDocument(openapi.Info{Title: "Switcher API", Version: "test"}, handlers.Operations...)
+
+// contractCase runs a handler of an operation with a request and expects a
+// status. Both the request and the response are checked against the spec
+type contractCase struct {
+	name       string
+	op         openapi.Operation
+	handler    http.HandlerFunc
+	pathValues map[string]string
+	headers    map[string]string
+	body       any
+	status     int
+}
+
+func runContract(t *testing.T, tc contractCase) {
+	t.Helper()
+
+	var body []byte
+	if tc.body != nil {
+		var err error
+		body, err = json.Marshal(tc.body)
+		require.NoError(t, err)
+	}
+	require.NoError(t, contractDoc.ValidateRequest(tc.op.Method, tc.op.Path, body), "request doesn't match the spec")
+
+	req := httptest.NewRequest(tc.op.Method, tc.op.Path, bytes.NewReader(body))
+	for name, value := range tc.pathValues {
+		req.SetPathValue(name, value)
+	}
+	for name, value := range tc.headers {
+		req.Header.Set(name, value)
+	}
+	rr := httptest.NewRecorder()
+
+	tc.handler(rr, req)
+
+	require.Equal(t, tc.status, rr.Code, rr.Body.String())
+	assert.NoError(t, contractDoc.ValidateResponse(tc.op.Method, tc.op.Path, rr.Code, rr.Header().Get("Content-Type"), rr.Body.Bytes()),
+		"response doesn't match the spec: %s", rr.Body.String())
+}
+
+// contractSuites groups the cases of every operation by the handlers serving
+// them. Each suite sets up its own mocks
+var contractSuites = []struct {
+	name  string
+	cases func(t *testing.T) []contractCase
+}{
+	{"games", gamesContract},
+	{"spectating", spectatingContract},
+	{"chat", chatContract},
+	{"bots", botsContract},
+	{"invites", invitesContract},
+	{"lobby", lobbyContract},
+	{"gameplay", gameplayContract},
+	{"players", playersContract},
+	{"users", usersContract},
+	{"stats", statsContract},
+	{"matchmaking", matchmakingContract},
+	{"health", healthContract},
+}
+
+func TestContract(t *testing.T) {
+	for _, suite := range contractSuites {
+		t.Run(suite.name, func(t *testing.T) {
+			for _, tc := range suite.cases(t) {
+				t.Run(tc.name, func(t *testing.T) {
+					runContract(t, tc)
+				})
+			}
+		})
+	}
+}
+
+func TestContract_EveryOperationHasACase(t *testing.T) {
+	covered := map[string]bool{}
+	for _, suite := range contractSuites {
+		for _, tc := range suite.cases(t) {
+			covered[tc.op.ID] = true
+		}
+	}
+
+	// New operations need a case before the spec can drift from them
+	for _, op := range handlers.Operations {
+		assert.True(t, covered[op.ID], "operation %s has no contract case", op.ID)
+	}
+}
+
+func gamesContract(t *testing.T) []contractCase {
+	mockService := new(game_mock.MockGameService)
+	mockPlayerService := new(player_mock.MockPlayerService)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
+	h := handlers.NewGameHandlers(mockService, mockPlayerService, mockLobbyFeedService, new(websocket_mock.MockWebSocketHub))
+
+	gameID := uuid.New()
+	missingID := uuid.New()
+	password := "secret"
+	createdGame := game.Game{ID: gameID, Name: "Test Game", MaxPlayers: 4, MinPlayers: 2, PlayersCount: 1, IsPrivate: true, Password: &password, Rules: ruleSet.Classic()}
+	host := player.Player{ID: uuid.New(), Name: "Host", Host: true, GameID: gameID, Turn: player.FIRST}
+	state := gameState.GameState{ID: uuid.New(), State: gameState.WAITING, GameID: gameID}
+
+	mockService.On("CreateGame", mock.Anything, mock.Anything, mock.Anything).Return(&createdGame, &state, &host, nil)
+	mockService.On("SearchGames", mock.Anything, mock.Anything).Return(&game.GamePage{Games: []game.Game{createdGame}, Total: 1}, nil)
+	mockService.On("GetGameByID", mock.Anything, gameID).Return(&createdGame, nil)
+	mockService.On("GetGameByID", mock.Anything, missingID).Return((*game.Game)(nil), game.ErrNotFound)
+	mockService.On("DeleteGame", mock.Anything, gameID).Return(nil)
+	mockPlayerService.On("GetWinner", mock.Anything, gameID).Return(&host, nil)
+	mockPlayerService.On("GetWinner", mock.Anything, missingID).Return((*player.Player)(nil), nil)
+	mockLobbyFeedService.On("GameAdded", mock.Anything, mock.Anything).Return()
+	mockLobbyFeedService.On("GameRemoved", mock.Anything).Return()
+
+	return []contractCase{
+		{
+			name: "create game", op: handlers.CreateGameOp, handler: h.HandleCreateGame,
+			body: map[string]any{
+				"game":     map[string]any{"name": "Test Game", "max_players": 4, "min_players": 2},
+				"player":   map[string]any{"name": "Host"},
+				"rule_set": "classic",
+			},
+			status: http.StatusCreated,
+		},
+		{
+			name: "get games", op: handlers.GetGamesOp, handler: h.HandleGetGames,
+			status: http.StatusOK,
+		},
+		{
+			name: "get game", op: handlers.GetGameByIDOp, handler: h.HandleGetGameByID,
+			pathValues: map[string]string{"gameID": gameID.String()},
+			status:     http.StatusOK,
+		},
+		{
+			name: "get missing game", op: handlers.GetGameByIDOp, handler: h.HandleGetGameByID,
+			pathValues: map[string]string{"gameID": missingID.String()},
+			status:     http.StatusNotFound,
+		},
+		{
+			name: "invalid game ID", op: handlers.GetGameByIDOp, handler: h.HandleGetGameByID,
+			pathValues: map[string]string{"gameID": "not-a-uuid"},
+			status:     http.StatusBadRequest,
+		},
+		{
+			name: "delete game", op: handlers.DeleteGameOp, handler: h.HandleDeleteGame,
+			pathValues: map[string]string{"gameID": gameID.String()},
+			status:     http.StatusNoContent,
+		},
+		{
+			name: "get winner", op: handlers.GetWinnerOp, handler: h.HandlerGetWinner,
+			pathValues: map[string]string{"gameID": gameID.String()},
+			status:     http.StatusOK,
+		},
+		{
+			name: "no winner", op: handlers.GetWinnerOp, handler: h.HandlerGetWinner,
+			pathValues: map[string]string{"gameID": missingID.String()},
+			status:     http.StatusNotFound,
+		},
+	}
+}
+
+func spectatingContract(t *testing.T) []contractCase {
+	mockGameService := new(game_mock.MockGameService)
+	mockGameStateService := new(gameState_mock.MockGameStateService)
+	mockPlayerService := new(player_mock.MockPlayerService)
+	mockBoardService := new(board_mock.MockBoardService)
+	mockFigureCardService := new(figureCard_mock.MockFigureCardService)
+	mockGameEventService := new(gameEvent_mock.MockGameEventService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	spectatorHandlers := handlers.NewSpectatorHandlers(mockGameService, mockGameStateService, mockPlayerService,
+		mockBoardService, mockFigureCardService, mockWSHub)
+	replayHandlers := handlers.NewReplayHandlers(mockGameEventService, mockGameService, mockGameStateService)
+
+	gameID := uuid.New()
+	finishedID := uuid.New()
+	playerID := uuid.New()
+	spectatedGame := game.Game{ID: gameID, Name: "Test Game", MaxPlayers: 4, MinPlayers: 2, Rules: ruleSet.Classic()}
+	finishedGame := game.Game{ID: finishedID, Name: "Finished Game", MaxPlayers: 4, MinPlayers: 2, Seed: 42, Rules: ruleSet.Classic()}
+
+	mockGameService.On("GetGameByID", mock.Anything, gameID).Return(&spectatedGame, nil)
+	mockGameService.On("GetGameByID", mock.Anything, finishedID).Return(&finishedGame, nil)
+	mockGameStateService.On("GetGameStateByGameID", mock.Anything, gameID).
+		Return(&gameState.GameState{ID: uuid.New(), State: gameState.PLAYING, GameID: gameID, CurrentPlayerID: playerID}, nil)
+	mockGameStateService.On("GetGameStateByGameID", mock.Anything, finishedID).
+		Return(&gameState.GameState{ID: uuid.New(), State: gameState.FINISHED, GameID: finishedID}, nil)
+	mockPlayerService.On("GetPlayersInGame", mock.Anything, gameID).
+		Return([]player.Player{{ID: playerID, Name: "Player", GameID: gameID, Turn: player.FIRST}}, nil)
+	mockBoardService.On("GetBoard", mock.Anything, gameID).Return(&board.BoardAndBoxesOut{
+		GameID:        gameID,
+		BoardID:       uuid.New(),
+		Boxes:         [][]board.BoxOut{{{Color: board.RED, PosX: 0, PosY: 0}}},
+		FormedFigures: [][]board.BoxOut{},
+	}, nil)
+	mockFigureCardService.On("GetShownFigureCards", mock.Anything, gameID).Return([]figureCard.FigureCard{
+		{ID: uuid.New(), Type: figureCard.FIG01, Show: true, PlayerID: playerID, GameID: gameID},
+	}, nil)
+	mockWSHub.On("GetSpectatorsInGame", gameID).Return(1)
+	mockGameEventService.On("GetEvents", mock.Anything, gameID).Return(replayEvents(t, gameID), nil)
+	mockGameEventService.On("GetEvents", mock.Anything, finishedID).Return(replayEvents(t, finishedID), nil)
+
+	return []contractCase{
+		{
+			name: "spectate game", op: handlers.SpectateGameOp, handler: spectatorHandlers.HandleSpectateGame,
+			pathValues: map[string]string{"gameID": gameID.String()},
+			status:     http.StatusOK,
+		},
+		{
+			name: "replay running game", op: handlers.GetReplayOp, handler: replayHandlers.HandleGetReplay,
+			pathValues: map[string]string{"gameID": gameID.String()},
+			status:     http.StatusOK,
+		},
+		{
+			name: "replay finished game", op: handlers.GetReplayOp, handler: replayHandlers.HandleGetReplay,
+			pathValues: map[string]string{"gameID": finishedID.String()},
+			status:     http.StatusOK,
+		},
+	}
+}
+
+func chatContract(t *testing.T) []contractCase {
+	mockChatService := new(chat_mock.MockChatService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	h := handlers.NewChatHandlers(mockChatService, mockWSHub)
+
+	gameID := uuid.New()
+	playerID := uuid.New()
+	message := chat.ChatMessage{ID: uuid.New(), GameID: gameID, PlayerID: playerID, PlayerName: "Player", Content: "hi", CreatedAt: time.Now()}
+
+	mockChatService.On("SendMessage", mock.Anything, gameID, playerID, "hi").Return(&message, nil)
+	mockChatService.On("SendMessage", mock.Anything, gameID, playerID, "").Return((*chat.ChatMessage)(nil), chat.ErrEmptyMessage)
+	mockWSHub.On("BroadcastToGame", gameID, mock.Anything, mock.Anything).Return()
+
+	return []contractCase{
+		{
+			name: "send message", op: handlers.SendChatMessageOp, handler: h.HandleSendChatMessage,
+			pathValues: map[string]string{"gameID": gameID.String()},
+			body:       map[string]any{"player_id": playerID, "content": "hi"},
+			status:     http.StatusCreated,
+		},
+		{
+			name: "empty message", op: handlers.SendChatMessageOp, handler: h.HandleSendChatMessage,
+			pathValues: map[string]string{"gameID": gameID.String()},
+			body:       map[string]any{"player_id": playerID, "content": ""},
+			status:     http.StatusBadRequest,
+		},
+	}
+}
+
+func botsContract(t *testing.T) []contractCase {
+	mockBotService := new(bot_mock.MockBotService)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	h := handlers.NewBotHandlers(mockBotService, mockLobbyFeedService, mockWSHub)
+
+	gameID := uuid.New()
+	hostID := uuid.New()
+	botPlayer := player.Player{ID: uuid.New(), Name: "Bot", GameID: gameID, Bot: true, BotLevel: string(bot.EASY)}
+
+	mockBotService.On("AddBot", mock.Anything, gameID, hostID, bot.EASY).Return(&botPlayer, nil)
+	mockLobbyFeedService.On("GameUpdated", mock.Anything, gameID).Return()
+	mockWSHub.On("BroadcastEvent", mock.Anything, mock.Anything).Return()
+
+	return []contractCase{
+		{
+			name: "add bot", op: handlers.AddBotOp, handler: h.HandleAddBot,
+			pathValues: map[string]string{"gameID": gameID.String()},
+			body:       map[string]any{"player_id": hostID, "level": string(bot.EASY)},
+			status:     http.StatusCreated,
+		},
+		{
+			name: "invalid level", op: handlers.AddBotOp, handler: h.HandleAddBot,
+			pathValues: map[string]string{"gameID": gameID.String()},
+			body:       map[string]any{"player_id": hostID, "level": "impossible"},
+			status:     http.StatusBadRequest,
+		},
+	}
+}
+
+func invitesContract(t *testing.T) []contractCase {
+	mockInviteService := new(invite_mock.MockInviteService)
+	h := handlers.NewInviteHandlers(mockInviteService)
+
+	gameID := uuid.New()
+	hostID := uuid.New()
+	passwordHash := "$2a$10$hash"
+	createdInvite := invite.Invite{GameID: gameID, Code: "ABC234", Token: "token", URL: "https://example.com/join/ABC234", ExpiresAt: time.Now().Add(time.Hour)}
+	invitedGame := game.Game{ID: gameID, Name: "Test Game", MaxPlayers: 4, MinPlayers: 2, IsPrivate: true, Password: &passwordHash, Rules: ruleSet.Classic()}
+
+	mockInviteService.On("CreateInvite", mock.Anything, gameID, hostID, time.Duration(0)).Return(&createdInvite, nil)
+	mockInviteService.On("GetGameByCode", mock.Anything, "ABC234").Return(&invitedGame, nil)
+	mockInviteService.On("GetGameByCode", mock.Anything, "NOPE22").Return((*game.Game)(nil), invite.ErrCodeNotFound)
+
+	return []contractCase{
+		{
+			name: "create invite", op: handlers.CreateInviteOp, handler: h.HandleCreateInvite,
+			pathValues: map[string]string{"gameID": gameID.String()},
+			body:       map[string]any{"player_id": hostID},
+			status:     http.StatusCreated,
+		},
+		{
+			name: "get game by code", op: handlers.GetGameByCodeOp, handler: h.HandleGetGameByCode,
+			pathValues: map[string]string{"code": "ABC234"},
+			status:     http.StatusOK,
+		},
+		{
+			name: "unknown code", op: handlers.GetGameByCodeOp, handler: h.HandleGetGameByCode,
+			pathValues: map[string]string{"code": "NOPE22"},
+			status:     http.StatusNotFound,
+		},
+	}
+}
+
+func lobbyContract(t *testing.T) []contractCase {
+	mockLobbyService := new(lobby_mock.MockLobbyService)
+	h := handlers.NewGameStateHandlers(mockLobbyService)
+
+	gameID := uuid.New()
+	hostID := uuid.New()
+	playerID := uuid.New()
+	startsAt := time.Now()
+	lobbyState := lobby.LobbyState{
+		GameID:     gameID,
+		Players:    []lobby.LobbyPlayer{},
+		MinPlayers: 2,
+		MaxPlayers: 4,
+		StartsAt:   &startsAt,
+	}
+
+	mockLobbyService.On("GetLobby", mock.Anything, gameID).Return(&lobbyState, nil)
+	mockLobbyService.On("SetReady", mock.Anything, gameID, playerID, true).Return(&lobbyState, nil)
+	mockLobbyService.On("KickPlayer", mock.Anything, gameID, hostID, playerID, true).Return(nil)
+	mockLobbyService.On("LeaveGame", mock.Anything, gameID, playerID).Return(nil)
+	mockLobbyService.On("RequestStart", mock.Anything, gameID, hostID, false).Return(nil)
+
+	return []contractCase{
+		{
+			name: "get lobby", op: handlers.GetLobbyOp, handler: h.HandleGetLobby,
+			pathValues: map[string]string{"gameID": gameID.String()},
+			status:     http.StatusOK,
+		},
+		{
+			name: "set ready", op: handlers.SetReadyOp, handler: h.HandleSetReady,
+			pathValues: map[string]string{"gameID": gameID.String(), "playerID": playerID.String()},
+			body:       map[string]any{"ready": true},
+			status:     http.StatusOK,
+		},
+		{
+			name: "kick player", op: handlers.KickPlayerOp, handler: h.HandleKickPlayer,
+			pathValues: map[string]string{"gameID": gameID.String(), "playerID": playerID.String()},
+			body:       map[string]any{"player_id": hostID, "ban": true},
+			status:     http.StatusOK,
+		},
+		{
+			name: "leave game", op: handlers.LeaveGameOp, handler: h.HandleLeaveGame,
+			pathValues: map[string]string{"gameID": gameID.String(), "playerID": playerID.String()},
+			status:     http.StatusOK,
+		},
+		{
+			name: "start game", op: handlers.StartGameOp, handler: h.HandleStartGame,
+			pathValues: map[string]string{"gameID": gameID.String()},
+			body:       map[string]any{"player_id": hostID},
+			status:     http.StatusOK,
+		},
+	}
+}
+
+func gameplayContract(t *testing.T) []contractCase {
+	mockGameplayService := new(gameplay_mock.MockGameplayService)
+	h := handlers.NewGameplayHandlers(mockGameplayService)
+
+	gameID := uuid.New()
+	playerID := uuid.New()
+	cardID := uuid.New()
+	figureCardID := uuid.New()
+	from := board.BoardPosition{PosX: 0, PosY: 0}
+	to := board.BoardPosition{PosX: 0, PosY: 2}
+	hints := gameplay.MoveHints{
+		GameID:   gameID,
+		PlayerID: playerID,
+		Movements: []gameplay.MovementOption{
+			{MovementCardID: cardID, Type: movementCard.LINEAR_CONT, Swaps: []gameplay.Swap{{From: from, To: to}}},
+		},
+		Figures: []gameplay.FigureOption{
+			{FigureCardID: figureCardID, Type: figureCard.FIG01, PlayerID: playerID, Placements: []gameplay.FigurePlacement{}},
+		},
+	}
+
+	mockGameplayService.On("PlayMovement", mock.Anything, gameID, playerID, cardID, from, to).Return(nil)
+	mockGameplayService.On("PlayFigure", mock.Anything, gameID, playerID, figureCardID, from).Return(nil)
+	mockGameplayService.On("BlockFigure", mock.Anything, gameID, playerID, figureCardID, from).Return(gameplay.ErrNotYourTurn)
+	mockGameplayService.On("EndTurn", mock.Anything, gameID, playerID).Return(nil)
+	mockGameplayService.On("GetMoveHints", mock.Anything, gameID, playerID).Return(&hints, nil)
+
+	pathValues := map[string]string{"gameID": gameID.String(), "playerID": playerID.String()}
+	return []contractCase{
+		{
+			name: "play movement", op: handlers.PlayMovementOp, handler: h.HandlePlayMovement,
+			pathValues: pathValues,
+			body:       map[string]any{"movement_card_id": cardID, "from": from, "to": to},
+			status:     http.StatusOK,
+		},
+		{
+			name: "play figure", op: handlers.PlayFigureOp, handler: h.HandlePlayFigure,
+			pathValues: pathValues,
+			body:       map[string]any{"figure_card_id": figureCardID, "position": from},
+			status:     http.StatusOK,
+		},
+		{
+			name: "block figure out of turn", op: handlers.BlockFigureOp, handler: h.HandleBlockFigure,
+			pathValues: pathValues,
+			body:       map[string]any{"figure_card_id": figureCardID, "position": from},
+			status:     http.StatusForbidden,
+		},
+		{
+			name: "end turn", op: handlers.EndTurnOp, handler: h.HandleEndTurn,
+			pathValues: pathValues,
+			status:     http.StatusOK,
+		},
+		{
+			name: "get moves", op: handlers.GetMovesOp, handler: h.HandleGetMoves,
+			pathValues: pathValues,
+			status:     http.StatusOK,
+		},
+	}
+}
+
+func playersContract(t *testing.T) []contractCase {
+	mockPlayerService := new(player_mock.MockPlayerService)
+	mockGameService := new(game_mock.MockGameService)
+	mockGameStateService := new(gameState_mock.MockGameStateService)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	h := handlers.NewPlayerHandlers(mockPlayerService, mockGameService, mockGameStateService,
+		new(invite_mock.MockInviteService), mockLobbyFeedService, mockWSHub)
+
+	gameID := uuid.New()
+	host := player.Player{ID: uuid.New(), Name: "Host", Host: true, GameID: gameID, Turn: player.FIRST}
+	joined := player.Player{ID: uuid.New(), Name: "Bob", GameID: gameID}
+	state := gameState.GameState{ID: uuid.New(), State: gameState.WAITING, GameID: gameID}
+
+	mockGameService.On("GetGameByID", mock.Anything, gameID).
+		Return(&game.Game{ID: gameID, Name: "Test Game", MaxPlayers: 4, MinPlayers: 2, Rules: ruleSet.Classic()}, nil)
+	mockGameService.On("IsBanned", mock.Anything, gameID, "Bob", mock.Anything).Return(false, nil)
+	mockGameStateService.On("GetGameStateByGameID", mock.Anything, gameID).Return(&state, nil)
+	mockPlayerService.On("CountPlayers", mock.Anything, gameID).Return(1, nil)
+	mockPlayerService.On("CreatePlayer", mock.Anything, mock.Anything).Return(&joined, nil)
+	mockPlayerService.On("GetPlayersInGame", mock.Anything, gameID).Return([]player.Player{host, joined}, nil)
+	mockPlayerService.On("GetPlayerByID", mock.Anything, mock.Anything, mock.Anything).Return(host, nil)
+	mockLobbyFeedService.On("GameUpdated", mock.Anything, gameID).Return()
+	mockWSHub.On("BroadcastEvent", mock.Anything, mock.Anything).Return()
+
+	return []contractCase{
+		{
+			name: "join game", op: handlers.JoinGameOp, handler: h.HandleJoinGame,
+			pathValues: map[string]string{"gameID": gameID.String()},
+			body:       map[string]any{"player_name": "Bob"},
+			status:     http.StatusCreated,
+		},
+		{
+			name: "get players", op: handlers.GetPlayersOp, handler: h.HandleGetPlayers,
+			pathValues: map[string]string{"gameID": gameID.String()},
+			status:     http.StatusOK,
+		},
+		{
+			name: "get player", op: handlers.GetPlayerOp, handler: h.HandleGetPlayer,
+			pathValues: map[string]string{"gameID": gameID.String(), "playerID": host.ID.String()},
+			status:     http.StatusOK,
+		},
+	}
+}
+
+func usersContract(t *testing.T) []contractCase {
+	mockUserService := new(user_mock.MockUserService)
+	h := handlers.NewUserHandlers(mockUserService)
+
+	u := user.User{ID: uuid.New(), Username: "alice", Rating: 1200, CreatedAt: time.Now()}
+	session := user.Session{Token: "token", ExpiresAt: time.Now().Add(time.Hour)}
+
+	mockUserService.On("Register", mock.Anything, "alice", "password123").Return(&u, &session, nil)
+	mockUserService.On("Login", mock.Anything, "alice", "password123").Return(&u, &session, nil)
+	mockUserService.On("Login", mock.Anything, "alice", "wrong-password").Return((*user.User)(nil), (*user.Session)(nil), user.ErrInvalidCredentials)
+	mockUserService.On("Logout", mock.Anything, "token").Return(nil)
+
+	return []contractCase{
+		{
+			name: "register", op: handlers.RegisterOp, handler: h.HandleRegister,
+			body:   map[string]any{"username": "alice", "password": "password123"},
+			status: http.StatusCreated,
+		},
+		{
+			name: "login", op: handlers.LoginOp, handler: h.HandleLogin,
+			body:   map[string]any{"username": "alice", "password": "password123"},
+			status: http.StatusOK,
+		},
+		{
+			name: "wrong password", op: handlers.LoginOp, handler: h.HandleLogin,
+			body:   map[string]any{"username": "alice", "password": "wrong-password"},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name: "logout", op: handlers.LogoutOp, handler: h.HandleLogout,
+			headers: map[string]string{"Authorization": "Bearer token"},
+			status:  http.StatusOK,
+		},
+		{
+			name: "logout without session", op: handlers.LogoutOp, handler: h.HandleLogout,
+			status: http.StatusUnauthorized,
+		},
+		{
+			name: "get me without session", op: handlers.GetMeOp, handler: h.HandleGetMe,
+			status: http.StatusUnauthorized,
+		},
+	}
+}
+
+func statsContract(t *testing.T) []contractCase {
+	mockStatsService := new(stats_mock.MockStatsService)
+	h := handlers.NewStatsHandlers(mockStatsService)
+
+	userID := uuid.New()
+	playerStats := stats.PlayerStats{Player: "alice", UserID: &userID, Games: 2, Wins: 1, WinRate: 0.5, Matches: []stats.MatchSummary{}}
+	leaderboard := []stats.LeaderboardEntry{{Rank: 1, UserID: userID, Username: "alice", Games: 2, Wins: 1, WinRate: 0.5}}
+
+	mockStatsService.On("GetPlayerStats", mock.Anything, "alice").Return(&playerStats, nil)
+	mockStatsService.On("GetLeaderboard", mock.Anything, stats.DEFAULT_LEADERBOARD_SIZE).Return(leaderboard, nil)
+
+	return []contractCase{
+		{
+			name: "get player stats", op: handlers.GetPlayerStatsOp, handler: h.HandleGetPlayerStats,
+			pathValues: map[string]string{"player": "alice"},
+			status:     http.StatusOK,
+		},
+		{
+			name: "get leaderboard", op: handlers.GetLeaderboardOp, handler: h.HandleGetLeaderboard,
+			status: http.StatusOK,
+		},
+	}
+}
+
+func matchmakingContract(t *testing.T) []contractCase {
+	mockMatchmakingService := new(matchmaking_mock.MockMatchmakingService)
+	h := handlers.NewMatchmakingHandlers(mockMatchmakingService, new(websocket_mock.MockWebSocketHub))
+
+	status := matchmaking.Status{TicketID: uuid.New(), State: matchmaking.QUEUED, Rating: 1200, PlayerCount: 2, QueueSize: 1}
+
+	mockMatchmakingService.On("Join", mock.Anything, mock.Anything).Return(&status, nil)
+	mockMatchmakingService.On("GetStatus", status.TicketID).Return(&status, nil)
+	mockMatchmakingService.On("Leave", mock.Anything, status.TicketID).Return(nil)
+
+	return []contractCase{
+		{
+			name: "join queue", op: handlers.JoinQueueOp, handler: h.HandleJoinQueue,
+			body:   map[string]any{"player_name": "Guest", "player_count": 2},
+			status: http.StatusAccepted,
+		},
+		{
+			name: "queue status", op: handlers.GetQueueStatusOp, handler: h.HandleGetQueueStatus,
+			pathValues: map[string]string{"ticketID": status.TicketID.String()},
+			status:     http.StatusOK,
+		},
+		{
+			name: "leave queue", op: handlers.LeaveQueueOp, handler: h.HandleLeaveQueue,
+			pathValues: map[string]string{"ticketID": status.TicketID.String()},
+			status:     http.StatusOK,
+		},
+	}
+}
+
+func healthContract(t *testing.T) []contractCase {
+	readyService := new(health_mock.MockHealthService)
+	drainingService := new(health_mock.MockHealthService)
+	healthHandlers := handlers.NewHealthHandlers(readyService)
+	drainingHandlers := handlers.NewHealthHandlers(drainingService)
+
+	readyService.On("Ready", mock.Anything).Return(health.Report{
+		Status: health.STATUS_OK,
+		Checks: map[string]string{"database": health.STATUS_OK},
+	})
+	readyService.On("Version").Return(health.BuildInfo{Version: "v1.2.0", GoVersion: "go1.23.4"})
+	drainingService.On("Ready", mock.Anything).Return(health.Report{
+		Status: health.STATUS_DRAINING,
+		Checks: map[string]string{"database": health.STATUS_OK},
+	})
+
+	return []contractCase{
+		{name: "alive", op: handlers.HealthzOp, handler: healthHandlers.HandleHealthz, status: http.StatusOK},
+		{name: "ready", op: handlers.ReadyzOp, handler: healthHandlers.HandleReadyz, status: http.StatusOK},
+		{name: "draining", op: handlers.ReadyzOp, handler: drainingHandlers.HandleReadyz, status: http.StatusServiceUnavailable},
+		{name: "version", op: handlers.VersionOp, handler: healthHandlers.HandleVersion, status: http.StatusOK},
+	}
+}
+
+func TestContract_RequestsOutsideTheSpecAreRejected(t *testing.T) {
+	// Unknown fields and wrong types are drift the frontend would hit
+	err := contractDoc.ValidateRequest(http.MethodPatch, handlers.SetReadyOp.Path, []byte(`{"ready": "yes"}`))
+	assert.Error(t, err)
+
+	err = contractDoc.ValidateRequest(http.MethodPost, handlers.JoinGameOp.Path, []byte(`{"player_name": "Bob", "pasword": "x"}`))
+	assert.Error(t, err)
+}
+
+func TestOpenAPISpec(t *testing.T) {
+	router := openapi.NewRouter(openapi.Info{Title: "Switcher API", Version: "test"})
+	for _, op := range handlers.Operations {
+		router.HandleFunc(op, func(w http.ResponseWriter, r *http.Request) {})
+	}
+
+	rr := httptest.NewRecorder()
+	router.HandleSpec(rr, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+
+	var spec struct {
+		OpenAPI string                    `json:"openapi"`
+		Paths   map[string]map[string]any `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &spec))
+	assert.Equal(t, "3.0.3", spec.OpenAPI)
+
+	// Every operation is documented once
+	ids := map[string]bool{}
+	for _, op := range handlers.Operations {
+		assert.False(t, ids[op.ID], "duplicated operation ID %s", op.ID)
+		ids[op.ID] = true
+		assert.Contains(t, spec.Paths[op.Path], map[string]string{
+			http.MethodGet: "get", http.MethodPost: "post", http.MethodPatch: "patch", http.MethodDelete: "delete",
+		}[op.Method], op.Pattern())
+	}
+}
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"github.com/NachoGz/switcher-backend-go/internal/asyncapi"
+	"github.com/NachoGz/switcher-backend-go/internal/chat"
+	"github.com/NachoGz/switcher-backend-go/internal/gameEvent"
+	"github.com/NachoGz/switcher-backend-go/internal/lobby"
+	"github.com/NachoGz/switcher-backend-go/internal/lobbyFeed"
+	"github.com/NachoGz/switcher-backend-go/internal/matchmaking"
+	"github.com/NachoGz/switcher-backend-go/internal/websocket"
+)
+
+const (
+	LOBBY_CHANNEL       = "/ws"
+	GAME_CHANNEL        = "/ws/{gameID}"
+	MATCHMAKING_CHANNEL = "/ws/matchmaking/{ticketID}"
+)
+
+// Channels are the websocket routes registered in main
+var Channels = []asyncapi.Channel{
+	{
+		Path: LOBBY_CHANNEL,
+		Description: "Lobby room with the games waiting for players. Messages about a single game " +
+			"are also sent with its ID as prefix of the type, like <game id>:GAME_STARTED",
+	},
+	{
+		Path: GAME_CHANNEL,
+		Description: "Game room. Players connect with the player_id query parameter, connections " +
+			"without it are spectators and only receive public messages. Messages of unknown " +
+			"types sent by players are relayed to the other players",
+	},
+	{
+		Path:        MATCHMAKING_CHANNEL,
+		Description: "Status of a matchmaking ticket until it's matched",
+	},
+}
+
+// Events documents the catalog of websocket messages kept by the websocket
+// package. Every type in it must be here with its payload, tests fail
+// otherwise
+var Events = []asyncapi.Message{
+	// Game room
+	{Type: websocket.MOVEMENT_PLAYED, Channel: GAME_CHANNEL, Summary: "A player played a movement card", Payload: gameEvent.MovementPayload{}},
+	{Type: websocket.FIGURE_PLAYED, Channel: GAME_CHANNEL, Summary: "A player completed one of their figures", Payload: gameEvent.FigurePayload{}},
+	{Type: websocket.FIGURE_BLOCKED, Channel: GAME_CHANNEL, Summary: "A player blocked a figure of another player", Payload: gameEvent.FigurePayload{}},
+	{Type: websocket.TURN_ENDED, Channel: GAME_CHANNEL, Summary: "The turn passed to the next player", Payload: gameEvent.TurnEndedPayload{}},
+	{Type: websocket.GAME_WON, Channel: GAME_CHANNEL, Summary: "A player won the game", Payload: gameEvent.GameWonPayload{}},
+	{Type: websocket.GAME_ABANDONED, Channel: GAME_CHANNEL, Summary: "Nobody played for too long and the game finished without a winner"},
+	{Type: websocket.GAME_STARTED, Channel: GAME_CHANNEL, Summary: "The game left the lobby and started"},
+	{Type: websocket.LOBBY_STATE, Channel: GAME_CHANNEL, Summary: "Players and ready flags of the lobby", Payload: lobby.LobbyState{}},
+	{Type: websocket.PLAYER_KICKED, Channel: GAME_CHANNEL, Summary: "The host removed a player", Payload: lobby.KickedPlayer{}},
+	{Type: websocket.CHAT_MESSAGE, Channel: GAME_CHANNEL, Summary: "A chat message of a player or the server", Payload: chat.ChatMessage{}},
+	{Type: websocket.CHAT_HISTORY, Channel: GAME_CHANNEL, Summary: "Recent chat messages, sent on connection", Payload: []chat.ChatMessage{}},
+	{Type: websocket.CHAT_ERROR, Channel: GAME_CHANNEL, Summary: "A chat command was rejected", Payload: chatErrorPayload{}},
+	{Type: websocket.CHAT_MESSAGE, Channel: GAME_CHANNEL, Summary: "Send a chat message", Payload: chatCommandPayload{}, Command: true},
+
+	// Lobby room
+	{Type: websocket.GAME_ADDED, Channel: LOBBY_CHANNEL, Summary: "A game was created", Payload: lobbyFeed.GameDelta{}},
+	{Type: websocket.GAME_UPDATED, Channel: LOBBY_CHANNEL, Summary: "A waiting game changed", Payload: lobbyFeed.GameDelta{}},
+	{Type: websocket.GAME_REMOVED, Channel: LOBBY_CHANNEL, Summary: "A game started or was deleted", Payload: lobbyFeed.GameDelta{}},
+	{Type: websocket.GAMES_SNAPSHOT, Channel: LOBBY_CHANNEL, Summary: "Every waiting game, sent on connection", Payload: lobbyFeed.Snapshot{}},
+	{Type: websocket.GAME_INFO_UPDATE, Channel: LOBBY_CHANNEL, Summary: "The players of a game changed, prefixed with its ID"},
+	{Type: websocket.GAME_STARTED, Channel: LOBBY_CHANNEL, Summary: "A game started, prefixed with its ID"},
+
+	// Matchmaking ticket room
+	{Type: websocket.MATCHMAKING_STATUS, Channel: MATCHMAKING_CHANNEL, Summary: "The ticket's place in the queue or its match", Payload: matchmaking.Status{}},
+}
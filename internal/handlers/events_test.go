@@ -0,0 +1,98 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/NachoGz/switcher-backend-go/internal/asyncapi"
+	"github.com/NachoGz/switcher-backend-go/internal/handlers"
+	"github.com/NachoGz/switcher-backend-go/internal/openapi"
+	"github.com/NachoGz/switcher-backend-go/internal/websocket"
+	websocket_mock "github.com/NachoGz/switcher-backend-go/internal/websocket/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEventsCatalog keeps the documented messages and the websocket catalog
+// in sync, the latter being the one the server checks against
+func TestEventsCatalog(t *testing.T) {
+	documented := map[string]bool{}
+	for _, event := range handlers.Events {
+		assert.True(t, websocket.IsEvent(event.Type), "%s is documented but not in the websocket catalog", event.Type)
+		if !event.Command {
+			documented[event.Type] = true
+		}
+	}
+
+	assert.ElementsMatch(t, websocket.Events(), slices.Collect(maps.Keys(documented)),
+		"the websocket catalog and the documented messages differ")
+}
+
+func TestMockHubRejectsUncataloguedEvents(t *testing.T) {
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockWSHub.On("BroadcastToGame", uuid.Nil, websocket.GAME_ADDED, nil).Return()
+
+	assert.NotPanics(t, func() { mockWSHub.BroadcastToGame(uuid.Nil, websocket.GAME_ADDED, nil) })
+	assert.Panics(t, func() { mockWSHub.BroadcastToGame(uuid.Nil, "GAME_CREATED", nil) })
+	assert.Panics(t, func() { mockWSHub.BroadcastEvent(uuid.Nil, uuid.NewString()+":GAME_CREATED") })
+}
+
+func TestAsyncAPISpec(t *testing.T) {
+	doc := asyncapi.NewDocument(openapi.Info{Title: "Switcher websocket API", Version: "test"}, handlers.Channels, handlers.Events)
+
+	rr := httptest.NewRecorder()
+	doc.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/asyncapi.json", nil))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+
+	var spec struct {
+		AsyncAPI string `json:"asyncapi"`
+		Channels map[string]struct {
+			Parameters map[string]any `json:"parameters"`
+			Subscribe  *struct {
+				Message struct {
+					OneOf []struct {
+						Ref string `json:"$ref"`
+					} `json:"oneOf"`
+				} `json:"message"`
+			} `json:"subscribe"`
+			Publish *json.RawMessage `json:"publish"`
+		} `json:"channels"`
+		Components struct {
+			Messages map[string]struct {
+				Name    string `json:"name"`
+				Payload struct {
+					Properties map[string]json.RawMessage `json:"properties"`
+				} `json:"payload"`
+			} `json:"messages"`
+			Schemas map[string]json.RawMessage `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &spec))
+	assert.Equal(t, "2.6.0", spec.AsyncAPI)
+
+	game := spec.Channels[handlers.GAME_CHANNEL]
+	assert.Contains(t, game.Parameters, "gameID")
+	require.NotNil(t, game.Subscribe)
+	assert.NotNil(t, game.Publish, "players send chat commands")
+
+	// Every message is referenced by its channel and carries its payload
+	for _, ref := range game.Subscribe.Message.OneOf {
+		key := ref.Ref[len("#/components/messages/"):]
+		assert.Contains(t, spec.Components.Messages, key)
+	}
+	moved := spec.Components.Messages[websocket.MOVEMENT_PLAYED]
+	assert.Equal(t, websocket.MOVEMENT_PLAYED, moved.Name)
+	assert.Contains(t, moved.Payload.Properties, "payload")
+	assert.Contains(t, spec.Components.Schemas, "MovementPayload")
+	assert.NotContains(t, spec.Components.Messages[websocket.GAME_STARTED].Payload.Properties, "payload")
+
+	assert.Contains(t, spec.Components.Messages, websocket.CHAT_MESSAGE+"_COMMAND")
+	assert.Nil(t, spec.Channels[handlers.MATCHMAKING_CHANNEL].Publish)
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/NachoGz/switcher-backend-go/internal/bot"
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
+	"github.com/NachoGz/switcher-backend-go/internal/websocket"
+	"github.com/google/uuid"
+)
+
+type addBotRequest struct {
+	PlayerID uuid.UUID `json:"player_id"`
+	Level    string    `json:"level"`
+}
+
+func (h *BotHandlers) HandleAddBot(w http.ResponseWriter, r *http.Request) {
+	gameID, err := uuid.Parse(r.PathValue("gameID"))
+	if err != nil {
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Couldn't parse game ID", err)
+		return
+	}
+
+	var params addBotRequest
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	level, err := bot.ParseLevel(params.Level)
+	if err != nil {
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Invalid bot level", err)
+		return
+	}
+
+	botPlayer, err := h.botService.AddBot(r.Context(), gameID, params.PlayerID, level)
+	if err != nil {
+		utils.RespondWithDomainError(w, r, err, "Couldn't add bot")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusCreated, botPlayer)
+
+	h.lobbyFeedService.GameUpdated(r.Context(), gameID)
+	h.wsHub.BroadcastEvent(uuid.Nil, websocket.GameScoped(gameID, websocket.GAME_INFO_UPDATE))
+}
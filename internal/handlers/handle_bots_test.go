@@ -0,0 +1,158 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NachoGz/switcher-backend-go/internal/bot"
+	bot_mock "github.com/NachoGz/switcher-backend-go/internal/bot/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/handlers"
+	lobbyFeed_mock "github.com/NachoGz/switcher-backend-go/internal/lobbyFeed/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/player"
+	websocket_mock "github.com/NachoGz/switcher-backend-go/internal/websocket/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandleAddBot_Success(t *testing.T) {
+	// Setup mocks
+	mockBotService := new(bot_mock.MockBotService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
+
+	// Test data
+	gameID := uuid.New()
+	hostID := uuid.New()
+
+	botPlayer := player.Player{
+		ID:       uuid.New(),
+		Name:     "Bot 1",
+		GameID:   gameID,
+		Bot:      true,
+		BotLevel: string(bot.HARD),
+	}
+
+	// Setup expectations
+	mockBotService.On("AddBot", mock.Anything, gameID, hostID, bot.HARD).
+		Return(&botPlayer, nil)
+	mockLobbyFeedService.On("GameUpdated", mock.Anything, gameID).
+		Return()
+	mockWSHub.On("BroadcastEvent", uuid.Nil, fmt.Sprintf("%s:GAME_INFO_UPDATE", gameID)).
+		Return()
+
+	// Create handlers
+	handlers := handlers.NewBotHandlers(mockBotService, mockLobbyFeedService, mockWSHub)
+
+	// Create request
+	body, _ := json.Marshal(map[string]interface{}{
+		"player_id": hostID,
+		"level":     "hard",
+	})
+	req, _ := http.NewRequest(http.MethodPost, "/games/"+gameID.String()+"/bots", bytes.NewBuffer(body))
+	req.SetPathValue("gameID", gameID.String())
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandleAddBot(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusCreated, rr.Code)
+
+	var response player.Player
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, botPlayer, response)
+
+	// Verify mocks are called
+	mockBotService.AssertExpectations(t)
+	mockLobbyFeedService.AssertExpectations(t)
+	mockWSHub.AssertExpectations(t)
+}
+
+func TestHandleAddBot_InvalidLevel(t *testing.T) {
+	// Setup mocks
+	mockBotService := new(bot_mock.MockBotService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
+
+	// Test data
+	gameID := uuid.New()
+
+	// Create handlers
+	handlers := handlers.NewBotHandlers(mockBotService, mockLobbyFeedService, mockWSHub)
+
+	// Create request
+	body, _ := json.Marshal(map[string]interface{}{
+		"player_id": uuid.New(),
+		"level":     "impossible",
+	})
+	req, _ := http.NewRequest(http.MethodPost, "/games/"+gameID.String()+"/bots", bytes.NewBuffer(body))
+	req.SetPathValue("gameID", gameID.String())
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandleAddBot(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockBotService.AssertNotCalled(t, "AddBot")
+}
+
+func TestHandleAddBot_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"NotHost", bot.ErrNotHost, http.StatusForbidden},
+		{"GameFull", bot.ErrGameFull, http.StatusConflict},
+		{"GameStarted", bot.ErrGameStarted, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mocks
+			mockBotService := new(bot_mock.MockBotService)
+			mockWSHub := new(websocket_mock.MockWebSocketHub)
+			mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
+
+			// Test data
+			gameID := uuid.New()
+			playerID := uuid.New()
+
+			// Setup expectations
+			mockBotService.On("AddBot", mock.Anything, gameID, playerID, bot.EASY).
+				Return(nil, tt.err)
+
+			// Create handlers
+			handlers := handlers.NewBotHandlers(mockBotService, mockLobbyFeedService, mockWSHub)
+
+			// Create request
+			body, _ := json.Marshal(map[string]interface{}{
+				"player_id": playerID,
+			})
+			req, _ := http.NewRequest(http.MethodPost, "/games/"+gameID.String()+"/bots", bytes.NewBuffer(body))
+			req.SetPathValue("gameID", gameID.String())
+			rr := httptest.NewRecorder()
+
+			// Call handler
+			handlers.HandleAddBot(rr, req)
+
+			// Check response
+			assert.Equal(t, tt.status, rr.Code)
+
+			var response map[string]interface{}
+			err := json.Unmarshal(rr.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.err.Error(), response["error"])
+
+			// Nothing is broadcast
+			mockWSHub.AssertNotCalled(t, "BroadcastEvent", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/NachoGz/switcher-backend-go/internal/lobby"
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
+	"github.com/NachoGz/switcher-backend-go/internal/websocket"
+	"github.com/google/uuid"
+)
+
+type chatMessageRequest struct {
+	PlayerID uuid.UUID `json:"player_id"`
+	Content  string    `json:"content"`
+}
+
+func (h *ChatHandlers) HandleSendChatMessage(w http.ResponseWriter, r *http.Request) {
+	gameID, err := uuid.Parse(r.PathValue("gameID"))
+	if err != nil {
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Couldn't parse game ID", err)
+		return
+	}
+
+	var params chatMessageRequest
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	message, err := h.chatService.SendMessage(r.Context(), gameID, params.PlayerID, params.Content)
+	if err != nil {
+		utils.RespondWithDomainError(w, r, err, "Couldn't send message")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusCreated, message)
+
+	h.wsHub.BroadcastToGame(gameID, websocket.CHAT_MESSAGE, message)
+}
+
+// chatErrorPayload tells a player why their chat command was rejected
+type chatErrorPayload struct {
+	Error string `json:"error"`
+	Code  string `json:"code,omitempty"`
+}
+
+// chatCommandPayload is sent by players with the CHAT_MESSAGE command
+type chatCommandPayload struct {
+	Content string `json:"content"`
+}
+
+// HandleChatCommand handles the CHAT_MESSAGE command sent through a player's websocket
+func (h *ChatHandlers) HandleChatCommand(client *websocket.Client, payload json.RawMessage) {
+	if client.PlayerID == uuid.Nil || client.GameID == uuid.Nil {
+		h.wsHub.SendToClient(client, websocket.CHAT_ERROR, chatErrorPayload{Error: "Only players can chat"})
+		return
+	}
+
+	var params chatCommandPayload
+	if err := json.Unmarshal(payload, &params); err != nil {
+		h.wsHub.SendToClient(client, websocket.CHAT_ERROR, chatErrorPayload{Error: "Invalid message"})
+		return
+	}
+
+	message, err := h.chatService.SendMessage(client.Context(), client.GameID, client.PlayerID, params.Content)
+	if err != nil {
+		slog.WarnContext(client.Context(), "Couldn't send chat message", "error", err)
+		problem := utils.ProblemFromError(err, "Couldn't send message")
+		h.wsHub.SendToClient(client, websocket.CHAT_ERROR, chatErrorPayload{
+			Error: problem.Detail,
+			Code:  problem.Code,
+		})
+		return
+	}
+
+	h.wsHub.BroadcastToGame(client.GameID, websocket.CHAT_MESSAGE, message)
+}
+
+// SendChatHistory sends the recent messages of the game to a client that just joined its room
+func (h *ChatHandlers) SendChatHistory(client *websocket.Client) {
+	if client.GameID == uuid.Nil {
+		return
+	}
+
+	history, err := h.chatService.GetHistory(client.Context(), client.GameID)
+	if err != nil {
+		slog.ErrorContext(client.Context(), "Couldn't get chat history", "error", err)
+		return
+	}
+
+	h.wsHub.SendToClient(client, websocket.CHAT_HISTORY, history)
+}
+
+// AnnouncePlayerRemoved posts a system message when a player leaves or is
+// removed from a game. Disconnections aren't announced, since players
+// reconnect on every page load
+func (h *ChatHandlers) AnnouncePlayerRemoved(ctx context.Context, gameID uuid.UUID, removed lobby.KickedPlayer) {
+	content := fmt.Sprintf("%s was kicked from the game", removed.Name)
+	switch {
+	case removed.Left:
+		content = fmt.Sprintf("%s left the game", removed.Name)
+	case removed.Banned:
+		content = fmt.Sprintf("%s was banned from the game", removed.Name)
+	}
+
+	message, err := h.chatService.PostSystemMessage(ctx, gameID, content)
+	if err != nil {
+		slog.ErrorContext(ctx, "Couldn't post system message", "error", err)
+		return
+	}
+
+	h.wsHub.BroadcastToGame(gameID, websocket.CHAT_MESSAGE, message)
+}
@@ -0,0 +1,150 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NachoGz/switcher-backend-go/internal/chat"
+	chat_mock "github.com/NachoGz/switcher-backend-go/internal/chat/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/handlers"
+	"github.com/NachoGz/switcher-backend-go/internal/lobby"
+	websocket_mock "github.com/NachoGz/switcher-backend-go/internal/websocket/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandleSendChatMessage_Success(t *testing.T) {
+	// Setup mocks
+	mockChatService := new(chat_mock.MockChatService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+
+	// Test data
+	gameID := uuid.New()
+	playerID := uuid.New()
+
+	responseMessage := chat.ChatMessage{
+		ID:         uuid.New(),
+		GameID:     gameID,
+		PlayerID:   playerID,
+		PlayerName: "Test Player",
+		Content:    "hello",
+	}
+
+	mockChatService.On("SendMessage", mock.Anything, gameID, playerID, "hello").
+		Return(&responseMessage, nil)
+	mockWSHub.On("BroadcastToGame", gameID, "CHAT_MESSAGE", &responseMessage).
+		Return()
+
+	// Create handlers
+	handlers := handlers.NewChatHandlers(mockChatService, mockWSHub)
+
+	// Create request
+	reqBodyBytes, _ := json.Marshal(map[string]interface{}{
+		"player_id": playerID,
+		"content":   "hello",
+	})
+	req, _ := http.NewRequest(http.MethodPost, "/games/"+gameID.String()+"/chat", bytes.NewReader(reqBodyBytes))
+	req.SetPathValue("gameID", gameID.String())
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandleSendChatMessage(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusCreated, rr.Code)
+
+	var response chat.ChatMessage
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", response.Content)
+
+	mockChatService.AssertExpectations(t)
+	mockWSHub.AssertExpectations(t)
+}
+
+func TestHandleSendChatMessage_RateLimited(t *testing.T) {
+	// Setup mocks
+	mockChatService := new(chat_mock.MockChatService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+
+	// Test data
+	gameID := uuid.New()
+	playerID := uuid.New()
+
+	mockChatService.On("SendMessage", mock.Anything, gameID, playerID, "spam").
+		Return(nil, chat.ErrRateLimited)
+
+	// Create handlers
+	handlers := handlers.NewChatHandlers(mockChatService, mockWSHub)
+
+	// Create request
+	reqBodyBytes, _ := json.Marshal(map[string]interface{}{
+		"player_id": playerID,
+		"content":   "spam",
+	})
+	req, _ := http.NewRequest(http.MethodPost, "/games/"+gameID.String()+"/chat", bytes.NewReader(reqBodyBytes))
+	req.SetPathValue("gameID", gameID.String())
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandleSendChatMessage(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, chat.ErrRateLimited.Error(), response["error"])
+
+	mockChatService.AssertExpectations(t)
+	mockWSHub.AssertNotCalled(t, "BroadcastToGame")
+}
+
+func TestAnnouncePlayerRemoved(t *testing.T) {
+	tests := []struct {
+		name     string
+		banned   bool
+		left     bool
+		expected string
+	}{
+		{"Kicked", false, false, "Ana was kicked from the game"},
+		{"Banned", true, false, "Ana was banned from the game"},
+		{"Left", false, true, "Ana left the game"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mocks
+			mockChatService := new(chat_mock.MockChatService)
+			mockWSHub := new(websocket_mock.MockWebSocketHub)
+
+			// Test data
+			gameID := uuid.New()
+			systemMessage := chat.ChatMessage{ID: uuid.New(), GameID: gameID, Content: tt.expected, System: true}
+
+			mockChatService.On("PostSystemMessage", mock.Anything, gameID, tt.expected).
+				Return(&systemMessage, nil)
+			mockWSHub.On("BroadcastToGame", gameID, "CHAT_MESSAGE", &systemMessage).
+				Return()
+
+			// Create handlers
+			handlers := handlers.NewChatHandlers(mockChatService, mockWSHub)
+
+			handlers.AnnouncePlayerRemoved(context.Background(), gameID, lobby.KickedPlayer{
+				PlayerID: uuid.New(),
+				Name:     "Ana",
+				Banned:   tt.banned,
+				Left:     tt.left,
+			})
+
+			mockChatService.AssertExpectations(t)
+			mockWSHub.AssertExpectations(t)
+		})
+	}
+}
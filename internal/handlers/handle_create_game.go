@@ -2,46 +2,89 @@ package handlers
 
 import (
 	"encoding/json"
-	"log"
 	"net/http"
 
 	"github.com/NachoGz/switcher-backend-go/internal/game"
 	gameState "github.com/NachoGz/switcher-backend-go/internal/game_state"
 	"github.com/NachoGz/switcher-backend-go/internal/player"
-	"github.com/google/uuid"
-
+	"github.com/NachoGz/switcher-backend-go/internal/ruleSet"
+	"github.com/NachoGz/switcher-backend-go/internal/user"
 	"github.com/NachoGz/switcher-backend-go/internal/utils"
+	"github.com/NachoGz/switcher-backend-go/internal/validation"
 )
 
-func (h *GameHandlers) HandleCreateGame(w http.ResponseWriter, r *http.Request) {
-	log.Println("Creating game")
+// newGameRequest is what the host chooses of a new game, with the password in
+// plain text
+type newGameRequest struct {
+	Name          string  `json:"name"`
+	MaxPlayers    int     `json:"max_players"`
+	MinPlayers    int     `json:"min_players"`
+	IsPrivate     bool    `json:"is_private"`
+	Password      *string `json:"password"`
+	MaxSpectators *int    `json:"max_spectators"`
+}
 
-	type GameRequest struct {
-		Game   game.Game     `json:"game"`
-		Player player.Player `json:"player"`
+func (g newGameRequest) toGame() game.Game {
+	return game.Game{
+		Name:          g.Name,
+		MaxPlayers:    g.MaxPlayers,
+		MinPlayers:    g.MinPlayers,
+		IsPrivate:     g.IsPrivate,
+		Password:      g.Password,
+		MaxSpectators: g.MaxSpectators,
 	}
+}
+
+type createGameRequest struct {
+	Game   newGameRequest `json:"game"`
+	Player player.Player  `json:"player"`
+	// Preset name and optional overrides of its rules
+	RuleSet string          `json:"rule_set"`
+	Rules   json.RawMessage `json:"rules"`
+}
+
+// createGameResponse is the new game with its state and host
+type createGameResponse struct {
+	Game      game.Game           `json:"game"`
+	GameState gameState.GameState `json:"game_state"`
+	Player    player.Player       `json:"player"`
+}
 
-	var params GameRequest
+func (h *GameHandlers) HandleCreateGame(w http.ResponseWriter, r *http.Request) {
+	var params createGameRequest
 	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
-		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request body", err)
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	rules, err := ruleSet.Resolve(params.RuleSet, params.Rules)
+	if err != nil {
+		utils.RespondWithDomainError(w, r, err, "Invalid rules")
+		return
+	}
+	requestedGame := params.Game.toGame()
+	requestedGame.Rules = rules
+
+	// Link the host to its account, guests play without one
+	params.Player.UserID = user.IDFromContext(r.Context())
+	if u, ok := user.FromContext(r.Context()); ok && params.Player.Name == "" {
+		params.Player.Name = u.Username
+	}
+
+	if err := validation.CreateGame(requestedGame, params.Player); err != nil {
+		utils.RespondWithDomainError(w, r, err, "Invalid game")
 		return
 	}
 
 	// Use service to create game
-	newGame, newGameState, newPlayer, err := h.gameService.CreateGame(r.Context(), params.Game, params.Player)
+	newGame, newGameState, newPlayer, err := h.gameService.CreateGame(r.Context(), requestedGame, params.Player)
 	if err != nil || newGame == nil || newGameState == nil || newPlayer == nil {
-		utils.RespondWithError(w, http.StatusInternalServerError, "Error creating game", err)
+		utils.RespondWithError(w, r, http.StatusInternalServerError, "Error creating game", err)
 		return
 	}
 
 	// Build response
-	type Response struct {
-		Game      game.Game           `json:"game"`
-		GameState gameState.GameState `json:"game_state"`
-		Player    player.Player       `json:"player"`
-	}
-
-	response := Response{
+	response := createGameResponse{
 		Game:      *newGame,
 		GameState: *newGameState,
 		Player:    *newPlayer,
@@ -49,5 +92,5 @@ func (h *GameHandlers) HandleCreateGame(w http.ResponseWriter, r *http.Request)
 
 	utils.RespondWithJSON(w, http.StatusCreated, response)
 
-	h.wsHub.BroadcastEvent(uuid.Nil, "GAMES_LIST_UPDATE")
+	h.lobbyFeedService.GameAdded(r.Context(), newGame.ID)
 }
@@ -12,8 +12,11 @@ import (
 	game_mock "github.com/NachoGz/switcher-backend-go/internal/game/mocks"
 	gameState "github.com/NachoGz/switcher-backend-go/internal/game_state"
 	"github.com/NachoGz/switcher-backend-go/internal/handlers"
+	lobbyFeed_mock "github.com/NachoGz/switcher-backend-go/internal/lobbyFeed/mocks"
 	"github.com/NachoGz/switcher-backend-go/internal/player"
 	player_mock "github.com/NachoGz/switcher-backend-go/internal/player/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/ruleSet"
+	"github.com/NachoGz/switcher-backend-go/internal/validation"
 	websocket_mock "github.com/NachoGz/switcher-backend-go/internal/websocket/mocks"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -25,6 +28,7 @@ func TestHandleCreateGame_Success(t *testing.T) {
 	mockService := new(game_mock.MockGameService)
 	mockPlayerService := new(player_mock.MockPlayerService)
 	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
 
 	// Test data
 	gameID := uuid.New()
@@ -38,6 +42,7 @@ func TestHandleCreateGame_Success(t *testing.T) {
 		MinPlayers: 2,
 		IsPrivate:  true,
 		Password:   &password,
+		Rules:      ruleSet.Classic(),
 	}
 
 	requestPlayer := player.Player{
@@ -73,16 +78,23 @@ func TestHandleCreateGame_Success(t *testing.T) {
 	mockService.On("CreateGame", mock.Anything, requestGame, requestPlayer).
 		Return(&responseGame, &responseGameState, &responsePlayer, nil)
 
-	mockWSHub.On("BroadcastEvent", uuid.Nil, "GAMES_LIST_UPDATE").
+	mockLobbyFeedService.On("GameAdded", mock.Anything, gameID).
 		Return()
 
 	// Create handlers with mock service
-	handlers := handlers.NewGameHandlers(mockService, mockPlayerService, mockWSHub)
+	handlers := handlers.NewGameHandlers(mockService, mockPlayerService, mockLobbyFeedService, mockWSHub)
 
-	// Create request body
+	// Create request body. The seed is ignored, the server always picks it
 	requestBody := map[string]interface{}{
-		"game":   requestGame,
+		"game": map[string]interface{}{
+			"name":        "Test Game",
+			"max_players": 4,
+			"min_players": 2,
+			"is_private":  true,
+			"password":    password,
+		},
 		"player": requestPlayer,
+		"seed":   42,
 	}
 
 	reqBodyBytes, _ := json.Marshal(requestBody)
@@ -106,6 +118,7 @@ func TestHandleCreateGame_Success(t *testing.T) {
 
 	// Verify mock was called
 	mockService.AssertExpectations(t)
+	mockLobbyFeedService.AssertExpectations(t)
 }
 
 func TestHandleCreateGame_InvalidRequestBody(t *testing.T) {
@@ -113,9 +126,10 @@ func TestHandleCreateGame_InvalidRequestBody(t *testing.T) {
 	mockService := new(game_mock.MockGameService)
 	mockPlayerService := new(player_mock.MockPlayerService)
 	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
 
 	// Create handlers with mock service
-	handlers := handlers.NewGameHandlers(mockService, mockPlayerService, mockWSHub)
+	handlers := handlers.NewGameHandlers(mockService, mockPlayerService, mockLobbyFeedService, mockWSHub)
 
 	// Create invalid request body
 	reqBodyBytes := []byte(`{invalid json}`)
@@ -145,12 +159,14 @@ func TestHandleCreateGame_ServiceError(t *testing.T) {
 	mockService := new(game_mock.MockGameService)
 	mockPlayerService := new(player_mock.MockPlayerService)
 	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
 
 	// Test data
 	requestGame := game.Game{
 		Name:       "Test Game",
 		MaxPlayers: 4,
 		MinPlayers: 2,
+		Rules:      ruleSet.Classic(),
 	}
 
 	requestPlayer := player.Player{
@@ -168,7 +184,7 @@ func TestHandleCreateGame_ServiceError(t *testing.T) {
 		Return(emptyGame, emptyGameState, emptyPlayer, errors.New("service error"))
 
 	// Create handlers with mock service
-	handlers := handlers.NewGameHandlers(mockService, mockPlayerService, mockWSHub)
+	handlers := handlers.NewGameHandlers(mockService, mockPlayerService, mockLobbyFeedService, mockWSHub)
 
 	// Create request body
 	requestBody := map[string]interface{}{
@@ -197,3 +213,186 @@ func TestHandleCreateGame_ServiceError(t *testing.T) {
 	// Verify mock was called
 	mockService.AssertExpectations(t)
 }
+
+func TestHandleCreateGame_RuleSetPreset(t *testing.T) {
+	// Setup mock
+	mockService := new(game_mock.MockGameService)
+	mockPlayerService := new(player_mock.MockPlayerService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
+
+	// Test data
+	password := ""
+	requestGame := game.Game{
+		Name:       "Test Game",
+		MaxPlayers: 4,
+		MinPlayers: 2,
+		Password:   &password,
+	}
+
+	requestPlayer := player.Player{
+		Name: "Test Player",
+		Host: true,
+	}
+
+	// Blitz rules with a longer turn
+	expectedRules := ruleSet.Blitz()
+	expectedRules.TurnDuration = 45
+
+	// Setup expectations
+	mockService.On("CreateGame", mock.Anything, mock.MatchedBy(func(g game.Game) bool {
+		return assert.ObjectsAreEqual(expectedRules, g.Rules)
+	}), requestPlayer).
+		Return(&game.Game{ID: uuid.New(), Rules: expectedRules}, &gameState.GameState{}, &player.Player{}, nil)
+
+	mockLobbyFeedService.On("GameAdded", mock.Anything, mock.Anything).
+		Return()
+
+	// Create handlers with mock service
+	handlers := handlers.NewGameHandlers(mockService, mockPlayerService, mockLobbyFeedService, mockWSHub)
+
+	// Create request body
+	requestBody := map[string]interface{}{
+		"game":     requestGame,
+		"player":   requestPlayer,
+		"rule_set": "blitz",
+		"rules":    map[string]interface{}{"turn_duration": 45},
+	}
+
+	reqBodyBytes, _ := json.Marshal(requestBody)
+	req, _ := http.NewRequest(http.MethodPost, "/games", bytes.NewReader(reqBodyBytes))
+	rr := httptest.NewRecorder()
+
+	// Call the handler
+	handlers.HandleCreateGame(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusCreated, rr.Code)
+
+	// Verify mock was called
+	mockService.AssertExpectations(t)
+}
+
+func TestHandleCreateGame_InvalidRules(t *testing.T) {
+	tests := []struct {
+		name        string
+		requestBody map[string]interface{}
+	}{
+		{
+			name:        "unknown preset",
+			requestBody: map[string]interface{}{"rule_set": "chess"},
+		},
+		{
+			name:        "board too small",
+			requestBody: map[string]interface{}{"rules": map[string]interface{}{"board_size": 2}},
+		},
+		{
+			name: "unknown movement card",
+			requestBody: map[string]interface{}{"rules": map[string]interface{}{
+				"movement_deck": map[string]int{"teleport": 20},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mock
+			mockService := new(game_mock.MockGameService)
+			mockPlayerService := new(player_mock.MockPlayerService)
+			mockWSHub := new(websocket_mock.MockWebSocketHub)
+			mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
+
+			// Create handlers with mock service
+			handlers := handlers.NewGameHandlers(mockService, mockPlayerService, mockLobbyFeedService, mockWSHub)
+
+			// Create request body
+			tt.requestBody["game"] = game.Game{Name: "Test Game", MaxPlayers: 4, MinPlayers: 2}
+			tt.requestBody["player"] = player.Player{Name: "Test Player", Host: true}
+
+			reqBodyBytes, _ := json.Marshal(tt.requestBody)
+			req, _ := http.NewRequest(http.MethodPost, "/games", bytes.NewReader(reqBodyBytes))
+			rr := httptest.NewRecorder()
+
+			// Call the handler
+			handlers.HandleCreateGame(rr, req)
+
+			// Check response
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			mockService.AssertNotCalled(t, "CreateGame")
+		})
+	}
+}
+
+func TestHandleCreateGame_InvalidFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		game   map[string]interface{}
+		player player.Player
+		fields []string
+	}{
+		{
+			name:   "empty names",
+			game:   map[string]interface{}{"max_players": 4, "min_players": 2},
+			player: player.Player{Host: true},
+			fields: []string{"game.name", "player.name"},
+		},
+		{
+			name:   "too many players",
+			game:   map[string]interface{}{"name": "Test Game", "max_players": 10, "min_players": 2},
+			player: player.Player{Name: "Test Player", Host: true},
+			fields: []string{"game.max_players"},
+		},
+		{
+			name:   "min players over max players",
+			game:   map[string]interface{}{"name": "Test Game", "max_players": 2, "min_players": 3},
+			player: player.Player{Name: "Test Player", Host: true},
+			fields: []string{"game.min_players"},
+		},
+		{
+			name:   "short password and bad player name",
+			game:   map[string]interface{}{"name": "Test Game", "max_players": 4, "min_players": 2, "password": "abc"},
+			player: player.Player{Name: "<script>", Host: true},
+			fields: []string{"game.password", "player.name"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mock
+			mockService := new(game_mock.MockGameService)
+			mockPlayerService := new(player_mock.MockPlayerService)
+			mockWSHub := new(websocket_mock.MockWebSocketHub)
+			mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
+
+			// Create handlers with mock service
+			handlers := handlers.NewGameHandlers(mockService, mockPlayerService, mockLobbyFeedService, mockWSHub)
+
+			// Create request
+			reqBodyBytes, _ := json.Marshal(map[string]interface{}{"game": tt.game, "player": tt.player})
+			req, _ := http.NewRequest(http.MethodPost, "/games", bytes.NewReader(reqBodyBytes))
+			rr := httptest.NewRecorder()
+
+			// Call the handler
+			handlers.HandleCreateGame(rr, req)
+
+			// Check response
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+			var response struct {
+				Code   string                  `json:"code"`
+				Errors []validation.FieldError `json:"errors"`
+			}
+			assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+			assert.Equal(t, "VALIDATION_FAILED", response.Code)
+
+			fields := make([]string, len(response.Errors))
+			for i, fieldErr := range response.Errors {
+				fields[i] = fieldErr.Field
+			}
+			assert.Equal(t, tt.fields, fields)
+
+			// Verify mocks are called
+			mockService.AssertNotCalled(t, "CreateGame")
+		})
+	}
+}
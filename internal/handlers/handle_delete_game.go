@@ -1,8 +1,6 @@
 package handlers
 
 import (
-	"context"
-	"log"
 	"net/http"
 
 	"github.com/NachoGz/switcher-backend-go/internal/utils"
@@ -10,24 +8,27 @@ import (
 )
 
 func (h *GameHandlers) HandleDeleteGame(w http.ResponseWriter, r *http.Request) {
-	log.Println("Deleting game...")
-
 	gameID, err := uuid.Parse(r.PathValue("gameID"))
 	if err != nil {
-		utils.RespondWithError(w, http.StatusBadRequest, "Couldn't parse game ID", err)
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Couldn't parse game ID", err)
 		return
 	}
 
-	err = h.gameService.DeleteGame(context.Background(), gameID)
+	// Keep the game to tell the lobby what was removed
+	deletedGame, err := h.gameService.GetGameByID(r.Context(), gameID)
 	if err != nil {
-		utils.RespondWithError(w, http.StatusInternalServerError, "Couldn't delete game", err)
+		utils.RespondWithDomainError(w, r, err, "Couldn't get game")
 		return
 	}
 
-	utils.RespondWithJSON(w, http.StatusNoContent, map[string]string{
-		"message": "Game deleted successfully",
-	})
+	err = h.gameService.DeleteGame(r.Context(), gameID)
+	if err != nil {
+		utils.RespondWithError(w, r, http.StatusInternalServerError, "Couldn't delete game", err)
+		return
+	}
 
-	h.wsHub.BroadcastEvent(uuid.Nil, "GAMES_LIST_UPDATE")
+	// 204 responses have no body
+	w.WriteHeader(http.StatusNoContent)
 
+	h.lobbyFeedService.GameRemoved(*deletedGame)
 }
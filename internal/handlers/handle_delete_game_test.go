@@ -7,8 +7,10 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/NachoGz/switcher-backend-go/internal/game"
 	game_mock "github.com/NachoGz/switcher-backend-go/internal/game/mocks"
 	"github.com/NachoGz/switcher-backend-go/internal/handlers"
+	lobbyFeed_mock "github.com/NachoGz/switcher-backend-go/internal/lobbyFeed/mocks"
 	player_mock "github.com/NachoGz/switcher-backend-go/internal/player/mocks"
 	"github.com/NachoGz/switcher-backend-go/internal/websocket"
 	websocket_mock "github.com/NachoGz/switcher-backend-go/internal/websocket/mocks"
@@ -22,19 +24,23 @@ func TestHandleDeleteGame_Success(t *testing.T) {
 	mockService := new(game_mock.MockGameService)
 	mockPlayerService := new(player_mock.MockPlayerService)
 	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
 
 	// Create test game
 	gameID := uuid.New()
 
 	// Setup expectations
+	deletedGame := game.Game{ID: gameID, Name: "Test Game", MaxPlayers: 4, MinPlayers: 2, PlayersCount: 1}
+	mockService.On("GetGameByID", mock.Anything, gameID).
+		Return(&deletedGame, nil)
 	mockService.On("DeleteGame", mock.Anything, gameID).
 		Return(nil)
 
-	mockWSHub.On("BroadcastEvent", uuid.Nil, "GAMES_LIST_UPDATE").
+	mockLobbyFeedService.On("GameRemoved", deletedGame).
 		Return()
 
 	// Create handlers
-	handlers := handlers.NewGameHandlers(mockService, mockPlayerService, mockWSHub)
+	handlers := handlers.NewGameHandlers(mockService, mockPlayerService, mockLobbyFeedService, mockWSHub)
 
 	// Create request
 	req, _ := http.NewRequest(http.MethodDelete, "/games/", nil)
@@ -46,18 +52,11 @@ func TestHandleDeleteGame_Success(t *testing.T) {
 
 	// Check response
 	assert.Equal(t, http.StatusNoContent, rr.Code)
-
-	var response map[string]string
-	err := json.Unmarshal(rr.Body.Bytes(), &response)
-	assert.NoError(t, err)
-
-	// Verify  response
-	assert.Contains(t, response, "message")
-	assert.Equal(t, "Game deleted successfully", response["message"])
+	assert.Empty(t, rr.Body.String())
 
 	// Verify mock was called
 	mockService.AssertExpectations(t)
-	mockWSHub.AssertExpectations(t)
+	mockLobbyFeedService.AssertExpectations(t)
 }
 
 func TestHandleDeleteGame_InvalidID(t *testing.T) {
@@ -65,9 +64,10 @@ func TestHandleDeleteGame_InvalidID(t *testing.T) {
 	mockService := new(game_mock.MockGameService)
 	mockPlayerService := new(player_mock.MockPlayerService)
 	mockWebsocket := new(websocket.Hub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
 
 	// Create handlers
-	handlers := handlers.NewGameHandlers(mockService, mockPlayerService, mockWebsocket)
+	handlers := handlers.NewGameHandlers(mockService, mockPlayerService, mockLobbyFeedService, mockWebsocket)
 
 	// Create request with invalid ID
 	req, _ := http.NewRequest(http.MethodDelete, "/games/", nil)
@@ -97,14 +97,17 @@ func TestHandleDeleteGame_ServiceError(t *testing.T) {
 	mockService := new(game_mock.MockGameService)
 	mockPlayerService := new(player_mock.MockPlayerService)
 	mockWebsocket := new(websocket.Hub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
 	gameID := uuid.New()
 
 	// Setup mock expectations with error
+	mockService.On("GetGameByID", mock.Anything, gameID).
+		Return(&game.Game{ID: gameID}, nil)
 	mockService.On("DeleteGame", mock.Anything, gameID).
 		Return(errors.New("database error"))
 
 	// Create handlers
-	handlers := handlers.NewGameHandlers(mockService, mockPlayerService, mockWebsocket)
+	handlers := handlers.NewGameHandlers(mockService, mockPlayerService, mockLobbyFeedService, mockWebsocket)
 
 	// Create request with invalid ID
 	req, _ := http.NewRequest(http.MethodDelete, "/games/", nil)
@@ -128,3 +131,36 @@ func TestHandleDeleteGame_ServiceError(t *testing.T) {
 	// Verify mock was not called
 	mockService.AssertExpectations(t)
 }
+
+func TestHandleDeleteGame_NotFound(t *testing.T) {
+	// Setup mock service
+	mockService := new(game_mock.MockGameService)
+	mockPlayerService := new(player_mock.MockPlayerService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
+	gameID := uuid.New()
+
+	// Setup expectations
+	mockService.On("GetGameByID", mock.Anything, gameID).
+		Return((*game.Game)(nil), game.ErrNotFound)
+
+	// Create handlers
+	handlers := handlers.NewGameHandlers(mockService, mockPlayerService, mockLobbyFeedService, mockWSHub)
+
+	// Create request
+	req, _ := http.NewRequest(http.MethodDelete, "/games/", nil)
+	req.SetPathValue("gameID", gameID.String())
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandleDeleteGame(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"code":"GAME_NOT_FOUND"`)
+
+	// Verify mocks are called
+	mockService.AssertExpectations(t)
+	mockService.AssertNotCalled(t, "DeleteGame", mock.Anything, mock.Anything)
+	mockLobbyFeedService.AssertNotCalled(t, "GameRemoved", mock.Anything)
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/NachoGz/switcher-backend-go/internal/board"
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
+	"github.com/google/uuid"
+)
+
+// parseGameAndPlayer reads the game and player IDs from the request path
+func parseGameAndPlayer(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	gameID, err := uuid.Parse(r.PathValue("gameID"))
+	if err != nil {
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Couldn't parse game ID", err)
+		return uuid.Nil, uuid.Nil, false
+	}
+	playerID, err := uuid.Parse(r.PathValue("playerID"))
+	if err != nil {
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Couldn't parse player ID", err)
+		return uuid.Nil, uuid.Nil, false
+	}
+	return gameID, playerID, true
+}
+
+type movementRequest struct {
+	MovementCardID uuid.UUID           `json:"movement_card_id"`
+	From           board.BoardPosition `json:"from"`
+	To             board.BoardPosition `json:"to"`
+}
+
+func (h *GameplayHandlers) HandlePlayMovement(w http.ResponseWriter, r *http.Request) {
+	gameID, playerID, ok := parseGameAndPlayer(w, r)
+	if !ok {
+		return
+	}
+
+	var params movementRequest
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	err := h.gameplayService.PlayMovement(r.Context(), gameID, playerID, params.MovementCardID, params.From, params.To)
+	if err != nil {
+		utils.RespondWithDomainError(w, r, err, "Couldn't play movement")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, messageResponse{Message: "Movement played successfully"})
+}
+
+type figureRequest struct {
+	FigureCardID uuid.UUID           `json:"figure_card_id"`
+	Position     board.BoardPosition `json:"position"`
+}
+
+func (h *GameplayHandlers) HandlePlayFigure(w http.ResponseWriter, r *http.Request) {
+	gameID, playerID, ok := parseGameAndPlayer(w, r)
+	if !ok {
+		return
+	}
+
+	var params figureRequest
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	err := h.gameplayService.PlayFigure(r.Context(), gameID, playerID, params.FigureCardID, params.Position)
+	if err != nil {
+		utils.RespondWithDomainError(w, r, err, "Couldn't play figure")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, messageResponse{Message: "Figure played successfully"})
+}
+
+func (h *GameplayHandlers) HandleBlockFigure(w http.ResponseWriter, r *http.Request) {
+	gameID, playerID, ok := parseGameAndPlayer(w, r)
+	if !ok {
+		return
+	}
+
+	var params figureRequest
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	err := h.gameplayService.BlockFigure(r.Context(), gameID, playerID, params.FigureCardID, params.Position)
+	if err != nil {
+		utils.RespondWithDomainError(w, r, err, "Couldn't block figure")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, messageResponse{Message: "Figure blocked successfully"})
+}
+
+func (h *GameplayHandlers) HandleEndTurn(w http.ResponseWriter, r *http.Request) {
+	gameID, playerID, ok := parseGameAndPlayer(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.gameplayService.EndTurn(r.Context(), gameID, playerID); err != nil {
+		utils.RespondWithDomainError(w, r, err, "Couldn't end turn")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, messageResponse{Message: "Turn ended successfully"})
+}
+
+func (h *GameplayHandlers) HandleGetMoves(w http.ResponseWriter, r *http.Request) {
+	gameID, playerID, ok := parseGameAndPlayer(w, r)
+	if !ok {
+		return
+	}
+
+	hints, err := h.gameplayService.GetMoveHints(r.Context(), gameID, playerID)
+	if err != nil {
+		utils.RespondWithDomainError(w, r, err, "Couldn't list moves")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, hints)
+}
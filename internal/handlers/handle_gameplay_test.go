@@ -0,0 +1,334 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NachoGz/switcher-backend-go/internal/board"
+	"github.com/NachoGz/switcher-backend-go/internal/figureCard"
+	"github.com/NachoGz/switcher-backend-go/internal/gameplay"
+	gameplay_mock "github.com/NachoGz/switcher-backend-go/internal/gameplay/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/handlers"
+	"github.com/NachoGz/switcher-backend-go/internal/movementCard"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandlePlayMovement_Success(t *testing.T) {
+	// Setup mocks
+	mockGameplayService := new(gameplay_mock.MockGameplayService)
+
+	// Test data
+	gameID := uuid.New()
+	playerID := uuid.New()
+	cardID := uuid.New()
+	from := board.BoardPosition{PosX: 1, PosY: 1}
+	to := board.BoardPosition{PosX: 2, PosY: 1}
+
+	// Setup expectations
+	mockGameplayService.On("PlayMovement", mock.Anything, gameID, playerID, cardID, from, to).
+		Return(nil)
+
+	// Create handlers
+	handlers := handlers.NewGameplayHandlers(mockGameplayService)
+
+	// Create request
+	body, _ := json.Marshal(map[string]interface{}{
+		"movement_card_id": cardID,
+		"from":             from,
+		"to":               to,
+	})
+	req, _ := http.NewRequest(http.MethodPost, "/games/"+gameID.String()+"/players/"+playerID.String()+"/movements", bytes.NewBuffer(body))
+	req.SetPathValue("gameID", gameID.String())
+	req.SetPathValue("playerID", playerID.String())
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandlePlayMovement(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	// Verify mocks are called
+	mockGameplayService.AssertExpectations(t)
+}
+
+func TestHandlePlayMovement_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"NotYourTurn", gameplay.ErrNotYourTurn, http.StatusForbidden},
+		{"GameNotPlaying", gameplay.ErrGameNotPlaying, http.StatusConflict},
+		{"CardNotInHand", gameplay.ErrCardNotInHand, http.StatusNotFound},
+		{"InvalidMove", gameplay.ErrInvalidMove, http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mocks
+			mockGameplayService := new(gameplay_mock.MockGameplayService)
+
+			// Test data
+			gameID := uuid.New()
+			playerID := uuid.New()
+
+			// Setup expectations
+			mockGameplayService.On("PlayMovement", mock.Anything, gameID, playerID, mock.Anything, mock.Anything, mock.Anything).
+				Return(tt.err)
+
+			// Create handlers
+			handlers := handlers.NewGameplayHandlers(mockGameplayService)
+
+			// Create request
+			body, _ := json.Marshal(map[string]interface{}{
+				"movement_card_id": uuid.New(),
+			})
+			req, _ := http.NewRequest(http.MethodPost, "/games/"+gameID.String()+"/players/"+playerID.String()+"/movements", bytes.NewBuffer(body))
+			req.SetPathValue("gameID", gameID.String())
+			req.SetPathValue("playerID", playerID.String())
+			rr := httptest.NewRecorder()
+
+			// Call handler
+			handlers.HandlePlayMovement(rr, req)
+
+			// Check response
+			assert.Equal(t, tt.status, rr.Code)
+
+			var response map[string]interface{}
+			err := json.Unmarshal(rr.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.err.Error(), response["error"])
+		})
+	}
+}
+
+func TestHandlePlayFigure_Success(t *testing.T) {
+	// Setup mocks
+	mockGameplayService := new(gameplay_mock.MockGameplayService)
+
+	// Test data
+	gameID := uuid.New()
+	playerID := uuid.New()
+	cardID := uuid.New()
+	pos := board.BoardPosition{PosX: 3, PosY: 4}
+
+	// Setup expectations
+	mockGameplayService.On("PlayFigure", mock.Anything, gameID, playerID, cardID, pos).
+		Return(nil)
+
+	// Create handlers
+	handlers := handlers.NewGameplayHandlers(mockGameplayService)
+
+	// Create request
+	body, _ := json.Marshal(map[string]interface{}{
+		"figure_card_id": cardID,
+		"position":       pos,
+	})
+	req, _ := http.NewRequest(http.MethodPost, "/games/"+gameID.String()+"/players/"+playerID.String()+"/figures", bytes.NewBuffer(body))
+	req.SetPathValue("gameID", gameID.String())
+	req.SetPathValue("playerID", playerID.String())
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandlePlayFigure(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	// Verify mocks are called
+	mockGameplayService.AssertExpectations(t)
+}
+
+func TestHandleBlockFigure_Disabled(t *testing.T) {
+	// Setup mocks
+	mockGameplayService := new(gameplay_mock.MockGameplayService)
+
+	// Test data
+	gameID := uuid.New()
+	playerID := uuid.New()
+	cardID := uuid.New()
+	pos := board.BoardPosition{PosX: 0, PosY: 0}
+
+	// Setup expectations
+	mockGameplayService.On("BlockFigure", mock.Anything, gameID, playerID, cardID, pos).
+		Return(gameplay.ErrBlockingDisabled)
+
+	// Create handlers
+	handlers := handlers.NewGameplayHandlers(mockGameplayService)
+
+	// Create request
+	body, _ := json.Marshal(map[string]interface{}{
+		"figure_card_id": cardID,
+		"position":       pos,
+	})
+	req, _ := http.NewRequest(http.MethodPost, "/games/"+gameID.String()+"/players/"+playerID.String()+"/blocks", bytes.NewBuffer(body))
+	req.SetPathValue("gameID", gameID.String())
+	req.SetPathValue("playerID", playerID.String())
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandleBlockFigure(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+
+	// Verify mocks are called
+	mockGameplayService.AssertExpectations(t)
+}
+
+func TestHandleEndTurn_Success(t *testing.T) {
+	// Setup mocks
+	mockGameplayService := new(gameplay_mock.MockGameplayService)
+
+	// Test data
+	gameID := uuid.New()
+	playerID := uuid.New()
+
+	// Setup expectations
+	mockGameplayService.On("EndTurn", mock.Anything, gameID, playerID).
+		Return(nil)
+
+	// Create handlers
+	handlers := handlers.NewGameplayHandlers(mockGameplayService)
+
+	// Create request
+	req, _ := http.NewRequest(http.MethodPost, "/games/"+gameID.String()+"/players/"+playerID.String()+"/end_turn", nil)
+	req.SetPathValue("gameID", gameID.String())
+	req.SetPathValue("playerID", playerID.String())
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandleEndTurn(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	// Verify mocks are called
+	mockGameplayService.AssertExpectations(t)
+}
+
+func TestHandleEndTurn_InvalidPlayerID(t *testing.T) {
+	// Setup mocks
+	mockGameplayService := new(gameplay_mock.MockGameplayService)
+
+	// Test data
+	gameID := uuid.New()
+
+	// Create handlers
+	handlers := handlers.NewGameplayHandlers(mockGameplayService)
+
+	// Create request
+	req, _ := http.NewRequest(http.MethodPost, "/games/"+gameID.String()+"/players/invalid/end_turn", nil)
+	req.SetPathValue("gameID", gameID.String())
+	req.SetPathValue("playerID", "invalid")
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandleEndTurn(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockGameplayService.AssertNotCalled(t, "EndTurn")
+}
+
+func TestHandleGetMoves_Success(t *testing.T) {
+	// Setup mocks
+	mockGameplayService := new(gameplay_mock.MockGameplayService)
+
+	// Test data
+	gameID := uuid.New()
+	playerID := uuid.New()
+	cardID := uuid.New()
+	figureID := uuid.New()
+
+	hints := gameplay.MoveHints{
+		GameID:   gameID,
+		PlayerID: playerID,
+		Movements: []gameplay.MovementOption{
+			{
+				MovementCardID: cardID,
+				Type:           movementCard.LINEAR_CONT,
+				Swaps: []gameplay.Swap{
+					{From: board.BoardPosition{PosX: 0, PosY: 0}, To: board.BoardPosition{PosX: 1, PosY: 0}},
+				},
+			},
+		},
+		Figures: []gameplay.FigureOption{
+			{
+				FigureCardID: figureID,
+				Type:         figureCard.FIGE02,
+				PlayerID:     playerID,
+				Placements: []gameplay.FigurePlacement{
+					{
+						Color: board.RED,
+						Boxes: []board.BoardPosition{{PosX: 0, PosY: 0}, {PosX: 1, PosY: 0}, {PosX: 0, PosY: 1}, {PosX: 1, PosY: 1}},
+						Movements: []gameplay.Movement{
+							{MovementCardID: cardID, From: board.BoardPosition{PosX: 0, PosY: 0}, To: board.BoardPosition{PosX: 1, PosY: 0}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// Setup expectations
+	mockGameplayService.On("GetMoveHints", mock.Anything, gameID, playerID).
+		Return(&hints, nil)
+
+	// Create handlers
+	handlers := handlers.NewGameplayHandlers(mockGameplayService)
+
+	// Create request
+	req, _ := http.NewRequest(http.MethodGet, "/games/"+gameID.String()+"/players/"+playerID.String()+"/moves", nil)
+	req.SetPathValue("gameID", gameID.String())
+	req.SetPathValue("playerID", playerID.String())
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandleGetMoves(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var response gameplay.MoveHints
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, hints, response)
+
+	// Verify mocks are called
+	mockGameplayService.AssertExpectations(t)
+}
+
+func TestHandleGetMoves_PlayerNotInGame(t *testing.T) {
+	// Setup mocks
+	mockGameplayService := new(gameplay_mock.MockGameplayService)
+
+	// Test data
+	gameID := uuid.New()
+	playerID := uuid.New()
+
+	// Setup expectations
+	mockGameplayService.On("GetMoveHints", mock.Anything, gameID, playerID).
+		Return(nil, gameplay.ErrPlayerNotInGame)
+
+	// Create handlers
+	handlers := handlers.NewGameplayHandlers(mockGameplayService)
+
+	// Create request
+	req, _ := http.NewRequest(http.MethodGet, "/games/"+gameID.String()+"/players/"+playerID.String()+"/moves", nil)
+	req.SetPathValue("gameID", gameID.String())
+	req.SetPathValue("playerID", playerID.String())
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandleGetMoves(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
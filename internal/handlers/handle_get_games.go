@@ -1,85 +1,118 @@
 package handlers
 
 import (
-	"log"
 	"math"
 	"net/http"
 	"strconv"
 
+	"github.com/NachoGz/switcher-backend-go/internal/game"
 	"github.com/NachoGz/switcher-backend-go/internal/utils"
 	"github.com/google/uuid"
 )
 
+// gameListResponse is a page of games. NextCursor is empty on the last page
+type gameListResponse struct {
+	TotalPages int         `json:"total_pages"`
+	Total      int         `json:"total"`
+	NextCursor string      `json:"next_cursor"`
+	Games      []game.Game `json:"games"`
+}
+
 func (h *GameHandlers) HandleGetGames(w http.ResponseWriter, r *http.Request) {
-	log.Println("Getting games")
+	query := r.URL.Query()
 
 	// Parse pagination parameters
 	page := 1 // Default page
-	if pageStr := r.URL.Query().Get("page"); pageStr != "" {
+	if pageStr := query.Get("page"); pageStr != "" {
 		pageVal, err := strconv.Atoi(pageStr)
 		if err != nil || pageVal < 1 {
-			utils.RespondWithError(w, http.StatusBadRequest, "Invalid page", err)
+			utils.RespondWithError(w, r, http.StatusBadRequest, "Invalid page", err)
 			return
 		}
 		page = pageVal
 	}
 
-	limit := 5 // Default limit
-	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+	limit := game.DEFAULT_PAGE_SIZE
+	if limitStr := query.Get("limit"); limitStr != "" {
 		limitVal, err := strconv.Atoi(limitStr)
 		if err != nil || limitVal < 1 {
-			utils.RespondWithError(w, http.StatusBadRequest, "Invalid limit", err)
+			utils.RespondWithError(w, r, http.StatusBadRequest, "Invalid limit", err)
 			return
 		}
-		limit = limitVal
+		limit = min(limitVal, game.MAX_PAGE_SIZE)
 	}
 
-	// filters
-	numPlayers := 0 // 0 means no filter
-	if numPlayersStr := r.URL.Query().Get("num_players"); numPlayersStr != "" {
-		log.Println("num_players:", numPlayersStr)
-		numPlayersVal, err := strconv.Atoi(numPlayersStr)
-		if err != nil || numPlayersVal < 0 {
-			utils.RespondWithError(w, http.StatusBadRequest, "Invalid number of players", err)
-			return
+	// filters, 0 means no filter
+	filter := game.GameFilter{
+		Name:   query.Get("name"),
+		Sort:   query.Get("sort"),
+		Cursor: query.Get("cursor"),
+		Page:   page,
+		Limit:  limit,
+	}
+
+	intFilters := []struct {
+		param   string
+		message string
+		value   *int
+	}{
+		{"num_players", "Invalid number of players", &filter.NumPlayers},
+		{"free_slots", "Invalid number of free slots", &filter.MinFreeSlots},
+		{"min_players", "Invalid minimum of players", &filter.MinPlayers},
+		{"max_players", "Invalid maximum of players", &filter.MaxPlayers},
+	}
+	for _, f := range intFilters {
+		if valueStr := query.Get(f.param); valueStr != "" {
+			value, err := strconv.Atoi(valueStr)
+			if err != nil || value < 0 {
+				utils.RespondWithError(w, r, http.StatusBadRequest, f.message, err)
+				return
+			}
+			*f.value = value
 		}
-		numPlayers = numPlayersVal
 	}
 
-	name := r.URL.Query().Get("name")
+	if privateStr := query.Get("private"); privateStr != "" {
+		private, err := strconv.ParseBool(privateStr)
+		if err != nil {
+			utils.RespondWithError(w, r, http.StatusBadRequest, "Invalid private filter", err)
+			return
+		}
+		filter.IsPrivate = &private
+	}
 
 	// Use service to get games
-	games, total, err := h.gameService.GetAvailableGames(r.Context(), numPlayers, page, limit, name)
+	result, err := h.gameService.SearchGames(r.Context(), filter)
 	if err != nil {
-		utils.RespondWithError(w, http.StatusInternalServerError, "Error getting games", err)
+		utils.RespondWithDomainError(w, r, err, "Error getting games")
 		return
 	}
 
 	// Calculate total pages
-	totalPages := int(math.Ceil(float64(total) / float64(limit)))
+	totalPages := int(math.Ceil(float64(result.Total) / float64(limit)))
 	if totalPages == 0 {
 		totalPages = 1
 	}
 
-	utils.RespondWithJSON(w, http.StatusOK, map[string]interface{}{
-		"total_pages": totalPages,
-		"games":       games,
+	utils.RespondWithJSON(w, http.StatusOK, gameListResponse{
+		TotalPages: totalPages,
+		Total:      result.Total,
+		NextCursor: result.NextCursor,
+		Games:      result.Games,
 	})
 }
 
 func (h *GameHandlers) HandleGetGameByID(w http.ResponseWriter, r *http.Request) {
 	gameID, err := uuid.Parse(r.PathValue("gameID"))
 	if err != nil {
-		utils.RespondWithError(w, http.StatusBadRequest, "Couldn't parse game ID", err)
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Couldn't parse game ID", err)
 		return
 	}
 
-	log.Println("Getting game with ID: ", gameID)
-
 	// Use service to get game
 	game, err := h.gameService.GetGameByID(r.Context(), gameID)
 	if err != nil {
-		utils.RespondWithError(w, http.StatusInternalServerError, "Error getting game", err)
+		utils.RespondWithDomainError(w, r, err, "Error getting game")
 		return
 	}
 
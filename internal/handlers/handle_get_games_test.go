@@ -3,6 +3,7 @@ package handlers_test
 import (
 	"encoding/json"
 	"errors"
+	"math"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -10,7 +11,9 @@ import (
 	"github.com/NachoGz/switcher-backend-go/internal/game"
 	game_mock "github.com/NachoGz/switcher-backend-go/internal/game/mocks"
 	"github.com/NachoGz/switcher-backend-go/internal/handlers"
+	lobbyFeed_mock "github.com/NachoGz/switcher-backend-go/internal/lobbyFeed/mocks"
 	player_mock "github.com/NachoGz/switcher-backend-go/internal/player/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
 	"github.com/NachoGz/switcher-backend-go/internal/websocket"
 	websocket_mock "github.com/NachoGz/switcher-backend-go/internal/websocket/mocks"
 	"github.com/google/uuid"
@@ -23,6 +26,7 @@ func TestHandleGetGames_Success(t *testing.T) {
 	mockService := new(game_mock.MockGameService)
 	mockPlayerService := new(player_mock.MockPlayerService)
 	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
 
 	// Create test games
 	password := "secret"
@@ -47,11 +51,11 @@ func TestHandleGetGames_Success(t *testing.T) {
 	}
 
 	// Set up mock expectations, default parameters
-	mockService.On("GetAvailableGames", mock.Anything, 0, 1, 5, "").
-		Return(games, 2, nil)
+	mockService.On("SearchGames", mock.Anything, game.GameFilter{Page: 1, Limit: 5}).
+		Return(&game.GamePage{Games: games, Total: 2}, nil)
 
 	// Create handlers
-	handlers := handlers.NewGameHandlers(mockService, mockPlayerService, mockWSHub)
+	handlers := handlers.NewGameHandlers(mockService, mockPlayerService, mockLobbyFeedService, mockWSHub)
 
 	// Create request without query parameters
 	req, _ := http.NewRequest(http.MethodGet, "/games", nil)
@@ -77,6 +81,10 @@ func TestHandleGetGames_Success(t *testing.T) {
 	assert.True(t, ok, "games should be an array")
 	assert.Equal(t, 2, len(gamesResponse))
 
+	// The password hash of private games is never sent
+	assert.NotContains(t, gamesResponse[0], "password")
+	assert.NotContains(t, rr.Body.String(), password)
+
 	// Verift mock was called
 	mockService.AssertExpectations(t)
 }
@@ -86,6 +94,7 @@ func TestHandleGetGames_withQueryParameters(t *testing.T) {
 	mockService := new(game_mock.MockGameService)
 	mockPlayerService := new(player_mock.MockPlayerService)
 	mockWebsocket := new(websocket.Hub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
 
 	// Create test games
 	password := "secret"
@@ -110,14 +119,25 @@ func TestHandleGetGames_withQueryParameters(t *testing.T) {
 	}
 
 	// Set mock expectations with parameters
-	mockService.On("GetAvailableGames", mock.Anything, 2, 1, 10, "Game").
-		Return(games, 2, nil)
+	private := true
+	mockService.On("SearchGames", mock.Anything, game.GameFilter{
+		Name:         "Game",
+		NumPlayers:   2,
+		IsPrivate:    &private,
+		MinFreeSlots: 1,
+		MinPlayers:   2,
+		MaxPlayers:   4,
+		Sort:         game.SORT_PLAYERS,
+		Cursor:       "abc",
+		Page:         1,
+		Limit:        10,
+	}).Return(&game.GamePage{Games: games, Total: 12, NextCursor: "next"}, nil)
 
 	// Create handlers
-	handlers := handlers.NewGameHandlers(mockService, mockPlayerService, mockWebsocket)
+	handlers := handlers.NewGameHandlers(mockService, mockPlayerService, mockLobbyFeedService, mockWebsocket)
 
 	// Create request with query parameters
-	req, _ := http.NewRequest(http.MethodGet, "/games?page=1&limit=10&num_players=2&name=Game", nil)
+	req, _ := http.NewRequest(http.MethodGet, "/games?page=1&limit=10&num_players=2&name=Game&private=true&free_slots=1&min_players=2&max_players=4&sort=players&cursor=abc", nil)
 	rr := httptest.NewRecorder()
 
 	// Call the handler
@@ -135,6 +155,11 @@ func TestHandleGetGames_withQueryParameters(t *testing.T) {
 	assert.True(t, ok, "games should be an array")
 	assert.Equal(t, 2, len(gamesResponse))
 
+	// Verify pagination
+	assert.Equal(t, float64(2), response["total_pages"])
+	assert.Equal(t, float64(12), response["total"])
+	assert.Equal(t, "next", response["next_cursor"])
+
 	// Verify mock was called
 	mockService.AssertExpectations(t)
 }
@@ -144,13 +169,14 @@ func TestHandleGetGames_ServiceError(t *testing.T) {
 	mockService := new(game_mock.MockGameService)
 	mockPlayerService := new(player_mock.MockPlayerService)
 	mockWebsocket := new(websocket.Hub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
 
 	// Setup expectations with error
-	mockService.On("GetAvailableGames", mock.Anything, 0, 1, 5, "").
-		Return([]game.Game{}, 0, errors.New("database error"))
+	mockService.On("SearchGames", mock.Anything, game.GameFilter{Page: 1, Limit: 5}).
+		Return(nil, errors.New("database error"))
 
 	// Create handler
-	handlers := handlers.NewGameHandlers(mockService, mockPlayerService, mockWebsocket)
+	handlers := handlers.NewGameHandlers(mockService, mockPlayerService, mockLobbyFeedService, mockWebsocket)
 
 	// Create request
 	req, _ := http.NewRequest(http.MethodGet, "/games", nil)
@@ -177,9 +203,10 @@ func TestHandleGetGames_InvalidPageParameter(t *testing.T) {
 	mockService := new(game_mock.MockGameService)
 	mockPlayerService := new(player_mock.MockPlayerService)
 	mockWebsocket := new(websocket.Hub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
 
 	// Create handlers
-	handlers := handlers.NewGameHandlers(mockService, mockPlayerService, mockWebsocket)
+	handlers := handlers.NewGameHandlers(mockService, mockPlayerService, mockLobbyFeedService, mockWebsocket)
 
 	// Create request
 	req, _ := http.NewRequest(http.MethodGet, "/games?page=invalid", nil)
@@ -200,7 +227,84 @@ func TestHandleGetGames_InvalidPageParameter(t *testing.T) {
 	assert.Equal(t, "Invalid page", response["error"])
 
 	// Verify mock was not called
-	mockService.AssertNotCalled(t, "GetAvailableGames")
+	mockService.AssertNotCalled(t, "SearchGames")
+}
+
+func TestHandleGetGames_InvalidFilters(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		message string
+	}{
+		{"Negative free slots", "free_slots=-1", "Invalid number of free slots"},
+		{"Invalid min players", "min_players=two", "Invalid minimum of players"},
+		{"Invalid max players", "max_players=-4", "Invalid maximum of players"},
+		{"Invalid private", "private=maybe", "Invalid private filter"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup mock service
+			mockService := new(game_mock.MockGameService)
+			mockPlayerService := new(player_mock.MockPlayerService)
+			mockWebsocket := new(websocket.Hub)
+			mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
+
+			// Create handlers
+			handlers := handlers.NewGameHandlers(mockService, mockPlayerService, mockLobbyFeedService, mockWebsocket)
+
+			// Create request
+			req, _ := http.NewRequest(http.MethodGet, "/games?"+tc.query, nil)
+			rr := httptest.NewRecorder()
+
+			// Call handler
+			handlers.HandleGetGames(rr, req)
+
+			// Check response
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+			var response map[string]interface{}
+			err := json.Unmarshal(rr.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.message, response["error"])
+
+			// Verify mock was not called
+			mockService.AssertNotCalled(t, "SearchGames")
+		})
+	}
+}
+
+func TestHandleGetGames_InvalidCursor(t *testing.T) {
+	// Setup mock service
+	mockService := new(game_mock.MockGameService)
+	mockPlayerService := new(player_mock.MockPlayerService)
+	mockWebsocket := new(websocket.Hub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
+
+	// Setup expectations
+	mockService.On("SearchGames", mock.Anything, game.GameFilter{Sort: game.SORT_NAME, Cursor: "bogus", Page: 1, Limit: 5}).
+		Return(nil, game.ErrInvalidCursor)
+
+	// Create handlers
+	handlers := handlers.NewGameHandlers(mockService, mockPlayerService, mockLobbyFeedService, mockWebsocket)
+
+	// Create request
+	req, _ := http.NewRequest(http.MethodGet, "/games?sort=name&cursor=bogus", nil)
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandleGetGames(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, game.ErrInvalidCursor.Error(), response["error"])
+
+	// Verify mock was called
+	mockService.AssertExpectations(t)
 }
 
 func TestHandleGetGamesByID_Success(t *testing.T) {
@@ -208,16 +312,19 @@ func TestHandleGetGamesByID_Success(t *testing.T) {
 	mockService := new(game_mock.MockGameService)
 	mockPlayerService := new(player_mock.MockPlayerService)
 	mockWebsocket := new(websocket.Hub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
 
 	// Create test game
 	gameID := uuid.New()
+	passwordHash := "$2a$10$hash"
 	newGame := &game.Game{
 		ID:           gameID,
 		Name:         "Test Game",
 		MaxPlayers:   4,
 		MinPlayers:   2,
 		PlayersCount: 2,
-		IsPrivate:    false,
+		IsPrivate:    true,
+		Password:     &passwordHash,
 	}
 
 	// Set up expectations
@@ -225,7 +332,7 @@ func TestHandleGetGamesByID_Success(t *testing.T) {
 		Return(newGame, nil)
 
 	// Create handlers
-	handlers := handlers.NewGameHandlers(mockService, mockPlayerService, mockWebsocket)
+	handlers := handlers.NewGameHandlers(mockService, mockPlayerService, mockLobbyFeedService, mockWebsocket)
 
 	// Create request
 	req, _ := http.NewRequest(http.MethodGet, "/games/", nil)
@@ -248,7 +355,8 @@ func TestHandleGetGamesByID_Success(t *testing.T) {
 	assert.Equal(t, 4, response.MaxPlayers)
 	assert.Equal(t, 2, response.MinPlayers)
 	assert.Equal(t, 2, response.PlayersCount)
-	assert.Equal(t, false, response.IsPrivate)
+	assert.Equal(t, true, response.IsPrivate)
+	assert.NotContains(t, rr.Body.String(), passwordHash)
 
 	// Verify mock was called
 	mockService.AssertExpectations(t)
@@ -259,9 +367,10 @@ func TestHandleGetGamesByID_InvalidID(t *testing.T) {
 	mockService := new(game_mock.MockGameService)
 	mockPlayerService := new(player_mock.MockPlayerService)
 	mockWebsocket := new(websocket_mock.MockWebSocketHub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
 
 	// Create handlers
-	handlers := handlers.NewGameHandlers(mockService, mockPlayerService, mockWebsocket)
+	handlers := handlers.NewGameHandlers(mockService, mockPlayerService, mockLobbyFeedService, mockWebsocket)
 
 	// Create request with invalid ID
 	req, _ := http.NewRequest(http.MethodGet, "/games/", nil)
@@ -291,6 +400,7 @@ func TestHandleGetGamesByID_ServiceError(t *testing.T) {
 	mockService := new(game_mock.MockGameService)
 	mockPlayerService := new(player_mock.MockPlayerService)
 	mockWebsocket := new(websocket_mock.MockWebSocketHub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
 	gameID := uuid.New()
 
 	// Setup mock expectations with error
@@ -298,7 +408,7 @@ func TestHandleGetGamesByID_ServiceError(t *testing.T) {
 		Return((*game.Game)(nil), errors.New("database error"))
 
 	// Create handlers
-	handlers := handlers.NewGameHandlers(mockService, mockPlayerService, mockWebsocket)
+	handlers := handlers.NewGameHandlers(mockService, mockPlayerService, mockLobbyFeedService, mockWebsocket)
 
 	// Create request with invalid ID
 	req, _ := http.NewRequest(http.MethodGet, "/games/", nil)
@@ -322,3 +432,108 @@ func TestHandleGetGamesByID_ServiceError(t *testing.T) {
 	// Verify mock was not called
 	mockService.AssertExpectations(t)
 }
+
+func TestHandleGetGameByID_NotFound(t *testing.T) {
+	// Setup mock service
+	mockService := new(game_mock.MockGameService)
+	mockPlayerService := new(player_mock.MockPlayerService)
+	mockWebsocket := new(websocket_mock.MockWebSocketHub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
+	gameID := uuid.New()
+
+	// Setup expectations
+	mockService.On("GetGameByID", mock.Anything, gameID).
+		Return((*game.Game)(nil), game.ErrNotFound)
+
+	// Create handlers
+	handlers := handlers.NewGameHandlers(mockService, mockPlayerService, mockLobbyFeedService, mockWebsocket)
+
+	// Create request
+	req, _ := http.NewRequest(http.MethodGet, "/games/", nil)
+	req.SetPathValue("gameID", gameID.String())
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandleGetGameByID(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, utils.PROBLEM_CONTENT_TYPE, rr.Header().Get("Content-Type"))
+
+	var response utils.Problem
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "GAME_NOT_FOUND", response.Code)
+	assert.Equal(t, http.StatusNotFound, response.Status)
+	assert.Equal(t, "Not Found", response.Title)
+	assert.Equal(t, game.ErrNotFound.Error(), response.Detail)
+
+	// Verify mocks are called
+	mockService.AssertExpectations(t)
+}
+
+func TestHandleGetGames_PageOverflow(t *testing.T) {
+	// Setup mock service
+	mockService := new(game_mock.MockGameService)
+	mockPlayerService := new(player_mock.MockPlayerService)
+	mockWebsocket := new(websocket.Hub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
+
+	// Setup expectations, the service rejects pages it can't skip to
+	mockService.On("SearchGames", mock.Anything, game.GameFilter{Page: math.MaxInt, Limit: game.MAX_PAGE_SIZE}).
+		Return(nil, game.ErrInvalidPage)
+
+	// Create handlers
+	handlers := handlers.NewGameHandlers(mockService, mockPlayerService, mockLobbyFeedService, mockWebsocket)
+
+	// Create request
+	req, _ := http.NewRequest(http.MethodGet, "/games?page=9223372036854775807&limit=50", nil)
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandleGetGames(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "INVALID_PAGE", response["code"])
+
+	// Verify mocks are called
+	mockService.AssertExpectations(t)
+}
+
+func TestHandleGetGames_LimitCapped(t *testing.T) {
+	// Setup mock service
+	mockService := new(game_mock.MockGameService)
+	mockPlayerService := new(player_mock.MockPlayerService)
+	mockWebsocket := new(websocket.Hub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
+
+	// Setup expectations
+	mockService.On("SearchGames", mock.Anything, game.GameFilter{Page: 1, Limit: game.MAX_PAGE_SIZE}).
+		Return(&game.GamePage{Games: []game.Game{}, Total: 120}, nil)
+
+	// Create handlers
+	handlers := handlers.NewGameHandlers(mockService, mockPlayerService, mockLobbyFeedService, mockWebsocket)
+
+	// Create request
+	req, _ := http.NewRequest(http.MethodGet, "/games?limit=1000000", nil)
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandleGetGames(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(3), response["total_pages"])
+
+	// Verify mocks are called
+	mockService.AssertExpectations(t)
+}
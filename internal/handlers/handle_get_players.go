@@ -1,8 +1,6 @@
 package handlers
 
 import (
-	"context"
-	"log"
 	"net/http"
 
 	"github.com/NachoGz/switcher-backend-go/internal/utils"
@@ -12,15 +10,13 @@ import (
 func (h *PlayerHandlers) HandleGetPlayers(w http.ResponseWriter, r *http.Request) {
 	gameID, err := uuid.Parse(r.PathValue("gameID"))
 	if err != nil {
-		utils.RespondWithError(w, http.StatusBadRequest, "Couldn't parse game ID", err)
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Couldn't parse game ID", err)
 		return
 	}
 
-	log.Println("Getting players from game: ", gameID)
-
-	players, err := h.playerService.GetPlayersInGame(context.Background(), gameID)
+	players, err := h.playerService.GetPlayersInGame(r.Context(), gameID)
 	if err != nil {
-		utils.RespondWithError(w, http.StatusInternalServerError, "failed to fetch players", err)
+		utils.RespondWithError(w, r, http.StatusInternalServerError, "failed to fetch players", err)
 		return
 	}
 
@@ -30,21 +26,19 @@ func (h *PlayerHandlers) HandleGetPlayers(w http.ResponseWriter, r *http.Request
 func (h *PlayerHandlers) HandleGetPlayer(w http.ResponseWriter, r *http.Request) {
 	gameID, err := uuid.Parse(r.PathValue("gameID"))
 	if err != nil {
-		utils.RespondWithError(w, http.StatusBadRequest, "Couldn't parse game ID", err)
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Couldn't parse game ID", err)
 		return
 	}
 
 	playerID, err := uuid.Parse(r.PathValue("playerID"))
 	if err != nil {
-		utils.RespondWithError(w, http.StatusBadRequest, "Couldn't parse player ID", err)
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Couldn't parse player ID", err)
 		return
 	}
 
-	log.Printf("Getting player %s from game %s: ", playerID, gameID)
-
-	player, err := h.playerService.GetPlayerByID(context.Background(), gameID, playerID)
+	player, err := h.playerService.GetPlayerByID(r.Context(), gameID, playerID)
 	if err != nil {
-		utils.RespondWithError(w, http.StatusInternalServerError, "failed to fetch player", err)
+		utils.RespondWithError(w, r, http.StatusInternalServerError, "failed to fetch player", err)
 		return
 	}
 
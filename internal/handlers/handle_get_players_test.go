@@ -10,6 +10,8 @@ import (
 	game_mock "github.com/NachoGz/switcher-backend-go/internal/game/mocks"
 	gameState_mock "github.com/NachoGz/switcher-backend-go/internal/game_state/mocks"
 	"github.com/NachoGz/switcher-backend-go/internal/handlers"
+	invite_mock "github.com/NachoGz/switcher-backend-go/internal/invite/mocks"
+	lobbyFeed_mock "github.com/NachoGz/switcher-backend-go/internal/lobbyFeed/mocks"
 	"github.com/NachoGz/switcher-backend-go/internal/player"
 	player_mock "github.com/NachoGz/switcher-backend-go/internal/player/mocks"
 	websocket_mock "github.com/NachoGz/switcher-backend-go/internal/websocket/mocks"
@@ -23,7 +25,9 @@ func TestHandleGetPlayer_Success(t *testing.T) {
 	mockPlayerService := new(player_mock.MockPlayerService)
 	mockGameService := new(game_mock.MockGameService)
 	mockGameStateService := new(gameState_mock.MockGameStateService)
+	mockInviteService := new(invite_mock.MockInviteService)
 	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
 
 	// Create test games
 	gameID := uuid.New()
@@ -50,7 +54,7 @@ func TestHandleGetPlayer_Success(t *testing.T) {
 		Return(responsePlayers, nil)
 
 	// Create handlers
-	handlers := handlers.NewPlayerHandlers(mockPlayerService, mockGameService, mockGameStateService, mockWSHub)
+	handlers := handlers.NewPlayerHandlers(mockPlayerService, mockGameService, mockGameStateService, mockInviteService, mockLobbyFeedService, mockWSHub)
 
 	// Create request without query parameters
 	req, _ := http.NewRequest(http.MethodGet, "/players/", nil)
@@ -82,10 +86,12 @@ func TestHandleGetPlayers_InvalidGameID(t *testing.T) {
 	mockPlayerService := new(player_mock.MockPlayerService)
 	mockGameService := new(game_mock.MockGameService)
 	mockGameStateService := new(gameState_mock.MockGameStateService)
+	mockInviteService := new(invite_mock.MockInviteService)
 	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
 
 	// Create handlers
-	handlers := handlers.NewPlayerHandlers(mockPlayerService, mockGameService, mockGameStateService, mockWSHub)
+	handlers := handlers.NewPlayerHandlers(mockPlayerService, mockGameService, mockGameStateService, mockInviteService, mockLobbyFeedService, mockWSHub)
 
 	// Create request without query parameters
 	req, _ := http.NewRequest(http.MethodGet, "/players/", nil)
@@ -116,7 +122,9 @@ func TestHandleGetPlayers_ServiceError(t *testing.T) {
 	mockPlayerService := new(player_mock.MockPlayerService)
 	mockGameService := new(game_mock.MockGameService)
 	mockGameStateService := new(gameState_mock.MockGameStateService)
+	mockInviteService := new(invite_mock.MockInviteService)
 	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
 
 	// Test data
 	gameID := uuid.New()
@@ -126,7 +134,7 @@ func TestHandleGetPlayers_ServiceError(t *testing.T) {
 		Return([]player.Player{}, errors.New("error fetching players"))
 
 	// Create handlers
-	handlers := handlers.NewPlayerHandlers(mockPlayerService, mockGameService, mockGameStateService, mockWSHub)
+	handlers := handlers.NewPlayerHandlers(mockPlayerService, mockGameService, mockGameStateService, mockInviteService, mockLobbyFeedService, mockWSHub)
 
 	// Create request without query parameters
 	req, _ := http.NewRequest(http.MethodGet, "/players/", nil)
@@ -157,7 +165,9 @@ func TestHandleGetPlayerByID_Success(t *testing.T) {
 	mockPlayerService := new(player_mock.MockPlayerService)
 	mockGameService := new(game_mock.MockGameService)
 	mockGameStateService := new(gameState_mock.MockGameStateService)
+	mockInviteService := new(invite_mock.MockInviteService)
 	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
 
 	// Create test games
 	gameID := uuid.New()
@@ -176,7 +186,7 @@ func TestHandleGetPlayerByID_Success(t *testing.T) {
 		Return(responsePlayer, nil)
 
 	// Create handlers
-	handlers := handlers.NewPlayerHandlers(mockPlayerService, mockGameService, mockGameStateService, mockWSHub)
+	handlers := handlers.NewPlayerHandlers(mockPlayerService, mockGameService, mockGameStateService, mockInviteService, mockLobbyFeedService, mockWSHub)
 
 	// Create request without query parameters
 	req, _ := http.NewRequest(http.MethodGet, "/players/", nil)
@@ -206,13 +216,15 @@ func TestHandleGetGamesByID_InvalidGameID(t *testing.T) {
 	mockPlayerService := new(player_mock.MockPlayerService)
 	mockGameService := new(game_mock.MockGameService)
 	mockGameStateService := new(gameState_mock.MockGameStateService)
+	mockInviteService := new(invite_mock.MockInviteService)
 	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
 
 	// Test data
 	playerID := uuid.New()
 
 	// Create handlers
-	handlers := handlers.NewPlayerHandlers(mockPlayerService, mockGameService, mockGameStateService, mockWSHub)
+	handlers := handlers.NewPlayerHandlers(mockPlayerService, mockGameService, mockGameStateService, mockInviteService, mockLobbyFeedService, mockWSHub)
 
 	// Create request without query parameters
 	req, _ := http.NewRequest(http.MethodGet, "/players/", nil)
@@ -244,13 +256,15 @@ func TestHandleGetGamesByID_InvalidPlayerID(t *testing.T) {
 	mockPlayerService := new(player_mock.MockPlayerService)
 	mockGameService := new(game_mock.MockGameService)
 	mockGameStateService := new(gameState_mock.MockGameStateService)
+	mockInviteService := new(invite_mock.MockInviteService)
 	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
 
 	// Test data
 	gameID := uuid.New()
 
 	// Create handlers
-	handlers := handlers.NewPlayerHandlers(mockPlayerService, mockGameService, mockGameStateService, mockWSHub)
+	handlers := handlers.NewPlayerHandlers(mockPlayerService, mockGameService, mockGameStateService, mockInviteService, mockLobbyFeedService, mockWSHub)
 
 	// Create request without query parameters
 	req, _ := http.NewRequest(http.MethodGet, "/players/", nil)
@@ -282,7 +296,9 @@ func TestHandleGetPlayerByID_ServiceError(t *testing.T) {
 	mockPlayerService := new(player_mock.MockPlayerService)
 	mockGameService := new(game_mock.MockGameService)
 	mockGameStateService := new(gameState_mock.MockGameStateService)
+	mockInviteService := new(invite_mock.MockInviteService)
 	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
 
 	// Create test games
 	gameID := uuid.New()
@@ -293,7 +309,7 @@ func TestHandleGetPlayerByID_ServiceError(t *testing.T) {
 		Return(player.Player{}, errors.New("error getting player"))
 
 	// Create handlers
-	handlers := handlers.NewPlayerHandlers(mockPlayerService, mockGameService, mockGameStateService, mockWSHub)
+	handlers := handlers.NewPlayerHandlers(mockPlayerService, mockGameService, mockGameStateService, mockInviteService, mockLobbyFeedService, mockWSHub)
 
 	// Create request without query parameters
 	req, _ := http.NewRequest(http.MethodGet, "/players/", nil)
@@ -1,8 +1,6 @@
 package handlers
 
 import (
-	"context"
-	"log"
 	"net/http"
 
 	"github.com/NachoGz/switcher-backend-go/internal/utils"
@@ -11,24 +9,19 @@ import (
 
 func (h *GameHandlers) HandlerGetWinner(w http.ResponseWriter, r *http.Request) {
 	gameID, err := uuid.Parse(r.PathValue("gameID"))
-	log.Println(gameID.String())
 	if err != nil {
-		utils.RespondWithError(w, http.StatusBadRequest, "Couldn't parse game ID", err)
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Couldn't parse game ID", err)
 		return
 	}
 
-	log.Println("Getting winner from game: ", gameID)
-
-	winner, err := h.playerService.GetWinner(context.Background(), gameID)
+	winner, err := h.playerService.GetWinner(r.Context(), gameID)
 	if err != nil && winner != nil {
-		utils.RespondWithError(w, http.StatusInternalServerError, "Couldn't fetch winner", err)
+		utils.RespondWithError(w, r, http.StatusInternalServerError, "Couldn't fetch winner", err)
 		return
 	}
 
 	if winner == nil {
-		utils.RespondWithJSON(w, http.StatusNotFound, map[string]string{
-			"message": "There is no winner",
-		})
+		utils.RespondWithJSON(w, http.StatusNotFound, messageResponse{Message: "There is no winner"})
 		return
 	} else {
 		utils.RespondWithJSON(w, http.StatusOK, winner)
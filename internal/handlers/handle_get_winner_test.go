@@ -9,6 +9,7 @@ import (
 
 	game_mock "github.com/NachoGz/switcher-backend-go/internal/game/mocks"
 	"github.com/NachoGz/switcher-backend-go/internal/handlers"
+	lobbyFeed_mock "github.com/NachoGz/switcher-backend-go/internal/lobbyFeed/mocks"
 	"github.com/NachoGz/switcher-backend-go/internal/player"
 	player_mock "github.com/NachoGz/switcher-backend-go/internal/player/mocks"
 	websocket_mock "github.com/NachoGz/switcher-backend-go/internal/websocket/mocks"
@@ -22,6 +23,7 @@ func TestGetWinner_Success(t *testing.T) {
 	mockPlayerService := new(player_mock.MockPlayerService)
 	mockGameService := new(game_mock.MockGameService)
 	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
 
 	gameID := uuid.New()
 	players := []player.Player{
@@ -48,7 +50,7 @@ func TestGetWinner_Success(t *testing.T) {
 		Return(&players[0], nil)
 
 	// Create handlers
-	handlers := handlers.NewGameHandlers(mockGameService, mockPlayerService, mockWSHub)
+	handlers := handlers.NewGameHandlers(mockGameService, mockPlayerService, mockLobbyFeedService, mockWSHub)
 
 	// Create request
 	req, _ := http.NewRequest(http.MethodGet, "/games/"+gameID.String()+"/winner", nil)
@@ -77,9 +79,10 @@ func TestHandleGetWinner_InvalidID(t *testing.T) {
 	mockService := new(game_mock.MockGameService)
 	mockPlayerService := new(player_mock.MockPlayerService)
 	mockWebsocket := new(websocket_mock.MockWebSocketHub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
 
 	// Create handlers
-	handlers := handlers.NewGameHandlers(mockService, mockPlayerService, mockWebsocket)
+	handlers := handlers.NewGameHandlers(mockService, mockPlayerService, mockLobbyFeedService, mockWebsocket)
 
 	// Create request with invalid ID
 	req, _ := http.NewRequest(http.MethodGet, "/games/invalid-id/winner", nil)
@@ -109,6 +112,7 @@ func TestHandleGetWinner_ServiceError(t *testing.T) {
 	mockService := new(game_mock.MockGameService)
 	mockPlayerService := new(player_mock.MockPlayerService)
 	mockWebsocket := new(websocket_mock.MockWebSocketHub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
 	gameID := uuid.New()
 
 	// Setup mock expectations with error
@@ -116,7 +120,7 @@ func TestHandleGetWinner_ServiceError(t *testing.T) {
 		Return(&player.Player{}, errors.New("database error"))
 
 	// Create handlers
-	handlers := handlers.NewGameHandlers(mockService, mockPlayerService, mockWebsocket)
+	handlers := handlers.NewGameHandlers(mockService, mockPlayerService, mockLobbyFeedService, mockWebsocket)
 
 	// Create request with invalid ID
 	req, _ := http.NewRequest(http.MethodGet, "/games/"+gameID.String()+"/winner", nil)
@@ -146,6 +150,7 @@ func TestHandleGetWinner_NoWinner(t *testing.T) {
 	mockService := new(game_mock.MockGameService)
 	mockPlayerService := new(player_mock.MockPlayerService)
 	mockWebsocket := new(websocket_mock.MockWebSocketHub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
 	gameID := uuid.New()
 
 	// Setup mock expectations with error
@@ -153,7 +158,7 @@ func TestHandleGetWinner_NoWinner(t *testing.T) {
 		Return((*player.Player)(nil), errors.New("database error"))
 
 	// Create handlers
-	handlers := handlers.NewGameHandlers(mockService, mockPlayerService, mockWebsocket)
+	handlers := handlers.NewGameHandlers(mockService, mockPlayerService, mockLobbyFeedService, mockWebsocket)
 
 	// Create request with invalid ID
 	req, _ := http.NewRequest(http.MethodGet, "/games/"+gameID.String()+"/winner", nil)
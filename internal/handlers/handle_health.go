@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/NachoGz/switcher-backend-go/internal/health"
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
+)
+
+// HandleHealthz answers as long as the process can serve requests at all
+func (h *HealthHandlers) HandleHealthz(w http.ResponseWriter, r *http.Request) {
+	utils.RespondWithJSON(w, http.StatusOK, health.Liveness{Status: health.STATUS_OK})
+}
+
+// HandleReadyz answers 503 while a dependency fails or the server is
+// shutting down, so load balancers stop sending it requests
+func (h *HealthHandlers) HandleReadyz(w http.ResponseWriter, r *http.Request) {
+	report := h.healthService.Ready(r.Context())
+	if report.Status != health.STATUS_OK {
+		utils.RespondWithJSON(w, http.StatusServiceUnavailable, report)
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, report)
+}
+
+func (h *HealthHandlers) HandleVersion(w http.ResponseWriter, r *http.Request) {
+	utils.RespondWithJSON(w, http.StatusOK, h.healthService.Version())
+}
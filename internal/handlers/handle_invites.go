@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
+	"github.com/google/uuid"
+)
+
+// PlayerID is the host creating the invite. A missing TTL uses the default
+type createInviteRequest struct {
+	PlayerID   uuid.UUID `json:"player_id"`
+	TTLSeconds int       `json:"ttl_seconds"`
+}
+
+func (h *InviteHandlers) HandleCreateInvite(w http.ResponseWriter, r *http.Request) {
+	gameID, err := uuid.Parse(r.PathValue("gameID"))
+	if err != nil {
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Couldn't parse game ID", err)
+		return
+	}
+
+	var params createInviteRequest
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	ttl := time.Duration(params.TTLSeconds) * time.Second
+	createdInvite, err := h.inviteService.CreateInvite(r.Context(), gameID, params.PlayerID, ttl)
+	if err != nil {
+		utils.RespondWithDomainError(w, r, err, "Couldn't create invite")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusCreated, createdInvite)
+}
+
+func (h *InviteHandlers) HandleGetGameByCode(w http.ResponseWriter, r *http.Request) {
+	foundGame, err := h.inviteService.GetGameByCode(r.Context(), r.PathValue("code"))
+	if err != nil {
+		utils.RespondWithDomainError(w, r, err, "Couldn't get game")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, foundGame)
+}
@@ -0,0 +1,148 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NachoGz/switcher-backend-go/internal/game"
+	"github.com/NachoGz/switcher-backend-go/internal/handlers"
+	"github.com/NachoGz/switcher-backend-go/internal/invite"
+	invite_mock "github.com/NachoGz/switcher-backend-go/internal/invite/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandleCreateInvite_Success(t *testing.T) {
+	// Setup mocks
+	mockInviteService := new(invite_mock.MockInviteService)
+
+	// Test data
+	gameID := uuid.New()
+	hostID := uuid.New()
+	createdInvite := invite.Invite{
+		GameID:    gameID,
+		Code:      "ABC234",
+		Token:     "token",
+		URL:       "/join/ABC234?invite=token",
+		ExpiresAt: time.Now().Add(time.Hour).Truncate(time.Second).UTC(),
+	}
+
+	// Setup expectations
+	mockInviteService.On("CreateInvite", mock.Anything, gameID, hostID, time.Hour).
+		Return(&createdInvite, nil)
+
+	// Create handlers
+	handlers := handlers.NewInviteHandlers(mockInviteService)
+
+	// Create request
+	body, _ := json.Marshal(map[string]interface{}{"player_id": hostID, "ttl_seconds": 3600})
+	req, _ := http.NewRequest(http.MethodPost, "/games/invites", bytes.NewBuffer(body))
+	req.SetPathValue("gameID", gameID.String())
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandleCreateInvite(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusCreated, rr.Code)
+
+	var response invite.Invite
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, createdInvite, response)
+
+	// Verify mocks are called
+	mockInviteService.AssertExpectations(t)
+}
+
+func TestHandleCreateInvite_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{"Invalid ttl", invite.ErrInvalidTTL, http.StatusBadRequest},
+		{"Not host", invite.ErrNotHost, http.StatusForbidden},
+		{"Game started", invite.ErrGameStarted, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mocks
+			mockInviteService := new(invite_mock.MockInviteService)
+
+			// Setup expectations
+			mockInviteService.On("CreateInvite", mock.Anything, mock.Anything, mock.Anything, time.Duration(0)).
+				Return(nil, tt.err)
+
+			// Create handlers
+			handlers := handlers.NewInviteHandlers(mockInviteService)
+
+			// Create request
+			body, _ := json.Marshal(map[string]interface{}{"player_id": uuid.New()})
+			req, _ := http.NewRequest(http.MethodPost, "/games/invites", bytes.NewBuffer(body))
+			req.SetPathValue("gameID", uuid.New().String())
+			rr := httptest.NewRecorder()
+
+			// Call handler
+			handlers.HandleCreateInvite(rr, req)
+
+			// Check response
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Contains(t, rr.Body.String(), tt.err.Error())
+		})
+	}
+}
+
+func TestHandleGetGameByCode(t *testing.T) {
+	// Setup mocks
+	mockInviteService := new(invite_mock.MockInviteService)
+
+	// Test data
+	gameID := uuid.New()
+	passwordHash := "$2a$10$hash"
+
+	// Setup expectations
+	mockInviteService.On("GetGameByCode", mock.Anything, "ABC234").
+		Return(&game.Game{ID: gameID, Name: "Test Game", MaxPlayers: 4, MinPlayers: 2, IsPrivate: true, Password: &passwordHash}, nil)
+	mockInviteService.On("GetGameByCode", mock.Anything, "NOPE22").
+		Return(nil, invite.ErrCodeNotFound)
+
+	// Create handlers
+	handlers := handlers.NewInviteHandlers(mockInviteService)
+
+	// Create request
+	req, _ := http.NewRequest(http.MethodGet, "/games/by-code/ABC234", nil)
+	req.SetPathValue("code", "ABC234")
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandleGetGameByCode(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var response game.Game
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, gameID, response.ID)
+	assert.Nil(t, response.Password)
+	assert.NotContains(t, rr.Body.String(), passwordHash)
+
+	// Unknown codes
+	req, _ = http.NewRequest(http.MethodGet, "/games/by-code/NOPE22", nil)
+	req.SetPathValue("code", "NOPE22")
+	rr = httptest.NewRecorder()
+
+	handlers.HandleGetGameByCode(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	// Verify mocks are called
+	mockInviteService.AssertExpectations(t)
+}
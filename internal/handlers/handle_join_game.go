@@ -1,96 +1,127 @@
 package handlers
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"net/http"
 
+	"github.com/NachoGz/switcher-backend-go/internal/game"
 	"github.com/NachoGz/switcher-backend-go/internal/player"
+	"github.com/NachoGz/switcher-backend-go/internal/user"
 	"github.com/NachoGz/switcher-backend-go/internal/utils"
+	"github.com/NachoGz/switcher-backend-go/internal/validation"
+	"github.com/NachoGz/switcher-backend-go/internal/websocket"
 	"github.com/google/uuid"
 )
 
-func (h *PlayerHandlers) HandleJoinGame(w http.ResponseWriter, r *http.Request) {
-	log.Println("Joining game...")
+type joinGameRequest struct {
+	PlayerName string  `json:"player_name"`
+	Password   *string `json:"password"`
+	// An invite from the host replaces the password
+	Invite *string `json:"invite"`
+}
 
-	type PlayerJoinRequest struct {
-		PlayerName string  `json:"player_name"`
-		Password   *string `json:"password"`
-	}
+type joinGameResponse struct {
+	Message  string    `json:"message"`
+	PlayerID uuid.UUID `json:"player_id"`
+}
 
-	var params PlayerJoinRequest
+func (h *PlayerHandlers) HandleJoinGame(w http.ResponseWriter, r *http.Request) {
+	var params joinGameRequest
 	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
-		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request body", err)
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Invalid request body", err)
 		return
 	}
 
 	gameID, err := uuid.Parse(r.PathValue("gameID"))
 	if err != nil {
-		utils.RespondWithError(w, http.StatusBadRequest, "Couldn't parse game ID", err)
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Couldn't parse game ID", err)
+		return
+	}
+
+	// Registered users default to their username
+	playerName := params.PlayerName
+	if u, ok := user.FromContext(r.Context()); ok && playerName == "" {
+		playerName = u.Username
+	}
+
+	if err := validation.JoinGame(playerName, params.Password); err != nil {
+		utils.RespondWithDomainError(w, r, err, "Invalid request")
 		return
 	}
 
-	game, err := h.gameService.GetGameByID(context.Background(), gameID)
+	joinedGame, err := h.gameService.GetGameByID(r.Context(), gameID)
 	if err != nil {
-		utils.RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Couldn't get game with ID: %s", gameID), err)
+		utils.RespondWithDomainError(w, r, err, fmt.Sprintf("Couldn't get game with ID: %s", gameID))
 		return
 	}
 
-	playersInGame, err := h.playerService.CountPlayers(context.Background(), gameID)
+	playersInGame, err := h.playerService.CountPlayers(r.Context(), gameID)
 	if err != nil {
-		utils.RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Couldn't get amount of players in game with ID: %s", gameID), err)
+		utils.RespondWithError(w, r, http.StatusInternalServerError, fmt.Sprintf("Couldn't get amount of players in game with ID: %s", gameID), err)
 		return
 	}
 
-	if game.MaxPlayers == int(playersInGame) {
-		utils.RespondWithError(w, http.StatusForbidden, "The game is full", err)
+	if joinedGame.MaxPlayers == int(playersInGame) {
+		utils.RespondWithDomainError(w, r, game.ErrGameFull, "The game is full")
 		return
 	}
 
-	if game.IsPrivate && game.Password != nil {
-		storedPasswordHash := game.Password
+	if joinedGame.IsPrivate && joinedGame.Password != nil && params.Invite != nil {
+		if err := h.inviteService.VerifyInvite(gameID, *params.Invite); err != nil {
+			utils.RespondWithDomainError(w, r, err, "Couldn't verify invite")
+			return
+		}
+	} else if joinedGame.IsPrivate && joinedGame.Password != nil {
+		storedPasswordHash := joinedGame.Password
 
 		// No password entered
 		if params.Password == nil {
-			utils.RespondWithError(w, http.StatusForbidden, "Password required for private games", err)
+			utils.RespondWithDomainError(w, r, game.ErrPasswordRequired, "Password required for private games")
 			return
 		}
 
 		if err := utils.CheckPasswordHash(*storedPasswordHash, *params.Password); err != nil {
-			utils.RespondWithError(w, http.StatusForbidden, "Incorrect password", err)
+			utils.RespondWithDomainError(w, r, game.ErrWrongPassword, "Incorrect password")
 			return
 		}
+	}
 
-		log.Println(*storedPasswordHash)
-		log.Println(*params.Password)
-
+	gameState, err := h.gameStateService.GetGameStateByGameID(r.Context(), gameID)
+	if err != nil {
+		utils.RespondWithError(w, r, http.StatusInternalServerError, fmt.Sprintf("Couldn't get game state for game %s", gameID), err)
+		return
 	}
 
-	gameState, err := h.gameStateService.GetGameStateByGameID(context.Background(), gameID)
+	// Players kicked with a ban can't come back with the same name or account
+	banned, err := h.gameService.IsBanned(r.Context(), gameID, playerName, user.IDFromContext(r.Context()))
 	if err != nil {
-		utils.RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("Couldn't get game state for game %s", gameID), err)
+		utils.RespondWithError(w, r, http.StatusInternalServerError, "Couldn't check the ban list", err)
+		return
+	}
+	if banned {
+		utils.RespondWithDomainError(w, r, game.ErrBanned, "You were banned from this game")
 		return
 	}
 
 	// Create player
-	player, err := h.playerService.CreatePlayer(context.Background(), player.Player{
-		Name:        params.PlayerName,
+	player, err := h.playerService.CreatePlayer(r.Context(), player.Player{
+		Name:        playerName,
 		GameID:      gameID,
 		GameStateID: gameState.ID,
 		Host:        false,
+		UserID:      user.IDFromContext(r.Context()),
 	})
 	if err != nil {
-		utils.RespondWithError(w, http.StatusInternalServerError, "Couldn't create player", err)
+		utils.RespondWithError(w, r, http.StatusInternalServerError, "Couldn't create player", err)
 		return
 	}
 
-	utils.RespondWithJSON(w, http.StatusCreated, map[string]interface{}{
-		"message":   "Joined game successfully",
-		"player_id": player.ID,
+	utils.RespondWithJSON(w, http.StatusCreated, joinGameResponse{
+		Message:  "Joined game successfully",
+		PlayerID: player.ID,
 	})
 
-	h.wsHub.BroadcastEvent(uuid.Nil, "GAMES_LIST_UPDATE")
-	h.wsHub.BroadcastEvent(uuid.Nil, fmt.Sprintf("%s:GAME_INFO_UPDATE", gameID))
+	h.lobbyFeedService.GameUpdated(r.Context(), gameID)
+	h.wsHub.BroadcastEvent(uuid.Nil, websocket.GameScoped(gameID, websocket.GAME_INFO_UPDATE))
 }
@@ -14,8 +14,12 @@ import (
 	gameState "github.com/NachoGz/switcher-backend-go/internal/game_state"
 	gameState_mock "github.com/NachoGz/switcher-backend-go/internal/game_state/mocks"
 	"github.com/NachoGz/switcher-backend-go/internal/handlers"
+	"github.com/NachoGz/switcher-backend-go/internal/invite"
+	invite_mock "github.com/NachoGz/switcher-backend-go/internal/invite/mocks"
+	lobbyFeed_mock "github.com/NachoGz/switcher-backend-go/internal/lobbyFeed/mocks"
 	"github.com/NachoGz/switcher-backend-go/internal/player"
 	player_mock "github.com/NachoGz/switcher-backend-go/internal/player/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/user"
 	"github.com/NachoGz/switcher-backend-go/internal/utils"
 	websocket_mock "github.com/NachoGz/switcher-backend-go/internal/websocket/mocks"
 	"github.com/google/uuid"
@@ -28,7 +32,9 @@ func TestHandleJoinGamePublic_Success(t *testing.T) {
 	mockPlayerService := new(player_mock.MockPlayerService)
 	mockGameService := new(game_mock.MockGameService)
 	mockGameStateService := new(gameState_mock.MockGameStateService)
+	mockInviteService := new(invite_mock.MockInviteService)
 	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
 
 	// Test data
 	gameID := uuid.New()
@@ -75,17 +81,20 @@ func TestHandleJoinGamePublic_Success(t *testing.T) {
 	mockGameStateService.On("GetGameStateByGameID", mock.Anything, gameID).
 		Return(&responseGameState, nil)
 
+	mockGameService.On("IsBanned", mock.Anything, gameID, requestPlayer.Name, requestPlayer.UserID).
+		Return(false, nil)
+
 	mockPlayerService.On("CreatePlayer", mock.Anything, requestPlayer).
 		Return(&responsePlayer, nil)
 
-	mockWSHub.On("BroadcastEvent", uuid.Nil, "GAMES_LIST_UPDATE").
+	mockLobbyFeedService.On("GameUpdated", mock.Anything, gameID).
 		Return()
 
 	mockWSHub.On("BroadcastEvent", uuid.Nil, fmt.Sprintf("%s:GAME_INFO_UPDATE", gameID)).
 		Return()
 
 	// Create handlers
-	handlers := handlers.NewPlayerHandlers(mockPlayerService, mockGameService, mockGameStateService, mockWSHub)
+	handlers := handlers.NewPlayerHandlers(mockPlayerService, mockGameService, mockGameStateService, mockInviteService, mockLobbyFeedService, mockWSHub)
 
 	// Create request body
 	requestBody := map[string]interface{}{
@@ -124,7 +133,9 @@ func TestHandleJoinGamePrivate_Success(t *testing.T) {
 	mockPlayerService := new(player_mock.MockPlayerService)
 	mockGameService := new(game_mock.MockGameService)
 	mockGameStateService := new(gameState_mock.MockGameStateService)
+	mockInviteService := new(invite_mock.MockInviteService)
 	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
 
 	// Test data
 	gameID := uuid.New()
@@ -176,17 +187,20 @@ func TestHandleJoinGamePrivate_Success(t *testing.T) {
 	mockGameStateService.On("GetGameStateByGameID", mock.Anything, gameID).
 		Return(&responseGameState, nil)
 
+	mockGameService.On("IsBanned", mock.Anything, gameID, requestPlayer.Name, requestPlayer.UserID).
+		Return(false, nil)
+
 	mockPlayerService.On("CreatePlayer", mock.Anything, requestPlayer).
 		Return(&responsePlayer, nil)
 
-	mockWSHub.On("BroadcastEvent", uuid.Nil, "GAMES_LIST_UPDATE").
+	mockLobbyFeedService.On("GameUpdated", mock.Anything, gameID).
 		Return()
 
 	mockWSHub.On("BroadcastEvent", uuid.Nil, fmt.Sprintf("%s:GAME_INFO_UPDATE", gameID)).
 		Return()
 
 	// Create handlers
-	handlers := handlers.NewPlayerHandlers(mockPlayerService, mockGameService, mockGameStateService, mockWSHub)
+	handlers := handlers.NewPlayerHandlers(mockPlayerService, mockGameService, mockGameStateService, mockInviteService, mockLobbyFeedService, mockWSHub)
 
 	// Create request body
 	requestBody := map[string]interface{}{
@@ -225,7 +239,9 @@ func TestHandleJoinGamePrivate_IncorrectPassword(t *testing.T) {
 	mockPlayerService := new(player_mock.MockPlayerService)
 	mockGameService := new(game_mock.MockGameService)
 	mockGameStateService := new(gameState_mock.MockGameStateService)
+	mockInviteService := new(invite_mock.MockInviteService)
 	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
 
 	// Test data
 	gameID := uuid.New()
@@ -260,7 +276,7 @@ func TestHandleJoinGamePrivate_IncorrectPassword(t *testing.T) {
 		Return(responseGame.PlayersCount, nil)
 
 	// Create handlers
-	handlers := handlers.NewPlayerHandlers(mockPlayerService, mockGameService, mockGameStateService, mockWSHub)
+	handlers := handlers.NewPlayerHandlers(mockPlayerService, mockGameService, mockGameStateService, mockInviteService, mockLobbyFeedService, mockWSHub)
 
 	// Create request body
 	requestBody := map[string]interface{}{
@@ -286,7 +302,8 @@ func TestHandleJoinGamePrivate_IncorrectPassword(t *testing.T) {
 
 	// Verify error message
 	assert.Contains(t, response, "error")
-	assert.Equal(t, "Incorrect password", response["error"])
+	assert.Equal(t, game.ErrWrongPassword.Error(), response["error"])
+	assert.Equal(t, "WRONG_PASSWORD", response["code"])
 
 	// Verify only the necessary services were called
 	mockPlayerService.AssertExpectations(t)
@@ -303,7 +320,9 @@ func TestHandleJoinGamePrivate_NoPasswordGiven(t *testing.T) {
 	mockPlayerService := new(player_mock.MockPlayerService)
 	mockGameService := new(game_mock.MockGameService)
 	mockGameStateService := new(gameState_mock.MockGameStateService)
+	mockInviteService := new(invite_mock.MockInviteService)
 	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
 
 	// Test data
 	gameID := uuid.New()
@@ -338,7 +357,7 @@ func TestHandleJoinGamePrivate_NoPasswordGiven(t *testing.T) {
 		Return(responseGame.PlayersCount, nil)
 
 	// Create handlers
-	handlers := handlers.NewPlayerHandlers(mockPlayerService, mockGameService, mockGameStateService, mockWSHub)
+	handlers := handlers.NewPlayerHandlers(mockPlayerService, mockGameService, mockGameStateService, mockInviteService, mockLobbyFeedService, mockWSHub)
 
 	// Create request body
 	requestBody := map[string]interface{}{
@@ -363,7 +382,8 @@ func TestHandleJoinGamePrivate_NoPasswordGiven(t *testing.T) {
 
 	// Verify error message
 	assert.Contains(t, response, "error")
-	assert.Equal(t, "Password required for private games", response["error"])
+	assert.Equal(t, game.ErrPasswordRequired.Error(), response["error"])
+	assert.Equal(t, "PASSWORD_REQUIRED", response["code"])
 
 	// Verify only the necessary services were called
 	mockPlayerService.AssertExpectations(t)
@@ -379,7 +399,9 @@ func TestHandleJoinGame_InvalidGameID(t *testing.T) {
 	mockPlayerService := new(player_mock.MockPlayerService)
 	mockGameService := new(game_mock.MockGameService)
 	mockGameStateService := new(gameState_mock.MockGameStateService)
+	mockInviteService := new(invite_mock.MockInviteService)
 	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
 
 	// Test data
 	gameID := uuid.New()
@@ -393,7 +415,7 @@ func TestHandleJoinGame_InvalidGameID(t *testing.T) {
 	}
 
 	// Create handlers with mock service
-	handlers := handlers.NewPlayerHandlers(mockPlayerService, mockGameService, mockGameStateService, mockWSHub)
+	handlers := handlers.NewPlayerHandlers(mockPlayerService, mockGameService, mockGameStateService, mockInviteService, mockLobbyFeedService, mockWSHub)
 
 	// Create request body
 	requestBody := map[string]interface{}{
@@ -434,13 +456,15 @@ func TestHandleJoinGame_InvalidRequestBody(t *testing.T) {
 	mockPlayerService := new(player_mock.MockPlayerService)
 	mockGameService := new(game_mock.MockGameService)
 	mockGameStateService := new(gameState_mock.MockGameStateService)
+	mockInviteService := new(invite_mock.MockInviteService)
 	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
 
 	// Test data
 	gameID := uuid.New()
 
 	// Create handlers with mock service
-	handlers := handlers.NewPlayerHandlers(mockPlayerService, mockGameService, mockGameStateService, mockWSHub)
+	handlers := handlers.NewPlayerHandlers(mockPlayerService, mockGameService, mockGameStateService, mockInviteService, mockLobbyFeedService, mockWSHub)
 
 	// Create request body
 	reqBodyBytes := []byte(`{invalid json}`)
@@ -472,12 +496,47 @@ func TestHandleJoinGame_InvalidRequestBody(t *testing.T) {
 	mockWSHub.AssertNotCalled(t, "BroadcastEvent")
 }
 
+func TestHandleJoinGame_InvalidPlayerName(t *testing.T) {
+	// Setup mock
+	mockPlayerService := new(player_mock.MockPlayerService)
+	mockGameService := new(game_mock.MockGameService)
+	mockGameStateService := new(gameState_mock.MockGameStateService)
+	mockInviteService := new(invite_mock.MockInviteService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
+
+	// Test data
+	gameID := uuid.New()
+
+	// Create handlers with mock service
+	handlers := handlers.NewPlayerHandlers(mockPlayerService, mockGameService, mockGameStateService, mockInviteService, mockLobbyFeedService, mockWSHub)
+
+	// Create request
+	req, _ := http.NewRequest(http.MethodPost, "/games/join/", bytes.NewReader([]byte(`{"player_name": "Robert'); DROP TABLE players;--"}`)))
+	req.SetPathValue("gameID", gameID.String())
+	rr := httptest.NewRecorder()
+
+	// Call the handler
+	handlers.HandleJoinGame(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"code":"VALIDATION_FAILED"`)
+	assert.Contains(t, rr.Body.String(), `"field":"player_name"`)
+
+	// Ensure services are not called
+	mockGameService.AssertNotCalled(t, "GetGameByID")
+	mockPlayerService.AssertNotCalled(t, "CreatePlayer")
+}
+
 func TestHandleJoinGame_GameNotFound(t *testing.T) {
 	// Setup mock
 	mockPlayerService := new(player_mock.MockPlayerService)
 	mockGameService := new(game_mock.MockGameService)
 	mockGameStateService := new(gameState_mock.MockGameStateService)
+	mockInviteService := new(invite_mock.MockInviteService)
 	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
 
 	// Test data
 	gameID := uuid.New()
@@ -491,10 +550,10 @@ func TestHandleJoinGame_GameNotFound(t *testing.T) {
 	}
 
 	mockGameService.On("GetGameByID", mock.Anything, gameID).
-		Return(&game.Game{}, errors.New("game not found"))
+		Return((*game.Game)(nil), game.ErrNotFound)
 
 	// Create handlers with mock service
-	handlers := handlers.NewPlayerHandlers(mockPlayerService, mockGameService, mockGameStateService, mockWSHub)
+	handlers := handlers.NewPlayerHandlers(mockPlayerService, mockGameService, mockGameStateService, mockInviteService, mockLobbyFeedService, mockWSHub)
 
 	// Create request body
 	requestBody := map[string]interface{}{
@@ -512,15 +571,16 @@ func TestHandleJoinGame_GameNotFound(t *testing.T) {
 	handlers.HandleJoinGame(rr, req)
 
 	// Check response
-	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
 
 	var response map[string]interface{}
 	err := json.Unmarshal(rr.Body.Bytes(), &response)
 	assert.NoError(t, err)
 
 	// Verify error message
-	assert.Contains(t, response, "error")
-	assert.Equal(t, fmt.Sprintf("Couldn't get game with ID: %s", gameID), response["error"])
+	assert.Equal(t, game.ErrNotFound.Error(), response["error"])
+	assert.Equal(t, "GAME_NOT_FOUND", response["code"])
+	assert.Equal(t, float64(http.StatusNotFound), response["status"])
 
 	// Ensure services are not called
 	mockGameService.AssertExpectations(t)
@@ -535,7 +595,9 @@ func TestHandleJoinGame_NoPlayersInGame(t *testing.T) {
 	mockPlayerService := new(player_mock.MockPlayerService)
 	mockGameService := new(game_mock.MockGameService)
 	mockGameStateService := new(gameState_mock.MockGameStateService)
+	mockInviteService := new(invite_mock.MockInviteService)
 	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
 
 	// Test data
 	gameID := uuid.New()
@@ -569,7 +631,7 @@ func TestHandleJoinGame_NoPlayersInGame(t *testing.T) {
 		Return(0, errors.New("no players in game"))
 
 	// Create handlers with mock service
-	handlers := handlers.NewPlayerHandlers(mockPlayerService, mockGameService, mockGameStateService, mockWSHub)
+	handlers := handlers.NewPlayerHandlers(mockPlayerService, mockGameService, mockGameStateService, mockInviteService, mockLobbyFeedService, mockWSHub)
 
 	// Create request body
 	requestBody := map[string]interface{}{
@@ -610,7 +672,9 @@ func TestHandleJoinGame_FullGame(t *testing.T) {
 	mockPlayerService := new(player_mock.MockPlayerService)
 	mockGameService := new(game_mock.MockGameService)
 	mockGameStateService := new(gameState_mock.MockGameStateService)
+	mockInviteService := new(invite_mock.MockInviteService)
 	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
 
 	// Test data
 	gameID := uuid.New()
@@ -644,7 +708,7 @@ func TestHandleJoinGame_FullGame(t *testing.T) {
 		Return(4, nil)
 
 	// Create handlers with mock service
-	handlers := handlers.NewPlayerHandlers(mockPlayerService, mockGameService, mockGameStateService, mockWSHub)
+	handlers := handlers.NewPlayerHandlers(mockPlayerService, mockGameService, mockGameStateService, mockInviteService, mockLobbyFeedService, mockWSHub)
 
 	// Create request body
 	requestBody := map[string]interface{}{
@@ -662,7 +726,8 @@ func TestHandleJoinGame_FullGame(t *testing.T) {
 	handlers.HandleJoinGame(rr, req)
 
 	// Check response
-	assert.Equal(t, http.StatusForbidden, rr.Code)
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	assert.Equal(t, utils.PROBLEM_CONTENT_TYPE, rr.Header().Get("Content-Type"))
 
 	var response map[string]interface{}
 	err = json.Unmarshal(rr.Body.Bytes(), &response)
@@ -670,7 +735,8 @@ func TestHandleJoinGame_FullGame(t *testing.T) {
 
 	// Verify error message
 	assert.Contains(t, response, "error")
-	assert.Equal(t, "The game is full", response["error"])
+	assert.Equal(t, game.ErrGameFull.Error(), response["error"])
+	assert.Equal(t, "GAME_FULL", response["code"])
 
 	// Ensure services are not called
 	mockGameService.AssertExpectations(t)
@@ -685,7 +751,9 @@ func TestHandleJoinGame_GameStateError(t *testing.T) {
 	mockPlayerService := new(player_mock.MockPlayerService)
 	mockGameService := new(game_mock.MockGameService)
 	mockGameStateService := new(gameState_mock.MockGameStateService)
+	mockInviteService := new(invite_mock.MockInviteService)
 	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
 
 	// Test data
 	gameID := uuid.New()
@@ -722,7 +790,7 @@ func TestHandleJoinGame_GameStateError(t *testing.T) {
 		Return(&gameState.GameState{}, errors.New("game state not found"))
 
 	// Create handlers with mock service
-	handlers := handlers.NewPlayerHandlers(mockPlayerService, mockGameService, mockGameStateService, mockWSHub)
+	handlers := handlers.NewPlayerHandlers(mockPlayerService, mockGameService, mockGameStateService, mockInviteService, mockLobbyFeedService, mockWSHub)
 
 	// Create request body
 	requestBody := map[string]interface{}{
@@ -763,7 +831,9 @@ func TestHandleJoinGame_CreatePlayerError(t *testing.T) {
 	mockPlayerService := new(player_mock.MockPlayerService)
 	mockGameService := new(game_mock.MockGameService)
 	mockGameStateService := new(gameState_mock.MockGameStateService)
+	mockInviteService := new(invite_mock.MockInviteService)
 	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
 
 	// Test data
 	gameID := uuid.New()
@@ -805,11 +875,14 @@ func TestHandleJoinGame_CreatePlayerError(t *testing.T) {
 	mockGameStateService.On("GetGameStateByGameID", mock.Anything, gameID).
 		Return(&responseGameState, nil)
 
+	mockGameService.On("IsBanned", mock.Anything, gameID, requestPlayer.Name, requestPlayer.UserID).
+		Return(false, nil)
+
 	mockPlayerService.On("CreatePlayer", mock.Anything, requestPlayer).
 		Return(&player.Player{}, errors.New("Couldn't create player"))
 
 	// Create handlers with mock service
-	handlers := handlers.NewPlayerHandlers(mockPlayerService, mockGameService, mockGameStateService, mockWSHub)
+	handlers := handlers.NewPlayerHandlers(mockPlayerService, mockGameService, mockGameStateService, mockInviteService, mockLobbyFeedService, mockWSHub)
 
 	// Create request body
 	requestBody := map[string]interface{}{
@@ -844,3 +917,250 @@ func TestHandleJoinGame_CreatePlayerError(t *testing.T) {
 	mockPlayerService.AssertExpectations(t)
 	mockWSHub.AssertNotCalled(t, "BroadcastEvent")
 }
+
+func TestHandleJoinGame_LoggedInUser(t *testing.T) {
+	// Setup mock
+	mockPlayerService := new(player_mock.MockPlayerService)
+	mockGameService := new(game_mock.MockGameService)
+	mockGameStateService := new(gameState_mock.MockGameStateService)
+	mockInviteService := new(invite_mock.MockInviteService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
+
+	// Test data
+	gameID := uuid.New()
+	gameStateID := uuid.New()
+	account := user.User{ID: uuid.New(), Username: "alice"}
+
+	responseGame := game.Game{
+		ID:         gameID,
+		Name:       "Test Game",
+		MaxPlayers: 4,
+		MinPlayers: 2,
+	}
+
+	responseGameState := gameState.GameState{
+		ID:     gameStateID,
+		State:  gameState.WAITING,
+		GameID: gameID,
+	}
+
+	// The player is linked to the account and named after it
+	requestPlayer := player.Player{
+		GameID:      gameID,
+		GameStateID: gameStateID,
+		Name:        "alice",
+		UserID:      &account.ID,
+	}
+
+	// Setup expectations
+	mockGameService.On("GetGameByID", mock.Anything, gameID).
+		Return(&responseGame, nil)
+	mockPlayerService.On("CountPlayers", mock.Anything, gameID).
+		Return(1, nil)
+	mockGameStateService.On("GetGameStateByGameID", mock.Anything, gameID).
+		Return(&responseGameState, nil)
+	mockGameService.On("IsBanned", mock.Anything, gameID, requestPlayer.Name, requestPlayer.UserID).
+		Return(false, nil)
+	mockPlayerService.On("CreatePlayer", mock.Anything, requestPlayer).
+		Return(&player.Player{ID: uuid.New(), Name: "alice", GameID: gameID, UserID: &account.ID}, nil)
+	mockLobbyFeedService.On("GameUpdated", mock.Anything, gameID).
+		Return()
+	mockWSHub.On("BroadcastEvent", uuid.Nil, fmt.Sprintf("%s:GAME_INFO_UPDATE", gameID)).
+		Return()
+
+	// Create handlers
+	handlers := handlers.NewPlayerHandlers(mockPlayerService, mockGameService, mockGameStateService, mockInviteService, mockLobbyFeedService, mockWSHub)
+
+	// Create request
+	req, _ := http.NewRequest(http.MethodPost, "/players/join/"+gameID.String(), bytes.NewReader([]byte(`{}`)))
+	req.SetPathValue("gameID", gameID.String())
+	req = req.WithContext(user.NewContext(req.Context(), &account))
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandleJoinGame(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusCreated, rr.Code)
+
+	// Verify mocks are called
+	mockPlayerService.AssertExpectations(t)
+}
+
+func TestHandleJoinGame_Banned(t *testing.T) {
+	// Setup mock
+	mockPlayerService := new(player_mock.MockPlayerService)
+	mockGameService := new(game_mock.MockGameService)
+	mockGameStateService := new(gameState_mock.MockGameStateService)
+	mockInviteService := new(invite_mock.MockInviteService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
+
+	// Test data
+	gameID := uuid.New()
+	responseGame := game.Game{
+		ID:           gameID,
+		Name:         "Test Game",
+		MaxPlayers:   4,
+		MinPlayers:   2,
+		PlayersCount: 1,
+	}
+
+	// Setup expectations
+	mockGameService.On("GetGameByID", mock.Anything, gameID).
+		Return(&responseGame, nil)
+	mockPlayerService.On("CountPlayers", mock.Anything, gameID).
+		Return(1, nil)
+	mockGameStateService.On("GetGameStateByGameID", mock.Anything, gameID).
+		Return(&gameState.GameState{ID: uuid.New(), GameID: gameID}, nil)
+	mockGameService.On("IsBanned", mock.Anything, gameID, "Troll", (*uuid.UUID)(nil)).
+		Return(true, nil)
+
+	// Create handlers
+	handlers := handlers.NewPlayerHandlers(mockPlayerService, mockGameService, mockGameStateService, mockInviteService, mockLobbyFeedService, mockWSHub)
+
+	// Create request
+	req, _ := http.NewRequest(http.MethodPost, "/players/join/"+gameID.String(), bytes.NewReader([]byte(`{"player_name": "Troll"}`)))
+	req.SetPathValue("gameID", gameID.String())
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandleJoinGame(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+	assert.Contains(t, rr.Body.String(), game.ErrBanned.Error())
+
+	// Verify mocks are called
+	mockGameService.AssertExpectations(t)
+	mockPlayerService.AssertNotCalled(t, "CreatePlayer")
+	mockWSHub.AssertNotCalled(t, "BroadcastEvent")
+}
+
+func TestHandleJoinGamePrivate_Invite(t *testing.T) {
+	// Setup mock
+	mockPlayerService := new(player_mock.MockPlayerService)
+	mockGameService := new(game_mock.MockGameService)
+	mockGameStateService := new(gameState_mock.MockGameStateService)
+	mockInviteService := new(invite_mock.MockInviteService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
+
+	// Test data
+	gameID := uuid.New()
+	gameStateID := uuid.New()
+	hashedPassword, err := utils.HashPassword("password")
+	assert.NoError(t, err)
+
+	responseGame := game.Game{
+		ID:           gameID,
+		Name:         "Test Game",
+		MaxPlayers:   4,
+		MinPlayers:   2,
+		IsPrivate:    true,
+		PlayersCount: 1,
+		Password:     &hashedPassword,
+	}
+
+	requestPlayer := player.Player{
+		GameID:      gameID,
+		GameStateID: gameStateID,
+		Name:        "Test Player",
+	}
+
+	// Setup expectations
+	mockGameService.On("GetGameByID", mock.Anything, gameID).
+		Return(&responseGame, nil)
+	mockPlayerService.On("CountPlayers", mock.Anything, gameID).
+		Return(1, nil)
+	mockInviteService.On("VerifyInvite", gameID, "invite-token").
+		Return(nil)
+	mockGameStateService.On("GetGameStateByGameID", mock.Anything, gameID).
+		Return(&gameState.GameState{ID: gameStateID, GameID: gameID}, nil)
+	mockGameService.On("IsBanned", mock.Anything, gameID, requestPlayer.Name, requestPlayer.UserID).
+		Return(false, nil)
+	mockPlayerService.On("CreatePlayer", mock.Anything, requestPlayer).
+		Return(&player.Player{ID: uuid.New(), Name: requestPlayer.Name, GameID: gameID}, nil)
+	mockWSHub.On("BroadcastEvent", uuid.Nil, mock.Anything).
+		Return()
+	mockLobbyFeedService.On("GameUpdated", mock.Anything, gameID).
+		Return()
+
+	// Create handlers
+	handlers := handlers.NewPlayerHandlers(mockPlayerService, mockGameService, mockGameStateService, mockInviteService, mockLobbyFeedService, mockWSHub)
+
+	// Create request, the invite replaces the password
+	reqBodyBytes, _ := json.Marshal(map[string]interface{}{
+		"player_name": requestPlayer.Name,
+		"invite":      "invite-token",
+	})
+	req, _ := http.NewRequest(http.MethodPost, "/games/join/", bytes.NewReader(reqBodyBytes))
+	req.SetPathValue("gameID", gameID.String())
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandleJoinGame(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusCreated, rr.Code)
+
+	// Verify mocks are called
+	mockInviteService.AssertExpectations(t)
+	mockPlayerService.AssertExpectations(t)
+}
+
+func TestHandleJoinGamePrivate_InvalidInvite(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"Invalid", invite.ErrInvalidInvite},
+		{"Expired", invite.ErrExpiredInvite},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mock
+			mockPlayerService := new(player_mock.MockPlayerService)
+			mockGameService := new(game_mock.MockGameService)
+			mockGameStateService := new(gameState_mock.MockGameStateService)
+			mockInviteService := new(invite_mock.MockInviteService)
+			mockWSHub := new(websocket_mock.MockWebSocketHub)
+			mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
+
+			// Test data
+			gameID := uuid.New()
+			hashedPassword, err := utils.HashPassword("password")
+			assert.NoError(t, err)
+
+			// Setup expectations
+			mockGameService.On("GetGameByID", mock.Anything, gameID).
+				Return(&game.Game{ID: gameID, MaxPlayers: 4, IsPrivate: true, Password: &hashedPassword}, nil)
+			mockPlayerService.On("CountPlayers", mock.Anything, gameID).
+				Return(1, nil)
+			mockInviteService.On("VerifyInvite", gameID, "bad-token").
+				Return(tt.err)
+
+			// Create handlers
+			handlers := handlers.NewPlayerHandlers(mockPlayerService, mockGameService, mockGameStateService, mockInviteService, mockLobbyFeedService, mockWSHub)
+
+			// Create request
+			reqBodyBytes, _ := json.Marshal(map[string]interface{}{
+				"player_name": "Test Player",
+				"invite":      "bad-token",
+			})
+			req, _ := http.NewRequest(http.MethodPost, "/games/join/", bytes.NewReader(reqBodyBytes))
+			req.SetPathValue("gameID", gameID.String())
+			rr := httptest.NewRecorder()
+
+			// Call handler
+			handlers.HandleJoinGame(rr, req)
+
+			// Check response
+			assert.Equal(t, http.StatusForbidden, rr.Code)
+			assert.Contains(t, rr.Body.String(), tt.err.Error())
+			mockPlayerService.AssertNotCalled(t, "CreatePlayer")
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
+	"github.com/google/uuid"
+)
+
+func (h *GameStateHandlers) HandleGetLobby(w http.ResponseWriter, r *http.Request) {
+	gameID, err := uuid.Parse(r.PathValue("gameID"))
+	if err != nil {
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Couldn't parse game ID", err)
+		return
+	}
+
+	lobbyState, err := h.lobbyService.GetLobby(r.Context(), gameID)
+	if err != nil {
+		utils.RespondWithError(w, r, http.StatusInternalServerError, "Couldn't get lobby", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, lobbyState)
+}
+
+type setReadyRequest struct {
+	Ready bool `json:"ready"`
+}
+
+func (h *GameStateHandlers) HandleSetReady(w http.ResponseWriter, r *http.Request) {
+	gameID, err := uuid.Parse(r.PathValue("gameID"))
+	if err != nil {
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Couldn't parse game ID", err)
+		return
+	}
+
+	playerID, err := uuid.Parse(r.PathValue("playerID"))
+	if err != nil {
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Couldn't parse player ID", err)
+		return
+	}
+
+	var params setReadyRequest
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	lobbyState, err := h.lobbyService.SetReady(r.Context(), gameID, playerID, params.Ready)
+	if err != nil {
+		utils.RespondWithDomainError(w, r, err, "Couldn't update player")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, lobbyState)
+}
+
+// PlayerID is the host kicking the player. Ban keeps them from rejoining
+type kickPlayerRequest struct {
+	PlayerID uuid.UUID `json:"player_id"`
+	Ban      bool      `json:"ban"`
+}
+
+func (h *GameStateHandlers) HandleKickPlayer(w http.ResponseWriter, r *http.Request) {
+	gameID, err := uuid.Parse(r.PathValue("gameID"))
+	if err != nil {
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Couldn't parse game ID", err)
+		return
+	}
+
+	playerID, err := uuid.Parse(r.PathValue("playerID"))
+	if err != nil {
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Couldn't parse player ID", err)
+		return
+	}
+
+	var params kickPlayerRequest
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	if err := h.lobbyService.KickPlayer(r.Context(), gameID, params.PlayerID, playerID, params.Ban); err != nil {
+		utils.RespondWithDomainError(w, r, err, "Couldn't kick player")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, messageResponse{Message: "Player kicked successfully"})
+}
+
+func (h *GameStateHandlers) HandleLeaveGame(w http.ResponseWriter, r *http.Request) {
+	gameID, err := uuid.Parse(r.PathValue("gameID"))
+	if err != nil {
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Couldn't parse game ID", err)
+		return
+	}
+
+	playerID, err := uuid.Parse(r.PathValue("playerID"))
+	if err != nil {
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Couldn't parse player ID", err)
+		return
+	}
+
+	if err := h.lobbyService.LeaveGame(r.Context(), gameID, playerID); err != nil {
+		utils.RespondWithDomainError(w, r, err, "Couldn't leave the game")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, messageResponse{Message: "Left the game successfully"})
+}
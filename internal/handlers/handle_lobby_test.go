@@ -0,0 +1,235 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NachoGz/switcher-backend-go/internal/handlers"
+	"github.com/NachoGz/switcher-backend-go/internal/lobby"
+	lobby_mock "github.com/NachoGz/switcher-backend-go/internal/lobby/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandleSetReady_Success(t *testing.T) {
+	// Setup mock
+	mockLobbyService := new(lobby_mock.MockLobbyService)
+
+	// Test data
+	gameID := uuid.New()
+	playerID := uuid.New()
+	lobbyState := lobby.LobbyState{
+		GameID: gameID,
+		Players: []lobby.LobbyPlayer{
+			{ID: uuid.New(), Name: "Host", Host: true, Ready: true},
+			{ID: playerID, Name: "Guest", Ready: true},
+		},
+		MinPlayers: 2,
+		MaxPlayers: 4,
+		AllReady:   true,
+		CanStart:   true,
+	}
+
+	// Setup expectations
+	mockLobbyService.On("SetReady", mock.Anything, gameID, playerID, true).
+		Return(&lobbyState, nil)
+
+	// Create handlers
+	handlers := handlers.NewGameStateHandlers(mockLobbyService)
+
+	// Create request
+	req, _ := http.NewRequest(http.MethodPatch, "/players/ready", bytes.NewBufferString(`{"ready": true}`))
+	req.SetPathValue("gameID", gameID.String())
+	req.SetPathValue("playerID", playerID.String())
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandleSetReady(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var response lobby.LobbyState
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, lobbyState, response)
+
+	// Verify mocks are called
+	mockLobbyService.AssertExpectations(t)
+}
+
+func TestHandleSetReady_GameStarted(t *testing.T) {
+	// Setup mock
+	mockLobbyService := new(lobby_mock.MockLobbyService)
+
+	// Test data
+	gameID := uuid.New()
+	playerID := uuid.New()
+
+	// Setup expectations
+	mockLobbyService.On("SetReady", mock.Anything, gameID, playerID, false).
+		Return(nil, lobby.ErrGameStarted)
+
+	// Create handlers
+	handlers := handlers.NewGameStateHandlers(mockLobbyService)
+
+	// Create request
+	req, _ := http.NewRequest(http.MethodPatch, "/players/ready", bytes.NewBufferString(`{"ready": false}`))
+	req.SetPathValue("gameID", gameID.String())
+	req.SetPathValue("playerID", playerID.String())
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandleSetReady(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	assert.Contains(t, rr.Body.String(), lobby.ErrGameStarted.Error())
+}
+
+func TestHandleSetReady_InvalidPlayerID(t *testing.T) {
+	// Setup mock
+	mockLobbyService := new(lobby_mock.MockLobbyService)
+
+	// Create handlers
+	handlers := handlers.NewGameStateHandlers(mockLobbyService)
+
+	// Create request
+	req, _ := http.NewRequest(http.MethodPatch, "/players/ready", bytes.NewBufferString(`{"ready": true}`))
+	req.SetPathValue("gameID", uuid.New().String())
+	req.SetPathValue("playerID", "invalid-id")
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandleSetReady(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockLobbyService.AssertNotCalled(t, "SetReady")
+}
+
+func TestHandleKickPlayer_Success(t *testing.T) {
+	// Setup mock
+	mockLobbyService := new(lobby_mock.MockLobbyService)
+
+	// Test data
+	gameID := uuid.New()
+	hostID := uuid.New()
+	playerID := uuid.New()
+
+	// Setup expectations
+	mockLobbyService.On("KickPlayer", mock.Anything, gameID, hostID, playerID, true).
+		Return(nil)
+
+	// Create handlers
+	handlers := handlers.NewGameStateHandlers(mockLobbyService)
+
+	// Create request
+	body, _ := json.Marshal(map[string]interface{}{"player_id": hostID, "ban": true})
+	req, _ := http.NewRequest(http.MethodPost, "/games/kick", bytes.NewBuffer(body))
+	req.SetPathValue("gameID", gameID.String())
+	req.SetPathValue("playerID", playerID.String())
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandleKickPlayer(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), "Player kicked successfully")
+
+	// Verify mocks are called
+	mockLobbyService.AssertExpectations(t)
+}
+
+func TestHandleKickPlayer_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{"Not host", lobby.ErrNotHost, http.StatusForbidden},
+		{"Kicking the host", lobby.ErrKickHost, http.StatusForbidden},
+		{"Player not found", lobby.ErrPlayerNotFound, http.StatusNotFound},
+		{"Game started", lobby.ErrGameStarted, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mock
+			mockLobbyService := new(lobby_mock.MockLobbyService)
+
+			// Setup expectations
+			mockLobbyService.On("KickPlayer", mock.Anything, mock.Anything, mock.Anything, mock.Anything, false).
+				Return(tt.err)
+
+			// Create handlers
+			handlers := handlers.NewGameStateHandlers(mockLobbyService)
+
+			// Create request
+			body, _ := json.Marshal(map[string]interface{}{"player_id": uuid.New()})
+			req, _ := http.NewRequest(http.MethodPost, "/games/kick", bytes.NewBuffer(body))
+			req.SetPathValue("gameID", uuid.New().String())
+			req.SetPathValue("playerID", uuid.New().String())
+			rr := httptest.NewRecorder()
+
+			// Call handler
+			handlers.HandleKickPlayer(rr, req)
+
+			// Check response
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Contains(t, rr.Body.String(), tt.err.Error())
+		})
+	}
+}
+
+func TestHandleLeaveGame(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{"Success", nil, http.StatusOK, "Left the game successfully"},
+		{"Host leaving", lobby.ErrHostLeaving, http.StatusForbidden, lobby.ErrHostLeaving.Error()},
+		{"Game started", lobby.ErrGameStarted, http.StatusConflict, lobby.ErrGameStarted.Error()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mock
+			mockLobbyService := new(lobby_mock.MockLobbyService)
+
+			// Test data
+			gameID := uuid.New()
+			playerID := uuid.New()
+
+			// Setup expectations
+			mockLobbyService.On("LeaveGame", mock.Anything, gameID, playerID).
+				Return(tt.err)
+
+			// Create handlers
+			handlers := handlers.NewGameStateHandlers(mockLobbyService)
+
+			// Create request
+			req, _ := http.NewRequest(http.MethodPost, "/games/leave", nil)
+			req.SetPathValue("gameID", gameID.String())
+			req.SetPathValue("playerID", playerID.String())
+			rr := httptest.NewRecorder()
+
+			// Call handler
+			handlers.HandleLeaveGame(rr, req)
+
+			// Check response
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Contains(t, rr.Body.String(), tt.expectedBody)
+
+			// Verify mocks are called
+			mockLobbyService.AssertExpectations(t)
+		})
+	}
+}
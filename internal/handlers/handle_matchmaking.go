@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net"
+	"net/http"
+
+	"github.com/NachoGz/switcher-backend-go/internal/matchmaking"
+	"github.com/NachoGz/switcher-backend-go/internal/user"
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
+	"github.com/NachoGz/switcher-backend-go/internal/validation"
+	"github.com/NachoGz/switcher-backend-go/internal/websocket"
+	"github.com/google/uuid"
+)
+
+type joinQueueRequest struct {
+	PlayerName  string `json:"player_name"`
+	PlayerCount int    `json:"player_count"`
+}
+
+func (h *MatchmakingHandlers) HandleJoinQueue(w http.ResponseWriter, r *http.Request) {
+	var params joinQueueRequest
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	// Registered users are rated and default to their username
+	req := matchmaking.JoinRequest{
+		PlayerName:  params.PlayerName,
+		PlayerCount: params.PlayerCount,
+		UserID:      user.IDFromContext(r.Context()),
+		Client:      clientAddress(r),
+	}
+	if u, ok := user.FromContext(r.Context()); ok && req.PlayerName == "" {
+		req.PlayerName = u.Username
+	}
+
+	if err := validation.JoinQueue(req.PlayerName); err != nil {
+		utils.RespondWithDomainError(w, r, err, "Invalid request")
+		return
+	}
+
+	status, err := h.matchmakingService.Join(r.Context(), req)
+	if err != nil {
+		utils.RespondWithDomainError(w, r, err, "Couldn't join the queue")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusAccepted, status)
+}
+
+// clientAddress returns the host the request came from
+func clientAddress(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func (h *MatchmakingHandlers) HandleGetQueueStatus(w http.ResponseWriter, r *http.Request) {
+	ticketID, err := uuid.Parse(r.PathValue("ticketID"))
+	if err != nil {
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Couldn't parse ticket ID", err)
+		return
+	}
+
+	status, err := h.matchmakingService.GetStatus(ticketID)
+	if err != nil {
+		utils.RespondWithDomainError(w, r, err, "Couldn't get ticket status")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, status)
+}
+
+func (h *MatchmakingHandlers) HandleLeaveQueue(w http.ResponseWriter, r *http.Request) {
+	ticketID, err := uuid.Parse(r.PathValue("ticketID"))
+	if err != nil {
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Couldn't parse ticket ID", err)
+		return
+	}
+
+	if err := h.matchmakingService.Leave(r.Context(), ticketID); err != nil {
+		utils.RespondWithDomainError(w, r, err, "Couldn't leave the queue")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, messageResponse{Message: "Left the queue successfully"})
+}
+
+// HandleQueueWebSocket opens a websocket where the status of a ticket is
+// pushed until it's matched
+func (h *MatchmakingHandlers) HandleQueueWebSocket(w http.ResponseWriter, r *http.Request) {
+	ticketID, err := uuid.Parse(r.PathValue("ticketID"))
+	if err != nil {
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Couldn't parse ticket ID", err)
+		return
+	}
+
+	status, err := h.matchmakingService.GetStatus(ticketID)
+	if err != nil {
+		utils.RespondWithDomainError(w, r, err, "Couldn't get ticket status")
+		return
+	}
+
+	conn, err := websocket.NewConnection(w, r)
+	if err != nil {
+		slog.WarnContext(r.Context(), "Error upgrading to websocket", "error", err)
+		return
+	}
+
+	// The ticket gets a room of its own, apart from the games. Its client
+	// only listens
+	client := &websocket.Client{
+		Server:    h.wsHub,
+		Conn:      conn,
+		Send:      websocket.NewSendBuffer(),
+		TicketID:  ticketID,
+		Spectator: true,
+	}
+
+	h.wsHub.RegisterClient(client)
+	h.wsHub.SendToClient(client, websocket.MATCHMAKING_STATUS, status)
+
+	go client.WritePump()
+	go client.ReadPump()
+}
@@ -0,0 +1,204 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NachoGz/switcher-backend-go/internal/handlers"
+	"github.com/NachoGz/switcher-backend-go/internal/matchmaking"
+	matchmaking_mock "github.com/NachoGz/switcher-backend-go/internal/matchmaking/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/user"
+	websocket_mock "github.com/NachoGz/switcher-backend-go/internal/websocket/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandleJoinQueue_Success(t *testing.T) {
+	// Setup mocks
+	mockMatchmakingService := new(matchmaking_mock.MockMatchmakingService)
+	mockWsHub := new(websocket_mock.MockWebSocketHub)
+
+	// Test data
+	status := matchmaking.Status{
+		TicketID:    uuid.New(),
+		State:       matchmaking.QUEUED,
+		Rating:      1500,
+		PlayerCount: 2,
+		QueueSize:   1,
+		Band:        matchmaking.BASE_BAND,
+	}
+
+	// Setup expectations
+	mockMatchmakingService.On("Join", mock.Anything, matchmaking.JoinRequest{
+		PlayerName:  "Alice",
+		Client:      "203.0.113.7",
+		PlayerCount: 2,
+	}).Return(&status, nil)
+
+	// Create handlers
+	handlers := handlers.NewMatchmakingHandlers(mockMatchmakingService, mockWsHub)
+
+	// Create request
+	body, _ := json.Marshal(map[string]interface{}{"player_name": "Alice", "player_count": 2})
+	req, _ := http.NewRequest(http.MethodPost, "/matchmaking/join", bytes.NewBuffer(body))
+	req.RemoteAddr = "203.0.113.7:51234"
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandleJoinQueue(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusAccepted, rr.Code)
+
+	var response matchmaking.Status
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, status, response)
+
+	// Verify mocks are called
+	mockMatchmakingService.AssertExpectations(t)
+}
+
+func TestHandleJoinQueue_LoggedInUser(t *testing.T) {
+	// Setup mocks
+	mockMatchmakingService := new(matchmaking_mock.MockMatchmakingService)
+	mockWsHub := new(websocket_mock.MockWebSocketHub)
+
+	// Test data
+	account := user.User{ID: uuid.New(), Username: "alice"}
+
+	// Setup expectations
+	mockMatchmakingService.On("Join", mock.Anything, matchmaking.JoinRequest{
+		PlayerName: "alice",
+		UserID:     &account.ID,
+	}).Return(&matchmaking.Status{TicketID: uuid.New(), State: matchmaking.QUEUED}, nil)
+
+	// Create handlers
+	handlers := handlers.NewMatchmakingHandlers(mockMatchmakingService, mockWsHub)
+
+	// Create request
+	req, _ := http.NewRequest(http.MethodPost, "/matchmaking/join", bytes.NewBufferString(`{}`))
+	req = req.WithContext(user.NewContext(req.Context(), &account))
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandleJoinQueue(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusAccepted, rr.Code)
+
+	// Verify mocks are called
+	mockMatchmakingService.AssertExpectations(t)
+}
+
+func TestHandleJoinQueue_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{"Missing name", matchmaking.ErrMissingName, http.StatusBadRequest},
+		{"Invalid player count", matchmaking.ErrInvalidPlayerCount, http.StatusBadRequest},
+		{"Already queued", matchmaking.ErrAlreadyQueued, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mocks
+			mockMatchmakingService := new(matchmaking_mock.MockMatchmakingService)
+			mockWsHub := new(websocket_mock.MockWebSocketHub)
+
+			// Setup expectations
+			mockMatchmakingService.On("Join", mock.Anything, mock.Anything).Return(nil, tt.err)
+
+			// Create handlers
+			handlers := handlers.NewMatchmakingHandlers(mockMatchmakingService, mockWsHub)
+
+			// Create request
+			req, _ := http.NewRequest(http.MethodPost, "/matchmaking/join", bytes.NewBufferString(`{"player_count": 7}`))
+			rr := httptest.NewRecorder()
+
+			// Call handler
+			handlers.HandleJoinQueue(rr, req)
+
+			// Check response
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Contains(t, rr.Body.String(), tt.err.Error())
+		})
+	}
+}
+
+func TestHandleGetQueueStatus(t *testing.T) {
+	// Setup mocks
+	mockMatchmakingService := new(matchmaking_mock.MockMatchmakingService)
+	mockWsHub := new(websocket_mock.MockWebSocketHub)
+
+	// Test data
+	ticketID := uuid.New()
+	gameID := uuid.New()
+	playerID := uuid.New()
+	status := matchmaking.Status{
+		TicketID: ticketID,
+		State:    matchmaking.MATCHED,
+		GameID:   &gameID,
+		PlayerID: &playerID,
+	}
+
+	// Setup expectations
+	mockMatchmakingService.On("GetStatus", ticketID).Return(&status, nil)
+
+	// Create handlers
+	handlers := handlers.NewMatchmakingHandlers(mockMatchmakingService, mockWsHub)
+
+	// Create request
+	req, _ := http.NewRequest(http.MethodGet, "/matchmaking/"+ticketID.String(), nil)
+	req.SetPathValue("ticketID", ticketID.String())
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandleGetQueueStatus(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var response matchmaking.Status
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, status, response)
+
+	// Verify mocks are called
+	mockMatchmakingService.AssertExpectations(t)
+}
+
+func TestHandleLeaveQueue_NotFound(t *testing.T) {
+	// Setup mocks
+	mockMatchmakingService := new(matchmaking_mock.MockMatchmakingService)
+	mockWsHub := new(websocket_mock.MockWebSocketHub)
+
+	// Test data
+	ticketID := uuid.New()
+
+	// Setup expectations
+	mockMatchmakingService.On("Leave", mock.Anything, ticketID).Return(matchmaking.ErrTicketNotFound)
+
+	// Create handlers
+	handlers := handlers.NewMatchmakingHandlers(mockMatchmakingService, mockWsHub)
+
+	// Create request
+	req, _ := http.NewRequest(http.MethodDelete, "/matchmaking/"+ticketID.String(), nil)
+	req.SetPathValue("ticketID", ticketID.String())
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandleLeaveQueue(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	// Verify mocks are called
+	mockMatchmakingService.AssertExpectations(t)
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/NachoGz/switcher-backend-go/internal/board"
+	"github.com/NachoGz/switcher-backend-go/internal/gameEvent"
+	gameState "github.com/NachoGz/switcher-backend-go/internal/game_state"
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
+	"github.com/google/uuid"
+)
+
+type replayResponse struct {
+	GameID uuid.UUID             `json:"game_id"`
+	Steps  int                   `json:"steps"`
+	Step   int                   `json:"step"`
+	Events []gameEvent.GameEvent `json:"events"`
+	Board  [][]board.ColorEnum   `json:"board"`
+	// Seed the setup was dealt from, as a string since it doesn't fit in a
+	// JSON number. Only sent once the game has finished
+	Seed string `json:"seed,omitempty"`
+}
+
+// HandleGetReplay returns the event log of a game and the board rebuilt at
+// the requested step, or at the last one if no step is given
+func (h *ReplayHandlers) HandleGetReplay(w http.ResponseWriter, r *http.Request) {
+	gameID, err := uuid.Parse(r.PathValue("gameID"))
+	if err != nil {
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Couldn't parse game ID", err)
+		return
+	}
+
+	events, err := h.gameEventService.GetEvents(r.Context(), gameID)
+	if err != nil {
+		utils.RespondWithError(w, r, http.StatusInternalServerError, "Error getting game events", err)
+		return
+	}
+
+	replayer, err := gameEvent.NewReplayer(events)
+	if err != nil {
+		utils.RespondWithDomainError(w, r, err, "Error replaying game")
+		return
+	}
+
+	step := replayer.Steps()
+	if stepStr := r.URL.Query().Get("step"); stepStr != "" {
+		step, err = strconv.Atoi(stepStr)
+		if err != nil {
+			utils.RespondWithError(w, r, http.StatusBadRequest, "Invalid step", err)
+			return
+		}
+	}
+
+	boardAtStep, err := replayer.BoardAt(step)
+	if err != nil {
+		utils.RespondWithDomainError(w, r, err, "Error replaying game")
+		return
+	}
+
+	response := replayResponse{
+		GameID: gameID,
+		Steps:  replayer.Steps(),
+		Step:   step,
+		Events: events,
+		Board:  boardAtStep,
+	}
+
+	// The deal and the seed give away every hand and deck, so they wait
+	// until nothing is hidden
+	state, err := h.gameStateService.GetGameStateByGameID(r.Context(), gameID)
+	if err != nil {
+		utils.RespondWithDomainError(w, r, err, "Error getting game state")
+		return
+	}
+	if state.State == gameState.FINISHED {
+		finished, err := h.gameService.GetGameByID(r.Context(), gameID)
+		if err != nil {
+			utils.RespondWithDomainError(w, r, err, "Error getting game")
+			return
+		}
+		response.Seed = strconv.FormatInt(finished.Seed, 10)
+	} else {
+		response.Events, err = gameEvent.WithoutHiddenCards(events)
+		if err != nil {
+			utils.RespondWithError(w, r, http.StatusInternalServerError, "Error replaying game", err)
+			return
+		}
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
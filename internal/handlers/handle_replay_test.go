@@ -0,0 +1,197 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NachoGz/switcher-backend-go/internal/board"
+	"github.com/NachoGz/switcher-backend-go/internal/game"
+	game_mock "github.com/NachoGz/switcher-backend-go/internal/game/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/gameEvent"
+	gameEvent_mock "github.com/NachoGz/switcher-backend-go/internal/gameEvent/mocks"
+	gameState "github.com/NachoGz/switcher-backend-go/internal/game_state"
+	gameState_mock "github.com/NachoGz/switcher-backend-go/internal/game_state/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/handlers"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func replayEvents(t *testing.T, gameID uuid.UUID) []gameEvent.GameEvent {
+	t.Helper()
+
+	started, err := json.Marshal(gameEvent.GameStartedPayload{
+		Board: [][]board.ColorEnum{
+			{board.RED, board.GREEN},
+			{board.BLUE, board.YELLOW},
+		},
+	})
+	assert.NoError(t, err)
+
+	movement, err := json.Marshal(gameEvent.MovementPayload{
+		From: board.BoardPosition{PosX: 0, PosY: 0},
+		To:   board.BoardPosition{PosX: 1, PosY: 1},
+	})
+	assert.NoError(t, err)
+
+	return []gameEvent.GameEvent{
+		{ID: uuid.New(), GameID: gameID, Seq: 1, Type: gameEvent.GAME_STARTED, Payload: started},
+		{ID: uuid.New(), GameID: gameID, Seq: 2, Type: gameEvent.MOVEMENT_PLAYED, Payload: movement},
+	}
+}
+
+func TestHandleGetReplay_Success(t *testing.T) {
+	// Setup mocks
+	mockGameEventService := new(gameEvent_mock.MockGameEventService)
+	mockGameService := new(game_mock.MockGameService)
+	mockGameStateService := new(gameState_mock.MockGameStateService)
+
+	// Test data
+	gameID := uuid.New()
+
+	mockGameEventService.On("GetEvents", mock.Anything, gameID).
+		Return(replayEvents(t, gameID), nil)
+	mockGameStateService.On("GetGameStateByGameID", mock.Anything, gameID).
+		Return(&gameState.GameState{GameID: gameID, State: gameState.FINISHED}, nil)
+	mockGameService.On("GetGameByID", mock.Anything, gameID).
+		Return(&game.Game{ID: gameID, Seed: 9007199254740993}, nil)
+
+	// Create handlers
+	handlers := handlers.NewReplayHandlers(mockGameEventService, mockGameService, mockGameStateService)
+
+	// Create request
+	req, _ := http.NewRequest(http.MethodGet, "/games/"+gameID.String()+"/replay", nil)
+	req.SetPathValue("gameID", gameID.String())
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandleGetReplay(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var response struct {
+		Steps int                 `json:"steps"`
+		Step  int                 `json:"step"`
+		Board [][]board.ColorEnum `json:"board"`
+		Seed  string              `json:"seed"`
+	}
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	assert.NoError(t, err)
+
+	// Shown once the game is over, without losing precision
+	assert.Equal(t, "9007199254740993", response.Seed)
+	assert.Equal(t, 1, response.Steps)
+	assert.Equal(t, 1, response.Step)
+	assert.Equal(t, [][]board.ColorEnum{
+		{board.YELLOW, board.GREEN},
+		{board.BLUE, board.RED},
+	}, response.Board)
+
+	// Verify mocks are called
+	mockGameEventService.AssertExpectations(t)
+	mockGameStateService.AssertExpectations(t)
+	mockGameService.AssertExpectations(t)
+}
+
+func TestHandleGetReplay_Step(t *testing.T) {
+	// Setup mocks
+	mockGameEventService := new(gameEvent_mock.MockGameEventService)
+	mockGameService := new(game_mock.MockGameService)
+	mockGameStateService := new(gameState_mock.MockGameStateService)
+
+	// Test data
+	gameID := uuid.New()
+
+	mockGameEventService.On("GetEvents", mock.Anything, gameID).
+		Return(replayEvents(t, gameID), nil)
+	mockGameStateService.On("GetGameStateByGameID", mock.Anything, gameID).
+		Return(&gameState.GameState{GameID: gameID, State: gameState.PLAYING}, nil)
+
+	// Create handlers
+	handlers := handlers.NewReplayHandlers(mockGameEventService, mockGameService, mockGameStateService)
+
+	// Create request
+	req, _ := http.NewRequest(http.MethodGet, "/games/"+gameID.String()+"/replay?step=0", nil)
+	req.SetPathValue("gameID", gameID.String())
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandleGetReplay(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var response map[string]json.RawMessage
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.NotContains(t, response, "seed", "the seed is hidden while the game runs")
+
+	var step int
+	assert.NoError(t, json.Unmarshal(response["step"], &step))
+	var boardAtStep [][]board.ColorEnum
+	assert.NoError(t, json.Unmarshal(response["board"], &boardAtStep))
+
+	assert.Equal(t, 0, step)
+	assert.Equal(t, [][]board.ColorEnum{
+		{board.RED, board.GREEN},
+		{board.BLUE, board.YELLOW},
+	}, boardAtStep)
+	mockGameService.AssertNotCalled(t, "GetGameByID", mock.Anything, mock.Anything)
+}
+
+func TestHandleGetReplay_InvalidStep(t *testing.T) {
+	// Setup mocks
+	mockGameEventService := new(gameEvent_mock.MockGameEventService)
+	mockGameService := new(game_mock.MockGameService)
+	mockGameStateService := new(gameState_mock.MockGameStateService)
+
+	// Test data
+	gameID := uuid.New()
+
+	mockGameEventService.On("GetEvents", mock.Anything, gameID).
+		Return(replayEvents(t, gameID), nil)
+
+	// Create handlers
+	handlers := handlers.NewReplayHandlers(mockGameEventService, mockGameService, mockGameStateService)
+
+	// Create request
+	req, _ := http.NewRequest(http.MethodGet, "/games/"+gameID.String()+"/replay?step=5", nil)
+	req.SetPathValue("gameID", gameID.String())
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandleGetReplay(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestHandleGetReplay_NotStarted(t *testing.T) {
+	// Setup mocks
+	mockGameEventService := new(gameEvent_mock.MockGameEventService)
+	mockGameService := new(game_mock.MockGameService)
+	mockGameStateService := new(gameState_mock.MockGameStateService)
+
+	// Test data
+	gameID := uuid.New()
+
+	mockGameEventService.On("GetEvents", mock.Anything, gameID).
+		Return([]gameEvent.GameEvent{}, nil)
+
+	// Create handlers
+	handlers := handlers.NewReplayHandlers(mockGameEventService, mockGameService, mockGameStateService)
+
+	// Create request
+	req, _ := http.NewRequest(http.MethodGet, "/games/"+gameID.String()+"/replay", nil)
+	req.SetPathValue("gameID", gameID.String())
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandleGetReplay(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/NachoGz/switcher-backend-go/internal/board"
+	"github.com/NachoGz/switcher-backend-go/internal/figureCard"
+	"github.com/NachoGz/switcher-backend-go/internal/game"
+	gameState "github.com/NachoGz/switcher-backend-go/internal/game_state"
+	"github.com/NachoGz/switcher-backend-go/internal/player"
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
+	"github.com/google/uuid"
+)
+
+type spectateResponse struct {
+	Game            game.Game               `json:"game"`
+	State           gameState.State         `json:"state"`
+	CurrentPlayerID uuid.UUID               `json:"current_player_id"`
+	ForbiddenColor  string                  `json:"forbidden_color"`
+	Players         []player.Player         `json:"players"`
+	Board           *board.BoardAndBoxesOut `json:"board"`
+	FigureCards     []figureCard.FigureCard `json:"figure_cards"`
+	Spectators      int                     `json:"spectators"`
+}
+
+// HandleSpectateGame returns the public snapshot of a running game. Only the
+// figure cards that are face up are included
+func (h *SpectatorHandlers) HandleSpectateGame(w http.ResponseWriter, r *http.Request) {
+	gameID, err := uuid.Parse(r.PathValue("gameID"))
+	if err != nil {
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Couldn't parse game ID", err)
+		return
+	}
+
+	spectatedGame, err := h.gameService.GetGameByID(r.Context(), gameID)
+	if err != nil {
+		utils.RespondWithDomainError(w, r, err, "Couldn't get game")
+		return
+	}
+
+	if spectatedGame.MaxSpectators != nil && *spectatedGame.MaxSpectators == 0 {
+		utils.RespondWithError(w, r, http.StatusForbidden, "Spectating is disabled for this game", nil)
+		return
+	}
+
+	state, err := h.gameStateService.GetGameStateByGameID(r.Context(), gameID)
+	if err != nil {
+		utils.RespondWithError(w, r, http.StatusInternalServerError, "Error getting game state", err)
+		return
+	}
+
+	if state.State == gameState.WAITING {
+		utils.RespondWithError(w, r, http.StatusConflict, "The game hasn't started yet", nil)
+		return
+	}
+
+	players, err := h.playerService.GetPlayersInGame(r.Context(), gameID)
+	if err != nil {
+		utils.RespondWithError(w, r, http.StatusInternalServerError, "Error fetching players", err)
+		return
+	}
+
+	gameBoard, err := h.boardService.GetBoard(r.Context(), gameID)
+	if err != nil {
+		utils.RespondWithError(w, r, http.StatusInternalServerError, "Error fetching board", err)
+		return
+	}
+
+	figureCards, err := h.figureCardService.GetShownFigureCards(r.Context(), gameID)
+	if err != nil {
+		utils.RespondWithError(w, r, http.StatusInternalServerError, "Error fetching figure cards", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, spectateResponse{
+		Game:            *spectatedGame,
+		State:           state.State,
+		CurrentPlayerID: state.CurrentPlayerID,
+		ForbiddenColor:  state.ForbiddenColor.String,
+		Players:         players,
+		Board:           gameBoard,
+		FigureCards:     figureCards,
+		Spectators:      h.wsHub.GetSpectatorsInGame(gameID),
+	})
+}
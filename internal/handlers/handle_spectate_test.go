@@ -0,0 +1,207 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NachoGz/switcher-backend-go/internal/board"
+	board_mock "github.com/NachoGz/switcher-backend-go/internal/board/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/figureCard"
+	figureCard_mock "github.com/NachoGz/switcher-backend-go/internal/figureCard/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/game"
+	game_mock "github.com/NachoGz/switcher-backend-go/internal/game/mocks"
+	gameState "github.com/NachoGz/switcher-backend-go/internal/game_state"
+	gameState_mock "github.com/NachoGz/switcher-backend-go/internal/game_state/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/handlers"
+	"github.com/NachoGz/switcher-backend-go/internal/player"
+	player_mock "github.com/NachoGz/switcher-backend-go/internal/player/mocks"
+	websocket_mock "github.com/NachoGz/switcher-backend-go/internal/websocket/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandleSpectateGame_Success(t *testing.T) {
+	// Setup mocks
+	mockGameService := new(game_mock.MockGameService)
+	mockGameStateService := new(gameState_mock.MockGameStateService)
+	mockPlayerService := new(player_mock.MockPlayerService)
+	mockBoardService := new(board_mock.MockBoardService)
+	mockFigureCardService := new(figureCard_mock.MockFigureCardService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+
+	// Test data
+	gameID := uuid.New()
+	playerID := uuid.New()
+	passwordHash := "hash"
+
+	responseGame := game.Game{
+		ID:         gameID,
+		Name:       "Test Game",
+		MaxPlayers: 4,
+		MinPlayers: 2,
+		IsPrivate:  true,
+		Password:   &passwordHash,
+	}
+
+	responseGameState := gameState.GameState{
+		ID:              uuid.New(),
+		State:           gameState.PLAYING,
+		GameID:          gameID,
+		CurrentPlayerID: playerID,
+	}
+
+	responsePlayers := []player.Player{
+		{ID: playerID, Name: "Test Player", GameID: gameID, Turn: player.FIRST},
+	}
+
+	responseBoard := &board.BoardAndBoxesOut{
+		GameID:  gameID,
+		BoardID: uuid.New(),
+		Boxes: [][]board.BoxOut{
+			{{Color: board.RED, PosX: 0, PosY: 0}},
+		},
+		FormedFigures: [][]board.BoxOut{},
+	}
+
+	shownCards := []figureCard.FigureCard{
+		{ID: uuid.New(), Type: figureCard.FIG01, Show: true, PlayerID: playerID, GameID: gameID},
+	}
+
+	// Setup expectations
+	mockGameService.On("GetGameByID", mock.Anything, gameID).
+		Return(&responseGame, nil)
+	mockGameStateService.On("GetGameStateByGameID", mock.Anything, gameID).
+		Return(&responseGameState, nil)
+	mockPlayerService.On("GetPlayersInGame", mock.Anything, gameID).
+		Return(responsePlayers, nil)
+	mockBoardService.On("GetBoard", mock.Anything, gameID).
+		Return(responseBoard, nil)
+	mockFigureCardService.On("GetShownFigureCards", mock.Anything, gameID).
+		Return(shownCards, nil)
+	mockWSHub.On("GetSpectatorsInGame", gameID).
+		Return(2)
+
+	// Create handlers
+	handlers := handlers.NewSpectatorHandlers(mockGameService, mockGameStateService, mockPlayerService,
+		mockBoardService, mockFigureCardService, mockWSHub)
+
+	// Create request
+	req, _ := http.NewRequest(http.MethodGet, "/games/"+gameID.String()+"/spectate", nil)
+	req.SetPathValue("gameID", gameID.String())
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandleSpectateGame(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	assert.NoError(t, err)
+
+	assert.Equal(t, string(gameState.PLAYING), response["state"])
+	assert.Equal(t, playerID.String(), response["current_player_id"])
+	assert.Equal(t, float64(2), response["spectators"])
+	assert.Len(t, response["figure_cards"], 1)
+
+	// The password hash must not leak
+	responseGameJSON := response["game"].(map[string]interface{})
+	assert.Nil(t, responseGameJSON["password"])
+
+	// Verify mocks are called
+	mockGameService.AssertExpectations(t)
+	mockGameStateService.AssertExpectations(t)
+	mockPlayerService.AssertExpectations(t)
+	mockBoardService.AssertExpectations(t)
+	mockFigureCardService.AssertExpectations(t)
+	mockWSHub.AssertExpectations(t)
+}
+
+func TestHandleSpectateGame_Disabled(t *testing.T) {
+	// Setup mocks
+	mockGameService := new(game_mock.MockGameService)
+	mockGameStateService := new(gameState_mock.MockGameStateService)
+	mockPlayerService := new(player_mock.MockPlayerService)
+	mockBoardService := new(board_mock.MockBoardService)
+	mockFigureCardService := new(figureCard_mock.MockFigureCardService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+
+	// Test data
+	gameID := uuid.New()
+	maxSpectators := 0
+
+	responseGame := game.Game{
+		ID:            gameID,
+		Name:          "Test Game",
+		MaxSpectators: &maxSpectators,
+	}
+
+	mockGameService.On("GetGameByID", mock.Anything, gameID).
+		Return(&responseGame, nil)
+
+	// Create handlers
+	handlers := handlers.NewSpectatorHandlers(mockGameService, mockGameStateService, mockPlayerService,
+		mockBoardService, mockFigureCardService, mockWSHub)
+
+	// Create request
+	req, _ := http.NewRequest(http.MethodGet, "/games/"+gameID.String()+"/spectate", nil)
+	req.SetPathValue("gameID", gameID.String())
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandleSpectateGame(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "Spectating is disabled for this game", response["error"])
+
+	// Nothing else is fetched
+	mockGameStateService.AssertNotCalled(t, "GetGameStateByGameID")
+	mockBoardService.AssertNotCalled(t, "GetBoard")
+	mockFigureCardService.AssertNotCalled(t, "GetShownFigureCards")
+}
+
+func TestHandleSpectateGame_NotStarted(t *testing.T) {
+	// Setup mocks
+	mockGameService := new(game_mock.MockGameService)
+	mockGameStateService := new(gameState_mock.MockGameStateService)
+	mockPlayerService := new(player_mock.MockPlayerService)
+	mockBoardService := new(board_mock.MockBoardService)
+	mockFigureCardService := new(figureCard_mock.MockFigureCardService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+
+	// Test data
+	gameID := uuid.New()
+
+	mockGameService.On("GetGameByID", mock.Anything, gameID).
+		Return(&game.Game{ID: gameID, Name: "Test Game"}, nil)
+	mockGameStateService.On("GetGameStateByGameID", mock.Anything, gameID).
+		Return(&gameState.GameState{GameID: gameID, State: gameState.WAITING}, nil)
+
+	// Create handlers
+	handlers := handlers.NewSpectatorHandlers(mockGameService, mockGameStateService, mockPlayerService,
+		mockBoardService, mockFigureCardService, mockWSHub)
+
+	// Create request
+	req, _ := http.NewRequest(http.MethodGet, "/games/"+gameID.String()+"/spectate", nil)
+	req.SetPathValue("gameID", gameID.String())
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandleSpectateGame(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusConflict, rr.Code)
+
+	mockGameService.AssertExpectations(t)
+	mockGameStateService.AssertExpectations(t)
+	mockBoardService.AssertNotCalled(t, "GetBoard")
+}
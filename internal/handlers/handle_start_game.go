@@ -1,75 +1,44 @@
 package handlers
 
 import (
-	"context"
-	"fmt"
-	"log"
+	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 
-	gameState "github.com/NachoGz/switcher-backend-go/internal/game_state"
 	"github.com/NachoGz/switcher-backend-go/internal/utils"
 	"github.com/google/uuid"
 )
 
+// Force starts the game even if some players aren't ready
+type startGameRequest struct {
+	PlayerID uuid.UUID `json:"player_id"`
+	Force    bool      `json:"force"`
+}
+
 func (h *GameStateHandlers) HandleStartGame(w http.ResponseWriter, r *http.Request) {
-	log.Println("Starting game...")
 	gameID, err := uuid.Parse(r.PathValue("gameID"))
 	if err != nil {
-		utils.RespondWithError(w, http.StatusBadRequest, "Couldn't parse game ID", err)
-		return
-	}
-
-	// Update state to playing
-	err = h.gameStateService.UpdateGameState(context.Background(), gameID, gameState.PLAYING)
-	if err != nil {
-		utils.RespondWithError(w, http.StatusInternalServerError, "Error updating game state", err)
-		return
-	}
-
-	players, err := h.playerService.GetPlayersInGame(context.Background(), gameID)
-	if err != nil {
-		utils.RespondWithError(w, http.StatusInternalServerError, "Error fetching players", err)
-		return
-	}
-
-	// Assign random turns
-	firstPlayerID, err := h.playerService.AssignRandomTurns(context.Background(), players)
-	if err != nil {
-		utils.RespondWithError(w, http.StatusInternalServerError, "Error setting turns", err)
-		return
-	}
-
-	// Set current player
-	err = h.gameStateService.UpdateCurrentPlayer(context.Background(), gameID, firstPlayerID)
-	if err != nil {
-		utils.RespondWithError(w, http.StatusInternalServerError, "Error updating current player", err)
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Couldn't parse game ID", err)
 		return
 	}
 
-	// Create board and decks for players
-	err = h.boardService.ConfigureBoard(context.Background(), gameID)
-	if err != nil {
-		utils.RespondWithError(w, http.StatusInternalServerError, "Error configuring board", err)
+	// Clients written before the host check send no body, tell them what's missing
+	// instead of failing to decode it
+	var params startGameRequest
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil && !errors.Is(err, io.EOF) {
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Invalid request body", err)
 		return
 	}
-
-	err = h.movementCardService.CreateMovementCardDeck(context.Background(), gameID)
-	if err != nil {
-		utils.RespondWithError(w, http.StatusInternalServerError, "Error creating movement card deck", err)
+	if params.PlayerID == uuid.Nil {
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Missing player_id of the host starting the game", nil)
 		return
 	}
 
-	err = h.figureCardService.CreateFigureCardDeck(context.Background(), gameID)
-	if err != nil {
-		utils.RespondWithError(w, http.StatusInternalServerError, "Error creating figure card deck", err)
+	if err := h.lobbyService.RequestStart(r.Context(), gameID, params.PlayerID, params.Force); err != nil {
+		utils.RespondWithDomainError(w, r, err, "Error starting game")
 		return
 	}
 
-	utils.RespondWithJSON(w, http.StatusOK, map[string]interface{}{
-		"message": "Game started successfully",
-	})
-
-	h.wsHub.BroadcastEvent(uuid.Nil, "GAMES_LIST_UPDATE")
-	h.wsHub.BroadcastEvent(uuid.Nil, fmt.Sprintf("%s:GAME_STARTED", gameID))
-
+	utils.RespondWithJSON(w, http.StatusOK, messageResponse{Message: "Game started successfully"})
 }
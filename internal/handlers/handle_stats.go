@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/NachoGz/switcher-backend-go/internal/stats"
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
+)
+
+func (h *StatsHandlers) HandleGetPlayerStats(w http.ResponseWriter, r *http.Request) {
+	playerKey := r.PathValue("player")
+	if playerKey == "" {
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Missing player name or user ID", nil)
+		return
+	}
+
+	playerStats, err := h.statsService.GetPlayerStats(r.Context(), playerKey)
+	if err != nil {
+		utils.RespondWithDomainError(w, r, err, "Couldn't get player stats")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, playerStats)
+}
+
+func (h *StatsHandlers) HandleGetLeaderboard(w http.ResponseWriter, r *http.Request) {
+	limit := stats.DEFAULT_LEADERBOARD_SIZE
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		limitVal, err := strconv.Atoi(limitStr)
+		if err != nil || limitVal < 1 {
+			utils.RespondWithError(w, r, http.StatusBadRequest, "Invalid limit", err)
+			return
+		}
+		limit = limitVal
+	}
+
+	leaderboard, err := h.statsService.GetLeaderboard(r.Context(), limit)
+	if err != nil {
+		utils.RespondWithError(w, r, http.StatusInternalServerError, "Couldn't get leaderboard", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, leaderboard)
+}
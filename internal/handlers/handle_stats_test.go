@@ -0,0 +1,132 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NachoGz/switcher-backend-go/internal/handlers"
+	"github.com/NachoGz/switcher-backend-go/internal/stats"
+	stats_mock "github.com/NachoGz/switcher-backend-go/internal/stats/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandleGetPlayerStats_Success(t *testing.T) {
+	// Setup mocks
+	mockStatsService := new(stats_mock.MockStatsService)
+
+	// Test data
+	playerStats := stats.PlayerStats{
+		Player:  "alice",
+		Games:   2,
+		Wins:    1,
+		WinRate: 0.5,
+		Matches: []stats.MatchSummary{},
+	}
+
+	// Setup expectations
+	mockStatsService.On("GetPlayerStats", mock.Anything, "alice").
+		Return(&playerStats, nil)
+
+	// Create handlers
+	handlers := handlers.NewStatsHandlers(mockStatsService)
+
+	// Create request
+	req, _ := http.NewRequest(http.MethodGet, "/stats/players/alice", nil)
+	req.SetPathValue("player", "alice")
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandleGetPlayerStats(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var response stats.PlayerStats
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, playerStats, response)
+
+	// Verify mocks are called
+	mockStatsService.AssertExpectations(t)
+}
+
+func TestHandleGetPlayerStats_NotFound(t *testing.T) {
+	// Setup mocks
+	mockStatsService := new(stats_mock.MockStatsService)
+
+	// Setup expectations
+	mockStatsService.On("GetPlayerStats", mock.Anything, "nobody").
+		Return(nil, stats.ErrPlayerNotFound)
+
+	// Create handlers
+	handlers := handlers.NewStatsHandlers(mockStatsService)
+
+	// Create request
+	req, _ := http.NewRequest(http.MethodGet, "/stats/players/nobody", nil)
+	req.SetPathValue("player", "nobody")
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandleGetPlayerStats(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestHandleGetLeaderboard_Success(t *testing.T) {
+	// Setup mocks
+	mockStatsService := new(stats_mock.MockStatsService)
+
+	// Test data
+	leaderboard := []stats.LeaderboardEntry{
+		{Rank: 1, UserID: uuid.New(), Username: "alice", Games: 3, Wins: 2},
+	}
+
+	// Setup expectations
+	mockStatsService.On("GetLeaderboard", mock.Anything, 5).
+		Return(leaderboard, nil)
+
+	// Create handlers
+	handlers := handlers.NewStatsHandlers(mockStatsService)
+
+	// Create request
+	req, _ := http.NewRequest(http.MethodGet, "/stats/leaderboard?limit=5", nil)
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandleGetLeaderboard(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var response []stats.LeaderboardEntry
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, leaderboard, response)
+
+	// Verify mocks are called
+	mockStatsService.AssertExpectations(t)
+}
+
+func TestHandleGetLeaderboard_InvalidLimit(t *testing.T) {
+	// Setup mocks
+	mockStatsService := new(stats_mock.MockStatsService)
+
+	// Create handlers
+	handlers := handlers.NewStatsHandlers(mockStatsService)
+
+	// Create request
+	req, _ := http.NewRequest(http.MethodGet, "/stats/leaderboard?limit=abc", nil)
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandleGetLeaderboard(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockStatsService.AssertNotCalled(t, "GetLeaderboard")
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/NachoGz/switcher-backend-go/internal/user"
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
+	"github.com/NachoGz/switcher-backend-go/internal/validation"
+)
+
+type credentialsRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type sessionResponse struct {
+	User    user.User    `json:"user"`
+	Session user.Session `json:"session"`
+}
+
+func (h *UserHandlers) HandleRegister(w http.ResponseWriter, r *http.Request) {
+	var params credentialsRequest
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	if err := validation.Register(params.Username, params.Password); err != nil {
+		utils.RespondWithDomainError(w, r, err, "Invalid user")
+		return
+	}
+
+	u, session, err := h.userService.Register(r.Context(), params.Username, params.Password)
+	if err != nil {
+		utils.RespondWithDomainError(w, r, err, "Couldn't register user")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusCreated, sessionResponse{User: *u, Session: *session})
+}
+
+func (h *UserHandlers) HandleLogin(w http.ResponseWriter, r *http.Request) {
+	var params credentialsRequest
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	u, session, err := h.userService.Login(r.Context(), params.Username, params.Password)
+	if err != nil {
+		utils.RespondWithDomainError(w, r, err, "Couldn't log in")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, sessionResponse{User: *u, Session: *session})
+}
+
+func (h *UserHandlers) HandleLogout(w http.ResponseWriter, r *http.Request) {
+	token, ok := utils.BearerToken(r)
+	if !ok {
+		utils.RespondWithError(w, r, http.StatusUnauthorized, "Not logged in", nil)
+		return
+	}
+
+	if err := h.userService.Logout(r.Context(), token); err != nil {
+		utils.RespondWithError(w, r, http.StatusInternalServerError, "Couldn't log out", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, messageResponse{Message: "Logged out successfully"})
+}
+
+func (h *UserHandlers) HandleGetMe(w http.ResponseWriter, r *http.Request) {
+	u, ok := user.FromContext(r.Context())
+	if !ok {
+		utils.RespondWithError(w, r, http.StatusUnauthorized, "Not logged in", nil)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, u)
+}
@@ -0,0 +1,210 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NachoGz/switcher-backend-go/internal/handlers"
+	"github.com/NachoGz/switcher-backend-go/internal/user"
+	user_mock "github.com/NachoGz/switcher-backend-go/internal/user/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandleRegister_Success(t *testing.T) {
+	// Setup mocks
+	mockUserService := new(user_mock.MockUserService)
+
+	// Test data
+	account := user.User{ID: uuid.New(), Username: "alice"}
+	session := user.Session{Token: "token", ExpiresAt: time.Now().Add(time.Hour).UTC()}
+
+	// Setup expectations
+	mockUserService.On("Register", mock.Anything, "alice", "secret123").
+		Return(&account, &session, nil)
+
+	// Create handlers
+	handlers := handlers.NewUserHandlers(mockUserService)
+
+	// Create request
+	body, _ := json.Marshal(map[string]string{"username": "alice", "password": "secret123"})
+	req, _ := http.NewRequest(http.MethodPost, "/users/register", bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandleRegister(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusCreated, rr.Code)
+
+	var response struct {
+		User    user.User    `json:"user"`
+		Session user.Session `json:"session"`
+	}
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, account.ID, response.User.ID)
+	assert.Equal(t, "token", response.Session.Token)
+
+	// Verify mocks are called
+	mockUserService.AssertExpectations(t)
+}
+
+func TestHandleRegister_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"MissingCredentials", user.ErrMissingCredentials, http.StatusBadRequest},
+		{"UsernameTaken", user.ErrUsernameTaken, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mocks
+			mockUserService := new(user_mock.MockUserService)
+
+			// Setup expectations
+			mockUserService.On("Register", mock.Anything, mock.Anything, mock.Anything).
+				Return(nil, nil, tt.err)
+
+			// Create handlers
+			handlers := handlers.NewUserHandlers(mockUserService)
+
+			// Create request
+			body, _ := json.Marshal(map[string]string{"username": "alice", "password": "secret123"})
+			req, _ := http.NewRequest(http.MethodPost, "/users/register", bytes.NewBuffer(body))
+			rr := httptest.NewRecorder()
+
+			// Call handler
+			handlers.HandleRegister(rr, req)
+
+			// Check response
+			assert.Equal(t, tt.status, rr.Code)
+
+			var response map[string]interface{}
+			err := json.Unmarshal(rr.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.err.Error(), response["error"])
+		})
+	}
+}
+
+func TestHandleRegister_InvalidFields(t *testing.T) {
+	// Setup mocks
+	mockUserService := new(user_mock.MockUserService)
+
+	// Create handlers
+	handlers := handlers.NewUserHandlers(mockUserService)
+
+	// Create request
+	body, _ := json.Marshal(map[string]string{"username": strings.Repeat("a", 33), "password": "short"})
+	req, _ := http.NewRequest(http.MethodPost, "/users/register", bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandleRegister(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	var response struct {
+		Code   string `json:"code"`
+		Errors []struct {
+			Field string `json:"field"`
+			Code  string `json:"code"`
+		} `json:"errors"`
+	}
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "VALIDATION_FAILED", response.Code)
+	assert.Len(t, response.Errors, 2)
+
+	// The service is never reached
+	mockUserService.AssertNotCalled(t, "Register", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHandleLogin_InvalidCredentials(t *testing.T) {
+	// Setup mocks
+	mockUserService := new(user_mock.MockUserService)
+
+	// Setup expectations
+	mockUserService.On("Login", mock.Anything, "alice", "wrong").
+		Return(nil, nil, user.ErrInvalidCredentials)
+
+	// Create handlers
+	handlers := handlers.NewUserHandlers(mockUserService)
+
+	// Create request
+	body, _ := json.Marshal(map[string]string{"username": "alice", "password": "wrong"})
+	req, _ := http.NewRequest(http.MethodPost, "/users/login", bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandleLogin(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+
+	// Verify mocks are called
+	mockUserService.AssertExpectations(t)
+}
+
+func TestHandleLogout_Success(t *testing.T) {
+	// Setup mocks
+	mockUserService := new(user_mock.MockUserService)
+
+	// Setup expectations
+	mockUserService.On("Logout", mock.Anything, "token").
+		Return(nil)
+
+	// Create handlers
+	handlers := handlers.NewUserHandlers(mockUserService)
+
+	// Create request
+	req, _ := http.NewRequest(http.MethodPost, "/users/logout", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	rr := httptest.NewRecorder()
+
+	// Call handler
+	handlers.HandleLogout(rr, req)
+
+	// Check response
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	// Verify mocks are called
+	mockUserService.AssertExpectations(t)
+}
+
+func TestHandleGetMe(t *testing.T) {
+	// Setup mocks
+	mockUserService := new(user_mock.MockUserService)
+
+	// Create handlers
+	handlers := handlers.NewUserHandlers(mockUserService)
+
+	// Guests aren't logged in
+	req, _ := http.NewRequest(http.MethodGet, "/users/me", nil)
+	rr := httptest.NewRecorder()
+	handlers.HandleGetMe(rr, req)
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+
+	// The authenticated user comes from the request context
+	account := user.User{ID: uuid.New(), Username: "alice"}
+	req = req.WithContext(user.NewContext(req.Context(), &account))
+	rr = httptest.NewRecorder()
+	handlers.HandleGetMe(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var response user.User
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, account.ID, response.ID)
+}
@@ -1,10 +1,11 @@
 package handlers
 
 import (
-	"log"
+	"log/slog"
 	"net/http"
 
 	"github.com/NachoGz/switcher-backend-go/internal/game"
+	"github.com/NachoGz/switcher-backend-go/internal/logging"
 	"github.com/NachoGz/switcher-backend-go/internal/player"
 	"github.com/NachoGz/switcher-backend-go/internal/utils"
 	"github.com/NachoGz/switcher-backend-go/internal/websocket"
@@ -31,13 +32,13 @@ func NewWSHandlers(hub websocket.WebSocketHub, gameService game.GameService, pla
 func (h *WSHandlers) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
 	gameID, err := uuid.Parse(r.PathValue("gameID"))
 	if err != nil && gameID != uuid.Nil {
-		utils.RespondWithError(w, http.StatusBadRequest, "Invalid game ID", err)
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Invalid game ID", err)
 		return
 	}
 
 	playerID, err := uuid.Parse(r.URL.Query().Get("player_id"))
 	if err != nil && playerID != uuid.Nil {
-		utils.RespondWithError(w, http.StatusBadRequest, "Invalid player ID", err)
+		utils.RespondWithError(w, r, http.StatusBadRequest, "Invalid player ID", err)
 		return
 	}
 
@@ -45,27 +46,48 @@ func (h *WSHandlers) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
 	if playerID != uuid.Nil {
 		player, err := h.playerService.GetPlayerByID(r.Context(), playerID, gameID)
 		if err != nil || player.GameID != gameID {
-			utils.RespondWithError(w, http.StatusForbidden, "Player not in this game", err)
+			utils.RespondWithError(w, r, http.StatusForbidden, "Player not in this game", err)
 			return
 		}
 	}
 
-	log.Printf("WebSocket connection: game=%s player=%s", gameID, playerID)
+	// Connections to a game room without a player are spectators
+	spectator := gameID != uuid.Nil && playerID == uuid.Nil
+	var maxSpectators *int
+	if spectator {
+		game, err := h.gameService.GetGameByID(r.Context(), gameID)
+		if err != nil {
+			utils.RespondWithDomainError(w, r, err, "Couldn't get game")
+			return
+		}
+
+		// Rejected early when the game is already full. The hub checks again
+		// on register, where connections can't race
+		maxSpectators = game.MaxSpectators
+		if !game.SpectatingAllowed(h.hub.GetSpectatorsInGame(gameID)) {
+			utils.RespondWithError(w, r, http.StatusForbidden, "Spectating is not available for this game", nil)
+			return
+		}
+	}
+
+	slog.InfoContext(r.Context(), "Websocket connection", logging.GameID(gameID), logging.PlayerID(playerID), "spectator", spectator)
 
 	// Upgrade HTTP connection to WebSocket
 	conn, err := websocket.NewConnection(w, r)
 	if err != nil {
-		log.Printf("Error upgrading to websocket: %v", err)
+		slog.WarnContext(r.Context(), "Error upgrading to websocket", "error", err)
 		return
 	}
 
 	// Create client
 	client := &websocket.Client{
-		Server:   h.hub,
-		Conn:     conn,
-		Send:     make(chan []byte, 256),
-		GameID:   gameID,
-		PlayerID: playerID,
+		Server:        h.hub,
+		Conn:          conn,
+		Send:          websocket.NewSendBuffer(),
+		GameID:        gameID,
+		PlayerID:      playerID,
+		Spectator:     spectator,
+		MaxSpectators: maxSpectators,
 	}
 
 	// Register client
@@ -2,50 +2,55 @@ package handlers
 
 import (
 	"github.com/NachoGz/switcher-backend-go/internal/board"
+	"github.com/NachoGz/switcher-backend-go/internal/bot"
+	"github.com/NachoGz/switcher-backend-go/internal/chat"
 	"github.com/NachoGz/switcher-backend-go/internal/figureCard"
 	"github.com/NachoGz/switcher-backend-go/internal/game"
+	"github.com/NachoGz/switcher-backend-go/internal/gameEvent"
 	gameState "github.com/NachoGz/switcher-backend-go/internal/game_state"
-	"github.com/NachoGz/switcher-backend-go/internal/movementCard"
+	"github.com/NachoGz/switcher-backend-go/internal/gameplay"
+	"github.com/NachoGz/switcher-backend-go/internal/health"
+	"github.com/NachoGz/switcher-backend-go/internal/invite"
+	"github.com/NachoGz/switcher-backend-go/internal/lobby"
+	"github.com/NachoGz/switcher-backend-go/internal/lobbyFeed"
+	"github.com/NachoGz/switcher-backend-go/internal/matchmaking"
 	"github.com/NachoGz/switcher-backend-go/internal/player"
+	"github.com/NachoGz/switcher-backend-go/internal/stats"
+	"github.com/NachoGz/switcher-backend-go/internal/user"
 	"github.com/NachoGz/switcher-backend-go/internal/websocket"
 )
 
+// messageResponse is the body of the routes that only confirm an action
+type messageResponse struct {
+	Message string `json:"message"`
+}
+
 type GameStateHandlers struct {
-	gameStateService    gameState.GameStateService
-	playerService       player.PlayerService
-	boardService        board.BoardService
-	movementCardService movementCard.MovementCardService
-	figureCardService   figureCard.FigureCardService
-	wsHub               websocket.WebSocketHub
+	lobbyService lobby.LobbyService
 }
 
 // NewHandlers creates a new handlers instance
-func NewGameStateHandlers(gameStateService gameState.GameStateService, playerService player.PlayerService,
-	boardService board.BoardService, movementCardService movementCard.MovementCardService,
-	figureCardService figureCard.FigureCardService, wsHub websocket.WebSocketHub) *GameStateHandlers {
+func NewGameStateHandlers(lobbyService lobby.LobbyService) *GameStateHandlers {
 	return &GameStateHandlers{
-		gameStateService:    gameStateService,
-		playerService:       playerService,
-		boardService:        boardService,
-		movementCardService: movementCardService,
-		figureCardService:   figureCardService,
-		wsHub:               wsHub,
+		lobbyService: lobbyService,
 	}
 }
 
 // Handlers struct holds handlers with service dependency
 type GameHandlers struct {
-	gameService   game.GameService
-	playerService player.PlayerService
-	wsHub         websocket.WebSocketHub
+	gameService      game.GameService
+	playerService    player.PlayerService
+	lobbyFeedService lobbyFeed.LobbyFeedService
+	wsHub            websocket.WebSocketHub
 }
 
 // NewHandlers creates a new handlers instance
-func NewGameHandlers(gameService game.GameService, playerService player.PlayerService, wsHub websocket.WebSocketHub) *GameHandlers {
+func NewGameHandlers(gameService game.GameService, playerService player.PlayerService, lobbyFeedService lobbyFeed.LobbyFeedService, wsHub websocket.WebSocketHub) *GameHandlers {
 	return &GameHandlers{
-		gameService:   gameService,
-		playerService: playerService,
-		wsHub:         wsHub,
+		gameService:      gameService,
+		playerService:    playerService,
+		lobbyFeedService: lobbyFeedService,
+		wsHub:            wsHub,
 	}
 }
 
@@ -54,15 +59,163 @@ type PlayerHandlers struct {
 	playerService    player.PlayerService
 	gameService      game.GameService
 	gameStateService gameState.GameStateService
+	inviteService    invite.InviteService
+	lobbyFeedService lobbyFeed.LobbyFeedService
 	wsHub            websocket.WebSocketHub
 }
 
 // NewHandlers creates a new handlers instance
-func NewPlayerHandlers(playerService player.PlayerService, gameService game.GameService, gameStateService gameState.GameStateService, wsHub websocket.WebSocketHub) *PlayerHandlers {
+func NewPlayerHandlers(playerService player.PlayerService, gameService game.GameService, gameStateService gameState.GameStateService, inviteService invite.InviteService, lobbyFeedService lobbyFeed.LobbyFeedService, wsHub websocket.WebSocketHub) *PlayerHandlers {
 	return &PlayerHandlers{
 		playerService:    playerService,
 		gameService:      gameService,
 		gameStateService: gameStateService,
+		inviteService:    inviteService,
+		lobbyFeedService: lobbyFeedService,
+		wsHub:            wsHub,
+	}
+}
+
+// SpectatorHandlers holds the handlers used by spectators of running games
+type SpectatorHandlers struct {
+	gameService       game.GameService
+	gameStateService  gameState.GameStateService
+	playerService     player.PlayerService
+	boardService      board.BoardService
+	figureCardService figureCard.FigureCardService
+	wsHub             websocket.WebSocketHub
+}
+
+// NewSpectatorHandlers creates a new spectator handlers instance
+func NewSpectatorHandlers(gameService game.GameService, gameStateService gameState.GameStateService,
+	playerService player.PlayerService, boardService board.BoardService,
+	figureCardService figureCard.FigureCardService, wsHub websocket.WebSocketHub) *SpectatorHandlers {
+	return &SpectatorHandlers{
+		gameService:       gameService,
+		gameStateService:  gameStateService,
+		playerService:     playerService,
+		boardService:      boardService,
+		figureCardService: figureCardService,
+		wsHub:             wsHub,
+	}
+}
+
+// ChatHandlers holds the in-game chat handlers
+type ChatHandlers struct {
+	chatService chat.ChatService
+	wsHub       websocket.WebSocketHub
+}
+
+// NewChatHandlers creates a new chat handlers instance
+func NewChatHandlers(chatService chat.ChatService, wsHub websocket.WebSocketHub) *ChatHandlers {
+	return &ChatHandlers{
+		chatService: chatService,
+		wsHub:       wsHub,
+	}
+}
+
+// ReplayHandlers holds the handlers to replay finished or running games
+type ReplayHandlers struct {
+	gameEventService gameEvent.GameEventService
+	gameService      game.GameService
+	gameStateService gameState.GameStateService
+}
+
+// NewReplayHandlers creates a new replay handlers instance
+func NewReplayHandlers(gameEventService gameEvent.GameEventService, gameService game.GameService, gameStateService gameState.GameStateService) *ReplayHandlers {
+	return &ReplayHandlers{
+		gameEventService: gameEventService,
+		gameService:      gameService,
+		gameStateService: gameStateService,
+	}
+}
+
+// GameplayHandlers holds the handlers used by players to play their turns
+type GameplayHandlers struct {
+	gameplayService gameplay.GameplayService
+}
+
+// NewGameplayHandlers creates a new gameplay handlers instance
+func NewGameplayHandlers(gameplayService gameplay.GameplayService) *GameplayHandlers {
+	return &GameplayHandlers{
+		gameplayService: gameplayService,
+	}
+}
+
+// BotHandlers holds the handlers to add bots to games
+type BotHandlers struct {
+	botService       bot.BotService
+	lobbyFeedService lobbyFeed.LobbyFeedService
+	wsHub            websocket.WebSocketHub
+}
+
+// NewBotHandlers creates a new bot handlers instance
+func NewBotHandlers(botService bot.BotService, lobbyFeedService lobbyFeed.LobbyFeedService, wsHub websocket.WebSocketHub) *BotHandlers {
+	return &BotHandlers{
+		botService:       botService,
+		lobbyFeedService: lobbyFeedService,
 		wsHub:            wsHub,
 	}
 }
+
+// UserHandlers holds the handlers of user accounts
+type UserHandlers struct {
+	userService user.UserService
+}
+
+// NewUserHandlers creates a new user handlers instance
+func NewUserHandlers(userService user.UserService) *UserHandlers {
+	return &UserHandlers{
+		userService: userService,
+	}
+}
+
+// StatsHandlers holds the handlers of player statistics
+type StatsHandlers struct {
+	statsService stats.StatsService
+}
+
+// NewStatsHandlers creates a new stats handlers instance
+func NewStatsHandlers(statsService stats.StatsService) *StatsHandlers {
+	return &StatsHandlers{
+		statsService: statsService,
+	}
+}
+
+// MatchmakingHandlers holds the handlers of the matchmaking queue
+type MatchmakingHandlers struct {
+	matchmakingService matchmaking.MatchmakingService
+	wsHub              websocket.WebSocketHub
+}
+
+// NewMatchmakingHandlers creates a new matchmaking handlers instance
+func NewMatchmakingHandlers(matchmakingService matchmaking.MatchmakingService, wsHub websocket.WebSocketHub) *MatchmakingHandlers {
+	return &MatchmakingHandlers{
+		matchmakingService: matchmakingService,
+		wsHub:              wsHub,
+	}
+}
+
+// InviteHandlers holds the handlers of join codes and invites
+type InviteHandlers struct {
+	inviteService invite.InviteService
+}
+
+// NewInviteHandlers creates a new invite handlers instance
+func NewInviteHandlers(inviteService invite.InviteService) *InviteHandlers {
+	return &InviteHandlers{
+		inviteService: inviteService,
+	}
+}
+
+// HealthHandlers holds the handlers of the health probes
+type HealthHandlers struct {
+	healthService health.HealthService
+}
+
+// NewHealthHandlers creates a new health handlers instance
+func NewHealthHandlers(healthService health.HealthService) *HealthHandlers {
+	return &HealthHandlers{
+		healthService: healthService,
+	}
+}
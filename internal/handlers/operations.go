@@ -0,0 +1,274 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/NachoGz/switcher-backend-go/internal/chat"
+	"github.com/NachoGz/switcher-backend-go/internal/game"
+	"github.com/NachoGz/switcher-backend-go/internal/gameplay"
+	"github.com/NachoGz/switcher-backend-go/internal/health"
+	"github.com/NachoGz/switcher-backend-go/internal/invite"
+	"github.com/NachoGz/switcher-backend-go/internal/lobby"
+	"github.com/NachoGz/switcher-backend-go/internal/matchmaking"
+	"github.com/NachoGz/switcher-backend-go/internal/openapi"
+	"github.com/NachoGz/switcher-backend-go/internal/player"
+	"github.com/NachoGz/switcher-backend-go/internal/stats"
+	"github.com/NachoGz/switcher-backend-go/internal/user"
+)
+
+// Operations of the HTTP API. They are registered with their handlers in
+// main and are the source of the OpenAPI document
+var (
+	// Game routes
+	CreateGameOp = openapi.Operation{
+		Method: http.MethodPost, Path: "/games", ID: "createGame", Tag: "games",
+		Summary:   "Create a game and its host",
+		Request:   createGameRequest{},
+		Responses: openapi.Responses{http.StatusCreated: createGameResponse{}},
+	}
+	GetGamesOp = openapi.Operation{
+		Method: http.MethodGet, Path: "/games", ID: "getGames", Tag: "games",
+		Summary: "Search the games in the lobby",
+		Query: []openapi.Param{
+			{Name: "page", Type: "integer", Description: "Page to get, starting at 1"},
+			{Name: "limit", Type: "integer", Description: "Games per page, at most 50"},
+			{Name: "cursor", Type: "string", Description: "Cursor of the next page, replaces page"},
+			{Name: "name", Type: "string", Description: "Part of the game name"},
+			{Name: "sort", Type: "string", Description: "Order of the games"},
+			{Name: "num_players", Type: "integer", Description: "Players in the game"},
+			{Name: "free_slots", Type: "integer", Description: "Minimum free slots"},
+			{Name: "min_players", Type: "integer", Description: "Minimum players of the game"},
+			{Name: "max_players", Type: "integer", Description: "Maximum players of the game"},
+			{Name: "private", Type: "boolean", Description: "Only private or public games"},
+		},
+		Responses: openapi.Responses{http.StatusOK: gameListResponse{}},
+	}
+	GetGameByIDOp = openapi.Operation{
+		Method: http.MethodGet, Path: "/games/{gameID}", ID: "getGame", Tag: "games",
+		Summary:   "Get a game",
+		Responses: openapi.Responses{http.StatusOK: game.Game{}},
+	}
+	// It clashes with the GET /games/{gameID}/... routes, so it's matched
+	// before the rest
+	GetGameByCodeOp = openapi.Operation{
+		Method: http.MethodGet, Path: "/games/by-code/{code}", ID: "getGameByCode", Tag: "games",
+		Summary:   "Get a game by its join code",
+		Responses: openapi.Responses{http.StatusOK: game.Game{}},
+		Priority:  true,
+	}
+	DeleteGameOp = openapi.Operation{
+		Method: http.MethodDelete, Path: "/games/{gameID}", ID: "deleteGame", Tag: "games",
+		Summary:   "Delete a game",
+		Responses: openapi.Responses{http.StatusNoContent: nil},
+	}
+	GetWinnerOp = openapi.Operation{
+		Method: http.MethodGet, Path: "/games/{gameID}/winner", ID: "getWinner", Tag: "games",
+		Summary: "Get the winner of a game",
+		Responses: openapi.Responses{
+			http.StatusOK:       player.Player{},
+			http.StatusNotFound: messageResponse{},
+		},
+	}
+	SpectateGameOp = openapi.Operation{
+		Method: http.MethodGet, Path: "/games/{gameID}/spectate", ID: "spectateGame", Tag: "games",
+		Summary:   "Get the public snapshot of a running game",
+		Responses: openapi.Responses{http.StatusOK: spectateResponse{}},
+	}
+	SendChatMessageOp = openapi.Operation{
+		Method: http.MethodPost, Path: "/games/{gameID}/chat", ID: "sendChatMessage", Tag: "chat",
+		Summary:   "Send a chat message to a game",
+		Request:   chatMessageRequest{},
+		Responses: openapi.Responses{http.StatusCreated: chat.ChatMessage{}},
+	}
+	GetReplayOp = openapi.Operation{
+		Method: http.MethodGet, Path: "/games/{gameID}/replay", ID: "getReplay", Tag: "games",
+		Summary: "Get the events of a game and its board at a step",
+		Query: []openapi.Param{
+			{Name: "step", Type: "integer", Description: "Step to rebuild the board at, the last one by default"},
+		},
+		Responses: openapi.Responses{http.StatusOK: replayResponse{}},
+	}
+	AddBotOp = openapi.Operation{
+		Method: http.MethodPost, Path: "/games/{gameID}/bots", ID: "addBot", Tag: "games",
+		Summary:   "Add a bot to a game",
+		Request:   addBotRequest{},
+		Responses: openapi.Responses{http.StatusCreated: player.Player{}},
+	}
+
+	// Gameplay routes
+	PlayMovementOp = openapi.Operation{
+		Method: http.MethodPost, Path: "/games/{gameID}/players/{playerID}/movements", ID: "playMovement", Tag: "gameplay",
+		Summary:   "Play a movement card",
+		Request:   movementRequest{},
+		Responses: openapi.Responses{http.StatusOK: messageResponse{}},
+	}
+	PlayFigureOp = openapi.Operation{
+		Method: http.MethodPost, Path: "/games/{gameID}/players/{playerID}/figures", ID: "playFigure", Tag: "gameplay",
+		Summary:   "Complete one of the player's figures",
+		Request:   figureRequest{},
+		Responses: openapi.Responses{http.StatusOK: messageResponse{}},
+	}
+	BlockFigureOp = openapi.Operation{
+		Method: http.MethodPost, Path: "/games/{gameID}/players/{playerID}/blocks", ID: "blockFigure", Tag: "gameplay",
+		Summary:   "Block a figure of another player",
+		Request:   figureRequest{},
+		Responses: openapi.Responses{http.StatusOK: messageResponse{}},
+	}
+	EndTurnOp = openapi.Operation{
+		Method: http.MethodPost, Path: "/games/{gameID}/players/{playerID}/end_turn", ID: "endTurn", Tag: "gameplay",
+		Summary:   "End the player's turn",
+		Responses: openapi.Responses{http.StatusOK: messageResponse{}},
+	}
+	GetMovesOp = openapi.Operation{
+		Method: http.MethodGet, Path: "/games/{gameID}/players/{playerID}/moves", ID: "getMoves", Tag: "gameplay",
+		Summary:   "Get the legal moves and figures of the player",
+		Responses: openapi.Responses{http.StatusOK: gameplay.MoveHints{}},
+	}
+
+	// Game State routes
+	StartGameOp = openapi.Operation{
+		Method: http.MethodPatch, Path: "/game_state/start/{gameID}", ID: "startGame", Tag: "lobby",
+		Summary:   "Start a game as its host, named by player_id in the body",
+		Request:   startGameRequest{},
+		Responses: openapi.Responses{http.StatusOK: messageResponse{}},
+	}
+	GetLobbyOp = openapi.Operation{
+		Method: http.MethodGet, Path: "/games/{gameID}/lobby", ID: "getLobby", Tag: "lobby",
+		Summary:   "Get the lobby of a game",
+		Responses: openapi.Responses{http.StatusOK: lobby.LobbyState{}},
+	}
+	KickPlayerOp = openapi.Operation{
+		Method: http.MethodPost, Path: "/games/{gameID}/kick/{playerID}", ID: "kickPlayer", Tag: "lobby",
+		Summary:   "Kick a player from the lobby",
+		Request:   kickPlayerRequest{},
+		Responses: openapi.Responses{http.StatusOK: messageResponse{}},
+	}
+	LeaveGameOp = openapi.Operation{
+		Method: http.MethodPost, Path: "/games/{gameID}/leave/{playerID}", ID: "leaveGame", Tag: "lobby",
+		Summary:   "Leave the lobby of a game",
+		Responses: openapi.Responses{http.StatusOK: messageResponse{}},
+	}
+	SetReadyOp = openapi.Operation{
+		Method: http.MethodPatch, Path: "/players/{gameID}/{playerID}/ready", ID: "setReady", Tag: "lobby",
+		Summary:   "Mark a player as ready or not",
+		Request:   setReadyRequest{},
+		Responses: openapi.Responses{http.StatusOK: lobby.LobbyState{}},
+	}
+
+	// Invite routes
+	CreateInviteOp = openapi.Operation{
+		Method: http.MethodPost, Path: "/games/{gameID}/invites", ID: "createInvite", Tag: "lobby",
+		Summary:   "Create an invite to a private game",
+		Request:   createInviteRequest{},
+		Responses: openapi.Responses{http.StatusCreated: invite.Invite{}},
+	}
+
+	// Player routes
+	JoinGameOp = openapi.Operation{
+		Method: http.MethodPost, Path: "/players/join/{gameID}", ID: "joinGame", Tag: "players",
+		Summary:   "Join a game",
+		Request:   joinGameRequest{},
+		Responses: openapi.Responses{http.StatusCreated: joinGameResponse{}},
+	}
+	GetPlayersOp = openapi.Operation{
+		Method: http.MethodGet, Path: "/players/{gameID}", ID: "getPlayers", Tag: "players",
+		Summary:   "Get the players of a game",
+		Responses: openapi.Responses{http.StatusOK: []player.Player{}},
+	}
+	GetPlayerOp = openapi.Operation{
+		Method: http.MethodGet, Path: "/players/{gameID}/{playerID}", ID: "getPlayer", Tag: "players",
+		Summary:   "Get a player of a game",
+		Responses: openapi.Responses{http.StatusOK: player.Player{}},
+	}
+
+	// User routes
+	RegisterOp = openapi.Operation{
+		Method: http.MethodPost, Path: "/users/register", ID: "register", Tag: "users",
+		Summary:   "Create an account and log in",
+		Request:   credentialsRequest{},
+		Responses: openapi.Responses{http.StatusCreated: sessionResponse{}},
+	}
+	LoginOp = openapi.Operation{
+		Method: http.MethodPost, Path: "/users/login", ID: "login", Tag: "users",
+		Summary:   "Log in",
+		Request:   credentialsRequest{},
+		Responses: openapi.Responses{http.StatusOK: sessionResponse{}},
+	}
+	LogoutOp = openapi.Operation{
+		Method: http.MethodPost, Path: "/users/logout", ID: "logout", Tag: "users",
+		Summary:   "End the current session",
+		Responses: openapi.Responses{http.StatusOK: messageResponse{}},
+	}
+	GetMeOp = openapi.Operation{
+		Method: http.MethodGet, Path: "/users/me", ID: "getMe", Tag: "users",
+		Summary:   "Get the logged in user",
+		Responses: openapi.Responses{http.StatusOK: user.User{}},
+	}
+
+	// Stats routes
+	GetPlayerStatsOp = openapi.Operation{
+		Method: http.MethodGet, Path: "/stats/players/{player}", ID: "getPlayerStats", Tag: "stats",
+		Summary:   "Get the stats of a player name or user ID",
+		Responses: openapi.Responses{http.StatusOK: stats.PlayerStats{}},
+	}
+	GetLeaderboardOp = openapi.Operation{
+		Method: http.MethodGet, Path: "/stats/leaderboard", ID: "getLeaderboard", Tag: "stats",
+		Summary: "Get the best rated users",
+		Query: []openapi.Param{
+			{Name: "limit", Type: "integer", Description: "Size of the leaderboard"},
+		},
+		Responses: openapi.Responses{http.StatusOK: []stats.LeaderboardEntry{}},
+	}
+
+	// Matchmaking routes
+	JoinQueueOp = openapi.Operation{
+		Method: http.MethodPost, Path: "/matchmaking/join", ID: "joinQueue", Tag: "matchmaking",
+		Summary:   "Join the matchmaking queue",
+		Request:   joinQueueRequest{},
+		Responses: openapi.Responses{http.StatusAccepted: matchmaking.Status{}},
+	}
+	GetQueueStatusOp = openapi.Operation{
+		Method: http.MethodGet, Path: "/matchmaking/{ticketID}", ID: "getQueueStatus", Tag: "matchmaking",
+		Summary:   "Get the status of a matchmaking ticket",
+		Responses: openapi.Responses{http.StatusOK: matchmaking.Status{}},
+	}
+	LeaveQueueOp = openapi.Operation{
+		Method: http.MethodDelete, Path: "/matchmaking/{ticketID}", ID: "leaveQueue", Tag: "matchmaking",
+		Summary:   "Leave the matchmaking queue",
+		Responses: openapi.Responses{http.StatusOK: messageResponse{}},
+	}
+
+	// Health routes, for probes and load balancers
+	HealthzOp = openapi.Operation{
+		Method: http.MethodGet, Path: "/healthz", ID: "healthz", Tag: "health",
+		Summary:   "Check the process is alive",
+		Responses: openapi.Responses{http.StatusOK: health.Liveness{}},
+	}
+	ReadyzOp = openapi.Operation{
+		Method: http.MethodGet, Path: "/readyz", ID: "readyz", Tag: "health",
+		Summary: "Check the server can take requests",
+		Responses: openapi.Responses{
+			http.StatusOK:                 health.Report{},
+			http.StatusServiceUnavailable: health.Report{},
+		},
+	}
+	VersionOp = openapi.Operation{
+		Method: http.MethodGet, Path: "/version", ID: "version", Tag: "health",
+		Summary:   "Get the version the server was built from",
+		Responses: openapi.Responses{http.StatusOK: health.BuildInfo{}},
+	}
+)
+
+// Operations lists every operation of the API
+var Operations = []openapi.Operation{
+	CreateGameOp, GetGamesOp, GetGameByIDOp, GetGameByCodeOp, DeleteGameOp, GetWinnerOp,
+	SpectateGameOp, SendChatMessageOp, GetReplayOp, AddBotOp,
+	PlayMovementOp, PlayFigureOp, BlockFigureOp, EndTurnOp, GetMovesOp,
+	StartGameOp, GetLobbyOp, KickPlayerOp, LeaveGameOp, SetReadyOp,
+	CreateInviteOp,
+	JoinGameOp, GetPlayersOp, GetPlayerOp,
+	RegisterOp, LoginOp, LogoutOp, GetMeOp,
+	GetPlayerStatsOp, GetLeaderboardOp,
+	JoinQueueOp, GetQueueStatusOp, LeaveQueueOp,
+	HealthzOp, ReadyzOp, VersionOp,
+}
@@ -0,0 +1,60 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// DatabaseCheck pings the database
+func DatabaseCheck(db *sql.DB) Check {
+	return func(ctx context.Context) error {
+		return db.PingContext(ctx)
+	}
+}
+
+// MigrationsCheck fails until goose has applied the latest migration of
+// migrations to the database
+func MigrationsCheck(db *sql.DB, migrations fs.FS) Check {
+	return func(ctx context.Context) error {
+		latest, err := LatestMigration(migrations)
+		if err != nil {
+			return err
+		}
+
+		var applied sql.NullInt64
+		err = db.QueryRowContext(ctx,
+			"SELECT MAX(version_id) FROM goose_db_version WHERE is_applied").Scan(&applied)
+		if err != nil {
+			return fmt.Errorf("error reading applied migrations: %w", err)
+		}
+		if applied.Int64 < latest {
+			return fmt.Errorf("migration %d is not applied, database is at %d", latest, applied.Int64)
+		}
+		return nil
+	}
+}
+
+// LatestMigration is the version of the newest migration, read from the
+// number its file name starts with
+func LatestMigration(migrations fs.FS) (int64, error) {
+	files, err := fs.Glob(migrations, "*.sql")
+	if err != nil {
+		return 0, err
+	}
+
+	var latest int64
+	for _, file := range files {
+		prefix, _, _ := strings.Cut(path.Base(file), "_")
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("migration %s doesn't start with its version", file)
+		}
+		latest = max(latest, version)
+	}
+	return latest, nil
+}
@@ -0,0 +1,8 @@
+package health
+
+import "context"
+
+type HealthService interface {
+	Ready(ctx context.Context) Report
+	Version() BuildInfo
+}
@@ -0,0 +1,22 @@
+package health_mock
+
+import (
+	"context"
+
+	"github.com/NachoGz/switcher-backend-go/internal/health"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockHealthService struct {
+	mock.Mock
+}
+
+func (m *MockHealthService) Ready(ctx context.Context) health.Report {
+	args := m.Called(ctx)
+	return args.Get(0).(health.Report)
+}
+
+func (m *MockHealthService) Version() health.BuildInfo {
+	args := m.Called()
+	return args.Get(0).(health.BuildInfo)
+}
@@ -0,0 +1,42 @@
+package health
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	STATUS_OK       = "ok"
+	STATUS_FAILING  = "failing"
+	STATUS_DRAINING = "draining"
+
+	// Longest a check can take before it counts as failed
+	CHECK_TIMEOUT = 2 * time.Second
+)
+
+// Check reports whether a dependency of the server works
+type Check func(ctx context.Context) error
+
+// Report is the result of the readiness checks
+type Report struct {
+	// "ok", "failing" or "draining" once the server is shutting down
+	Status string `json:"status"`
+	// Result of each check by name, "ok" or the error
+	Checks map[string]string `json:"checks"`
+}
+
+// Liveness is the answer to a liveness probe
+type Liveness struct {
+	Status string `json:"status"`
+}
+
+// BuildInfo describes the binary being run
+type BuildInfo struct {
+	Version   string `json:"version"`
+	GoVersion string `json:"go_version"`
+	Revision  string `json:"revision,omitempty"`
+	// Time of the commit the binary was built from
+	CommitTime string `json:"commit_time,omitempty"`
+	// The binary was built with uncommitted changes
+	Modified bool `json:"modified"`
+}
@@ -0,0 +1,75 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+type Service struct {
+	checks   []namedCheck
+	draining atomic.Bool
+	build    BuildInfo
+}
+
+func NewService(build BuildInfo) *Service {
+	return &Service{build: build}
+}
+
+// Ensure Service implements HealthService
+var _ HealthService = (*Service)(nil)
+
+// AddCheck adds a check the server must pass to be ready
+func (s *Service) AddCheck(name string, check Check) {
+	s.checks = append(s.checks, namedCheck{name: name, check: check})
+}
+
+// Drain marks the server as shutting down. It stops being ready so load
+// balancers send no new requests to it
+func (s *Service) Drain() {
+	s.draining.Store(true)
+}
+
+// Ready runs every check at once, each within CHECK_TIMEOUT
+func (s *Service) Ready(ctx context.Context) Report {
+	report := Report{Status: STATUS_OK, Checks: make(map[string]string, len(s.checks))}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, c := range s.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, CHECK_TIMEOUT)
+			defer cancel()
+
+			result := STATUS_OK
+			if err := c.check(checkCtx); err != nil {
+				result = err.Error()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[c.name] = result
+			if result != STATUS_OK {
+				report.Status = STATUS_FAILING
+			}
+		}()
+	}
+	wg.Wait()
+
+	if s.draining.Load() {
+		report.Status = STATUS_DRAINING
+	}
+	return report
+}
+
+// Version describes the binary being run
+func (s *Service) Version() BuildInfo {
+	return s.build
+}
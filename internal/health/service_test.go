@@ -0,0 +1,91 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/NachoGz/switcher-backend-go/internal/health"
+	"github.com/NachoGz/switcher-backend-go/sql/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func passing(ctx context.Context) error {
+	return nil
+}
+
+func TestReady(t *testing.T) {
+	service := health.NewService(health.BuildInfo{})
+	service.AddCheck("database", passing)
+	service.AddCheck("hub", passing)
+
+	report := service.Ready(context.Background())
+	assert.Equal(t, health.STATUS_OK, report.Status)
+	assert.Equal(t, map[string]string{"database": health.STATUS_OK, "hub": health.STATUS_OK}, report.Checks)
+}
+
+func TestReady_FailingCheck(t *testing.T) {
+	service := health.NewService(health.BuildInfo{})
+	service.AddCheck("database", passing)
+	service.AddCheck("migrations", func(ctx context.Context) error {
+		return errors.New("migration 22 is not applied")
+	})
+
+	report := service.Ready(context.Background())
+	assert.Equal(t, health.STATUS_FAILING, report.Status)
+	assert.Equal(t, health.STATUS_OK, report.Checks["database"])
+	assert.Equal(t, "migration 22 is not applied", report.Checks["migrations"])
+}
+
+func TestReady_SlowCheckTimesOut(t *testing.T) {
+	service := health.NewService(health.BuildInfo{})
+	service.AddCheck("hub", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, health.STATUS_FAILING, service.Ready(ctx).Status)
+}
+
+func TestReady_Draining(t *testing.T) {
+	service := health.NewService(health.BuildInfo{})
+	service.AddCheck("database", passing)
+
+	service.Drain()
+
+	report := service.Ready(context.Background())
+	assert.Equal(t, health.STATUS_DRAINING, report.Status)
+	assert.Equal(t, health.STATUS_OK, report.Checks["database"])
+}
+
+func TestLatestMigration(t *testing.T) {
+	migrations := fstest.MapFS{
+		"001_games.sql":          {},
+		"010_game_events.sql":    {},
+		"002_game_state.sql":     {},
+		"schema.go":              {},
+		"not_a_migration.txt":    {},
+		"021_search_indexes.sql": {},
+	}
+
+	latest, err := health.LatestMigration(migrations)
+	require.NoError(t, err)
+	assert.Equal(t, int64(21), latest)
+
+	_, err = health.LatestMigration(fstest.MapFS{"games.sql": {}})
+	assert.Error(t, err)
+
+	// Every embedded migration is numbered
+	_, err = health.LatestMigration(schema.Migrations)
+	assert.NoError(t, err)
+}
+
+func TestReadBuildInfo(t *testing.T) {
+	build := health.ReadBuildInfo()
+	assert.NotEmpty(t, build.Version)
+	assert.NotEmpty(t, build.GoVersion)
+}
@@ -0,0 +1,36 @@
+package health
+
+import "runtime/debug"
+
+const UNKNOWN_VERSION = "unknown"
+
+// ReadBuildInfo reads the version and commit the binary was built from
+func ReadBuildInfo() BuildInfo {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return BuildInfo{Version: UNKNOWN_VERSION}
+	}
+	return buildInfo(info)
+}
+
+func buildInfo(info *debug.BuildInfo) BuildInfo {
+	build := BuildInfo{
+		Version:   info.Main.Version,
+		GoVersion: info.GoVersion,
+	}
+	if build.Version == "" {
+		build.Version = UNKNOWN_VERSION
+	}
+
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			build.Revision = setting.Value
+		case "vcs.time":
+			build.CommitTime = setting.Value
+		case "vcs.modified":
+			build.Modified = setting.Value == "true"
+		}
+	}
+	return build
+}
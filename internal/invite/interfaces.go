@@ -0,0 +1,15 @@
+package invite
+
+import (
+	"context"
+	"time"
+
+	"github.com/NachoGz/switcher-backend-go/internal/game"
+	"github.com/google/uuid"
+)
+
+type InviteService interface {
+	CreateInvite(ctx context.Context, gameID uuid.UUID, hostID uuid.UUID, ttl time.Duration) (*Invite, error)
+	VerifyInvite(gameID uuid.UUID, token string) error
+	GetGameByCode(ctx context.Context, code string) (*game.Game, error)
+}
@@ -0,0 +1,36 @@
+package invite_mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/NachoGz/switcher-backend-go/internal/game"
+	"github.com/NachoGz/switcher-backend-go/internal/invite"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockInviteService struct {
+	mock.Mock
+}
+
+func (m *MockInviteService) CreateInvite(ctx context.Context, gameID uuid.UUID, hostID uuid.UUID, ttl time.Duration) (*invite.Invite, error) {
+	args := m.Called(ctx, gameID, hostID, ttl)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*invite.Invite), args.Error(1)
+}
+
+func (m *MockInviteService) VerifyInvite(gameID uuid.UUID, token string) error {
+	args := m.Called(gameID, token)
+	return args.Error(0)
+}
+
+func (m *MockInviteService) GetGameByCode(ctx context.Context, code string) (*game.Game, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*game.Game), args.Error(1)
+}
@@ -0,0 +1,40 @@
+package invite
+
+import (
+	"errors"
+	"time"
+
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
+	"github.com/google/uuid"
+)
+
+const (
+	DEFAULT_INVITE_TTL = 24 * time.Hour
+	MAX_INVITE_TTL     = 7 * 24 * time.Hour
+
+	JOIN_CODE_LENGTH = 6
+	// Letters and digits that can't be mistaken for one another, no 0/O or 1/I/L
+	JOIN_CODE_ALPHABET = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+	// Attempts at finding a join code no other game uses
+	JOIN_CODE_ATTEMPTS = 5
+)
+
+var (
+	ErrNotHost       = utils.NewDomainError(utils.ErrForbidden, "NOT_HOST", "only the host can invite players")
+	ErrGameStarted   = utils.NewDomainError(utils.ErrConflict, "GAME_STARTED", "the game has already started")
+	ErrInvalidTTL    = utils.NewDomainError(utils.ErrInvalidInput, "INVALID_INVITE_TTL", "invites can last up to 7 days")
+	ErrInvalidInvite = utils.NewDomainError(utils.ErrForbidden, "INVALID_INVITE", "invalid invite")
+	ErrExpiredInvite = utils.NewDomainError(utils.ErrForbidden, "EXPIRED_INVITE", "the invite has expired")
+	ErrCodeNotFound  = utils.NewDomainError(utils.ErrNotFound, "JOIN_CODE_NOT_FOUND", "no game found with that code")
+	ErrNoFreeCode    = errors.New("couldn't find a free join code")
+)
+
+// Invite lets anyone holding it join a private game without its password
+// until it expires
+type Invite struct {
+	GameID    uuid.UUID `json:"game_id"`
+	Code      string    `json:"code"`
+	Token     string    `json:"token"`
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -0,0 +1,181 @@
+package invite
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/NachoGz/switcher-backend-go/internal/game"
+	gameState "github.com/NachoGz/switcher-backend-go/internal/game_state"
+	"github.com/NachoGz/switcher-backend-go/internal/player"
+	"github.com/google/uuid"
+)
+
+// Service hands out join codes and signed invites for games. Invites aren't
+// stored, anyone holding a valid one can join until it expires
+type Service struct {
+	gameService      game.GameService
+	gameStateService gameState.GameStateService
+	playerService    player.PlayerService
+	secret           []byte
+	// Address the invite URLs point to, usually the frontend
+	baseURL string
+	now     func() time.Time
+}
+
+// NewService creates a new invite service
+func NewService(
+	gameService game.GameService,
+	gameStateService gameState.GameStateService,
+	playerService player.PlayerService,
+	secret []byte,
+	baseURL string,
+) *Service {
+	return &Service{
+		gameService:      gameService,
+		gameStateService: gameStateService,
+		playerService:    playerService,
+		secret:           secret,
+		baseURL:          strings.TrimSuffix(baseURL, "/"),
+		now:              time.Now,
+	}
+}
+
+// Ensure Service implements InviteService
+var _ InviteService = (*Service)(nil)
+
+// CreateInvite creates an invite to a game that hasn't started, giving the
+// game a join code if it didn't have one. A zero ttl uses the default
+func (s *Service) CreateInvite(ctx context.Context, gameID uuid.UUID, hostID uuid.UUID, ttl time.Duration) (*Invite, error) {
+	if ttl == 0 {
+		ttl = DEFAULT_INVITE_TTL
+	}
+	if ttl < 0 || ttl > MAX_INVITE_TTL {
+		return nil, ErrInvalidTTL
+	}
+
+	state, err := s.gameStateService.GetGameStateByGameID(ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching game state: %w", err)
+	}
+	if state.State != gameState.WAITING {
+		return nil, ErrGameStarted
+	}
+
+	host, err := s.playerService.GetPlayerByID(ctx, hostID, gameID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotHost
+		}
+		return nil, fmt.Errorf("error fetching player: %w", err)
+	}
+	if !host.Host {
+		return nil, ErrNotHost
+	}
+
+	currentGame, err := s.gameService.GetGameByID(ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching game: %w", err)
+	}
+
+	code := ""
+	if currentGame.JoinCode != nil {
+		code = *currentGame.JoinCode
+	} else {
+		code, err = s.assignJoinCode(ctx, gameID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	expiresAt := s.now().Add(ttl).Truncate(time.Second)
+	token := sign(s.secret, gameID, expiresAt)
+
+	return &Invite{
+		GameID:    gameID,
+		Code:      code,
+		Token:     token,
+		URL:       fmt.Sprintf("%s/join/%s?invite=%s", s.baseURL, code, url.QueryEscape(token)),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// VerifyInvite checks an invite token is valid for the game
+func (s *Service) VerifyInvite(gameID uuid.UUID, token string) error {
+	return verify(s.secret, token, gameID, s.now())
+}
+
+// GetGameByCode returns the game with the given join code. Codes are not
+// case sensitive
+func (s *Service) GetGameByCode(ctx context.Context, code string) (*game.Game, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if !validCode(code) {
+		return nil, ErrCodeNotFound
+	}
+
+	foundGame, err := s.gameService.GetGameByJoinCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrCodeNotFound
+		}
+		return nil, fmt.Errorf("error fetching game: %w", err)
+	}
+	return foundGame, nil
+}
+
+// assignJoinCode gives a game a random join code no other game is using
+func (s *Service) assignJoinCode(ctx context.Context, gameID uuid.UUID) (string, error) {
+	for range JOIN_CODE_ATTEMPTS {
+		code, err := newJoinCode()
+		if err != nil {
+			return "", err
+		}
+
+		_, err = s.gameService.GetGameByJoinCode(ctx, code)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return "", fmt.Errorf("error checking join code: %w", err)
+		}
+
+		if err := s.gameService.SetJoinCode(ctx, gameID, code); err != nil {
+			return "", fmt.Errorf("error setting join code: %w", err)
+		}
+		return code, nil
+	}
+	return "", ErrNoFreeCode
+}
+
+// newJoinCode generates a random join code
+func newJoinCode() (string, error) {
+	code := make([]byte, JOIN_CODE_LENGTH)
+	size := big.NewInt(int64(len(JOIN_CODE_ALPHABET)))
+	for i := range code {
+		n, err := rand.Int(rand.Reader, size)
+		if err != nil {
+			return "", err
+		}
+		code[i] = JOIN_CODE_ALPHABET[n.Int64()]
+	}
+	return string(code), nil
+}
+
+// validCode reports whether a code could have been generated by newJoinCode
+func validCode(code string) bool {
+	if len(code) != JOIN_CODE_LENGTH {
+		return false
+	}
+	for _, c := range code {
+		if !strings.ContainsRune(JOIN_CODE_ALPHABET, c) {
+			return false
+		}
+	}
+	return true
+}
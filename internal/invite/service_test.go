@@ -0,0 +1,220 @@
+package invite_test
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NachoGz/switcher-backend-go/internal/game"
+	game_mock "github.com/NachoGz/switcher-backend-go/internal/game/mocks"
+	gameState "github.com/NachoGz/switcher-backend-go/internal/game_state"
+	gameState_mock "github.com/NachoGz/switcher-backend-go/internal/game_state/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/invite"
+	"github.com/NachoGz/switcher-backend-go/internal/player"
+	player_mock "github.com/NachoGz/switcher-backend-go/internal/player/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// expectWaitingGame sets up a game waiting for players, hosted by hostID
+func expectWaitingGame(mockGameService *game_mock.MockGameService, mockGameStateService *gameState_mock.MockGameStateService, mockPlayerService *player_mock.MockPlayerService, gameID uuid.UUID, hostID uuid.UUID, joinCode *string) {
+	mockGameStateService.On("GetGameStateByGameID", mock.Anything, gameID).
+		Return(&gameState.GameState{GameID: gameID, State: gameState.WAITING}, nil)
+	mockPlayerService.On("GetPlayerByID", mock.Anything, hostID, gameID).
+		Return(player.Player{ID: hostID, GameID: gameID, Host: true}, nil)
+	mockGameService.On("GetGameByID", mock.Anything, gameID).
+		Return(&game.Game{ID: gameID, IsPrivate: true, JoinCode: joinCode}, nil)
+}
+
+func TestCreateInvite_NewCode(t *testing.T) {
+	// Create mocks
+	mockGameService := new(game_mock.MockGameService)
+	mockGameStateService := new(gameState_mock.MockGameStateService)
+	mockPlayerService := new(player_mock.MockPlayerService)
+
+	// Create the service with all the mocks
+	service := invite.NewService(mockGameService, mockGameStateService, mockPlayerService, []byte("secret"), "https://switcher.test/")
+
+	gameID := uuid.New()
+	hostID := uuid.New()
+	expectWaitingGame(mockGameService, mockGameStateService, mockPlayerService, gameID, hostID, nil)
+	mockGameService.On("GetGameByJoinCode", mock.Anything, mock.Anything).
+		Return(nil, sql.ErrNoRows)
+	mockGameService.On("SetJoinCode", mock.Anything, gameID, mock.Anything).
+		Return(nil)
+
+	createdInvite, err := service.CreateInvite(context.Background(), gameID, hostID, 0)
+
+	assert.NoError(t, err)
+	assert.Len(t, createdInvite.Code, invite.JOIN_CODE_LENGTH)
+	for _, c := range createdInvite.Code {
+		assert.Contains(t, invite.JOIN_CODE_ALPHABET, string(c))
+	}
+	assert.True(t, strings.HasPrefix(createdInvite.URL, "https://switcher.test/join/"+createdInvite.Code+"?invite="))
+	assert.WithinDuration(t, time.Now().Add(invite.DEFAULT_INVITE_TTL), createdInvite.ExpiresAt, 2*time.Second)
+	mockGameService.AssertCalled(t, "SetJoinCode", mock.Anything, gameID, createdInvite.Code)
+
+	assert.NoError(t, service.VerifyInvite(gameID, createdInvite.Token))
+}
+
+func TestCreateInvite_KeepsCode(t *testing.T) {
+	// Create mocks
+	mockGameService := new(game_mock.MockGameService)
+	mockGameStateService := new(gameState_mock.MockGameStateService)
+	mockPlayerService := new(player_mock.MockPlayerService)
+
+	// Create the service with all the mocks
+	service := invite.NewService(mockGameService, mockGameStateService, mockPlayerService, []byte("secret"), "https://switcher.test/")
+
+	gameID := uuid.New()
+	hostID := uuid.New()
+	code := "ABC234"
+	expectWaitingGame(mockGameService, mockGameStateService, mockPlayerService, gameID, hostID, &code)
+
+	createdInvite, err := service.CreateInvite(context.Background(), gameID, hostID, time.Hour)
+
+	assert.NoError(t, err)
+	assert.Equal(t, code, createdInvite.Code)
+	mockGameService.AssertNotCalled(t, "SetJoinCode", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateInvite_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		state gameState.State
+		host  bool
+		ttl   time.Duration
+		err   error
+	}{
+		{"Not host", gameState.WAITING, false, 0, invite.ErrNotHost},
+		{"Game started", gameState.PLAYING, true, 0, invite.ErrGameStarted},
+		{"Negative ttl", gameState.WAITING, true, -time.Hour, invite.ErrInvalidTTL},
+		{"Ttl too long", gameState.WAITING, true, invite.MAX_INVITE_TTL + time.Hour, invite.ErrInvalidTTL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create mocks
+			mockGameService := new(game_mock.MockGameService)
+			mockGameStateService := new(gameState_mock.MockGameStateService)
+			mockPlayerService := new(player_mock.MockPlayerService)
+
+			// Create the service with all the mocks
+			service := invite.NewService(mockGameService, mockGameStateService, mockPlayerService, []byte("secret"), "https://switcher.test/")
+
+			gameID := uuid.New()
+			playerID := uuid.New()
+			mockGameStateService.On("GetGameStateByGameID", mock.Anything, gameID).
+				Return(&gameState.GameState{GameID: gameID, State: tt.state}, nil)
+			mockPlayerService.On("GetPlayerByID", mock.Anything, playerID, gameID).
+				Return(player.Player{ID: playerID, GameID: gameID, Host: tt.host}, nil)
+
+			_, err := service.CreateInvite(context.Background(), gameID, playerID, tt.ttl)
+
+			assert.ErrorIs(t, err, tt.err)
+			mockGameService.AssertNotCalled(t, "SetJoinCode", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestVerifyInvite(t *testing.T) {
+	// Create mocks
+	mockGameService := new(game_mock.MockGameService)
+	mockGameStateService := new(gameState_mock.MockGameStateService)
+	mockPlayerService := new(player_mock.MockPlayerService)
+
+	// Create the service with all the mocks
+	service := invite.NewService(mockGameService, mockGameStateService, mockPlayerService, []byte("secret"), "https://switcher.test/")
+
+	gameID := uuid.New()
+	hostID := uuid.New()
+	code := "ABC234"
+	expectWaitingGame(mockGameService, mockGameStateService, mockPlayerService, gameID, hostID, &code)
+
+	createdInvite, err := service.CreateInvite(context.Background(), gameID, hostID, time.Hour)
+	assert.NoError(t, err)
+
+	// Signed with another secret
+	otherService := invite.NewService(mockGameService, mockGameStateService, mockPlayerService, []byte("other secret"), "")
+
+	payload, signature, _ := strings.Cut(createdInvite.Token, ".")
+	tests := []struct {
+		name    string
+		service *invite.Service
+		gameID  uuid.UUID
+		token   string
+		err     error
+	}{
+		{"Valid", service, gameID, createdInvite.Token, nil},
+		{"Other game", service, uuid.New(), createdInvite.Token, invite.ErrInvalidInvite},
+		{"Other secret", otherService, gameID, createdInvite.Token, invite.ErrInvalidInvite},
+		{"Tampered signature", service, gameID, payload + "." + strings.ToUpper(signature), invite.ErrInvalidInvite},
+		{"Missing signature", service, gameID, payload, invite.ErrInvalidInvite},
+		{"Garbage", service, gameID, "not-a-token", invite.ErrInvalidInvite},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.service.VerifyInvite(tt.gameID, tt.token)
+			if tt.err == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestVerifyInvite_Expired(t *testing.T) {
+	// Create mocks
+	mockGameService := new(game_mock.MockGameService)
+	mockGameStateService := new(gameState_mock.MockGameStateService)
+	mockPlayerService := new(player_mock.MockPlayerService)
+
+	// Create the service with all the mocks
+	service := invite.NewService(mockGameService, mockGameStateService, mockPlayerService, []byte("secret"), "https://switcher.test/")
+
+	gameID := uuid.New()
+	hostID := uuid.New()
+	code := "ABC234"
+	expectWaitingGame(mockGameService, mockGameStateService, mockPlayerService, gameID, hostID, &code)
+
+	// Expiry times are rounded down to the second
+	createdInvite, err := service.CreateInvite(context.Background(), gameID, hostID, time.Millisecond)
+	assert.NoError(t, err)
+	time.Sleep(5 * time.Millisecond)
+
+	assert.ErrorIs(t, service.VerifyInvite(gameID, createdInvite.Token), invite.ErrExpiredInvite)
+}
+
+func TestGetGameByCode(t *testing.T) {
+	// Create mocks
+	mockGameService := new(game_mock.MockGameService)
+	mockGameStateService := new(gameState_mock.MockGameStateService)
+	mockPlayerService := new(player_mock.MockPlayerService)
+
+	// Create the service with all the mocks
+	service := invite.NewService(mockGameService, mockGameStateService, mockPlayerService, []byte("secret"), "https://switcher.test/")
+
+	gameID := uuid.New()
+	mockGameService.On("GetGameByJoinCode", mock.Anything, "ABC234").
+		Return(&game.Game{ID: gameID}, nil)
+	mockGameService.On("GetGameByJoinCode", mock.Anything, "XYZ789").
+		Return(nil, sql.ErrNoRows)
+
+	// Codes are trimmed and upper cased
+	foundGame, err := service.GetGameByCode(context.Background(), " abc234 ")
+	assert.NoError(t, err)
+	assert.Equal(t, gameID, foundGame.ID)
+
+	_, err = service.GetGameByCode(context.Background(), "XYZ789")
+	assert.ErrorIs(t, err, invite.ErrCodeNotFound)
+
+	// Codes that could never exist don't reach the database
+	_, err = service.GetGameByCode(context.Background(), "O0O0O0")
+	assert.ErrorIs(t, err, invite.ErrCodeNotFound)
+	mockGameService.AssertNumberOfCalls(t, "GetGameByJoinCode", 2)
+}
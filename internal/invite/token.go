@@ -0,0 +1,60 @@
+package invite
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// The payload of a token is the game ID followed by the expiry as a unix time
+const payloadSize = 16 + 8
+
+// sign builds an invite token for a game. Tokens are the base64 payload and
+// its HMAC-SHA256, joined by a dot
+func sign(secret []byte, gameID uuid.UUID, expiresAt time.Time) string {
+	payload := make([]byte, payloadSize)
+	copy(payload, gameID[:])
+	binary.BigEndian.PutUint64(payload[16:], uint64(expiresAt.Unix()))
+
+	encoding := base64.RawURLEncoding
+	return encoding.EncodeToString(payload) + "." + encoding.EncodeToString(mac(secret, payload))
+}
+
+// verify checks a token was signed with the secret for the given game and
+// hasn't expired at the given time
+func verify(secret []byte, token string, gameID uuid.UUID, now time.Time) error {
+	encodedPayload, encodedMAC, ok := strings.Cut(token, ".")
+	if !ok {
+		return ErrInvalidInvite
+	}
+
+	encoding := base64.RawURLEncoding
+	payload, err := encoding.DecodeString(encodedPayload)
+	if err != nil || len(payload) != payloadSize {
+		return ErrInvalidInvite
+	}
+	signature, err := encoding.DecodeString(encodedMAC)
+	if err != nil || !hmac.Equal(signature, mac(secret, payload)) {
+		return ErrInvalidInvite
+	}
+
+	if !hmac.Equal(payload[:16], gameID[:]) {
+		return ErrInvalidInvite
+	}
+	expiresAt := time.Unix(int64(binary.BigEndian.Uint64(payload[16:])), 0)
+	if !now.Before(expiresAt) {
+		return ErrExpiredInvite
+	}
+	return nil
+}
+
+func mac(secret []byte, payload []byte) []byte {
+	h := hmac.New(sha256.New, secret)
+	h.Write(payload)
+	return h.Sum(nil)
+}
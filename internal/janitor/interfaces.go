@@ -0,0 +1,15 @@
+package janitor
+
+import (
+	"context"
+
+	"github.com/NachoGz/switcher-backend-go/internal/websocket"
+	"github.com/google/uuid"
+)
+
+type JanitorService interface {
+	Run(ctx context.Context)
+	Sweep(ctx context.Context) (*SweepResult, error)
+	HandleClient(client *websocket.Client)
+	HandleGameFinished(ctx context.Context, gameID uuid.UUID, winnerID uuid.UUID)
+}
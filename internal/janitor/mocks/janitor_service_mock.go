@@ -0,0 +1,34 @@
+package janitor_mock
+
+import (
+	"context"
+
+	"github.com/NachoGz/switcher-backend-go/internal/janitor"
+	"github.com/NachoGz/switcher-backend-go/internal/websocket"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockJanitorService struct {
+	mock.Mock
+}
+
+func (m *MockJanitorService) Run(ctx context.Context) {
+	m.Called(ctx)
+}
+
+func (m *MockJanitorService) Sweep(ctx context.Context) (*janitor.SweepResult, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*janitor.SweepResult), args.Error(1)
+}
+
+func (m *MockJanitorService) HandleClient(client *websocket.Client) {
+	m.Called(client)
+}
+
+func (m *MockJanitorService) HandleGameFinished(ctx context.Context, gameID uuid.UUID, winnerID uuid.UUID) {
+	m.Called(ctx, gameID, winnerID)
+}
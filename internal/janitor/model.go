@@ -0,0 +1,64 @@
+package janitor
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	DEFAULT_INTERVAL      = time.Minute
+	DEFAULT_LOBBY_TIMEOUT = 30 * time.Minute
+	DEFAULT_MATCH_TIMEOUT = 10 * time.Minute
+	DEFAULT_RETENTION     = 7 * 24 * time.Hour
+)
+
+var (
+	ErrInvalidConfig = errors.New("janitor durations must be positive")
+)
+
+// Config sets how long games can go without activity before the janitor
+// cleans them up. Games with clients connected are never touched
+type Config struct {
+	// Time between sweeps
+	Interval time.Duration
+	// Games waiting for players are deleted after this long
+	LobbyTimeout time.Duration
+	// Games being played are finished after this long
+	MatchTimeout time.Duration
+	// Finished games are deleted after this long
+	Retention time.Duration
+}
+
+// DefaultConfig returns the thresholds used unless configured otherwise
+func DefaultConfig() Config {
+	return Config{
+		Interval:     DEFAULT_INTERVAL,
+		LobbyTimeout: DEFAULT_LOBBY_TIMEOUT,
+		MatchTimeout: DEFAULT_MATCH_TIMEOUT,
+		Retention:    DEFAULT_RETENTION,
+	}
+}
+
+// Validate checks every duration of the config is positive
+func (c Config) Validate() error {
+	for _, d := range []time.Duration{c.Interval, c.LobbyTimeout, c.MatchTimeout, c.Retention} {
+		if d <= 0 {
+			return ErrInvalidConfig
+		}
+	}
+	return nil
+}
+
+// shortestTimeout is the least time a game has to be inactive for the
+// janitor to act on it
+func (c Config) shortestTimeout() time.Duration {
+	return min(c.LobbyTimeout, c.MatchTimeout, c.Retention)
+}
+
+// SweepResult counts the games and sessions cleaned up by a sweep
+type SweepResult struct {
+	ExpiredLobbies   int `json:"expired_lobbies"`
+	AbandonedMatches int `json:"abandoned_matches"`
+	DeletedGames     int `json:"deleted_games"`
+	DeletedSessions  int `json:"deleted_sessions"`
+}
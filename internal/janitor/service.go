@@ -0,0 +1,175 @@
+package janitor
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/NachoGz/switcher-backend-go/internal/game"
+	gameState "github.com/NachoGz/switcher-backend-go/internal/game_state"
+	"github.com/NachoGz/switcher-backend-go/internal/gameplay"
+	"github.com/NachoGz/switcher-backend-go/internal/lobbyFeed"
+	"github.com/NachoGz/switcher-backend-go/internal/logging"
+	"github.com/NachoGz/switcher-backend-go/internal/user"
+	"github.com/NachoGz/switcher-backend-go/internal/websocket"
+	"github.com/google/uuid"
+)
+
+// Service periodically cleans up the games everyone walked away from:
+// lobbies nobody joins, matches nobody plays and finished games past their
+// retention. It also deletes the sessions that have expired
+type Service struct {
+	gameService      game.GameService
+	gameplayService  gameplay.GameplayService
+	lobbyFeedService lobbyFeed.LobbyFeedService
+	wsHub            websocket.WebSocketHub
+	userService      user.UserService
+	config           Config
+	now              func() time.Time
+}
+
+// NewService creates a new janitor service
+func NewService(
+	gameService game.GameService,
+	gameplayService gameplay.GameplayService,
+	lobbyFeedService lobbyFeed.LobbyFeedService,
+	wsHub websocket.WebSocketHub,
+	userService user.UserService,
+	config Config,
+	now func() time.Time,
+) *Service {
+	return &Service{
+		gameService:      gameService,
+		gameplayService:  gameplayService,
+		lobbyFeedService: lobbyFeedService,
+		wsHub:            wsHub,
+		userService:      userService,
+		config:           config,
+		now:              now,
+	}
+}
+
+// Ensure Service implements JanitorService
+var _ JanitorService = (*Service)(nil)
+
+// Run sweeps the games every interval until the context is done
+func (s *Service) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			result, err := s.Sweep(ctx)
+			if err != nil {
+				slog.ErrorContext(ctx, "Error sweeping games", "error", err)
+				continue
+			}
+			if *result != (SweepResult{}) {
+				slog.InfoContext(ctx, "Janitor cleaned up games",
+					"expired_lobbies", result.ExpiredLobbies,
+					"abandoned_matches", result.AbandonedMatches,
+					"deleted_games", result.DeletedGames,
+					"deleted_sessions", result.DeletedSessions)
+			}
+		}
+	}
+}
+
+// Sweep cleans up the games that have been inactive for too long and have
+// nobody connected. A game that can't be cleaned up is retried on the next
+// sweep
+func (s *Service) Sweep(ctx context.Context) (*SweepResult, error) {
+	now := s.now()
+	games, err := s.gameService.ListInactiveGames(ctx, now.Add(-s.config.shortestTimeout()))
+	if err != nil {
+		return nil, fmt.Errorf("error listing inactive games: %w", err)
+	}
+
+	result := &SweepResult{}
+	// Expired sessions are useless already, failing to delete them can wait
+	// for the next sweep
+	deletedSessions, err := s.userService.DeleteExpiredSessions(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error deleting expired sessions", "error", err)
+	}
+	result.DeletedSessions = deletedSessions
+
+	for _, inactive := range games {
+		if s.wsHub.GetClientsInGame(inactive.ID) > 0 {
+			continue
+		}
+
+		idle := now.Sub(inactive.LastActivityAt)
+		switch {
+		case inactive.State == gameState.WAITING && idle >= s.config.LobbyTimeout:
+			if err := s.expireLobby(ctx, inactive.ID); err != nil {
+				slog.ErrorContext(ctx, "Error expiring lobby", logging.GameID(inactive.ID), "error", err)
+				continue
+			}
+			result.ExpiredLobbies++
+
+		case inactive.State == gameState.PLAYING && idle >= s.config.MatchTimeout:
+			if err := s.abandonMatch(ctx, inactive.ID, now); err != nil {
+				slog.ErrorContext(ctx, "Error finishing abandoned game", logging.GameID(inactive.ID), "error", err)
+				continue
+			}
+			result.AbandonedMatches++
+
+		case inactive.State == gameState.FINISHED && idle >= s.config.Retention:
+			if err := s.gameService.DeleteGame(ctx, inactive.ID); err != nil {
+				slog.ErrorContext(ctx, "Error deleting finished game", logging.GameID(inactive.ID), "error", err)
+				continue
+			}
+			result.DeletedGames++
+		}
+	}
+
+	return result, nil
+}
+
+// HandleClient records a client joining or leaving a game room as activity.
+// Meant to be used as a hub register and unregister hook
+func (s *Service) HandleClient(client *websocket.Client) {
+	// The lobby room is not a game
+	if client.GameID == uuid.Nil {
+		return
+	}
+	s.touch(context.Background(), client.GameID)
+}
+
+// HandleGameFinished starts the retention of a game once it has a winner
+func (s *Service) HandleGameFinished(ctx context.Context, gameID uuid.UUID, winnerID uuid.UUID) {
+	s.touch(ctx, gameID)
+}
+
+func (s *Service) touch(ctx context.Context, gameID uuid.UUID) {
+	if err := s.gameService.TouchGame(ctx, gameID, s.now()); err != nil {
+		slog.ErrorContext(ctx, "Error recording game activity", logging.GameID(gameID), "error", err)
+	}
+}
+
+// expireLobby deletes a game nobody is waiting in and takes it off the lobby
+func (s *Service) expireLobby(ctx context.Context, gameID uuid.UUID) error {
+	expired, err := s.gameService.GetGameByID(ctx, gameID)
+	if err != nil {
+		return err
+	}
+	if err := s.gameService.DeleteGame(ctx, gameID); err != nil {
+		return err
+	}
+
+	s.lobbyFeedService.GameRemoved(*expired)
+	return nil
+}
+
+// abandonMatch finishes a game without a winner. Its retention starts now
+func (s *Service) abandonMatch(ctx context.Context, gameID uuid.UUID, now time.Time) error {
+	if err := s.gameplayService.AbandonGame(ctx, gameID); err != nil {
+		return err
+	}
+	return s.gameService.TouchGame(ctx, gameID, now)
+}
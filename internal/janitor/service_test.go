@@ -0,0 +1,322 @@
+package janitor_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NachoGz/switcher-backend-go/internal/game"
+	game_mock "github.com/NachoGz/switcher-backend-go/internal/game/mocks"
+	gameState "github.com/NachoGz/switcher-backend-go/internal/game_state"
+	gameplay_mock "github.com/NachoGz/switcher-backend-go/internal/gameplay/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/janitor"
+	lobbyFeed_mock "github.com/NachoGz/switcher-backend-go/internal/lobbyFeed/mocks"
+	user_mock "github.com/NachoGz/switcher-backend-go/internal/user/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/websocket"
+	websocket_mock "github.com/NachoGz/switcher-backend-go/internal/websocket/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// fakeClock is a clock that only moves when told to
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+var testConfig = janitor.Config{
+	Interval:     time.Minute,
+	LobbyTimeout: 30 * time.Minute,
+	MatchTimeout: 10 * time.Minute,
+	Retention:    24 * time.Hour,
+}
+
+// expectInactive makes the given games the ones inactive since the shortest
+// timeout at the current time of the clock
+func expectInactive(mockGameService *game_mock.MockGameService, clock *fakeClock, games []game.InactiveGame) {
+	mockGameService.On("ListInactiveGames", mock.Anything, clock.Now().Add(-testConfig.MatchTimeout)).
+		Return(games, nil).Once()
+}
+
+func TestSweep_ExpiresLobby(t *testing.T) {
+	// Create mocks
+	mockGameService := new(game_mock.MockGameService)
+	mockGameplayService := new(gameplay_mock.MockGameplayService)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockUserService := new(user_mock.MockUserService)
+	clock := &fakeClock{now: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+
+	// Create the service with all the mocks
+	service := janitor.NewService(mockGameService, mockGameplayService, mockLobbyFeedService, mockWSHub, mockUserService, testConfig, clock.Now)
+	mockUserService.On("DeleteExpiredSessions", mock.Anything).Return(0, nil)
+
+	// Test data
+	lobby := game.Game{ID: uuid.New(), Name: "Empty lobby", PlayersCount: 1}
+	lastActivity := clock.Now().Add(-20 * time.Minute)
+	inactive := []game.InactiveGame{{ID: lobby.ID, State: gameState.WAITING, LastActivityAt: lastActivity}}
+
+	// Setup expectations
+	mockWSHub.On("GetClientsInGame", lobby.ID).Return(0)
+	mockGameService.On("GetGameByID", mock.Anything, lobby.ID).Return(&lobby, nil)
+	mockGameService.On("DeleteGame", mock.Anything, lobby.ID).Return(nil)
+	mockLobbyFeedService.On("GameRemoved", lobby).Return()
+
+	// Not inactive for long enough yet
+	expectInactive(mockGameService, clock, inactive)
+	result, err := service.Sweep(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, janitor.SweepResult{}, *result)
+	mockGameService.AssertNotCalled(t, "DeleteGame", mock.Anything, mock.Anything)
+
+	// Past the lobby timeout
+	clock.Advance(15 * time.Minute)
+	expectInactive(mockGameService, clock, inactive)
+	result, err = service.Sweep(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, janitor.SweepResult{ExpiredLobbies: 1}, *result)
+
+	// Verify mocks are called
+	mockGameService.AssertExpectations(t)
+	mockLobbyFeedService.AssertExpectations(t)
+}
+
+func TestSweep_AbandonsMatch(t *testing.T) {
+	// Create mocks
+	mockGameService := new(game_mock.MockGameService)
+	mockGameplayService := new(gameplay_mock.MockGameplayService)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockUserService := new(user_mock.MockUserService)
+	clock := &fakeClock{now: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+
+	// Create the service with all the mocks
+	service := janitor.NewService(mockGameService, mockGameplayService, mockLobbyFeedService, mockWSHub, mockUserService, testConfig, clock.Now)
+	mockUserService.On("DeleteExpiredSessions", mock.Anything).Return(0, nil)
+
+	// Test data
+	gameID := uuid.New()
+	inactive := []game.InactiveGame{{ID: gameID, State: gameState.PLAYING, LastActivityAt: clock.Now().Add(-time.Hour)}}
+
+	// Setup expectations
+	expectInactive(mockGameService, clock, inactive)
+	mockWSHub.On("GetClientsInGame", gameID).Return(0)
+	mockGameplayService.On("AbandonGame", mock.Anything, gameID).Return(nil)
+	mockGameService.On("TouchGame", mock.Anything, gameID, clock.Now()).Return(nil)
+
+	// Call service
+	result, err := service.Sweep(context.Background())
+
+	// Check results
+	assert.NoError(t, err)
+	assert.Equal(t, janitor.SweepResult{AbandonedMatches: 1}, *result)
+
+	// Verify mocks are called
+	mockGameService.AssertExpectations(t)
+	mockGameplayService.AssertExpectations(t)
+	mockGameService.AssertNotCalled(t, "DeleteGame", mock.Anything, mock.Anything)
+}
+
+func TestSweep_DeletesFinishedGames(t *testing.T) {
+	// Create mocks
+	mockGameService := new(game_mock.MockGameService)
+	mockGameplayService := new(gameplay_mock.MockGameplayService)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockUserService := new(user_mock.MockUserService)
+	clock := &fakeClock{now: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+
+	// Create the service with all the mocks
+	service := janitor.NewService(mockGameService, mockGameplayService, mockLobbyFeedService, mockWSHub, mockUserService, testConfig, clock.Now)
+	mockUserService.On("DeleteExpiredSessions", mock.Anything).Return(0, nil)
+
+	// Test data
+	oldGameID := uuid.New()
+	recentGameID := uuid.New()
+	inactive := []game.InactiveGame{
+		{ID: oldGameID, State: gameState.FINISHED, LastActivityAt: clock.Now().Add(-48 * time.Hour)},
+		{ID: recentGameID, State: gameState.FINISHED, LastActivityAt: clock.Now().Add(-time.Hour)},
+	}
+
+	// Setup expectations
+	expectInactive(mockGameService, clock, inactive)
+	mockWSHub.On("GetClientsInGame", mock.Anything).Return(0)
+	mockGameService.On("DeleteGame", mock.Anything, oldGameID).Return(nil)
+
+	// Call service
+	result, err := service.Sweep(context.Background())
+
+	// Check results
+	assert.NoError(t, err)
+	assert.Equal(t, janitor.SweepResult{DeletedGames: 1}, *result)
+
+	// Verify mocks are called
+	mockGameService.AssertExpectations(t)
+	mockGameService.AssertNotCalled(t, "DeleteGame", mock.Anything, recentGameID)
+	mockLobbyFeedService.AssertNotCalled(t, "GameRemoved", mock.Anything)
+}
+
+func TestSweep_SkipsConnectedGames(t *testing.T) {
+	// Create mocks
+	mockGameService := new(game_mock.MockGameService)
+	mockGameplayService := new(gameplay_mock.MockGameplayService)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockUserService := new(user_mock.MockUserService)
+	clock := &fakeClock{now: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+
+	// Create the service with all the mocks
+	service := janitor.NewService(mockGameService, mockGameplayService, mockLobbyFeedService, mockWSHub, mockUserService, testConfig, clock.Now)
+	mockUserService.On("DeleteExpiredSessions", mock.Anything).Return(0, nil)
+
+	// Test data, every game is past its threshold but has someone connected
+	inactive := []game.InactiveGame{
+		{ID: uuid.New(), State: gameState.WAITING, LastActivityAt: clock.Now().Add(-48 * time.Hour)},
+		{ID: uuid.New(), State: gameState.PLAYING, LastActivityAt: clock.Now().Add(-48 * time.Hour)},
+		{ID: uuid.New(), State: gameState.FINISHED, LastActivityAt: clock.Now().Add(-48 * time.Hour)},
+	}
+
+	// Setup expectations
+	expectInactive(mockGameService, clock, inactive)
+	mockWSHub.On("GetClientsInGame", mock.Anything).Return(1)
+
+	// Call service
+	result, err := service.Sweep(context.Background())
+
+	// Check results
+	assert.NoError(t, err)
+	assert.Equal(t, janitor.SweepResult{}, *result)
+	mockGameService.AssertNotCalled(t, "DeleteGame", mock.Anything, mock.Anything)
+	mockGameplayService.AssertNotCalled(t, "AbandonGame", mock.Anything, mock.Anything)
+}
+
+func TestSweep_Errors(t *testing.T) {
+	// Create mocks
+	mockGameService := new(game_mock.MockGameService)
+	mockGameplayService := new(gameplay_mock.MockGameplayService)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockUserService := new(user_mock.MockUserService)
+	clock := &fakeClock{now: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+
+	// Create the service with all the mocks
+	service := janitor.NewService(mockGameService, mockGameplayService, mockLobbyFeedService, mockWSHub, mockUserService, testConfig, clock.Now)
+	mockUserService.On("DeleteExpiredSessions", mock.Anything).Return(0, nil)
+
+	// Listing fails
+	mockGameService.On("ListInactiveGames", mock.Anything, mock.Anything).
+		Return([]game.InactiveGame{}, errors.New("database error")).Once()
+
+	result, err := service.Sweep(context.Background())
+	assert.ErrorContains(t, err, "database error")
+	assert.Nil(t, result)
+
+	// A game that can't be cleaned up doesn't stop the others
+	failingID := uuid.New()
+	deletedID := uuid.New()
+	expectInactive(mockGameService, clock, []game.InactiveGame{
+		{ID: failingID, State: gameState.FINISHED, LastActivityAt: clock.Now().Add(-48 * time.Hour)},
+		{ID: deletedID, State: gameState.FINISHED, LastActivityAt: clock.Now().Add(-48 * time.Hour)},
+	})
+	mockWSHub.On("GetClientsInGame", mock.Anything).Return(0)
+	mockGameService.On("DeleteGame", mock.Anything, failingID).Return(errors.New("database error"))
+	mockGameService.On("DeleteGame", mock.Anything, deletedID).Return(nil)
+
+	result, err = service.Sweep(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, janitor.SweepResult{DeletedGames: 1}, *result)
+}
+
+func TestSweep_DeletesExpiredSessions(t *testing.T) {
+	// Create mocks
+	mockGameService := new(game_mock.MockGameService)
+	mockGameplayService := new(gameplay_mock.MockGameplayService)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockUserService := new(user_mock.MockUserService)
+	clock := &fakeClock{now: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+
+	// Create the service with all the mocks
+	service := janitor.NewService(mockGameService, mockGameplayService, mockLobbyFeedService, mockWSHub, mockUserService, testConfig, clock.Now)
+
+	// Setup expectations
+	mockGameService.On("ListInactiveGames", mock.Anything, mock.Anything).Return([]game.InactiveGame{}, nil)
+	mockUserService.On("DeleteExpiredSessions", mock.Anything).Return(3, nil).Once()
+
+	result, err := service.Sweep(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, janitor.SweepResult{DeletedSessions: 3}, *result)
+
+	// Failing to delete them doesn't stop the games from being swept
+	mockUserService.On("DeleteExpiredSessions", mock.Anything).Return(0, errors.New("database error")).Once()
+
+	result, err = service.Sweep(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, janitor.SweepResult{}, *result)
+
+	// Verify mocks are called
+	mockGameService.AssertNumberOfCalls(t, "ListInactiveGames", 2)
+	mockUserService.AssertExpectations(t)
+}
+
+func TestHandleClient(t *testing.T) {
+	// Create mocks
+	mockGameService := new(game_mock.MockGameService)
+	mockGameplayService := new(gameplay_mock.MockGameplayService)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockUserService := new(user_mock.MockUserService)
+	clock := &fakeClock{now: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+
+	// Create the service with all the mocks
+	service := janitor.NewService(mockGameService, mockGameplayService, mockLobbyFeedService, mockWSHub, mockUserService, testConfig, clock.Now)
+
+	gameID := uuid.New()
+	mockGameService.On("TouchGame", mock.Anything, gameID, clock.Now()).Return(nil)
+
+	service.HandleClient(&websocket.Client{GameID: gameID})
+
+	// The lobby room is not a game
+	service.HandleClient(&websocket.Client{GameID: uuid.Nil})
+
+	mockGameService.AssertExpectations(t)
+	mockGameService.AssertNumberOfCalls(t, "TouchGame", 1)
+}
+
+func TestHandleGameFinished(t *testing.T) {
+	// Create mocks
+	mockGameService := new(game_mock.MockGameService)
+	mockGameplayService := new(gameplay_mock.MockGameplayService)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockUserService := new(user_mock.MockUserService)
+	clock := &fakeClock{now: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+
+	// Create the service with all the mocks
+	service := janitor.NewService(mockGameService, mockGameplayService, mockLobbyFeedService, mockWSHub, mockUserService, testConfig, clock.Now)
+
+	gameID := uuid.New()
+	clock.Advance(time.Hour)
+	mockGameService.On("TouchGame", mock.Anything, gameID, clock.Now()).Return(nil)
+
+	service.HandleGameFinished(context.Background(), gameID, uuid.New())
+
+	mockGameService.AssertExpectations(t)
+}
+
+func TestConfigValidate(t *testing.T) {
+	assert.NoError(t, janitor.DefaultConfig().Validate())
+
+	config := janitor.DefaultConfig()
+	config.Retention = 0
+	assert.ErrorIs(t, config.Validate(), janitor.ErrInvalidConfig)
+}
@@ -0,0 +1,20 @@
+package lobby
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// RemoveListener is called once a player has been removed from a game
+type RemoveListener func(ctx context.Context, gameID uuid.UUID, removed KickedPlayer)
+
+type LobbyService interface {
+	StartGame(ctx context.Context, gameID uuid.UUID) error
+	RequestStart(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID, force bool) error
+	GetLobby(ctx context.Context, gameID uuid.UUID) (*LobbyState, error)
+	SetReady(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID, ready bool) (*LobbyState, error)
+	KickPlayer(ctx context.Context, gameID uuid.UUID, hostID uuid.UUID, playerID uuid.UUID, ban bool) error
+	LeaveGame(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID) error
+	OnPlayerRemoved(listener RemoveListener)
+}
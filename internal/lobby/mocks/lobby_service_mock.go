@@ -0,0 +1,53 @@
+package lobby_mock
+
+import (
+	"context"
+
+	"github.com/NachoGz/switcher-backend-go/internal/lobby"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockLobbyService struct {
+	mock.Mock
+}
+
+func (m *MockLobbyService) StartGame(ctx context.Context, gameID uuid.UUID) error {
+	args := m.Called(ctx, gameID)
+	return args.Error(0)
+}
+
+func (m *MockLobbyService) RequestStart(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID, force bool) error {
+	args := m.Called(ctx, gameID, playerID, force)
+	return args.Error(0)
+}
+
+func (m *MockLobbyService) GetLobby(ctx context.Context, gameID uuid.UUID) (*lobby.LobbyState, error) {
+	args := m.Called(ctx, gameID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*lobby.LobbyState), args.Error(1)
+}
+
+func (m *MockLobbyService) SetReady(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID, ready bool) (*lobby.LobbyState, error) {
+	args := m.Called(ctx, gameID, playerID, ready)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*lobby.LobbyState), args.Error(1)
+}
+
+func (m *MockLobbyService) KickPlayer(ctx context.Context, gameID uuid.UUID, hostID uuid.UUID, playerID uuid.UUID, ban bool) error {
+	args := m.Called(ctx, gameID, hostID, playerID, ban)
+	return args.Error(0)
+}
+
+func (m *MockLobbyService) LeaveGame(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID) error {
+	args := m.Called(ctx, gameID, playerID)
+	return args.Error(0)
+}
+
+func (m *MockLobbyService) OnPlayerRemoved(listener lobby.RemoveListener) {
+	m.Called(listener)
+}
@@ -0,0 +1,68 @@
+package lobby
+
+import (
+	"time"
+
+	"github.com/NachoGz/switcher-backend-go/internal/player"
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
+	"github.com/google/uuid"
+)
+
+const (
+	// Time between everyone being ready and the game starting on its own
+	AUTO_START_COUNTDOWN = 10 * time.Second
+)
+
+var (
+	ErrNotHost          = utils.NewDomainError(utils.ErrForbidden, "NOT_HOST", "only the host can manage the game")
+	ErrKickHost         = utils.NewDomainError(utils.ErrForbidden, "CANNOT_KICK_HOST", "the host can't be kicked")
+	ErrHostLeaving      = utils.NewDomainError(utils.ErrForbidden, "HOST_CANNOT_LEAVE", "the host can't leave, delete the game instead")
+	ErrGameStarted      = utils.NewDomainError(utils.ErrConflict, "GAME_STARTED", "the game has already started")
+	ErrNotEnoughPlayers = utils.NewDomainError(utils.ErrConflict, "NOT_ENOUGH_PLAYERS", "not enough players to start the game")
+	ErrPlayersNotReady  = utils.NewDomainError(utils.ErrConflict, "PLAYERS_NOT_READY", "not every player is ready")
+	ErrPlayerNotFound   = utils.NewDomainError(utils.ErrNotFound, "PLAYER_NOT_FOUND", "player not found")
+	ErrStartInProgress  = utils.NewDomainError(utils.ErrConflict, "START_IN_PROGRESS", "the game is already being started")
+)
+
+// KickedPlayer is sent to the room when the host removes a player. Removal
+// listeners also get the players that left on their own
+type KickedPlayer struct {
+	PlayerID uuid.UUID `json:"player_id"`
+	Name     string    `json:"name"`
+	Banned   bool      `json:"banned"`
+	// The player left on their own, rather than being kicked
+	Left bool `json:"-"`
+}
+
+// LobbyPlayer is a player waiting in the lobby. The host and the bots are
+// always ready
+type LobbyPlayer struct {
+	ID    uuid.UUID `json:"id"`
+	Name  string    `json:"name"`
+	Host  bool      `json:"host"`
+	Bot   bool      `json:"bot"`
+	Ready bool      `json:"ready"`
+}
+
+// LobbyState is what is broadcast to the room whenever the lobby changes
+type LobbyState struct {
+	GameID     uuid.UUID     `json:"game_id"`
+	Players    []LobbyPlayer `json:"players"`
+	MinPlayers int           `json:"min_players"`
+	MaxPlayers int           `json:"max_players"`
+	AllReady   bool          `json:"all_ready"`
+	CanStart   bool          `json:"can_start"`
+	// Set while the auto-start countdown is running
+	StartsAt *time.Time `json:"starts_at,omitempty"`
+}
+
+// countdown is a pending auto-start of a game
+type countdown struct {
+	timer    *time.Timer
+	startsAt time.Time
+}
+
+// isReady reports whether a player doesn't hold back the start of the game
+func isReady(p player.Player) bool {
+	return p.Host || p.Bot || p.Ready
+}
@@ -0,0 +1,496 @@
+package lobby
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/NachoGz/switcher-backend-go/internal/board"
+	"github.com/NachoGz/switcher-backend-go/internal/database"
+	"github.com/NachoGz/switcher-backend-go/internal/figureCard"
+	"github.com/NachoGz/switcher-backend-go/internal/game"
+	"github.com/NachoGz/switcher-backend-go/internal/gameEvent"
+	gameState "github.com/NachoGz/switcher-backend-go/internal/game_state"
+	"github.com/NachoGz/switcher-backend-go/internal/gameplay"
+	"github.com/NachoGz/switcher-backend-go/internal/lobbyFeed"
+	"github.com/NachoGz/switcher-backend-go/internal/logging"
+	"github.com/NachoGz/switcher-backend-go/internal/metrics"
+	"github.com/NachoGz/switcher-backend-go/internal/movementCard"
+	"github.com/NachoGz/switcher-backend-go/internal/player"
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
+	"github.com/NachoGz/switcher-backend-go/internal/websocket"
+	"github.com/google/uuid"
+)
+
+// Service manages games that are waiting for players and starts them. Once
+// every player is ready the game starts on its own after a countdown
+type Service struct {
+	gameService         game.GameService
+	gameStateService    gameState.GameStateService
+	playerService       player.PlayerService
+	boardService        board.BoardService
+	movementCardService movementCard.MovementCardService
+	figureCardService   figureCard.FigureCardService
+	gameEventService    gameEvent.GameEventService
+	gameplayService     gameplay.GameplayService
+	lobbyFeedService    lobbyFeed.LobbyFeedService
+	wsHub               websocket.WebSocketHub
+	db                  *sql.DB
+	// Zero disables the auto-start
+	startDelay time.Duration
+
+	mu         sync.Mutex
+	countdowns map[uuid.UUID]*countdown
+	starting   map[uuid.UUID]bool
+
+	listenersMu     sync.RWMutex
+	removeListeners []RemoveListener
+}
+
+// NewService creates a new lobby service
+func NewService(
+	gameService game.GameService,
+	gameStateService gameState.GameStateService,
+	playerService player.PlayerService,
+	boardService board.BoardService,
+	movementCardService movementCard.MovementCardService,
+	figureCardService figureCard.FigureCardService,
+	gameEventService gameEvent.GameEventService,
+	gameplayService gameplay.GameplayService,
+	lobbyFeedService lobbyFeed.LobbyFeedService,
+	wsHub websocket.WebSocketHub,
+	db *sql.DB,
+	startDelay time.Duration,
+) *Service {
+	return &Service{
+		gameService:         gameService,
+		gameStateService:    gameStateService,
+		playerService:       playerService,
+		boardService:        boardService,
+		movementCardService: movementCardService,
+		figureCardService:   figureCardService,
+		gameEventService:    gameEventService,
+		gameplayService:     gameplayService,
+		lobbyFeedService:    lobbyFeedService,
+		wsHub:               wsHub,
+		db:                  db,
+		startDelay:          startDelay,
+		countdowns:          make(map[uuid.UUID]*countdown),
+		starting:            make(map[uuid.UUID]bool),
+	}
+}
+
+// Ensure Service implements LobbyService
+var _ LobbyService = (*Service)(nil)
+
+// StartGame assigns the turns, deals the board and the decks, and begins
+// the turn of the first player
+func (s *Service) StartGame(ctx context.Context, gameID uuid.UUID) error {
+	// A countdown and the host may try to start the game at the same time
+	s.mu.Lock()
+	if s.starting[gameID] {
+		s.mu.Unlock()
+		return ErrStartInProgress
+	}
+	s.starting[gameID] = true
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.starting, gameID)
+		s.mu.Unlock()
+	}()
+
+	s.cancelCountdown(gameID)
+	start := time.Now()
+
+	// The game only leaves the waiting state together with its whole setup, a
+	// failed start can be retried
+	var currentGame *game.Game
+	var firstPlayerID uuid.UUID
+	err := database.InTx(ctx, s.db, func(ctx context.Context) (err error) {
+		currentGame, firstPlayerID, err = s.setupGame(ctx, gameID)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	s.lobbyFeedService.GameRemoved(*currentGame)
+	s.wsHub.BroadcastEvent(uuid.Nil, websocket.GameScoped(gameID, websocket.GAME_STARTED))
+	s.wsHub.BroadcastEvent(gameID, websocket.GAME_STARTED)
+
+	s.gameplayService.BeginTurn(gameID, firstPlayerID)
+	metrics.GameStartDuration.Observe(time.Since(start).Seconds())
+	return nil
+}
+
+// setupGame moves the game to playing, assigns the turns and deals the board
+// and the decks. It returns the game and the player who goes first
+func (s *Service) setupGame(ctx context.Context, gameID uuid.UUID) (*game.Game, uuid.UUID, error) {
+	currentGame, err := s.gameService.GetGameByID(ctx, gameID)
+	if err != nil {
+		return nil, uuid.Nil, fmt.Errorf("error fetching game: %w", err)
+	}
+
+	// Every random choice of the setup comes from the game seed, in this order
+	rng := utils.NewRandomizer(currentGame.Seed)
+
+	// Only the first start to leave the waiting state sets the game up, another
+	// instance may have started it already
+	started, err := s.gameStateService.TransitionGameState(ctx, gameID, gameState.WAITING, gameState.PLAYING)
+	if err != nil {
+		return nil, uuid.Nil, fmt.Errorf("error updating game state: %w", err)
+	}
+	if !started {
+		return nil, uuid.Nil, ErrGameStarted
+	}
+
+	players, err := s.playerService.GetPlayersInGame(ctx, gameID)
+	if err != nil {
+		return nil, uuid.Nil, fmt.Errorf("error fetching players: %w", err)
+	}
+
+	// Assign random turns
+	firstPlayerID, err := s.playerService.AssignRandomTurns(ctx, players, rng)
+	if err != nil {
+		return nil, uuid.Nil, fmt.Errorf("error setting turns: %w", err)
+	}
+
+	// Set current player
+	if err := s.gameStateService.UpdateCurrentPlayer(ctx, gameID, firstPlayerID); err != nil {
+		return nil, uuid.Nil, fmt.Errorf("error updating current player: %w", err)
+	}
+
+	// Create board and decks for players
+	if err := s.boardService.ConfigureBoard(ctx, gameID, currentGame.Rules, rng); err != nil {
+		return nil, uuid.Nil, fmt.Errorf("error configuring board: %w", err)
+	}
+
+	if err := s.movementCardService.CreateMovementCardDeck(ctx, gameID, currentGame.Rules, rng); err != nil {
+		return nil, uuid.Nil, fmt.Errorf("error creating movement card deck: %w", err)
+	}
+
+	if err := s.figureCardService.CreateFigureCardDeck(ctx, gameID, currentGame.Rules, rng); err != nil {
+		return nil, uuid.Nil, fmt.Errorf("error creating figure card deck: %w", err)
+	}
+
+	// Record the initial board and decks so the game can be replayed
+	if err := s.gameEventService.RecordGameStarted(ctx, gameID); err != nil {
+		return nil, uuid.Nil, fmt.Errorf("error recording game start: %w", err)
+	}
+
+	return currentGame, firstPlayerID, nil
+}
+
+// RequestStart starts a game on behalf of its host. Every player must be
+// ready unless the host forces the start
+func (s *Service) RequestStart(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID, force bool) error {
+	host, err := s.playerService.GetPlayerByID(ctx, playerID, gameID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrPlayerNotFound
+		}
+		return fmt.Errorf("error fetching player: %w", err)
+	}
+	if !host.Host {
+		return ErrNotHost
+	}
+
+	lobbyState, waiting, err := s.lobbyState(ctx, gameID)
+	if err != nil {
+		return err
+	}
+	if !waiting {
+		return ErrGameStarted
+	}
+	if len(lobbyState.Players) < lobbyState.MinPlayers {
+		return ErrNotEnoughPlayers
+	}
+	if !lobbyState.AllReady && !force {
+		return ErrPlayersNotReady
+	}
+
+	return s.StartGame(ctx, gameID)
+}
+
+// GetLobby returns the current state of the lobby of a game
+func (s *Service) GetLobby(ctx context.Context, gameID uuid.UUID) (*LobbyState, error) {
+	lobbyState, _, err := s.lobbyState(ctx, gameID)
+	return lobbyState, err
+}
+
+// SetReady marks whether a player is ready, broadcasts the new lobby state
+// and starts or stops the auto-start countdown
+func (s *Service) SetReady(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID, ready bool) (*LobbyState, error) {
+	state, err := s.gameStateService.GetGameStateByGameID(ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching game state: %w", err)
+	}
+	if state.State != gameState.WAITING {
+		return nil, ErrGameStarted
+	}
+
+	if _, err := s.playerService.GetPlayerByID(ctx, playerID, gameID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPlayerNotFound
+		}
+		return nil, fmt.Errorf("error fetching player: %w", err)
+	}
+
+	if err := s.playerService.SetReady(ctx, playerID, ready); err != nil {
+		return nil, fmt.Errorf("error updating player: %w", err)
+	}
+
+	return s.broadcastLobby(ctx, gameID)
+}
+
+// KickPlayer removes a player from a game that hasn't started, closing its
+// websocket. Banned players can't join the game again with the same name or
+// account. Only the host can kick players
+func (s *Service) KickPlayer(ctx context.Context, gameID uuid.UUID, hostID uuid.UUID, playerID uuid.UUID, ban bool) error {
+	state, err := s.gameStateService.GetGameStateByGameID(ctx, gameID)
+	if err != nil {
+		return fmt.Errorf("error fetching game state: %w", err)
+	}
+	if state.State != gameState.WAITING {
+		return ErrGameStarted
+	}
+
+	host, err := s.playerService.GetPlayerByID(ctx, hostID, gameID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrPlayerNotFound
+		}
+		return fmt.Errorf("error fetching player: %w", err)
+	}
+	if !host.Host {
+		return ErrNotHost
+	}
+
+	kicked, err := s.playerService.GetPlayerByID(ctx, playerID, gameID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrPlayerNotFound
+		}
+		return fmt.Errorf("error fetching player: %w", err)
+	}
+	if kicked.Host {
+		return ErrKickHost
+	}
+
+	// Ban first so the player can't rejoin in between
+	if ban {
+		if err := s.gameService.BanPlayer(ctx, gameID, kicked.Name, kicked.UserID); err != nil {
+			return fmt.Errorf("error banning player: %w", err)
+		}
+	}
+
+	if err := s.playerService.DeletePlayer(ctx, playerID, gameID); err != nil {
+		return fmt.Errorf("error deleting player: %w", err)
+	}
+
+	removed := KickedPlayer{
+		PlayerID: playerID,
+		Name:     kicked.Name,
+		Banned:   ban,
+	}
+	s.wsHub.DisconnectPlayer(gameID, playerID, websocket.CLOSE_KICKED, "kicked by the host")
+	s.wsHub.BroadcastToGame(gameID, websocket.PLAYER_KICKED, removed)
+	s.playerRemoved(ctx, gameID, removed)
+	return nil
+}
+
+// LeaveGame removes a player from a game that hasn't started at their own
+// request, closing its websocket. The host can't leave
+func (s *Service) LeaveGame(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID) error {
+	state, err := s.gameStateService.GetGameStateByGameID(ctx, gameID)
+	if err != nil {
+		return fmt.Errorf("error fetching game state: %w", err)
+	}
+	if state.State != gameState.WAITING {
+		return ErrGameStarted
+	}
+
+	leaving, err := s.playerService.GetPlayerByID(ctx, playerID, gameID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrPlayerNotFound
+		}
+		return fmt.Errorf("error fetching player: %w", err)
+	}
+	if leaving.Host {
+		return ErrHostLeaving
+	}
+
+	if err := s.playerService.DeletePlayer(ctx, playerID, gameID); err != nil {
+		return fmt.Errorf("error deleting player: %w", err)
+	}
+
+	s.wsHub.DisconnectPlayer(gameID, playerID, websocket.CLOSE_LEFT, "left the game")
+	s.playerRemoved(ctx, gameID, KickedPlayer{
+		PlayerID: playerID,
+		Name:     leaving.Name,
+		Left:     true,
+	})
+	return nil
+}
+
+// playerRemoved tells the listeners, the lobby and the room that a player is
+// no longer in the game
+func (s *Service) playerRemoved(ctx context.Context, gameID uuid.UUID, removed KickedPlayer) {
+	s.listenersMu.RLock()
+	listeners := append([]RemoveListener(nil), s.removeListeners...)
+	s.listenersMu.RUnlock()
+	for _, listener := range listeners {
+		listener(ctx, gameID, removed)
+	}
+	s.lobbyFeedService.GameUpdated(ctx, gameID)
+	s.wsHub.BroadcastEvent(uuid.Nil, websocket.GameScoped(gameID, websocket.GAME_INFO_UPDATE))
+
+	if _, err := s.broadcastLobby(ctx, gameID); err != nil {
+		slog.ErrorContext(ctx, "Error broadcasting lobby", logging.GameID(gameID), "error", err)
+	}
+}
+
+// OnPlayerRemoved registers a listener called when a player is removed
+// from a game
+func (s *Service) OnPlayerRemoved(listener RemoveListener) {
+	s.listenersMu.Lock()
+	defer s.listenersMu.Unlock()
+	s.removeListeners = append(s.removeListeners, listener)
+}
+
+// broadcastLobby sends the lobby state to the room, starting or stopping the
+// auto-start countdown as needed
+func (s *Service) broadcastLobby(ctx context.Context, gameID uuid.UUID) (*LobbyState, error) {
+	lobbyState, _, err := s.lobbyState(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	if lobbyState.CanStart {
+		lobbyState.StartsAt = s.scheduleCountdown(gameID)
+	} else {
+		s.cancelCountdown(gameID)
+		lobbyState.StartsAt = nil
+	}
+
+	s.wsHub.BroadcastToGame(gameID, websocket.LOBBY_STATE, lobbyState)
+	return lobbyState, nil
+}
+
+// lobbyState builds the lobby of a game and reports whether it's still
+// waiting for players
+func (s *Service) lobbyState(ctx context.Context, gameID uuid.UUID) (*LobbyState, bool, error) {
+	currentGame, err := s.gameService.GetGameByID(ctx, gameID)
+	if err != nil {
+		return nil, false, fmt.Errorf("error fetching game: %w", err)
+	}
+
+	state, err := s.gameStateService.GetGameStateByGameID(ctx, gameID)
+	if err != nil {
+		return nil, false, fmt.Errorf("error fetching game state: %w", err)
+	}
+
+	players, err := s.playerService.GetPlayersInGame(ctx, gameID)
+	if err != nil {
+		return nil, false, fmt.Errorf("error fetching players: %w", err)
+	}
+
+	lobbyState := &LobbyState{
+		GameID:     gameID,
+		Players:    make([]LobbyPlayer, len(players)),
+		MinPlayers: currentGame.MinPlayers,
+		MaxPlayers: currentGame.MaxPlayers,
+		AllReady:   true,
+	}
+	for i, p := range players {
+		lobbyState.Players[i] = LobbyPlayer{
+			ID:    p.ID,
+			Name:  p.Name,
+			Host:  p.Host,
+			Bot:   p.Bot,
+			Ready: isReady(p),
+		}
+		if !isReady(p) {
+			lobbyState.AllReady = false
+		}
+	}
+
+	waiting := state.State == gameState.WAITING
+	lobbyState.CanStart = waiting && lobbyState.AllReady && len(players) >= currentGame.MinPlayers
+
+	s.mu.Lock()
+	if c, ok := s.countdowns[gameID]; ok {
+		startsAt := c.startsAt
+		lobbyState.StartsAt = &startsAt
+	}
+	s.mu.Unlock()
+
+	return lobbyState, waiting, nil
+}
+
+// scheduleCountdown starts the auto-start countdown of a game unless it's
+// already running, and returns when the game will start
+func (s *Service) scheduleCountdown(gameID uuid.UUID) *time.Time {
+	if s.startDelay <= 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.countdowns[gameID]
+	if !ok {
+		c = &countdown{startsAt: time.Now().Add(s.startDelay)}
+		c.timer = time.AfterFunc(s.startDelay, func() { s.autoStart(gameID, c) })
+		s.countdowns[gameID] = c
+	}
+
+	startsAt := c.startsAt
+	return &startsAt
+}
+
+// cancelCountdown stops the auto-start countdown of a game, if any
+func (s *Service) cancelCountdown(gameID uuid.UUID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if c, ok := s.countdowns[gameID]; ok {
+		c.timer.Stop()
+		delete(s.countdowns, gameID)
+	}
+}
+
+// autoStart starts a game when its countdown ends, as long as everyone is
+// still ready
+func (s *Service) autoStart(gameID uuid.UUID, c *countdown) {
+	s.mu.Lock()
+	if s.countdowns[gameID] != c {
+		// The countdown was cancelled in the meantime
+		s.mu.Unlock()
+		return
+	}
+	delete(s.countdowns, gameID)
+	s.mu.Unlock()
+
+	ctx := logging.WithGameID(context.Background(), gameID)
+	lobbyState, _, err := s.lobbyState(ctx, gameID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error fetching lobby", "error", err)
+		return
+	}
+	if !lobbyState.CanStart {
+		// Someone joined or left during the countdown
+		s.wsHub.BroadcastToGame(gameID, websocket.LOBBY_STATE, lobbyState)
+		return
+	}
+
+	if err := s.StartGame(ctx, gameID); err != nil {
+		slog.ErrorContext(ctx, "Error auto-starting game", "error", err)
+	}
+}
@@ -0,0 +1,775 @@
+package lobby_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	board_mock "github.com/NachoGz/switcher-backend-go/internal/board/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/database/databasetest"
+	figureCard_mock "github.com/NachoGz/switcher-backend-go/internal/figureCard/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/game"
+	game_mock "github.com/NachoGz/switcher-backend-go/internal/game/mocks"
+	gameEvent_mock "github.com/NachoGz/switcher-backend-go/internal/gameEvent/mocks"
+	gameState "github.com/NachoGz/switcher-backend-go/internal/game_state"
+	gameState_mock "github.com/NachoGz/switcher-backend-go/internal/game_state/mocks"
+	gameplay_mock "github.com/NachoGz/switcher-backend-go/internal/gameplay/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/lobby"
+	lobbyFeed_mock "github.com/NachoGz/switcher-backend-go/internal/lobbyFeed/mocks"
+	movementCard_mock "github.com/NachoGz/switcher-backend-go/internal/movementCard/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/player"
+	player_mock "github.com/NachoGz/switcher-backend-go/internal/player/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/websocket"
+	websocket_mock "github.com/NachoGz/switcher-backend-go/internal/websocket/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// expectStart sets up every step of starting a game, failing at the given one
+func expectStart(
+	mockGameService *game_mock.MockGameService,
+	mockGameStateService *gameState_mock.MockGameStateService,
+	mockPlayerService *player_mock.MockPlayerService,
+	mockBoardService *board_mock.MockBoardService,
+	mockMovementCardService *movementCard_mock.MockMovementCardService,
+	mockFigureCardService *figureCard_mock.MockFigureCardService,
+	mockGameEventService *gameEvent_mock.MockGameEventService,
+	mockGameplayService *gameplay_mock.MockGameplayService,
+	mockLobbyFeedService *lobbyFeed_mock.MockLobbyFeedService,
+	mockWSHub *websocket_mock.MockWebSocketHub,
+	gameID uuid.UUID,
+	players []player.Player,
+	failAt string,
+) {
+	errFor := func(step string) error {
+		if step == failAt {
+			return errors.New("database error")
+		}
+		return nil
+	}
+
+	mockGameService.On("GetGameByID", mock.Anything, gameID).
+		Return(&game.Game{ID: gameID, Seed: 42}, nil)
+	mockGameStateService.On("TransitionGameState", mock.Anything, gameID, gameState.WAITING, gameState.PLAYING).
+		Return(true, errFor("TransitionGameState"))
+	mockPlayerService.On("GetPlayersInGame", mock.Anything, gameID).
+		Return(players, errFor("GetPlayersInGame"))
+	mockPlayerService.On("AssignRandomTurns", mock.Anything, players, mock.Anything).
+		Return(players[0].ID, errFor("AssignRandomTurns"))
+	mockGameStateService.On("UpdateCurrentPlayer", mock.Anything, gameID, players[0].ID).
+		Return(errFor("UpdateCurrentPlayer"))
+	mockBoardService.On("ConfigureBoard", mock.Anything, gameID, mock.Anything, mock.Anything).
+		Return(errFor("ConfigureBoard"))
+	mockMovementCardService.On("CreateMovementCardDeck", mock.Anything, gameID, mock.Anything, mock.Anything).
+		Return(errFor("CreateMovementCardDeck"))
+	mockFigureCardService.On("CreateFigureCardDeck", mock.Anything, gameID, mock.Anything, mock.Anything).
+		Return(errFor("CreateFigureCardDeck"))
+	mockGameEventService.On("RecordGameStarted", mock.Anything, gameID).
+		Return(errFor("RecordGameStarted"))
+	mockLobbyFeedService.On("GameRemoved", mock.MatchedBy(func(removed game.Game) bool {
+		return removed.ID == gameID
+	})).
+		Return()
+	mockWSHub.On("BroadcastEvent", mock.Anything, mock.Anything).
+		Return()
+	mockGameplayService.On("BeginTurn", gameID, players[0].ID).
+		Return()
+}
+
+func testPlayers(gameID uuid.UUID) []player.Player {
+	return []player.Player{
+		{ID: uuid.New(), Name: "Test Player 1", Host: true, GameID: gameID},
+		{ID: uuid.New(), Name: "Test Player 2", GameID: gameID},
+	}
+}
+
+func TestStartGame(t *testing.T) {
+	// Create mocks
+	mockGameService := new(game_mock.MockGameService)
+	mockGameStateService := new(gameState_mock.MockGameStateService)
+	mockPlayerService := new(player_mock.MockPlayerService)
+	mockBoardService := new(board_mock.MockBoardService)
+	mockMovementCardService := new(movementCard_mock.MockMovementCardService)
+	mockFigureCardService := new(figureCard_mock.MockFigureCardService)
+	mockGameEventService := new(gameEvent_mock.MockGameEventService)
+	mockGameplayService := new(gameplay_mock.MockGameplayService)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	db, txs := databasetest.NewDB(t)
+
+	// Create the service with all the mocks
+	service := lobby.NewService(mockGameService, mockGameStateService, mockPlayerService, mockBoardService,
+		mockMovementCardService, mockFigureCardService, mockGameEventService, mockGameplayService, mockLobbyFeedService, mockWSHub, db, 0)
+
+	gameID := uuid.New()
+	players := testPlayers(gameID)
+	expectStart(mockGameService, mockGameStateService, mockPlayerService, mockBoardService,
+		mockMovementCardService, mockFigureCardService, mockGameEventService, mockGameplayService,
+		mockLobbyFeedService, mockWSHub, gameID, players, "")
+
+	err := service.StartGame(context.Background(), gameID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, txs.Committed())
+	mockGameStateService.AssertExpectations(t)
+	mockPlayerService.AssertExpectations(t)
+	mockBoardService.AssertExpectations(t)
+	mockMovementCardService.AssertExpectations(t)
+	mockFigureCardService.AssertExpectations(t)
+	mockGameEventService.AssertExpectations(t)
+	mockGameplayService.AssertExpectations(t)
+	mockLobbyFeedService.AssertExpectations(t)
+	mockWSHub.AssertCalled(t, "BroadcastEvent", uuid.Nil, fmt.Sprintf("%s:GAME_STARTED", gameID))
+	mockWSHub.AssertCalled(t, "BroadcastEvent", gameID, "GAME_STARTED")
+}
+
+func TestStartGame_Errors(t *testing.T) {
+	// Each step, the error it causes and the steps that must not run after it
+	tests := []struct {
+		step    string
+		message string
+		skipped []string
+	}{
+		{"TransitionGameState", "error updating game state", []string{"GetPlayersInGame", "AssignRandomTurns", "ConfigureBoard"}},
+		{"GetPlayersInGame", "error fetching players", []string{"AssignRandomTurns", "ConfigureBoard"}},
+		{"AssignRandomTurns", "error setting turns", []string{"UpdateCurrentPlayer", "ConfigureBoard"}},
+		{"UpdateCurrentPlayer", "error updating current player", []string{"ConfigureBoard", "CreateMovementCardDeck"}},
+		{"ConfigureBoard", "error configuring board", []string{"CreateMovementCardDeck", "CreateFigureCardDeck"}},
+		{"CreateMovementCardDeck", "error creating movement card deck", []string{"CreateFigureCardDeck"}},
+		{"CreateFigureCardDeck", "error creating figure card deck", []string{"RecordGameStarted"}},
+		{"RecordGameStarted", "error recording game start", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.step, func(t *testing.T) {
+			// Create mocks
+			mockGameService := new(game_mock.MockGameService)
+			mockGameStateService := new(gameState_mock.MockGameStateService)
+			mockPlayerService := new(player_mock.MockPlayerService)
+			mockBoardService := new(board_mock.MockBoardService)
+			mockMovementCardService := new(movementCard_mock.MockMovementCardService)
+			mockFigureCardService := new(figureCard_mock.MockFigureCardService)
+			mockGameEventService := new(gameEvent_mock.MockGameEventService)
+			mockGameplayService := new(gameplay_mock.MockGameplayService)
+			mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
+			mockWSHub := new(websocket_mock.MockWebSocketHub)
+			db, txs := databasetest.NewDB(t)
+
+			// Create the service with all the mocks
+			service := lobby.NewService(mockGameService, mockGameStateService, mockPlayerService, mockBoardService,
+				mockMovementCardService, mockFigureCardService, mockGameEventService, mockGameplayService, mockLobbyFeedService, mockWSHub, db, 0)
+
+			gameID := uuid.New()
+			players := testPlayers(gameID)
+			expectStart(mockGameService, mockGameStateService, mockPlayerService, mockBoardService,
+				mockMovementCardService, mockFigureCardService, mockGameEventService, mockGameplayService,
+				mockLobbyFeedService, mockWSHub, gameID, players, tt.step)
+
+			err := service.StartGame(context.Background(), gameID)
+
+			assert.ErrorContains(t, err, tt.message)
+
+			// Nothing of the setup is kept, the game is still waiting
+			assert.Equal(t, 0, txs.Committed())
+			assert.Equal(t, 1, txs.RolledBack())
+
+			calls := map[string]*mock.Mock{
+				"GetPlayersInGame":       &mockPlayerService.Mock,
+				"AssignRandomTurns":      &mockPlayerService.Mock,
+				"UpdateCurrentPlayer":    &mockGameStateService.Mock,
+				"ConfigureBoard":         &mockBoardService.Mock,
+				"CreateMovementCardDeck": &mockMovementCardService.Mock,
+				"CreateFigureCardDeck":   &mockFigureCardService.Mock,
+				"RecordGameStarted":      &mockGameEventService.Mock,
+			}
+			for _, step := range tt.skipped {
+				calls[step].AssertNotCalled(t, step)
+			}
+
+			// Nobody is told the game started
+			mockWSHub.AssertNotCalled(t, "BroadcastEvent")
+			mockLobbyFeedService.AssertNotCalled(t, "GameRemoved", mock.Anything)
+			mockGameplayService.AssertNotCalled(t, "BeginTurn")
+		})
+	}
+}
+
+func TestStartGame_FailedSetupKeepsGameWaiting(t *testing.T) {
+	// Create mocks
+	mockGameService := new(game_mock.MockGameService)
+	mockGameStateService := new(gameState_mock.MockGameStateService)
+	mockPlayerService := new(player_mock.MockPlayerService)
+	mockBoardService := new(board_mock.MockBoardService)
+	mockMovementCardService := new(movementCard_mock.MockMovementCardService)
+	mockFigureCardService := new(figureCard_mock.MockFigureCardService)
+	mockGameEventService := new(gameEvent_mock.MockGameEventService)
+	mockGameplayService := new(gameplay_mock.MockGameplayService)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	db, txs := databasetest.NewDB(t)
+
+	// Create the service with all the mocks
+	service := lobby.NewService(mockGameService, mockGameStateService, mockPlayerService, mockBoardService,
+		mockMovementCardService, mockFigureCardService, mockGameEventService, mockGameplayService, mockLobbyFeedService, mockWSHub, db, 0)
+
+	gameID := uuid.New()
+	players := testPlayers(gameID)
+	expectStart(mockGameService, mockGameStateService, mockPlayerService, mockBoardService,
+		mockMovementCardService, mockFigureCardService, mockGameEventService, mockGameplayService,
+		mockLobbyFeedService, mockWSHub, gameID, players, "ConfigureBoard")
+
+	err := service.StartGame(context.Background(), gameID)
+
+	// The move to playing is rolled back with the rest of the setup
+	assert.ErrorContains(t, err, "error configuring board")
+	mockGameStateService.AssertCalled(t, "TransitionGameState", mock.Anything, gameID, gameState.WAITING, gameState.PLAYING)
+	assert.Equal(t, 1, txs.Begun())
+	assert.Equal(t, 0, txs.Committed())
+	assert.Equal(t, 1, txs.RolledBack())
+
+	// So the game can be started again once the board can be dealt
+	mockBoardService.ExpectedCalls = nil
+	mockBoardService.On("ConfigureBoard", mock.Anything, gameID, mock.Anything, mock.Anything).
+		Return(nil)
+
+	err = service.StartGame(context.Background(), gameID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, txs.Committed())
+	mockGameplayService.AssertCalled(t, "BeginTurn", gameID, players[0].ID)
+}
+
+func TestStartGame_AlreadyStarted(t *testing.T) {
+	// Create mocks
+	mockGameService := new(game_mock.MockGameService)
+	mockGameStateService := new(gameState_mock.MockGameStateService)
+	mockPlayerService := new(player_mock.MockPlayerService)
+	mockBoardService := new(board_mock.MockBoardService)
+	mockMovementCardService := new(movementCard_mock.MockMovementCardService)
+	mockFigureCardService := new(figureCard_mock.MockFigureCardService)
+	mockGameEventService := new(gameEvent_mock.MockGameEventService)
+	mockGameplayService := new(gameplay_mock.MockGameplayService)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	db, _ := databasetest.NewDB(t)
+
+	// Create the service with all the mocks
+	service := lobby.NewService(mockGameService, mockGameStateService, mockPlayerService, mockBoardService,
+		mockMovementCardService, mockFigureCardService, mockGameEventService, mockGameplayService, mockLobbyFeedService, mockWSHub, db, 0)
+
+	// Another instance moved the game out of the waiting state first
+	gameID := uuid.New()
+	mockGameService.On("GetGameByID", mock.Anything, gameID).
+		Return(&game.Game{ID: gameID, Seed: 42}, nil)
+	mockGameStateService.On("TransitionGameState", mock.Anything, gameID, gameState.WAITING, gameState.PLAYING).
+		Return(false, nil)
+
+	err := service.StartGame(context.Background(), gameID)
+
+	assert.ErrorIs(t, err, lobby.ErrGameStarted)
+	mockPlayerService.AssertNotCalled(t, "AssignRandomTurns", mock.Anything, mock.Anything, mock.Anything)
+	mockBoardService.AssertNotCalled(t, "ConfigureBoard", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockWSHub.AssertNotCalled(t, "BroadcastEvent", mock.Anything, mock.Anything)
+	mockGameplayService.AssertNotCalled(t, "BeginTurn", mock.Anything, mock.Anything)
+}
+
+// expectLobby sets up a game waiting for players
+func expectLobby(mockGameService *game_mock.MockGameService, mockGameStateService *gameState_mock.MockGameStateService, mockPlayerService *player_mock.MockPlayerService, gameID uuid.UUID, players []player.Player, minPlayers int) {
+	mockGameService.On("GetGameByID", mock.Anything, gameID).
+		Return(&game.Game{ID: gameID, MinPlayers: minPlayers, MaxPlayers: 4}, nil)
+	mockGameStateService.On("GetGameStateByGameID", mock.Anything, gameID).
+		Return(&gameState.GameState{GameID: gameID, State: gameState.WAITING}, nil)
+	mockPlayerService.On("GetPlayersInGame", mock.Anything, gameID).
+		Return(players, nil)
+}
+
+func TestRequestStart(t *testing.T) {
+	tests := []struct {
+		name       string
+		caller     int
+		ready      bool
+		force      bool
+		minPlayers int
+		err        error
+	}{
+		{"Everyone ready", 0, true, false, 2, nil},
+		{"Not host", 1, true, false, 2, lobby.ErrNotHost},
+		{"Players not ready", 0, false, false, 2, lobby.ErrPlayersNotReady},
+		{"Host forces the start", 0, false, true, 2, nil},
+		{"Not enough players", 0, true, true, 3, lobby.ErrNotEnoughPlayers},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create mocks
+			mockGameService := new(game_mock.MockGameService)
+			mockGameStateService := new(gameState_mock.MockGameStateService)
+			mockPlayerService := new(player_mock.MockPlayerService)
+			mockBoardService := new(board_mock.MockBoardService)
+			mockMovementCardService := new(movementCard_mock.MockMovementCardService)
+			mockFigureCardService := new(figureCard_mock.MockFigureCardService)
+			mockGameEventService := new(gameEvent_mock.MockGameEventService)
+			mockGameplayService := new(gameplay_mock.MockGameplayService)
+			mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
+			mockWSHub := new(websocket_mock.MockWebSocketHub)
+			db, _ := databasetest.NewDB(t)
+
+			// Create the service with all the mocks
+			service := lobby.NewService(mockGameService, mockGameStateService, mockPlayerService, mockBoardService,
+				mockMovementCardService, mockFigureCardService, mockGameEventService, mockGameplayService, mockLobbyFeedService, mockWSHub, db, 0)
+
+			gameID := uuid.New()
+			players := testPlayers(gameID)
+			players[1].Ready = tt.ready
+			expectLobby(mockGameService, mockGameStateService, mockPlayerService, gameID, players, tt.minPlayers)
+			expectStart(mockGameService, mockGameStateService, mockPlayerService, mockBoardService,
+				mockMovementCardService, mockFigureCardService, mockGameEventService, mockGameplayService,
+				mockLobbyFeedService, mockWSHub, gameID, players, "")
+			mockPlayerService.On("GetPlayerByID", mock.Anything, players[tt.caller].ID, gameID).
+				Return(players[tt.caller], nil)
+
+			err := service.RequestStart(context.Background(), gameID, players[tt.caller].ID, tt.force)
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				mockGameStateService.AssertNotCalled(t, "TransitionGameState", mock.Anything, gameID, gameState.WAITING, gameState.PLAYING)
+				return
+			}
+			assert.NoError(t, err)
+			mockGameplayService.AssertCalled(t, "BeginTurn", gameID, players[0].ID)
+		})
+	}
+}
+
+func TestRequestStart_GameStarted(t *testing.T) {
+	// Create mocks
+	mockGameService := new(game_mock.MockGameService)
+	mockGameStateService := new(gameState_mock.MockGameStateService)
+	mockPlayerService := new(player_mock.MockPlayerService)
+	mockBoardService := new(board_mock.MockBoardService)
+	mockMovementCardService := new(movementCard_mock.MockMovementCardService)
+	mockFigureCardService := new(figureCard_mock.MockFigureCardService)
+	mockGameEventService := new(gameEvent_mock.MockGameEventService)
+	mockGameplayService := new(gameplay_mock.MockGameplayService)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	db, _ := databasetest.NewDB(t)
+
+	// Create the service with all the mocks
+	service := lobby.NewService(mockGameService, mockGameStateService, mockPlayerService, mockBoardService,
+		mockMovementCardService, mockFigureCardService, mockGameEventService, mockGameplayService, mockLobbyFeedService, mockWSHub, db, 0)
+
+	gameID := uuid.New()
+	players := testPlayers(gameID)
+	mockPlayerService.On("GetPlayerByID", mock.Anything, players[0].ID, gameID).
+		Return(players[0], nil)
+	mockGameService.On("GetGameByID", mock.Anything, gameID).
+		Return(&game.Game{ID: gameID, MinPlayers: 2}, nil)
+	mockGameStateService.On("GetGameStateByGameID", mock.Anything, gameID).
+		Return(&gameState.GameState{GameID: gameID, State: gameState.PLAYING}, nil)
+	mockPlayerService.On("GetPlayersInGame", mock.Anything, gameID).
+		Return(players, nil)
+
+	err := service.RequestStart(context.Background(), gameID, players[0].ID, true)
+
+	assert.ErrorIs(t, err, lobby.ErrGameStarted)
+}
+
+func TestSetReady(t *testing.T) {
+	// Create mocks
+	mockGameService := new(game_mock.MockGameService)
+	mockGameStateService := new(gameState_mock.MockGameStateService)
+	mockPlayerService := new(player_mock.MockPlayerService)
+	mockBoardService := new(board_mock.MockBoardService)
+	mockMovementCardService := new(movementCard_mock.MockMovementCardService)
+	mockFigureCardService := new(figureCard_mock.MockFigureCardService)
+	mockGameEventService := new(gameEvent_mock.MockGameEventService)
+	mockGameplayService := new(gameplay_mock.MockGameplayService)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	db, _ := databasetest.NewDB(t)
+
+	// Create the service with all the mocks
+	service := lobby.NewService(mockGameService, mockGameStateService, mockPlayerService, mockBoardService,
+		mockMovementCardService, mockFigureCardService, mockGameEventService, mockGameplayService, mockLobbyFeedService, mockWSHub, db, 0)
+
+	gameID := uuid.New()
+	players := testPlayers(gameID)
+	players = append(players, player.Player{ID: uuid.New(), Name: "Test Player 3", GameID: gameID})
+	expectLobby(mockGameService, mockGameStateService, mockPlayerService, gameID, players, 2)
+	mockPlayerService.On("GetPlayerByID", mock.Anything, players[1].ID, gameID).
+		Return(players[1], nil)
+	mockPlayerService.On("SetReady", mock.Anything, players[1].ID, true).
+		Return(nil)
+	mockWSHub.On("BroadcastToGame", gameID, "LOBBY_STATE", mock.Anything).
+		Return()
+
+	lobbyState, err := service.SetReady(context.Background(), gameID, players[1].ID, true)
+
+	// The third player is still holding back the start
+	assert.NoError(t, err)
+	assert.False(t, lobbyState.AllReady)
+	assert.False(t, lobbyState.CanStart)
+	assert.Nil(t, lobbyState.StartsAt)
+	assert.True(t, lobbyState.Players[0].Ready)
+	mockPlayerService.AssertExpectations(t)
+	mockWSHub.AssertCalled(t, "BroadcastToGame", gameID, "LOBBY_STATE", lobbyState)
+}
+
+func TestSetReady_PlayerNotFound(t *testing.T) {
+	// Create mocks
+	mockGameService := new(game_mock.MockGameService)
+	mockGameStateService := new(gameState_mock.MockGameStateService)
+	mockPlayerService := new(player_mock.MockPlayerService)
+	mockBoardService := new(board_mock.MockBoardService)
+	mockMovementCardService := new(movementCard_mock.MockMovementCardService)
+	mockFigureCardService := new(figureCard_mock.MockFigureCardService)
+	mockGameEventService := new(gameEvent_mock.MockGameEventService)
+	mockGameplayService := new(gameplay_mock.MockGameplayService)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	db, _ := databasetest.NewDB(t)
+
+	// Create the service with all the mocks
+	service := lobby.NewService(mockGameService, mockGameStateService, mockPlayerService, mockBoardService,
+		mockMovementCardService, mockFigureCardService, mockGameEventService, mockGameplayService, mockLobbyFeedService, mockWSHub, db, 0)
+
+	gameID := uuid.New()
+	playerID := uuid.New()
+	mockGameStateService.On("GetGameStateByGameID", mock.Anything, gameID).
+		Return(&gameState.GameState{GameID: gameID, State: gameState.WAITING}, nil)
+	mockPlayerService.On("GetPlayerByID", mock.Anything, playerID, gameID).
+		Return(player.Player{}, sql.ErrNoRows)
+
+	_, err := service.SetReady(context.Background(), gameID, playerID, true)
+
+	assert.ErrorIs(t, err, lobby.ErrPlayerNotFound)
+	mockPlayerService.AssertNotCalled(t, "SetReady", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSetReady_AutoStart(t *testing.T) {
+	// Create mocks
+	mockGameService := new(game_mock.MockGameService)
+	mockGameStateService := new(gameState_mock.MockGameStateService)
+	mockPlayerService := new(player_mock.MockPlayerService)
+	mockBoardService := new(board_mock.MockBoardService)
+	mockMovementCardService := new(movementCard_mock.MockMovementCardService)
+	mockFigureCardService := new(figureCard_mock.MockFigureCardService)
+	mockGameEventService := new(gameEvent_mock.MockGameEventService)
+	mockGameplayService := new(gameplay_mock.MockGameplayService)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	db, _ := databasetest.NewDB(t)
+
+	// Create the service with all the mocks
+	service := lobby.NewService(mockGameService, mockGameStateService, mockPlayerService, mockBoardService,
+		mockMovementCardService, mockFigureCardService, mockGameEventService, mockGameplayService, mockLobbyFeedService, mockWSHub, db, 10*time.Millisecond)
+
+	gameID := uuid.New()
+	players := testPlayers(gameID)
+	players[1].Ready = true
+	started := make(chan struct{})
+	mockGameplayService.On("BeginTurn", gameID, players[0].ID).
+		Run(func(mock.Arguments) { close(started) }).
+		Return()
+	expectLobby(mockGameService, mockGameStateService, mockPlayerService, gameID, players, 2)
+	expectStart(mockGameService, mockGameStateService, mockPlayerService, mockBoardService,
+		mockMovementCardService, mockFigureCardService, mockGameEventService, mockGameplayService,
+		mockLobbyFeedService, mockWSHub, gameID, players, "")
+	mockPlayerService.On("GetPlayerByID", mock.Anything, players[1].ID, gameID).
+		Return(players[1], nil)
+	mockPlayerService.On("SetReady", mock.Anything, players[1].ID, true).
+		Return(nil)
+	mockWSHub.On("BroadcastToGame", gameID, "LOBBY_STATE", mock.Anything).
+		Return()
+
+	lobbyState, err := service.SetReady(context.Background(), gameID, players[1].ID, true)
+
+	assert.NoError(t, err)
+	assert.True(t, lobbyState.CanStart)
+	assert.NotNil(t, lobbyState.StartsAt)
+
+	// The game starts on its own once the countdown ends
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("the game didn't start after the countdown")
+	}
+}
+
+func TestSetReady_CancelCountdown(t *testing.T) {
+	// Create mocks
+	mockGameService := new(game_mock.MockGameService)
+	mockGameStateService := new(gameState_mock.MockGameStateService)
+	mockPlayerService := new(player_mock.MockPlayerService)
+	mockBoardService := new(board_mock.MockBoardService)
+	mockMovementCardService := new(movementCard_mock.MockMovementCardService)
+	mockFigureCardService := new(figureCard_mock.MockFigureCardService)
+	mockGameEventService := new(gameEvent_mock.MockGameEventService)
+	mockGameplayService := new(gameplay_mock.MockGameplayService)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	db, _ := databasetest.NewDB(t)
+
+	// Create the service with all the mocks
+	service := lobby.NewService(mockGameService, mockGameStateService, mockPlayerService, mockBoardService,
+		mockMovementCardService, mockFigureCardService, mockGameEventService, mockGameplayService, mockLobbyFeedService, mockWSHub, db, 20*time.Millisecond)
+
+	gameID := uuid.New()
+	players := testPlayers(gameID)
+	readyPlayers := []player.Player{players[0], players[1]}
+	readyPlayers[1].Ready = true
+	mockGameService.On("GetGameByID", mock.Anything, gameID).
+		Return(&game.Game{ID: gameID, MinPlayers: 2, MaxPlayers: 4}, nil)
+	mockGameStateService.On("GetGameStateByGameID", mock.Anything, gameID).
+		Return(&gameState.GameState{GameID: gameID, State: gameState.WAITING}, nil)
+	mockPlayerService.On("GetPlayersInGame", mock.Anything, gameID).
+		Return(readyPlayers, nil).Once()
+	mockPlayerService.On("GetPlayersInGame", mock.Anything, gameID).
+		Return(players, nil)
+	mockPlayerService.On("GetPlayerByID", mock.Anything, players[1].ID, gameID).
+		Return(players[1], nil)
+	mockPlayerService.On("SetReady", mock.Anything, players[1].ID, mock.Anything).
+		Return(nil)
+	mockWSHub.On("BroadcastToGame", gameID, "LOBBY_STATE", mock.Anything).
+		Return()
+
+	lobbyState, err := service.SetReady(context.Background(), gameID, players[1].ID, true)
+	assert.NoError(t, err)
+	assert.NotNil(t, lobbyState.StartsAt)
+
+	lobbyState, err = service.SetReady(context.Background(), gameID, players[1].ID, false)
+	assert.NoError(t, err)
+	assert.Nil(t, lobbyState.StartsAt)
+
+	// The countdown never fires
+	time.Sleep(50 * time.Millisecond)
+	mockGameStateService.AssertNotCalled(t, "TransitionGameState", mock.Anything, gameID, gameState.WAITING, gameState.PLAYING)
+}
+
+func TestKickPlayer(t *testing.T) {
+	tests := []struct {
+		name string
+		ban  bool
+	}{
+		{"Kick", false},
+		{"Kick and ban", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create mocks
+			mockGameService := new(game_mock.MockGameService)
+			mockGameStateService := new(gameState_mock.MockGameStateService)
+			mockPlayerService := new(player_mock.MockPlayerService)
+			mockBoardService := new(board_mock.MockBoardService)
+			mockMovementCardService := new(movementCard_mock.MockMovementCardService)
+			mockFigureCardService := new(figureCard_mock.MockFigureCardService)
+			mockGameEventService := new(gameEvent_mock.MockGameEventService)
+			mockGameplayService := new(gameplay_mock.MockGameplayService)
+			mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
+			mockWSHub := new(websocket_mock.MockWebSocketHub)
+			db, _ := databasetest.NewDB(t)
+
+			// Create the service with all the mocks
+			service := lobby.NewService(mockGameService, mockGameStateService, mockPlayerService, mockBoardService,
+				mockMovementCardService, mockFigureCardService, mockGameEventService, mockGameplayService, mockLobbyFeedService, mockWSHub, db, 0)
+
+			gameID := uuid.New()
+			players := testPlayers(gameID)
+			host, kicked := players[0], players[1]
+			expectLobby(mockGameService, mockGameStateService, mockPlayerService, gameID, []player.Player{host}, 2)
+			mockPlayerService.On("GetPlayerByID", mock.Anything, host.ID, gameID).
+				Return(host, nil)
+			mockPlayerService.On("GetPlayerByID", mock.Anything, kicked.ID, gameID).
+				Return(kicked, nil)
+			mockPlayerService.On("DeletePlayer", mock.Anything, kicked.ID, gameID).
+				Return(nil)
+			if tt.ban {
+				mockGameService.On("BanPlayer", mock.Anything, gameID, kicked.Name, kicked.UserID).
+					Return(nil)
+			}
+			mockWSHub.On("DisconnectPlayer", gameID, kicked.ID, websocket.CLOSE_KICKED, mock.Anything).
+				Return()
+			mockWSHub.On("BroadcastToGame", gameID, mock.Anything, mock.Anything).
+				Return()
+			mockWSHub.On("BroadcastEvent", uuid.Nil, mock.Anything).
+				Return()
+			mockLobbyFeedService.On("GameUpdated", mock.Anything, gameID).
+				Return()
+
+			var removed []lobby.KickedPlayer
+			service.OnPlayerRemoved(func(ctx context.Context, id uuid.UUID, player lobby.KickedPlayer) {
+				assert.Equal(t, gameID, id)
+				removed = append(removed, player)
+			})
+
+			err := service.KickPlayer(context.Background(), gameID, host.ID, kicked.ID, tt.ban)
+
+			assert.NoError(t, err)
+			assert.Equal(t, []lobby.KickedPlayer{{PlayerID: kicked.ID, Name: kicked.Name, Banned: tt.ban}}, removed)
+			mockPlayerService.AssertExpectations(t)
+			mockGameService.AssertExpectations(t)
+			mockLobbyFeedService.AssertExpectations(t)
+			mockWSHub.AssertCalled(t, "BroadcastToGame", gameID, "PLAYER_KICKED", mock.Anything)
+			mockWSHub.AssertCalled(t, "BroadcastToGame", gameID, "LOBBY_STATE", mock.Anything)
+			mockWSHub.AssertCalled(t, "BroadcastEvent", uuid.Nil, fmt.Sprintf("%s:GAME_INFO_UPDATE", gameID))
+			if !tt.ban {
+				mockGameService.AssertNotCalled(t, "BanPlayer", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestKickPlayer_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		state  gameState.State
+		caller int
+		target int
+		err    error
+	}{
+		{"Game started", gameState.PLAYING, 0, 1, lobby.ErrGameStarted},
+		{"Not host", gameState.WAITING, 1, 0, lobby.ErrNotHost},
+		{"Kicking the host", gameState.WAITING, 0, 0, lobby.ErrKickHost},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create mocks
+			mockGameService := new(game_mock.MockGameService)
+			mockGameStateService := new(gameState_mock.MockGameStateService)
+			mockPlayerService := new(player_mock.MockPlayerService)
+			mockBoardService := new(board_mock.MockBoardService)
+			mockMovementCardService := new(movementCard_mock.MockMovementCardService)
+			mockFigureCardService := new(figureCard_mock.MockFigureCardService)
+			mockGameEventService := new(gameEvent_mock.MockGameEventService)
+			mockGameplayService := new(gameplay_mock.MockGameplayService)
+			mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
+			mockWSHub := new(websocket_mock.MockWebSocketHub)
+			db, _ := databasetest.NewDB(t)
+
+			// Create the service with all the mocks
+			service := lobby.NewService(mockGameService, mockGameStateService, mockPlayerService, mockBoardService,
+				mockMovementCardService, mockFigureCardService, mockGameEventService, mockGameplayService, mockLobbyFeedService, mockWSHub, db, 0)
+
+			gameID := uuid.New()
+			players := testPlayers(gameID)
+			mockGameStateService.On("GetGameStateByGameID", mock.Anything, gameID).
+				Return(&gameState.GameState{GameID: gameID, State: tt.state}, nil)
+			for _, p := range players {
+				mockPlayerService.On("GetPlayerByID", mock.Anything, p.ID, gameID).
+					Return(p, nil)
+			}
+
+			err := service.KickPlayer(context.Background(), gameID, players[tt.caller].ID, players[tt.target].ID, true)
+
+			assert.ErrorIs(t, err, tt.err)
+			mockPlayerService.AssertNotCalled(t, "DeletePlayer", mock.Anything, mock.Anything, mock.Anything)
+			mockWSHub.AssertNotCalled(t, "DisconnectPlayer", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestLeaveGame(t *testing.T) {
+	// Create mocks
+	mockGameService := new(game_mock.MockGameService)
+	mockGameStateService := new(gameState_mock.MockGameStateService)
+	mockPlayerService := new(player_mock.MockPlayerService)
+	mockBoardService := new(board_mock.MockBoardService)
+	mockMovementCardService := new(movementCard_mock.MockMovementCardService)
+	mockFigureCardService := new(figureCard_mock.MockFigureCardService)
+	mockGameEventService := new(gameEvent_mock.MockGameEventService)
+	mockGameplayService := new(gameplay_mock.MockGameplayService)
+	mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	db, _ := databasetest.NewDB(t)
+
+	// Create the service with all the mocks
+	service := lobby.NewService(mockGameService, mockGameStateService, mockPlayerService, mockBoardService,
+		mockMovementCardService, mockFigureCardService, mockGameEventService, mockGameplayService, mockLobbyFeedService, mockWSHub, db, 0)
+
+	gameID := uuid.New()
+	players := testPlayers(gameID)
+	host, leaving := players[0], players[1]
+	expectLobby(mockGameService, mockGameStateService, mockPlayerService, gameID, []player.Player{host}, 2)
+	mockPlayerService.On("GetPlayerByID", mock.Anything, leaving.ID, gameID).
+		Return(leaving, nil)
+	mockPlayerService.On("DeletePlayer", mock.Anything, leaving.ID, gameID).
+		Return(nil)
+	mockWSHub.On("DisconnectPlayer", gameID, leaving.ID, websocket.CLOSE_LEFT, mock.Anything).
+		Return()
+	mockWSHub.On("BroadcastToGame", gameID, mock.Anything, mock.Anything).
+		Return()
+	mockWSHub.On("BroadcastEvent", uuid.Nil, mock.Anything).
+		Return()
+	mockLobbyFeedService.On("GameUpdated", mock.Anything, gameID).
+		Return()
+
+	var removed []lobby.KickedPlayer
+	service.OnPlayerRemoved(func(ctx context.Context, id uuid.UUID, player lobby.KickedPlayer) {
+		removed = append(removed, player)
+	})
+
+	err := service.LeaveGame(context.Background(), gameID, leaving.ID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []lobby.KickedPlayer{{PlayerID: leaving.ID, Name: leaving.Name, Left: true}}, removed)
+	mockPlayerService.AssertExpectations(t)
+	mockLobbyFeedService.AssertExpectations(t)
+	mockWSHub.AssertExpectations(t)
+	mockWSHub.AssertCalled(t, "BroadcastToGame", gameID, "LOBBY_STATE", mock.Anything)
+	mockWSHub.AssertNotCalled(t, "BroadcastToGame", gameID, "PLAYER_KICKED", mock.Anything)
+}
+
+func TestLeaveGame_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		state  gameState.State
+		player int
+		err    error
+	}{
+		{"Game started", gameState.PLAYING, 1, lobby.ErrGameStarted},
+		{"Host leaving", gameState.WAITING, 0, lobby.ErrHostLeaving},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create mocks
+			mockGameService := new(game_mock.MockGameService)
+			mockGameStateService := new(gameState_mock.MockGameStateService)
+			mockPlayerService := new(player_mock.MockPlayerService)
+			mockBoardService := new(board_mock.MockBoardService)
+			mockMovementCardService := new(movementCard_mock.MockMovementCardService)
+			mockFigureCardService := new(figureCard_mock.MockFigureCardService)
+			mockGameEventService := new(gameEvent_mock.MockGameEventService)
+			mockGameplayService := new(gameplay_mock.MockGameplayService)
+			mockLobbyFeedService := new(lobbyFeed_mock.MockLobbyFeedService)
+			mockWSHub := new(websocket_mock.MockWebSocketHub)
+			db, _ := databasetest.NewDB(t)
+
+			// Create the service with all the mocks
+			service := lobby.NewService(mockGameService, mockGameStateService, mockPlayerService, mockBoardService,
+				mockMovementCardService, mockFigureCardService, mockGameEventService, mockGameplayService, mockLobbyFeedService, mockWSHub, db, 0)
+
+			gameID := uuid.New()
+			players := testPlayers(gameID)
+			mockGameStateService.On("GetGameStateByGameID", mock.Anything, gameID).
+				Return(&gameState.GameState{GameID: gameID, State: tt.state}, nil)
+			for _, p := range players {
+				mockPlayerService.On("GetPlayerByID", mock.Anything, p.ID, gameID).
+					Return(p, nil)
+			}
+
+			err := service.LeaveGame(context.Background(), gameID, players[tt.player].ID)
+
+			assert.ErrorIs(t, err, tt.err)
+			mockPlayerService.AssertNotCalled(t, "DeletePlayer", mock.Anything, mock.Anything, mock.Anything)
+			mockWSHub.AssertNotCalled(t, "DisconnectPlayer", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
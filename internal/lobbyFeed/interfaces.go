@@ -0,0 +1,16 @@
+package lobbyFeed
+
+import (
+	"context"
+
+	"github.com/NachoGz/switcher-backend-go/internal/game"
+	"github.com/NachoGz/switcher-backend-go/internal/websocket"
+	"github.com/google/uuid"
+)
+
+type LobbyFeedService interface {
+	GameAdded(ctx context.Context, gameID uuid.UUID)
+	GameUpdated(ctx context.Context, gameID uuid.UUID)
+	GameRemoved(removed game.Game)
+	SendSnapshot(client *websocket.Client)
+}
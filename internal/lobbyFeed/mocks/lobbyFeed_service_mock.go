@@ -0,0 +1,30 @@
+package lobbyFeed_mock
+
+import (
+	"context"
+
+	"github.com/NachoGz/switcher-backend-go/internal/game"
+	"github.com/NachoGz/switcher-backend-go/internal/websocket"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockLobbyFeedService struct {
+	mock.Mock
+}
+
+func (m *MockLobbyFeedService) GameAdded(ctx context.Context, gameID uuid.UUID) {
+	m.Called(ctx, gameID)
+}
+
+func (m *MockLobbyFeedService) GameUpdated(ctx context.Context, gameID uuid.UUID) {
+	m.Called(ctx, gameID)
+}
+
+func (m *MockLobbyFeedService) GameRemoved(removed game.Game) {
+	m.Called(removed)
+}
+
+func (m *MockLobbyFeedService) SendSnapshot(client *websocket.Client) {
+	m.Called(client)
+}
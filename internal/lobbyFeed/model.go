@@ -0,0 +1,31 @@
+package lobbyFeed
+
+import (
+	"github.com/NachoGz/switcher-backend-go/internal/game"
+	"github.com/NachoGz/switcher-backend-go/internal/websocket"
+)
+
+// Messages sent to the lobby room
+const (
+	GAME_ADDED     = websocket.GAME_ADDED
+	GAME_UPDATED   = websocket.GAME_UPDATED
+	GAME_REMOVED   = websocket.GAME_REMOVED
+	GAMES_SNAPSHOT = websocket.GAMES_SNAPSHOT
+
+	// Games fetched at a time when building a snapshot
+	SNAPSHOT_PAGE_SIZE = 50
+)
+
+// GameDelta is a change to the list of games waiting for players. Versions
+// grow by one with every delta
+type GameDelta struct {
+	Version uint64    `json:"version"`
+	Game    game.Game `json:"game"`
+}
+
+// Snapshot is the whole list of games waiting for players. Clients drop the
+// deltas whose version isn't greater than the snapshot's
+type Snapshot struct {
+	Version uint64      `json:"version"`
+	Games   []game.Game `json:"games"`
+}
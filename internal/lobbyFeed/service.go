@@ -0,0 +1,118 @@
+package lobbyFeed
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/NachoGz/switcher-backend-go/internal/game"
+	"github.com/NachoGz/switcher-backend-go/internal/logging"
+	"github.com/NachoGz/switcher-backend-go/internal/websocket"
+	"github.com/google/uuid"
+)
+
+// Service keeps the clients in the lobby room in sync with the games waiting
+// for players, sending them what changed instead of making them refetch
+type Service struct {
+	gameService game.GameService
+	wsHub       websocket.WebSocketHub
+
+	// Held while publishing so versions reach the hub in order
+	mu      sync.Mutex
+	version uint64
+}
+
+// NewService creates a new lobby feed service
+func NewService(
+	gameService game.GameService,
+	wsHub websocket.WebSocketHub,
+) *Service {
+	return &Service{
+		gameService: gameService,
+		wsHub:       wsHub,
+	}
+}
+
+// Ensure Service implements LobbyFeedService
+var _ LobbyFeedService = (*Service)(nil)
+
+// GameAdded announces a game that was just created
+func (s *Service) GameAdded(ctx context.Context, gameID uuid.UUID) {
+	s.publishGame(ctx, GAME_ADDED, gameID)
+}
+
+// GameUpdated announces a change to a waiting game, like its players count
+func (s *Service) GameUpdated(ctx context.Context, gameID uuid.UUID) {
+	s.publishGame(ctx, GAME_UPDATED, gameID)
+}
+
+// GameRemoved announces a game that started or was deleted. It takes the
+// game as it was last seen since it may no longer be stored
+func (s *Service) GameRemoved(removed game.Game) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.publish(GAME_REMOVED, removed)
+}
+
+// SendSnapshot sends the games waiting for players to a client that just
+// joined the lobby room. Meant to be used as a hub register hook
+func (s *Service) SendSnapshot(client *websocket.Client) {
+	if client.GameID != uuid.Nil {
+		return
+	}
+
+	// No delta can be published while the snapshot is built, so the
+	// snapshot reaches the client before any newer delta
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	games, err := s.waitingGames(client.Context())
+	if err != nil {
+		slog.ErrorContext(client.Context(), "Error building lobby snapshot", "error", err)
+		return
+	}
+
+	s.wsHub.SendToClient(client, GAMES_SNAPSHOT, Snapshot{
+		Version: s.version,
+		Games:   games,
+	})
+}
+
+func (s *Service) publishGame(ctx context.Context, eventType string, gameID uuid.UUID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	changed, err := s.gameService.GetGameByID(ctx, gameID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error fetching game for the lobby", logging.GameID(gameID), "error", err)
+		return
+	}
+	s.publish(eventType, *changed)
+}
+
+// publish broadcasts a delta with the next version. The caller must hold the lock
+func (s *Service) publish(eventType string, changed game.Game) {
+	s.version++
+	s.wsHub.BroadcastToGame(uuid.Nil, eventType, GameDelta{
+		Version: s.version,
+		Game:    changed,
+	})
+}
+
+// waitingGames pages through every game waiting for players
+func (s *Service) waitingGames(ctx context.Context) ([]game.Game, error) {
+	games := []game.Game{}
+	filter := game.GameFilter{Sort: game.SORT_NEWEST, Limit: SNAPSHOT_PAGE_SIZE}
+	for {
+		page, err := s.gameService.SearchGames(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		games = append(games, page.Games...)
+
+		if page.NextCursor == "" {
+			return games, nil
+		}
+		filter.Cursor = page.NextCursor
+	}
+}
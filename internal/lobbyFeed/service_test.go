@@ -0,0 +1,142 @@
+package lobbyFeed_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/NachoGz/switcher-backend-go/internal/game"
+	game_mock "github.com/NachoGz/switcher-backend-go/internal/game/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/lobbyFeed"
+	"github.com/NachoGz/switcher-backend-go/internal/websocket"
+	websocket_mock "github.com/NachoGz/switcher-backend-go/internal/websocket/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDeltas(t *testing.T) {
+	// Create mocks
+	mockGameService := new(game_mock.MockGameService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+
+	// Create the service with all the mocks
+	service := lobbyFeed.NewService(mockGameService, mockWSHub)
+
+	// Test data, private games carry the password hash that must not be sent
+	passwordHash := "$2a$10$hash"
+	added := game.Game{ID: uuid.New(), Name: "New game", PlayersCount: 1, IsPrivate: true, Password: &passwordHash, Seed: 42}
+	updated := game.Game{ID: added.ID, Name: "New game", PlayersCount: 2, IsPrivate: true, Password: &passwordHash, Seed: 42}
+
+	// Setup expectations
+	mockGameService.On("GetGameByID", mock.Anything, added.ID).Return(&added, nil).Once()
+	mockGameService.On("GetGameByID", mock.Anything, added.ID).Return(&updated, nil).Once()
+	mockWSHub.On("BroadcastToGame", uuid.Nil, lobbyFeed.GAME_ADDED, lobbyFeed.GameDelta{Version: 1, Game: added}).Return()
+	mockWSHub.On("BroadcastToGame", uuid.Nil, lobbyFeed.GAME_UPDATED, lobbyFeed.GameDelta{Version: 2, Game: updated}).Return()
+	mockWSHub.On("BroadcastToGame", uuid.Nil, lobbyFeed.GAME_REMOVED, lobbyFeed.GameDelta{Version: 3, Game: updated}).Return()
+
+	// Publish the changes
+	service.GameAdded(context.Background(), added.ID)
+	service.GameUpdated(context.Background(), added.ID)
+	service.GameRemoved(updated)
+
+	// Verify mocks are called
+	mockGameService.AssertExpectations(t)
+	mockWSHub.AssertExpectations(t)
+}
+
+func TestDeltas_GameNotFound(t *testing.T) {
+	// Create mocks
+	mockGameService := new(game_mock.MockGameService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+
+	// Create the service with all the mocks
+	service := lobbyFeed.NewService(mockGameService, mockWSHub)
+
+	gameID := uuid.New()
+	mockGameService.On("GetGameByID", mock.Anything, gameID).Return((*game.Game)(nil), errors.New("not found"))
+
+	service.GameUpdated(context.Background(), gameID)
+
+	// Nothing is published and the version is not used up
+	mockWSHub.AssertNotCalled(t, "BroadcastToGame", mock.Anything, mock.Anything, mock.Anything)
+
+	removed := game.Game{ID: gameID}
+	mockWSHub.On("BroadcastToGame", uuid.Nil, lobbyFeed.GAME_REMOVED, lobbyFeed.GameDelta{Version: 1, Game: removed}).Return()
+	service.GameRemoved(removed)
+	mockWSHub.AssertExpectations(t)
+}
+
+func TestSendSnapshot(t *testing.T) {
+	// Create mocks
+	mockGameService := new(game_mock.MockGameService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+
+	// Create the service with all the mocks
+	service := lobbyFeed.NewService(mockGameService, mockWSHub)
+
+	// Test data
+	first := game.Game{ID: uuid.New(), Name: "First"}
+	second := game.Game{ID: uuid.New(), Name: "Second"}
+	client := &websocket.Client{GameID: uuid.Nil}
+
+	// Publish a change so the snapshot is at version 1
+	mockWSHub.On("BroadcastToGame", uuid.Nil, lobbyFeed.GAME_REMOVED, mock.Anything).Return()
+	service.GameRemoved(game.Game{ID: uuid.New()})
+
+	// Setup expectations, the snapshot pages through every waiting game
+	mockGameService.On("SearchGames", mock.Anything, game.GameFilter{Sort: game.SORT_NEWEST, Limit: lobbyFeed.SNAPSHOT_PAGE_SIZE}).
+		Return(&game.GamePage{Games: []game.Game{first}, Total: 2, NextCursor: "next"}, nil)
+	mockGameService.On("SearchGames", mock.Anything, game.GameFilter{Sort: game.SORT_NEWEST, Cursor: "next", Limit: lobbyFeed.SNAPSHOT_PAGE_SIZE}).
+		Return(&game.GamePage{Games: []game.Game{second}, Total: 2}, nil)
+	mockWSHub.On("SendToClient", client, lobbyFeed.GAMES_SNAPSHOT, lobbyFeed.Snapshot{
+		Version: 1,
+		Games:   []game.Game{first, second},
+	}).Return()
+
+	service.SendSnapshot(client)
+
+	// Verify mocks are called
+	mockGameService.AssertExpectations(t)
+	mockWSHub.AssertExpectations(t)
+}
+
+func TestSendSnapshot_GameRoom(t *testing.T) {
+	// Create mocks
+	mockGameService := new(game_mock.MockGameService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+
+	// Create the service with all the mocks
+	service := lobbyFeed.NewService(mockGameService, mockWSHub)
+
+	// Clients of a game room don't get the lobby
+	service.SendSnapshot(&websocket.Client{GameID: uuid.New()})
+
+	mockGameService.AssertNotCalled(t, "SearchGames", mock.Anything, mock.Anything)
+	mockWSHub.AssertNotCalled(t, "SendToClient", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeltas_HideSecrets(t *testing.T) {
+	// Create mocks
+	mockGameService := new(game_mock.MockGameService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+
+	// Create the service with all the mocks
+	service := lobbyFeed.NewService(mockGameService, mockWSHub)
+
+	// Capture what is broadcast
+	var sent lobbyFeed.GameDelta
+	mockWSHub.On("BroadcastToGame", uuid.Nil, lobbyFeed.GAME_REMOVED, mock.Anything).Run(func(args mock.Arguments) {
+		sent = args.Get(2).(lobbyFeed.GameDelta)
+	}).Return()
+
+	passwordHash := "$2a$10$hash"
+	service.GameRemoved(game.Game{ID: uuid.New(), IsPrivate: true, Password: &passwordHash, Seed: 42})
+
+	encoded, err := json.Marshal(sent)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(encoded), "password")
+	assert.NotContains(t, string(encoded), passwordHash)
+	assert.NotContains(t, string(encoded), "seed")
+}
@@ -0,0 +1,67 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+)
+
+const (
+	REQUEST_ID_KEY = "request_id"
+	GAME_ID_KEY    = "game_id"
+	PLAYER_ID_KEY  = "player_id"
+)
+
+type contextKey int
+
+const (
+	requestIDKey contextKey = iota
+	gameIDKey
+	playerIDKey
+)
+
+// WithRequestID returns a copy of ctx that logs the ID of the request
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// RequestID returns the ID of the request stored in ctx, if any
+func RequestID(ctx context.Context) (string, bool) {
+	requestID, ok := ctx.Value(requestIDKey).(string)
+	return requestID, ok
+}
+
+// WithGameID returns a copy of ctx that logs the ID of the game
+func WithGameID(ctx context.Context, gameID uuid.UUID) context.Context {
+	return context.WithValue(ctx, gameIDKey, gameID)
+}
+
+// WithPlayerID returns a copy of ctx that logs the ID of the player
+func WithPlayerID(ctx context.Context, playerID uuid.UUID) context.Context {
+	return context.WithValue(ctx, playerIDKey, playerID)
+}
+
+// GameID is the attribute of a game, for logs without a request context
+func GameID(gameID uuid.UUID) slog.Attr {
+	return slog.String(GAME_ID_KEY, gameID.String())
+}
+
+// PlayerID is the attribute of a player, for logs without a request context
+func PlayerID(playerID uuid.UUID) slog.Attr {
+	return slog.String(PLAYER_ID_KEY, playerID.String())
+}
+
+func attrsFromContext(ctx context.Context) []slog.Attr {
+	var attrs []slog.Attr
+	if requestID, ok := RequestID(ctx); ok {
+		attrs = append(attrs, slog.String(REQUEST_ID_KEY, requestID))
+	}
+	if gameID, ok := ctx.Value(gameIDKey).(uuid.UUID); ok {
+		attrs = append(attrs, GameID(gameID))
+	}
+	if playerID, ok := ctx.Value(playerIDKey).(uuid.UUID); ok {
+		attrs = append(attrs, PlayerID(playerID))
+	}
+	return attrs
+}
@@ -0,0 +1,69 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+const DEFAULT_LEVEL = slog.LevelInfo
+
+// New builds a logger that writes JSON lines at the given level or above,
+// with the IDs of the request, game and player in the context and with
+// sensitive attributes redacted
+func New(w io.Writer, level slog.Leveler) *slog.Logger {
+	handler := slog.NewJSONHandler(w, &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: Redact,
+	})
+	return slog.New(&contextHandler{Handler: handler})
+}
+
+// ParseLevel reads a level such as "debug" or "WARN". An empty level is the
+// default one
+func ParseLevel(value string) (slog.Level, error) {
+	if strings.TrimSpace(value) == "" {
+		return DEFAULT_LEVEL, nil
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.TrimSpace(value))); err != nil {
+		return DEFAULT_LEVEL, fmt.Errorf("invalid log level %q: %w", value, err)
+	}
+	return level, nil
+}
+
+// contextHandler adds the correlation IDs carried by the context to every
+// record logged with it
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if ctx == nil {
+		return h.Handler.Handle(ctx, record)
+	}
+
+	// Attributes passed by the caller win over the context
+	present := map[string]bool{}
+	record.Attrs(func(a slog.Attr) bool {
+		present[a.Key] = true
+		return true
+	})
+	for _, attr := range attrsFromContext(ctx) {
+		if !present[attr.Key] {
+			record.AddAttrs(attr)
+		}
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,99 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/NachoGz/switcher-backend-go/internal/logging"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decode(t *testing.T, buf *bytes.Buffer) map[string]any {
+	t.Helper()
+
+	var line map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+	buf.Reset()
+	return line
+}
+
+func TestContextAttributes(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.New(&buf, slog.LevelInfo)
+
+	gameID := uuid.New()
+	playerID := uuid.New()
+	ctx := logging.WithRequestID(context.Background(), "req-1")
+	ctx = logging.WithGameID(ctx, gameID)
+	ctx = logging.WithPlayerID(ctx, playerID)
+
+	logger.InfoContext(ctx, "Played movement")
+	line := decode(t, &buf)
+	assert.Equal(t, "Played movement", line["msg"])
+	assert.Equal(t, "req-1", line[logging.REQUEST_ID_KEY])
+	assert.Equal(t, gameID.String(), line[logging.GAME_ID_KEY])
+	assert.Equal(t, playerID.String(), line[logging.PLAYER_ID_KEY])
+
+	// The caller's attributes win over the context
+	otherGame := uuid.New()
+	logger.InfoContext(ctx, "Other game", logging.GameID(otherGame))
+	assert.Equal(t, otherGame.String(), decode(t, &buf)[logging.GAME_ID_KEY])
+
+	logger.Info("No context")
+	assert.NotContains(t, decode(t, &buf), logging.REQUEST_ID_KEY)
+}
+
+func TestRedaction(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.New(&buf, slog.LevelInfo)
+
+	logger.Info("Joining game",
+		"password", "hunter2",
+		"PasswordHash", "$2a$10$abc",
+		slog.Group("request", slog.String("authorization", "Bearer abc"), slog.String("player_name", "ana")),
+		"session_token", "abc",
+	)
+
+	line := decode(t, &buf)
+	assert.Equal(t, logging.REDACTED, line["password"])
+	assert.Equal(t, logging.REDACTED, line["PasswordHash"])
+	assert.Equal(t, logging.REDACTED, line["session_token"])
+	request := line["request"].(map[string]any)
+	assert.Equal(t, logging.REDACTED, request["authorization"])
+	assert.Equal(t, "ana", request["player_name"])
+}
+
+func TestLevels(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.New(&buf, slog.LevelWarn)
+
+	logger.Info("Hidden")
+	assert.Zero(t, buf.Len())
+	logger.Warn("Shown")
+	assert.Equal(t, "WARN", decode(t, &buf)["level"])
+}
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected slog.Level
+		valid    bool
+	}{
+		{"", logging.DEFAULT_LEVEL, true},
+		{"debug", slog.LevelDebug, true},
+		{"WARN", slog.LevelWarn, true},
+		{" error ", slog.LevelError, true},
+		{"verbose", logging.DEFAULT_LEVEL, false},
+	}
+
+	for _, test := range tests {
+		level, err := logging.ParseLevel(test.value)
+		assert.Equal(t, test.expected, level, test.value)
+		assert.Equal(t, test.valid, err == nil, test.value)
+	}
+}
@@ -0,0 +1,27 @@
+package logging
+
+import (
+	"log/slog"
+	"strings"
+)
+
+const REDACTED = "[REDACTED]"
+
+// Attributes whose key contains any of these are never written
+var SENSITIVE_KEYS = []string{"password", "token", "secret", "authorization", "cookie", "invite"}
+
+// Redact hides the value of sensitive attributes, whatever group they are in.
+// Values logged as a whole struct are not inspected, log their fields instead
+func Redact(groups []string, a slog.Attr) slog.Attr {
+	if a.Value.Kind() == slog.KindGroup {
+		return a
+	}
+
+	key := strings.ToLower(a.Key)
+	for _, sensitive := range SENSITIVE_KEYS {
+		if strings.Contains(key, sensitive) {
+			return slog.String(a.Key, REDACTED)
+		}
+	}
+	return a
+}
@@ -0,0 +1,15 @@
+package matchmaking
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MatchmakingService interface {
+	Join(ctx context.Context, req JoinRequest) (*Status, error)
+	Leave(ctx context.Context, ticketID uuid.UUID) error
+	GetStatus(ticketID uuid.UUID) (*Status, error)
+	Match(ctx context.Context, now time.Time) int
+}
@@ -0,0 +1,40 @@
+package matchmaking_mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/NachoGz/switcher-backend-go/internal/matchmaking"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockMatchmakingService struct {
+	mock.Mock
+}
+
+func (m *MockMatchmakingService) Join(ctx context.Context, req matchmaking.JoinRequest) (*matchmaking.Status, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*matchmaking.Status), args.Error(1)
+}
+
+func (m *MockMatchmakingService) Leave(ctx context.Context, ticketID uuid.UUID) error {
+	args := m.Called(ctx, ticketID)
+	return args.Error(0)
+}
+
+func (m *MockMatchmakingService) GetStatus(ticketID uuid.UUID) (*matchmaking.Status, error) {
+	args := m.Called(ticketID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*matchmaking.Status), args.Error(1)
+}
+
+func (m *MockMatchmakingService) Match(ctx context.Context, now time.Time) int {
+	args := m.Called(ctx, now)
+	return args.Int(0)
+}
@@ -0,0 +1,94 @@
+package matchmaking
+
+import (
+	"time"
+
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
+	"github.com/google/uuid"
+)
+
+// TicketState enum
+type TicketState string
+
+const (
+	QUEUED  TicketState = "queued"
+	MATCHED TicketState = "matched"
+)
+
+const (
+	// How often the queue is scanned for matches
+	MATCH_INTERVAL = 2 * time.Second
+
+	// Rating difference accepted as soon as a player joins. It widens by
+	// BAND_GROWTH for every BAND_STEP spent waiting, up to MAX_BAND
+	BASE_BAND   = 100
+	BAND_GROWTH = 50
+	BAND_STEP   = 10 * time.Second
+	MAX_BAND    = 1000
+
+	// How long the status of a matched ticket can still be read
+	MATCHED_TTL = 5 * time.Minute
+
+	// Name of the games created by the queue
+	GAME_NAME = "Matchmaking game"
+
+	// Tickets a guest client can have waiting at once. Guests behind the
+	// same address share it
+	MAX_GUEST_TICKETS = 3
+)
+
+var (
+	ErrInvalidPlayerCount = utils.NewDomainError(utils.ErrInvalidInput, "INVALID_PLAYER_COUNT", "player count must be 2, 3 or 4, or 0 for any")
+	ErrMissingName        = utils.NewDomainError(utils.ErrInvalidInput, "MISSING_PLAYER_NAME", "player name is required")
+	ErrAlreadyQueued      = utils.NewDomainError(utils.ErrConflict, "ALREADY_QUEUED", "already in the matchmaking queue")
+	ErrTicketNotFound     = utils.NewDomainError(utils.ErrNotFound, "TICKET_NOT_FOUND", "matchmaking ticket not found")
+	ErrTooManyTickets     = utils.NewDomainError(utils.ErrRateLimited, "TOO_MANY_TICKETS", "too many tickets waiting from this client")
+)
+
+type JoinRequest struct {
+	PlayerName string
+	UserID     *uuid.UUID
+	// Address of the client, limiting the tickets of guests
+	Client string
+	// Preferred amount of players, 0 for any
+	PlayerCount int
+}
+
+// Ticket is a player waiting in the queue
+type Ticket struct {
+	ID          uuid.UUID
+	PlayerName  string
+	UserID      *uuid.UUID
+	Client      string
+	Rating      int
+	PlayerCount int
+	JoinedAt    time.Time
+
+	State     TicketState
+	GameID    uuid.UUID
+	PlayerID  uuid.UUID
+	MatchedAt time.Time
+}
+
+// Status is what a player in the queue is told about its ticket
+type Status struct {
+	TicketID    uuid.UUID   `json:"ticket_id"`
+	State       TicketState `json:"state"`
+	Rating      int         `json:"rating"`
+	PlayerCount int         `json:"player_count"`
+	QueueSize   int         `json:"queue_size"`
+	Band        int         `json:"band"`
+	WaitSeconds int         `json:"wait_seconds"`
+	GameID      *uuid.UUID  `json:"game_id,omitempty"`
+	PlayerID    *uuid.UUID  `json:"player_id,omitempty"`
+}
+
+// band returns the rating difference a player accepts after waiting
+func band(wait time.Duration) int {
+	return min(BASE_BAND+BAND_GROWTH*int(wait/BAND_STEP), MAX_BAND)
+}
+
+// fits reports whether a player preferring count can play a game of size
+func fits(count int, size int) bool {
+	return count == 0 || count == size
+}
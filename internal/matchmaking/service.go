@@ -0,0 +1,386 @@
+package matchmaking
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NachoGz/switcher-backend-go/internal/game"
+	"github.com/NachoGz/switcher-backend-go/internal/lobby"
+	"github.com/NachoGz/switcher-backend-go/internal/logging"
+	"github.com/NachoGz/switcher-backend-go/internal/player"
+	"github.com/NachoGz/switcher-backend-go/internal/rating"
+	"github.com/NachoGz/switcher-backend-go/internal/websocket"
+	"github.com/google/uuid"
+)
+
+// Service groups the players waiting in the queue by rating and preferred
+// amount of players, and starts a game for every group. Each ticket has its
+// own websocket room, keyed by the ticket ID, where its status is pushed
+type Service struct {
+	gameService   game.GameService
+	playerService player.PlayerService
+	lobbyService  lobby.LobbyService
+	ratingService rating.RatingService
+	wsHub         websocket.WebSocketHub
+
+	mu sync.Mutex
+	// Waiting tickets, oldest first
+	queue []*Ticket
+	// Matched tickets, kept for a while so clients can read where to go
+	matched map[uuid.UUID]*Ticket
+}
+
+// NewService creates a new matchmaking service
+func NewService(
+	gameService game.GameService,
+	playerService player.PlayerService,
+	lobbyService lobby.LobbyService,
+	ratingService rating.RatingService,
+	wsHub websocket.WebSocketHub,
+) *Service {
+	return &Service{
+		gameService:   gameService,
+		playerService: playerService,
+		lobbyService:  lobbyService,
+		ratingService: ratingService,
+		wsHub:         wsHub,
+		matched:       make(map[uuid.UUID]*Ticket),
+	}
+}
+
+// Ensure Service implements MatchmakingService
+var _ MatchmakingService = (*Service)(nil)
+
+// Run looks for matches every MATCH_INTERVAL until the context is done
+func (s *Service) Run(ctx context.Context) {
+	ticker := time.NewTicker(MATCH_INTERVAL)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.Match(ctx, now)
+		}
+	}
+}
+
+// Join puts a player in the queue
+func (s *Service) Join(ctx context.Context, req JoinRequest) (*Status, error) {
+	req.PlayerName = strings.TrimSpace(req.PlayerName)
+	if req.PlayerName == "" {
+		return nil, ErrMissingName
+	}
+	if req.PlayerCount != 0 && (req.PlayerCount < 2 || req.PlayerCount > 4) {
+		return nil, ErrInvalidPlayerCount
+	}
+
+	playerRating, err := s.ratingService.GetRating(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if req.UserID != nil {
+		for _, queued := range s.queue {
+			if queued.UserID != nil && *queued.UserID == *req.UserID {
+				return nil, ErrAlreadyQueued
+			}
+		}
+	} else {
+		// Guests can't be told apart, so their tickets are counted by client
+		guestTickets := 0
+		for _, queued := range s.queue {
+			if queued.UserID == nil && queued.Client == req.Client {
+				guestTickets++
+			}
+		}
+		if guestTickets >= MAX_GUEST_TICKETS {
+			return nil, ErrTooManyTickets
+		}
+	}
+
+	ticket := &Ticket{
+		ID:          uuid.New(),
+		PlayerName:  req.PlayerName,
+		UserID:      req.UserID,
+		Client:      req.Client,
+		Rating:      playerRating,
+		PlayerCount: req.PlayerCount,
+		JoinedAt:    time.Now(),
+		State:       QUEUED,
+	}
+	s.queue = append(s.queue, ticket)
+
+	return s.status(ticket, ticket.JoinedAt), nil
+}
+
+// Leave takes a waiting player out of the queue
+func (s *Service) Leave(ctx context.Context, ticketID uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, ticket := range s.queue {
+		if ticket.ID == ticketID {
+			s.queue = append(s.queue[:i], s.queue[i+1:]...)
+			return nil
+		}
+	}
+	return ErrTicketNotFound
+}
+
+// GetStatus returns the status of a waiting or recently matched ticket
+func (s *Service) GetStatus(ticketID uuid.UUID) (*Status, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if ticket, ok := s.matched[ticketID]; ok {
+		return s.status(ticket, time.Now()), nil
+	}
+	for _, ticket := range s.queue {
+		if ticket.ID == ticketID {
+			return s.status(ticket, time.Now()), nil
+		}
+	}
+	return nil, ErrTicketNotFound
+}
+
+// Match starts a game for every group of players that can play together at
+// the given time, pushes the new status of every ticket and returns the
+// amount of games started
+func (s *Service) Match(ctx context.Context, now time.Time) int {
+	s.mu.Lock()
+	groups := s.formGroups(now)
+	for id, ticket := range s.matched {
+		if now.Sub(ticket.MatchedAt) > MATCHED_TTL {
+			delete(s.matched, id)
+		}
+	}
+	s.mu.Unlock()
+
+	started := 0
+	for _, group := range groups {
+		if err := s.startMatch(ctx, group, now); err != nil {
+			slog.ErrorContext(ctx, "Error starting matchmaking game", "error", err)
+			s.requeue(group)
+			continue
+		}
+		started++
+	}
+
+	s.pushQueueStatus(now)
+	return started
+}
+
+// formGroups takes out of the queue the players that can be matched. The
+// players that waited the longest are served first. Players fit in the same
+// game when the ratings of every two of them are within the band of either
+func (s *Service) formGroups(now time.Time) [][]*Ticket {
+	taken := map[uuid.UUID]bool{}
+	groups := [][]*Ticket{}
+
+	for _, anchor := range s.queue {
+		if taken[anchor.ID] {
+			continue
+		}
+
+		sizes := []int{anchor.PlayerCount}
+		if anchor.PlayerCount == 0 {
+			sizes = []int{4, 3, 2}
+		}
+
+		for _, size := range sizes {
+			candidates := []*Ticket{}
+			for _, other := range s.queue {
+				if other == anchor || taken[other.ID] || !fits(other.PlayerCount, size) {
+					continue
+				}
+				if matches(anchor, other, now) {
+					candidates = append(candidates, other)
+				}
+			}
+			if len(candidates) < size-1 {
+				continue
+			}
+
+			// Closest ratings first, the queue order breaks ties
+			sort.SliceStable(candidates, func(i, j int) bool {
+				return abs(candidates[i].Rating-anchor.Rating) < abs(candidates[j].Rating-anchor.Rating)
+			})
+
+			// The candidates fit the anchor, but also have to fit each other
+			group := []*Ticket{anchor}
+			for _, candidate := range candidates {
+				if len(group) == size {
+					break
+				}
+				if matchesAll(group, candidate, now) {
+					group = append(group, candidate)
+				}
+			}
+			if len(group) < size {
+				continue
+			}
+
+			for _, ticket := range group {
+				taken[ticket.ID] = true
+			}
+			groups = append(groups, group)
+			break
+		}
+	}
+
+	remaining := s.queue[:0]
+	for _, ticket := range s.queue {
+		if !taken[ticket.ID] {
+			remaining = append(remaining, ticket)
+		}
+	}
+	s.queue = remaining
+	return groups
+}
+
+// matches tells whether two players are close enough in rating to play
+// together, given how long each has been waiting
+func matches(a *Ticket, b *Ticket, now time.Time) bool {
+	accepted := max(band(now.Sub(a.JoinedAt)), band(now.Sub(b.JoinedAt)))
+	return abs(a.Rating-b.Rating) <= accepted
+}
+
+// matchesAll tells whether a player matches every player of a group
+func matchesAll(group []*Ticket, ticket *Ticket, now time.Time) bool {
+	for _, member := range group {
+		if !matches(member, ticket, now) {
+			return false
+		}
+	}
+	return true
+}
+
+// startMatch creates a game for a group, with the first player as host, and
+// starts it right away
+func (s *Service) startMatch(ctx context.Context, group []*Ticket, now time.Time) error {
+	host := group[0]
+	newGame, newGameState, hostPlayer, err := s.gameService.CreateGame(ctx, game.Game{
+		Name:       GAME_NAME,
+		MaxPlayers: len(group),
+		MinPlayers: len(group),
+	}, player.Player{
+		Name:   host.PlayerName,
+		Host:   true,
+		UserID: host.UserID,
+	})
+	if err != nil {
+		return fmt.Errorf("error creating game: %w", err)
+	}
+
+	playerIDs := []uuid.UUID{hostPlayer.ID}
+	for _, ticket := range group[1:] {
+		newPlayer, err := s.playerService.CreatePlayer(ctx, player.Player{
+			Name:        ticket.PlayerName,
+			GameID:      newGame.ID,
+			GameStateID: newGameState.ID,
+			UserID:      ticket.UserID,
+		})
+		if err != nil {
+			s.discardGame(ctx, newGame.ID)
+			return fmt.Errorf("error creating player: %w", err)
+		}
+		playerIDs = append(playerIDs, newPlayer.ID)
+	}
+
+	if err := s.lobbyService.StartGame(ctx, newGame.ID); err != nil {
+		s.discardGame(ctx, newGame.ID)
+		return fmt.Errorf("error starting game: %w", err)
+	}
+
+	s.mu.Lock()
+	for i, ticket := range group {
+		ticket.State = MATCHED
+		ticket.GameID = newGame.ID
+		ticket.PlayerID = playerIDs[i]
+		ticket.MatchedAt = now
+		s.matched[ticket.ID] = ticket
+	}
+	statuses := make([]*Status, len(group))
+	for i, ticket := range group {
+		statuses[i] = s.status(ticket, now)
+	}
+	s.mu.Unlock()
+
+	for _, status := range statuses {
+		s.wsHub.BroadcastToTicket(status.TicketID, websocket.MATCHMAKING_STATUS, status)
+	}
+	return nil
+}
+
+// discardGame deletes a game that couldn't be started
+func (s *Service) discardGame(ctx context.Context, gameID uuid.UUID) {
+	if err := s.gameService.DeleteGame(ctx, gameID); err != nil {
+		slog.ErrorContext(ctx, "Error deleting matchmaking game", logging.GameID(gameID), "error", err)
+	}
+}
+
+// requeue puts back the players of a group that couldn't be started,
+// keeping their place in the queue
+func (s *Service) requeue(group []*Ticket) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.queue = append(s.queue, group...)
+	sort.SliceStable(s.queue, func(i, j int) bool {
+		return s.queue[i].JoinedAt.Before(s.queue[j].JoinedAt)
+	})
+}
+
+// pushQueueStatus sends every waiting player its current status
+func (s *Service) pushQueueStatus(now time.Time) {
+	s.mu.Lock()
+	statuses := make([]*Status, len(s.queue))
+	for i, ticket := range s.queue {
+		statuses[i] = s.status(ticket, now)
+	}
+	s.mu.Unlock()
+
+	for _, status := range statuses {
+		s.wsHub.BroadcastToTicket(status.TicketID, websocket.MATCHMAKING_STATUS, status)
+	}
+}
+
+// status builds the status of a ticket. The caller must hold the lock
+func (s *Service) status(ticket *Ticket, now time.Time) *Status {
+	status := &Status{
+		TicketID:    ticket.ID,
+		State:       ticket.State,
+		Rating:      ticket.Rating,
+		PlayerCount: ticket.PlayerCount,
+		QueueSize:   len(s.queue),
+	}
+
+	if ticket.State == MATCHED {
+		status.GameID = &ticket.GameID
+		status.PlayerID = &ticket.PlayerID
+		status.WaitSeconds = int(ticket.MatchedAt.Sub(ticket.JoinedAt).Seconds())
+		return status
+	}
+
+	wait := now.Sub(ticket.JoinedAt)
+	status.Band = band(wait)
+	status.WaitSeconds = int(wait.Seconds())
+	return status
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,401 @@
+package matchmaking_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NachoGz/switcher-backend-go/internal/game"
+	game_mock "github.com/NachoGz/switcher-backend-go/internal/game/mocks"
+	gameState "github.com/NachoGz/switcher-backend-go/internal/game_state"
+	lobby_mock "github.com/NachoGz/switcher-backend-go/internal/lobby/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/matchmaking"
+	"github.com/NachoGz/switcher-backend-go/internal/player"
+	player_mock "github.com/NachoGz/switcher-backend-go/internal/player/mocks"
+	rating_mock "github.com/NachoGz/switcher-backend-go/internal/rating/mocks"
+	websocket_mock "github.com/NachoGz/switcher-backend-go/internal/websocket/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// joinWithRating queues a registered user with the given rating
+func joinWithRating(t *testing.T, service *matchmaking.Service, mockRatingService *rating_mock.MockRatingService, name string, rating int, count int) *matchmaking.Status {
+	userID := uuid.New()
+	mockRatingService.On("GetRating", mock.Anything, &userID).Return(rating, nil).Once()
+
+	status, err := service.Join(context.Background(), matchmaking.JoinRequest{
+		PlayerName:  name,
+		UserID:      &userID,
+		PlayerCount: count,
+	})
+	assert.NoError(t, err)
+	return status
+}
+
+// expectGame sets up the creation and start of a game with the given amount
+// of players
+func expectGame(
+	mockGameService *game_mock.MockGameService,
+	mockPlayerService *player_mock.MockPlayerService,
+	mockLobbyService *lobby_mock.MockLobbyService,
+	players int,
+) uuid.UUID {
+	gameID := uuid.New()
+	mockGameService.On("CreateGame", mock.Anything, mock.MatchedBy(func(g game.Game) bool {
+		return g.MaxPlayers == players
+	}), mock.AnythingOfType("player.Player")).Return(
+		&game.Game{ID: gameID},
+		&gameState.GameState{ID: uuid.New()},
+		&player.Player{ID: uuid.New(), Host: true},
+		nil,
+	).Once()
+	mockPlayerService.On("CreatePlayer", mock.Anything, mock.MatchedBy(func(p player.Player) bool {
+		return p.GameID == gameID
+	})).Return(&player.Player{ID: uuid.New()}, nil).Times(players - 1)
+	mockLobbyService.On("StartGame", mock.Anything, gameID).Return(nil).Once()
+	return gameID
+}
+
+func TestJoin(t *testing.T) {
+	// Create mocks
+	mockGameService := new(game_mock.MockGameService)
+	mockPlayerService := new(player_mock.MockPlayerService)
+	mockLobbyService := new(lobby_mock.MockLobbyService)
+	mockRatingService := new(rating_mock.MockRatingService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockWSHub.On("BroadcastToTicket", mock.Anything, "MATCHMAKING_STATUS", mock.Anything).Return()
+
+	// Create the service with all the mocks
+	service := matchmaking.NewService(mockGameService, mockPlayerService, mockLobbyService, mockRatingService, mockWSHub)
+
+	// Test data
+	mockRatingService.On("GetRating", mock.Anything, (*uuid.UUID)(nil)).Return(1500, nil)
+
+	// Call service
+	status, err := service.Join(context.Background(), matchmaking.JoinRequest{PlayerName: " Guest ", PlayerCount: 2})
+
+	// Check response
+	assert.NoError(t, err)
+	assert.Equal(t, matchmaking.QUEUED, status.State)
+	assert.Equal(t, 1500, status.Rating)
+	assert.Equal(t, 1, status.QueueSize)
+	assert.Equal(t, matchmaking.BASE_BAND, status.Band)
+
+	fetched, err := service.GetStatus(status.TicketID)
+	assert.NoError(t, err)
+	assert.Equal(t, status.TicketID, fetched.TicketID)
+
+	mockRatingService.AssertExpectations(t)
+}
+
+func TestJoin_InvalidRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		req  matchmaking.JoinRequest
+		err  error
+	}{
+		{"Missing name", matchmaking.JoinRequest{PlayerName: "  ", PlayerCount: 2}, matchmaking.ErrMissingName},
+		{"Too few players", matchmaking.JoinRequest{PlayerName: "Alice", PlayerCount: 1}, matchmaking.ErrInvalidPlayerCount},
+		{"Too many players", matchmaking.JoinRequest{PlayerName: "Alice", PlayerCount: 5}, matchmaking.ErrInvalidPlayerCount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create mocks
+			mockGameService := new(game_mock.MockGameService)
+			mockPlayerService := new(player_mock.MockPlayerService)
+			mockLobbyService := new(lobby_mock.MockLobbyService)
+			mockRatingService := new(rating_mock.MockRatingService)
+			mockWSHub := new(websocket_mock.MockWebSocketHub)
+			mockWSHub.On("BroadcastToTicket", mock.Anything, "MATCHMAKING_STATUS", mock.Anything).Return()
+
+			// Create the service with all the mocks
+			service := matchmaking.NewService(mockGameService, mockPlayerService, mockLobbyService, mockRatingService, mockWSHub)
+
+			_, err := service.Join(context.Background(), tt.req)
+
+			assert.ErrorIs(t, err, tt.err)
+			mockRatingService.AssertNotCalled(t, "GetRating", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestJoin_AlreadyQueued(t *testing.T) {
+	// Create mocks
+	mockGameService := new(game_mock.MockGameService)
+	mockPlayerService := new(player_mock.MockPlayerService)
+	mockLobbyService := new(lobby_mock.MockLobbyService)
+	mockRatingService := new(rating_mock.MockRatingService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockWSHub.On("BroadcastToTicket", mock.Anything, "MATCHMAKING_STATUS", mock.Anything).Return()
+
+	// Create the service with all the mocks
+	service := matchmaking.NewService(mockGameService, mockPlayerService, mockLobbyService, mockRatingService, mockWSHub)
+
+	// Test data
+	userID := uuid.New()
+	mockRatingService.On("GetRating", mock.Anything, &userID).Return(1600, nil)
+	req := matchmaking.JoinRequest{PlayerName: "Alice", UserID: &userID, PlayerCount: 2}
+
+	// Call service
+	_, err := service.Join(context.Background(), req)
+	assert.NoError(t, err)
+	_, err = service.Join(context.Background(), req)
+
+	// Check response
+	assert.ErrorIs(t, err, matchmaking.ErrAlreadyQueued)
+}
+
+func TestJoin_GuestTicketLimit(t *testing.T) {
+	// Create mocks
+	mockGameService := new(game_mock.MockGameService)
+	mockPlayerService := new(player_mock.MockPlayerService)
+	mockLobbyService := new(lobby_mock.MockLobbyService)
+	mockRatingService := new(rating_mock.MockRatingService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockWSHub.On("BroadcastToTicket", mock.Anything, "MATCHMAKING_STATUS", mock.Anything).Return()
+
+	// Create the service with all the mocks
+	service := matchmaking.NewService(mockGameService, mockPlayerService, mockLobbyService, mockRatingService, mockWSHub)
+
+	// Test data
+	mockRatingService.On("GetRating", mock.Anything, (*uuid.UUID)(nil)).Return(1500, nil)
+	req := matchmaking.JoinRequest{PlayerName: "Guest", Client: "203.0.113.7"}
+
+	// Call service
+	tickets := []uuid.UUID{}
+	for range matchmaking.MAX_GUEST_TICKETS {
+		status, err := service.Join(context.Background(), req)
+		assert.NoError(t, err)
+		tickets = append(tickets, status.TicketID)
+	}
+	_, err := service.Join(context.Background(), req)
+
+	// Check response
+	assert.ErrorIs(t, err, matchmaking.ErrTooManyTickets)
+
+	// Other clients have their own limit
+	_, err = service.Join(context.Background(), matchmaking.JoinRequest{PlayerName: "Guest", Client: "198.51.100.2"})
+	assert.NoError(t, err)
+
+	// Leaving frees a ticket
+	assert.NoError(t, service.Leave(context.Background(), tickets[0]))
+	_, err = service.Join(context.Background(), req)
+	assert.NoError(t, err)
+}
+
+func TestLeave(t *testing.T) {
+	// Create mocks
+	mockGameService := new(game_mock.MockGameService)
+	mockPlayerService := new(player_mock.MockPlayerService)
+	mockLobbyService := new(lobby_mock.MockLobbyService)
+	mockRatingService := new(rating_mock.MockRatingService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockWSHub.On("BroadcastToTicket", mock.Anything, "MATCHMAKING_STATUS", mock.Anything).Return()
+
+	// Create the service with all the mocks
+	service := matchmaking.NewService(mockGameService, mockPlayerService, mockLobbyService, mockRatingService, mockWSHub)
+
+	// Test data
+	status := joinWithRating(t, service, mockRatingService, "Alice", 1500, 2)
+
+	// Call service
+	err := service.Leave(context.Background(), status.TicketID)
+
+	// Check response
+	assert.NoError(t, err)
+	_, err = service.GetStatus(status.TicketID)
+	assert.ErrorIs(t, err, matchmaking.ErrTicketNotFound)
+	assert.ErrorIs(t, service.Leave(context.Background(), status.TicketID), matchmaking.ErrTicketNotFound)
+}
+
+func TestMatch_SameBand(t *testing.T) {
+	// Create mocks
+	mockGameService := new(game_mock.MockGameService)
+	mockPlayerService := new(player_mock.MockPlayerService)
+	mockLobbyService := new(lobby_mock.MockLobbyService)
+	mockRatingService := new(rating_mock.MockRatingService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockWSHub.On("BroadcastToTicket", mock.Anything, "MATCHMAKING_STATUS", mock.Anything).Return()
+
+	// Create the service with all the mocks
+	service := matchmaking.NewService(mockGameService, mockPlayerService, mockLobbyService, mockRatingService, mockWSHub)
+
+	// Test data
+	alice := joinWithRating(t, service, mockRatingService, "Alice", 1500, 2)
+	bob := joinWithRating(t, service, mockRatingService, "Bob", 1550, 2)
+	gameID := expectGame(mockGameService, mockPlayerService, mockLobbyService, 2)
+
+	// Call service
+	started := service.Match(context.Background(), time.Now())
+
+	// Check response
+	assert.Equal(t, 1, started)
+	for _, ticketID := range []uuid.UUID{alice.TicketID, bob.TicketID} {
+		status, err := service.GetStatus(ticketID)
+		assert.NoError(t, err)
+		assert.Equal(t, matchmaking.MATCHED, status.State)
+		assert.Equal(t, gameID, *status.GameID)
+		assert.NotNil(t, status.PlayerID)
+		mockWSHub.AssertCalled(t, "BroadcastToTicket", ticketID, "MATCHMAKING_STATUS", mock.Anything)
+	}
+
+	mockGameService.AssertExpectations(t)
+	mockPlayerService.AssertExpectations(t)
+	mockLobbyService.AssertExpectations(t)
+}
+
+func TestMatch_BandWidensOverTime(t *testing.T) {
+	// Create mocks
+	mockGameService := new(game_mock.MockGameService)
+	mockPlayerService := new(player_mock.MockPlayerService)
+	mockLobbyService := new(lobby_mock.MockLobbyService)
+	mockRatingService := new(rating_mock.MockRatingService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockWSHub.On("BroadcastToTicket", mock.Anything, "MATCHMAKING_STATUS", mock.Anything).Return()
+
+	// Create the service with all the mocks
+	service := matchmaking.NewService(mockGameService, mockPlayerService, mockLobbyService, mockRatingService, mockWSHub)
+
+	// Test data
+	alice := joinWithRating(t, service, mockRatingService, "Alice", 1500, 2)
+	joinWithRating(t, service, mockRatingService, "Bob", 1800, 2)
+
+	// Call service
+	started := service.Match(context.Background(), time.Now())
+
+	// Check response
+	assert.Equal(t, 0, started)
+	status, err := service.GetStatus(alice.TicketID)
+	assert.NoError(t, err)
+	assert.Equal(t, matchmaking.QUEUED, status.State)
+	assert.Equal(t, 2, status.QueueSize)
+	mockGameService.AssertNotCalled(t, "CreateGame", mock.Anything, mock.Anything, mock.Anything)
+
+	// A gap of 300 needs four band steps
+	expectGame(mockGameService, mockPlayerService, mockLobbyService, 2)
+	started = service.Match(context.Background(), time.Now().Add(4*matchmaking.BAND_STEP+time.Second))
+
+	assert.Equal(t, 1, started)
+	mockGameService.AssertExpectations(t)
+}
+
+func TestMatch_EveryPairWithinBand(t *testing.T) {
+	// Create mocks
+	mockGameService := new(game_mock.MockGameService)
+	mockPlayerService := new(player_mock.MockPlayerService)
+	mockLobbyService := new(lobby_mock.MockLobbyService)
+	mockRatingService := new(rating_mock.MockRatingService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockWSHub.On("BroadcastToTicket", mock.Anything, "MATCHMAKING_STATUS", mock.Anything).Return()
+
+	// Create the service with all the mocks
+	service := matchmaking.NewService(mockGameService, mockPlayerService, mockLobbyService, mockRatingService, mockWSHub)
+
+	// Bob and Carol are both close to Alice but 160 apart from each other
+	alice := joinWithRating(t, service, mockRatingService, "Alice", 1500, 3)
+	bob := joinWithRating(t, service, mockRatingService, "Bob", 1420, 3)
+	carol := joinWithRating(t, service, mockRatingService, "Carol", 1580, 3)
+
+	now := time.Now()
+	started := service.Match(context.Background(), now)
+
+	assert.Equal(t, 0, started)
+	mockGameService.AssertNotCalled(t, "CreateGame", mock.Anything, mock.Anything, mock.Anything)
+
+	// Dave fits Alice and Bob, so Carol is the one left out
+	dave := joinWithRating(t, service, mockRatingService, "Dave", 1510, 3)
+	expectGame(mockGameService, mockPlayerService, mockLobbyService, 3)
+
+	started = service.Match(context.Background(), now)
+
+	assert.Equal(t, 1, started)
+	for _, ticketID := range []uuid.UUID{alice.TicketID, bob.TicketID, dave.TicketID} {
+		status, err := service.GetStatus(ticketID)
+		assert.NoError(t, err)
+		assert.Equal(t, matchmaking.MATCHED, status.State)
+	}
+	status, err := service.GetStatus(carol.TicketID)
+	assert.NoError(t, err)
+	assert.Equal(t, matchmaking.QUEUED, status.State)
+	mockGameService.AssertExpectations(t)
+}
+
+func TestMatch_PreferredPlayerCount(t *testing.T) {
+	// Create mocks
+	mockGameService := new(game_mock.MockGameService)
+	mockPlayerService := new(player_mock.MockPlayerService)
+	mockLobbyService := new(lobby_mock.MockLobbyService)
+	mockRatingService := new(rating_mock.MockRatingService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockWSHub.On("BroadcastToTicket", mock.Anything, "MATCHMAKING_STATUS", mock.Anything).Return()
+
+	// Create the service with all the mocks
+	service := matchmaking.NewService(mockGameService, mockPlayerService, mockLobbyService, mockRatingService, mockWSHub)
+
+	// Test data
+	alice := joinWithRating(t, service, mockRatingService, "Alice", 1500, 3)
+	joinWithRating(t, service, mockRatingService, "Bob", 1500, 2)
+	joinWithRating(t, service, mockRatingService, "Carol", 1500, 0)
+	dave := joinWithRating(t, service, mockRatingService, "Dave", 1500, 3)
+	expectGame(mockGameService, mockPlayerService, mockLobbyService, 3)
+
+	// Call service
+	started := service.Match(context.Background(), time.Now())
+
+	// Check response
+	assert.Equal(t, 1, started)
+	for _, ticketID := range []uuid.UUID{alice.TicketID, dave.TicketID} {
+		status, err := service.GetStatus(ticketID)
+		assert.NoError(t, err)
+		assert.Equal(t, matchmaking.MATCHED, status.State)
+	}
+
+	// Bob is left alone waiting for a 2 player game
+	mockGameService.AssertNumberOfCalls(t, "CreateGame", 1)
+}
+
+func TestMatch_StartFailsRequeues(t *testing.T) {
+	// Create mocks
+	mockGameService := new(game_mock.MockGameService)
+	mockPlayerService := new(player_mock.MockPlayerService)
+	mockLobbyService := new(lobby_mock.MockLobbyService)
+	mockRatingService := new(rating_mock.MockRatingService)
+	mockWSHub := new(websocket_mock.MockWebSocketHub)
+	mockWSHub.On("BroadcastToTicket", mock.Anything, "MATCHMAKING_STATUS", mock.Anything).Return()
+
+	// Create the service with all the mocks
+	service := matchmaking.NewService(mockGameService, mockPlayerService, mockLobbyService, mockRatingService, mockWSHub)
+
+	// Test data
+	alice := joinWithRating(t, service, mockRatingService, "Alice", 1500, 2)
+	bob := joinWithRating(t, service, mockRatingService, "Bob", 1500, 2)
+	gameID := uuid.New()
+
+	// Setup expectations
+	mockGameService.On("CreateGame", mock.Anything, mock.Anything, mock.Anything).Return(
+		&game.Game{ID: gameID},
+		&gameState.GameState{ID: uuid.New()},
+		&player.Player{ID: uuid.New(), Host: true},
+		nil,
+	)
+	mockPlayerService.On("CreatePlayer", mock.Anything, mock.Anything).Return(&player.Player{ID: uuid.New()}, nil)
+	mockLobbyService.On("StartGame", mock.Anything, gameID).Return(errors.New("db error"))
+	mockGameService.On("DeleteGame", mock.Anything, gameID).Return(nil)
+
+	// Call service
+	started := service.Match(context.Background(), time.Now())
+
+	// Check response
+	assert.Equal(t, 0, started)
+	for _, ticketID := range []uuid.UUID{alice.TicketID, bob.TicketID} {
+		status, err := service.GetStatus(ticketID)
+		assert.NoError(t, err)
+		assert.Equal(t, matchmaking.QUEUED, status.State)
+	}
+
+	mockGameService.AssertExpectations(t)
+	mockLobbyService.AssertExpectations(t)
+}
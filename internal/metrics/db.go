@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/NachoGz/switcher-backend-go/internal/database"
+)
+
+const namePrefix = "-- name: "
+
+// instrumentedDB times the queries run through a database.DBTX
+type instrumentedDB struct {
+	db database.DBTX
+}
+
+// InstrumentDB wraps db so the latency of every query is recorded under the
+// name sqlc gave it
+func InstrumentDB(db database.DBTX) database.DBTX {
+	return &instrumentedDB{db: db}
+}
+
+func (i *instrumentedDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	defer i.observe(query, time.Now())
+	result, err := i.db.ExecContext(ctx, query, args...)
+	i.countError(query, err)
+	return result, err
+}
+
+func (i *instrumentedDB) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	defer i.observe(query, time.Now())
+	stmt, err := i.db.PrepareContext(ctx, query)
+	i.countError(query, err)
+	return stmt, err
+}
+
+func (i *instrumentedDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	defer i.observe(query, time.Now())
+	rows, err := i.db.QueryContext(ctx, query, args...)
+	i.countError(query, err)
+	return rows, err
+}
+
+func (i *instrumentedDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	defer i.observe(query, time.Now())
+	row := i.db.QueryRowContext(ctx, query, args...)
+	// No rows is an answer, not a failure
+	if err := row.Err(); !errors.Is(err, sql.ErrNoRows) {
+		i.countError(query, err)
+	}
+	return row
+}
+
+func (i *instrumentedDB) observe(query string, start time.Time) {
+	DBQueryDuration.Observe(time.Since(start).Seconds(), QueryName(query))
+}
+
+func (i *instrumentedDB) countError(query string, err error) {
+	if err != nil {
+		DBQueryErrors.Inc(QueryName(query))
+	}
+}
+
+// QueryName is the name of a sqlc query, read from its "-- name: X :one"
+// header. Other queries are reported as "other"
+func QueryName(query string) string {
+	header, _, _ := strings.Cut(strings.TrimSpace(query), "\n")
+	if !strings.HasPrefix(header, namePrefix) {
+		return "other"
+	}
+	name, _, _ := strings.Cut(strings.TrimPrefix(header, namePrefix), " ")
+	if name == "" {
+		return "other"
+	}
+	return name
+}
@@ -0,0 +1,22 @@
+package metrics
+
+// Default is the registry served at /metrics
+var Default = NewRegistry()
+
+var (
+	HTTPRequests = Default.NewCounterVec("switcher_http_requests_total",
+		"HTTP requests by method, route pattern and status code", "method", "route", "status")
+	HTTPRequestDuration = Default.NewHistogramVec("switcher_http_request_duration_seconds",
+		"Latency of HTTP requests by method and route pattern", DEFAULT_BUCKETS, "method", "route")
+
+	WebsocketDroppedMessages = Default.NewCounterVec("switcher_websocket_dropped_messages_total",
+		"Websocket messages dropped because the client's send buffer was full, by kind of message", "kind")
+
+	GameStartDuration = Default.NewHistogramVec("switcher_game_start_duration_seconds",
+		"Time taken to set up a game when it starts", DEFAULT_BUCKETS)
+
+	DBQueryDuration = Default.NewHistogramVec("switcher_db_query_duration_seconds",
+		"Latency of database queries by query name", DEFAULT_BUCKETS, "query")
+	DBQueryErrors = Default.NewCounterVec("switcher_db_query_errors_total",
+		"Database queries that failed by query name", "query")
+)
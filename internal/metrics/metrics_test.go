@@ -0,0 +1,109 @@
+package metrics_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/NachoGz/switcher-backend-go/internal/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scrape(t *testing.T, registry *metrics.Registry) string {
+	t.Helper()
+
+	rr := httptest.NewRecorder()
+	registry.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, metrics.CONTENT_TYPE, rr.Header().Get("Content-Type"))
+	return rr.Body.String()
+}
+
+func TestCounterVec(t *testing.T) {
+	registry := metrics.NewRegistry()
+	requests := registry.NewCounterVec("requests_total", "Requests served", "method", "status")
+
+	requests.Inc("GET", "200")
+	requests.Inc("GET", "200")
+	requests.Add(3, "POST", "500")
+
+	assert.Equal(t, 2.0, requests.Value("GET", "200"))
+	assert.Equal(t, 0.0, requests.Value("DELETE", "200"))
+
+	body := scrape(t, registry)
+	assert.Contains(t, body, "# HELP requests_total Requests served\n# TYPE requests_total counter\n")
+	assert.Contains(t, body, `requests_total{method="GET",status="200"} 2`+"\n")
+	assert.Contains(t, body, `requests_total{method="POST",status="500"} 3`+"\n")
+
+	assert.Panics(t, func() { requests.Inc("GET") }, "wrong number of labels")
+	assert.Panics(t, func() { requests.Add(-1, "GET", "200") }, "counters only go up")
+}
+
+func TestHistogramVec(t *testing.T) {
+	registry := metrics.NewRegistry()
+	latency := registry.NewHistogramVec("latency_seconds", "Latency", []float64{1, 0.1}, "route")
+
+	latency.Observe(0.05, "/games")
+	latency.Observe(0.5, "/games")
+	latency.Observe(1, "/games")
+	latency.Observe(7, "/games")
+
+	assert.Equal(t, uint64(4), latency.Count("/games"))
+
+	body := scrape(t, registry)
+	// Buckets are cumulative and sorted, with a bound counting as inside
+	assert.Contains(t, body, strings.Join([]string{
+		`latency_seconds_bucket{route="/games",le="0.1"} 1`,
+		`latency_seconds_bucket{route="/games",le="1"} 3`,
+		`latency_seconds_bucket{route="/games",le="+Inf"} 4`,
+		`latency_seconds_sum{route="/games"} 8.55`,
+		`latency_seconds_count{route="/games"} 4`,
+	}, "\n"))
+}
+
+func TestGaugeFunc(t *testing.T) {
+	registry := metrics.NewRegistry()
+	registry.NewGaugeFunc("queue_depth", "Queued messages", nil, func() []metrics.Sample {
+		return []metrics.Sample{{Value: 4}}
+	})
+	registry.NewGaugeFunc("games", "Games by state", []string{"state"}, func() []metrics.Sample {
+		return []metrics.Sample{
+			{Labels: []string{"waiting"}, Value: 2},
+			{Labels: []string{"playing"}, Value: 1},
+			{Labels: nil, Value: 9},
+		}
+	})
+
+	body := scrape(t, registry)
+	assert.Contains(t, body, "# TYPE queue_depth gauge\nqueue_depth 4\n")
+	assert.Contains(t, body, "games{state=\"playing\"} 1\ngames{state=\"waiting\"} 2\n")
+	assert.NotContains(t, body, "games 9", "samples with the wrong labels are skipped")
+}
+
+func TestEscaping(t *testing.T) {
+	registry := metrics.NewRegistry()
+	counter := registry.NewCounterVec("escaped_total", "A \\ help\nline", "value")
+	counter.Inc("a \"quoted\"\\path\n")
+
+	body := scrape(t, registry)
+	assert.Contains(t, body, `# HELP escaped_total A \\ help\nline`+"\n")
+	assert.Contains(t, body, `escaped_total{value="a \"quoted\"\\path\n"} 1`)
+}
+
+func TestQueryName(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{"-- name: GetGame :one\nSELECT * FROM games WHERE id = $1", "GetGame"},
+		{"\n-- name: ListGames :many\nSELECT * FROM games", "ListGames"},
+		{"SELECT 1", "other"},
+		{"-- name: \nSELECT 1", "other"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, metrics.QueryName(test.query), test.query)
+	}
+}
@@ -0,0 +1,336 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const CONTENT_TYPE = "text/plain; version=0.0.4; charset=utf-8"
+
+// Upper bounds in seconds of the buckets of latency histograms
+var DEFAULT_BUCKETS = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// collector is a metric family written by a registry
+type collector interface {
+	write(w *bufio.Writer)
+}
+
+// Registry holds metric families and writes them in the Prometheus text
+// exposition format
+type Registry struct {
+	mu         sync.Mutex
+	collectors []collector
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+func (r *Registry) register(c collector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.collectors = append(r.collectors, c)
+}
+
+// WriteTo writes every metric in registration order
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	r.mu.Lock()
+	collectors := append([]collector(nil), r.collectors...)
+	r.mu.Unlock()
+
+	counter := &countingWriter{w: w}
+	buf := bufio.NewWriter(counter)
+	for _, c := range collectors {
+		c.write(buf)
+	}
+	err := buf.Flush()
+	return counter.n, err
+}
+
+// ServeHTTP serves the metrics to a Prometheus scrape
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", CONTENT_TYPE)
+	w.WriteHeader(http.StatusOK)
+	r.WriteTo(w)
+}
+
+// family holds the series of a metric by their label values
+type family struct {
+	name       string
+	help       string
+	kind       string
+	labelNames []string
+}
+
+func (f *family) writeHeader(w *bufio.Writer) {
+	fmt.Fprintf(w, "# HELP %s %s\n", f.name, escapeHelp(f.help))
+	fmt.Fprintf(w, "# TYPE %s %s\n", f.name, f.kind)
+}
+
+func (f *family) checkLabels(values []string) {
+	if len(values) != len(f.labelNames) {
+		panic(fmt.Sprintf("metric %s takes %d labels, got %d", f.name, len(f.labelNames), len(values)))
+	}
+}
+
+// CounterVec is a counter split by labels
+type CounterVec struct {
+	family
+	mu     sync.Mutex
+	series map[string]*counterSeries
+}
+
+type counterSeries struct {
+	labels []string
+	value  float64
+}
+
+func (r *Registry) NewCounterVec(name string, help string, labelNames ...string) *CounterVec {
+	c := &CounterVec{
+		family: family{name: name, help: help, kind: "counter", labelNames: labelNames},
+		series: map[string]*counterSeries{},
+	}
+	r.register(c)
+	return c
+}
+
+// Inc adds one to the series of the label values
+func (c *CounterVec) Inc(labels ...string) {
+	c.Add(1, labels...)
+}
+
+// Add adds a non negative value to the series of the label values
+func (c *CounterVec) Add(value float64, labels ...string) {
+	c.checkLabels(labels)
+	if value < 0 {
+		panic(fmt.Sprintf("counter %s can't decrease", c.name))
+	}
+
+	key := seriesKey(labels)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s, ok := c.series[key]
+	if !ok {
+		s = &counterSeries{labels: append([]string(nil), labels...)}
+		c.series[key] = s
+	}
+	s.value += value
+}
+
+// Value is the current value of the series of the label values
+func (c *CounterVec) Value(labels ...string) float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if s, ok := c.series[seriesKey(labels)]; ok {
+		return s.value
+	}
+	return 0
+}
+
+func (c *CounterVec) write(w *bufio.Writer) {
+	c.writeHeader(w)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, key := range sortedKeys(c.series) {
+		s := c.series[key]
+		fmt.Fprintf(w, "%s%s %s\n", c.name, formatLabels(c.labelNames, s.labels), formatValue(s.value))
+	}
+}
+
+// HistogramVec is a histogram split by labels
+type HistogramVec struct {
+	family
+	buckets []float64
+	mu      sync.Mutex
+	series  map[string]*histogramSeries
+}
+
+type histogramSeries struct {
+	labels []string
+	// Observations of each bucket, not cumulative
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+func (r *Registry) NewHistogramVec(name string, help string, buckets []float64, labelNames ...string) *HistogramVec {
+	sorted := append([]float64(nil), buckets...)
+	sort.Float64s(sorted)
+
+	h := &HistogramVec{
+		family:  family{name: name, help: help, kind: "histogram", labelNames: labelNames},
+		buckets: sorted,
+		series:  map[string]*histogramSeries{},
+	}
+	r.register(h)
+	return h
+}
+
+// Observe adds a value to the series of the label values
+func (h *HistogramVec) Observe(value float64, labels ...string) {
+	h.checkLabels(labels)
+
+	key := seriesKey(labels)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	s, ok := h.series[key]
+	if !ok {
+		s = &histogramSeries{
+			labels: append([]string(nil), labels...),
+			counts: make([]uint64, len(h.buckets)),
+		}
+		h.series[key] = s
+	}
+
+	// Values above every bound only count towards +Inf
+	if i := sort.SearchFloat64s(h.buckets, value); i < len(h.buckets) {
+		s.counts[i]++
+	}
+	s.count++
+	s.sum += value
+}
+
+// Count is the number of observations of the series of the label values
+func (h *HistogramVec) Count(labels ...string) uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if s, ok := h.series[seriesKey(labels)]; ok {
+		return s.count
+	}
+	return 0
+}
+
+func (h *HistogramVec) write(w *bufio.Writer) {
+	h.writeHeader(w)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	bucketLabels := append(append([]string(nil), h.labelNames...), "le")
+	for _, key := range sortedKeys(h.series) {
+		s := h.series[key]
+		var cumulative uint64
+		for i, bound := range h.buckets {
+			cumulative += s.counts[i]
+			labels := append(append([]string(nil), s.labels...), formatValue(bound))
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, formatLabels(bucketLabels, labels), cumulative)
+		}
+		labels := append(append([]string(nil), s.labels...), "+Inf")
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, formatLabels(bucketLabels, labels), s.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.name, formatLabels(h.labelNames, s.labels), formatValue(s.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, formatLabels(h.labelNames, s.labels), s.count)
+	}
+}
+
+// Sample is a value of a gauge with its label values
+type Sample struct {
+	Labels []string
+	Value  float64
+}
+
+// GaugeFunc is a gauge read when the metrics are scraped, for values owned
+// by something else such as the hub or the database
+type GaugeFunc struct {
+	family
+	collect func() []Sample
+}
+
+func (r *Registry) NewGaugeFunc(name string, help string, labelNames []string, collect func() []Sample) *GaugeFunc {
+	g := &GaugeFunc{
+		family:  family{name: name, help: help, kind: "gauge", labelNames: labelNames},
+		collect: collect,
+	}
+	r.register(g)
+	return g
+}
+
+func (g *GaugeFunc) write(w *bufio.Writer) {
+	g.writeHeader(w)
+
+	samples := g.collect()
+	sort.Slice(samples, func(i, j int) bool {
+		return seriesKey(samples[i].Labels) < seriesKey(samples[j].Labels)
+	})
+	for _, s := range samples {
+		if len(s.Labels) != len(g.labelNames) {
+			continue
+		}
+		fmt.Fprintf(w, "%s%s %s\n", g.name, formatLabels(g.labelNames, s.Labels), formatValue(s.Value))
+	}
+}
+
+// seriesKey joins label values with a byte that can't be in them
+func seriesKey(labels []string) string {
+	return strings.Join(labels, "\xff")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func formatLabels(names []string, values []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, name := range names {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(name)
+		b.WriteString(`="`)
+		b.WriteString(escapeLabel(values[i]))
+		b.WriteByte('"')
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+func formatValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+var (
+	labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+)
+
+func escapeLabel(value string) string {
+	return labelEscaper.Replace(value)
+}
+
+func escapeHelp(help string) string {
+	return helpEscaper.Replace(help)
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/NachoGz/switcher-backend-go/internal/user"
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
+)
+
+// AuthMiddleware attaches the user of a "Bearer" session token to the request
+// context. Requests without a valid token go through as guests, routes that
+// need a user turn them away
+func AuthMiddleware(userService user.UserService, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := utils.BearerToken(r)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		u, err := userService.Authenticate(r.Context(), token)
+		if errors.Is(err, user.ErrInvalidSession) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if err != nil {
+			utils.RespondWithError(w, r, http.StatusInternalServerError, "Couldn't check the session", err)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(user.NewContext(r.Context(), u)))
+	})
+}
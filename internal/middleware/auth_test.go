@@ -0,0 +1,57 @@
+package middleware_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NachoGz/switcher-backend-go/internal/middleware"
+	"github.com/NachoGz/switcher-backend-go/internal/user"
+	user_mock "github.com/NachoGz/switcher-backend-go/internal/user/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func serveAuth(userService user.UserService, token string) (*httptest.ResponseRecorder, *user.User) {
+	var seen *user.User
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen, _ = user.FromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/games", nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rr := httptest.NewRecorder()
+	middleware.AuthMiddleware(userService, next).ServeHTTP(rr, req)
+	return rr, seen
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	mockUserService := new(user_mock.MockUserService)
+	account := user.User{ID: uuid.New(), Username: "alice"}
+	mockUserService.On("Authenticate", mock.Anything, "valid").Return(&account, nil)
+	mockUserService.On("Authenticate", mock.Anything, "expired").Return(nil, user.ErrInvalidSession)
+	mockUserService.On("Authenticate", mock.Anything, "unchecked").Return(nil, errors.New("connection refused"))
+
+	// Requests without a token are guests
+	rr, seen := serveAuth(mockUserService, "")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Nil(t, seen)
+
+	rr, seen = serveAuth(mockUserService, "valid")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, &account, seen)
+
+	// A stale token doesn't keep guests out of public routes
+	rr, seen = serveAuth(mockUserService, "expired")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Nil(t, seen)
+
+	// Guests aren't let in when the session couldn't be checked
+	rr, _ = serveAuth(mockUserService, "unchecked")
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+}
@@ -1,16 +1,72 @@
 package middleware
 
-import "net/http"
+import (
+	"net/http"
+
+	"github.com/NachoGz/switcher-backend-go/internal/origins"
+)
+
+const (
+	CORS_ALLOWED_METHODS = "GET, POST, PUT, DELETE, OPTIONS, PATCH"
+	CORS_ALLOWED_HEADERS = "Content-Type, Authorization"
+	// Headers the frontend can read from responses
+	CORS_EXPOSED_HEADERS = REQUEST_ID_HEADER
+	// Seconds browsers may cache a preflight
+	CORS_MAX_AGE = "600"
+)
+
+// CORSOptions are the origins allowed to call the API from a browser and
+// whether they can send cookies and authorization headers
+type CORSOptions struct {
+	Origins          *origins.AllowList
+	AllowCredentials bool
+}
+
+// CORSMiddleware lets the allowed origins call the API from a browser.
+// Preflights from other origins are refused, other requests are served
+// without CORS headers so the browser hides the response
+func CORSMiddleware(options CORSOptions, next http.Handler) http.Handler {
+	// A wildcard can't be sent with credentials, the origin is echoed instead
+	wildcard := options.Origins.AllowsAny() && !options.AllowCredentials
 
-// CORS Middleware
-func CORSMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS, PATCH")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		origin := r.Header.Get("Origin")
+		preflight := r.Method == http.MethodOptions && origin != "" && r.Header.Get("Access-Control-Request-Method") != ""
+
+		// Caches must keep a response per origin when it depends on it
+		if !wildcard {
+			w.Header().Add("Vary", "Origin")
+		}
+		if preflight {
+			w.Header().Add("Vary", "Access-Control-Request-Method")
+			w.Header().Add("Vary", "Access-Control-Request-Headers")
+		}
+
+		allowed := origin != "" && options.Origins.Allowed(origin)
+		if preflight && !allowed {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		if allowed {
+			if wildcard {
+				w.Header().Set("Access-Control-Allow-Origin", "*")
+			} else {
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+			}
+			if options.AllowCredentials {
+				w.Header().Set("Access-Control-Allow-Credentials", "true")
+			}
+			w.Header().Set("Access-Control-Expose-Headers", CORS_EXPOSED_HEADERS)
+		}
 
 		// Handle preflight requests
 		if r.Method == http.MethodOptions {
+			if preflight {
+				w.Header().Set("Access-Control-Allow-Methods", CORS_ALLOWED_METHODS)
+				w.Header().Set("Access-Control-Allow-Headers", CORS_ALLOWED_HEADERS)
+				w.Header().Set("Access-Control-Max-Age", CORS_MAX_AGE)
+			}
 			w.WriteHeader(http.StatusNoContent)
 			return
 		}
@@ -0,0 +1,106 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NachoGz/switcher-backend-go/internal/middleware"
+	"github.com/NachoGz/switcher-backend-go/internal/origins"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCORSHandler(t *testing.T, allowCredentials bool, patterns ...string) http.Handler {
+	t.Helper()
+
+	allowed, err := origins.NewAllowList(patterns)
+	require.NoError(t, err)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	return middleware.CORSMiddleware(middleware.CORSOptions{Origins: allowed, AllowCredentials: allowCredentials}, next)
+}
+
+func serve(handler http.Handler, method string, origin string, preflight bool) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/games", nil)
+	if origin != "" {
+		req.Header.Set("Origin", origin)
+	}
+	if preflight {
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	}
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestCORSMiddleware_AllowedOrigin(t *testing.T) {
+	handler := newCORSHandler(t, true, "https://switcher.example")
+
+	rr := serve(handler, http.MethodGet, "https://switcher.example", false)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "https://switcher.example", rr.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "true", rr.Header().Get("Access-Control-Allow-Credentials"))
+	assert.Equal(t, middleware.REQUEST_ID_HEADER, rr.Header().Get("Access-Control-Expose-Headers"))
+	assert.Equal(t, []string{"Origin"}, rr.Header().Values("Vary"))
+
+	rr = serve(handler, http.MethodOptions, "https://switcher.example", true)
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+	assert.Equal(t, "https://switcher.example", rr.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, middleware.CORS_ALLOWED_METHODS, rr.Header().Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, middleware.CORS_ALLOWED_HEADERS, rr.Header().Get("Access-Control-Allow-Headers"))
+	assert.Equal(t, []string{"Origin", "Access-Control-Request-Method", "Access-Control-Request-Headers"}, rr.Header().Values("Vary"))
+}
+
+func TestCORSMiddleware_DeniedOrigin(t *testing.T) {
+	handler := newCORSHandler(t, true, "https://switcher.example")
+
+	// Served, but the browser hides the response
+	rr := serve(handler, http.MethodGet, "https://evil.example", false)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Empty(t, rr.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, rr.Header().Get("Access-Control-Allow-Credentials"))
+	assert.Equal(t, []string{"Origin"}, rr.Header().Values("Vary"))
+
+	rr = serve(handler, http.MethodOptions, "https://evil.example", true)
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+	assert.Empty(t, rr.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, rr.Header().Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, []string{"Origin", "Access-Control-Request-Method", "Access-Control-Request-Headers"}, rr.Header().Values("Vary"))
+
+	// Requests without an origin don't come from a browser on another site
+	rr = serve(handler, http.MethodGet, "", false)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Empty(t, rr.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestCORSMiddleware_WildcardSubdomain(t *testing.T) {
+	handler := newCORSHandler(t, false, "https://*.switcher.example")
+
+	rr := serve(handler, http.MethodOptions, "https://pr-7.switcher.example", true)
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+	assert.Equal(t, "https://pr-7.switcher.example", rr.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, rr.Header().Get("Access-Control-Allow-Credentials"))
+
+	rr = serve(handler, http.MethodOptions, "https://switcher.example", true)
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+
+	rr = serve(handler, http.MethodOptions, "https://pr-7.switcher.example.evil", true)
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+}
+
+func TestCORSMiddleware_AnyOrigin(t *testing.T) {
+	handler := newCORSHandler(t, false, origins.ANY)
+
+	rr := serve(handler, http.MethodGet, "https://anything.example", false)
+	assert.Equal(t, "*", rr.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, rr.Header().Values("Vary"), "the response is the same for every origin")
+
+	// With credentials the origin must be echoed
+	handler = newCORSHandler(t, true, origins.ANY)
+	rr = serve(handler, http.MethodGet, "https://anything.example", false)
+	assert.Equal(t, "https://anything.example", rr.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, []string{"Origin"}, rr.Header().Values("Vary"))
+}
@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/NachoGz/switcher-backend-go/internal/logging"
+	"github.com/google/uuid"
+)
+
+const (
+	REQUEST_ID_HEADER = "X-Request-ID"
+	// Longest request ID taken from a client, longer ones are replaced
+	MAX_REQUEST_ID_LENGTH = 128
+)
+
+// RequestIDMiddleware gives every request an ID, logged with everything done
+// for it and sent back in the X-Request-ID header. IDs sent by a proxy are
+// kept. Each request is logged once it's served
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(REQUEST_ID_HEADER)
+		if !validRequestID(requestID) {
+			requestID = uuid.NewString()
+		}
+		w.Header().Set(REQUEST_ID_HEADER, requestID)
+
+		ctx := logging.WithRequestID(r.Context(), requestID)
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(recorder, r.WithContext(ctx))
+
+		slog.LogAttrs(ctx, slog.LevelInfo, "Request served",
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.Int("status", recorder.status),
+			slog.Duration("duration", time.Since(start)),
+		)
+	})
+}
+
+// CorrelationMiddleware adds the game and player of the route to the
+// context, so everything logged for the request carries them. It must wrap
+// each route's handler, since path values are only set once the mux matched
+func CorrelationMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if gameID, err := uuid.Parse(r.PathValue("gameID")); err == nil {
+			ctx = logging.WithGameID(ctx, gameID)
+		}
+		if playerID, err := uuid.Parse(r.PathValue("playerID")); err == nil {
+			ctx = logging.WithPlayerID(ctx, playerID)
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// validRequestID keeps IDs from clients to short strings that are safe to log
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > MAX_REQUEST_ID_LENGTH {
+		return false
+	}
+	for _, c := range requestID {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-', c == '_', c == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NachoGz/switcher-backend-go/internal/metrics"
+)
+
+// MetricsMiddleware counts requests and times them by the route pattern that
+// served them. It must wrap the mux directly, since the pattern is only set
+// on the request once the mux has matched it
+func MetricsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(recorder, r)
+
+		route := routeLabel(r.Pattern)
+		metrics.HTTPRequests.Inc(r.Method, route, strconv.Itoa(recorder.status))
+		metrics.HTTPRequestDuration.Observe(time.Since(start).Seconds(), r.Method, route)
+	})
+}
+
+// routeLabel drops the method and host of a pattern, so the label stays the
+// same set of routes whatever the request
+func routeLabel(pattern string) string {
+	if pattern == "" {
+		return "unmatched"
+	}
+	if _, path, ok := strings.Cut(pattern, " "); ok {
+		pattern = path
+	}
+	if i := strings.Index(pattern, "/"); i > 0 {
+		pattern = pattern[i:]
+	}
+	return pattern
+}
+
+// statusRecorder keeps the status code written by a handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (s *statusRecorder) WriteHeader(status int) {
+	if !s.wroteHeader {
+		s.status = status
+		s.wroteHeader = true
+	}
+	s.ResponseWriter.WriteHeader(status)
+}
+
+func (s *statusRecorder) Write(b []byte) (int, error) {
+	s.wroteHeader = true
+	return s.ResponseWriter.Write(b)
+}
+
+// Hijack lets websocket upgrades take over the connection
+func (s *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := s.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not support hijacking")
+	}
+	s.status = http.StatusSwitchingProtocols
+	s.wroteHeader = true
+	return hijacker.Hijack()
+}
+
+func (s *statusRecorder) Flush() {
+	if flusher, ok := s.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (s *statusRecorder) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/NachoGz/switcher-backend-go/internal/database"
+	"github.com/NachoGz/switcher-backend-go/internal/ruleSet"
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
 	"github.com/google/uuid"
 )
 
@@ -12,8 +14,13 @@ type MovementCardRepository interface {
 	GetMovementCardDeck(ctx context.Context, gameID uuid.UUID) ([]database.MovementCard, error)
 	AssignMovementCard(ctx context.Context, params database.AssignMovementCardParams) error
 	MarkCardInPlayerHand(ctx context.Context, cardID uuid.UUID) error
+	GetMovementCardsByGame(ctx context.Context, gameID uuid.UUID) ([]database.MovementCard, error)
+	GetMovementCardsByPlayer(ctx context.Context, playerID uuid.UUID) ([]database.MovementCard, error)
+	MarkCardUsed(ctx context.Context, cardID uuid.UUID) error
+	DiscardMovementCard(ctx context.Context, params database.DiscardMovementCardParams) error
 }
 
 type MovementCardService interface {
-	CreateMovementCardDeck(ctx context.Context, gameID uuid.UUID) error
+	CreateMovementCardDeck(ctx context.Context, gameID uuid.UUID, rules ruleSet.RuleSet, rng utils.Randomizer) error
+	DBToModel(ctx context.Context, dbMovementCard database.MovementCard) MovementCard
 }
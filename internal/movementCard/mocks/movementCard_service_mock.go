@@ -3,6 +3,10 @@ package movementCard_mock
 import (
 	"context"
 
+	"github.com/NachoGz/switcher-backend-go/internal/database"
+	"github.com/NachoGz/switcher-backend-go/internal/movementCard"
+	"github.com/NachoGz/switcher-backend-go/internal/ruleSet"
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 )
@@ -11,7 +15,12 @@ type MockMovementCardService struct {
 	mock.Mock
 }
 
-func (m *MockMovementCardService) CreateMovementCardDeck(ctx context.Context, gameID uuid.UUID) error {
-	args := m.Called(ctx, gameID)
+func (m *MockMovementCardService) CreateMovementCardDeck(ctx context.Context, gameID uuid.UUID, rules ruleSet.RuleSet, rng utils.Randomizer) error {
+	args := m.Called(ctx, gameID, rules, rng)
 	return args.Error(0)
 }
+
+func (m *MockMovementCardService) DBToModel(ctx context.Context, dbMovementCard database.MovementCard) movementCard.MovementCard {
+	args := m.Called(ctx, dbMovementCard)
+	return args.Get(0).(movementCard.MovementCard)
+}
@@ -19,6 +19,12 @@ const (
 	LINEAR_LAT    TypeEnum = "linear_lat"
 )
 
+func GetAllCardTypes() []TypeEnum {
+	return []TypeEnum{
+		DIAGONAL_CONT, DIAGONAL_SPA, L_RIGHT, L_LEFT, LINEAR_LAT, LINEAR_CONT, LINEAR_SPA,
+	}
+}
+
 type MovementCard struct {
 	ID          uuid.UUID `json:"id"`
 	Type        TypeEnum  `json:"type"`
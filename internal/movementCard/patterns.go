@@ -0,0 +1,79 @@
+package movementCard
+
+import "github.com/NachoGz/switcher-backend-go/internal/board"
+
+// offsets are the relative positions each card type can swap a box with.
+// Every set contains the opposite of each offset, so a movement can be
+// undone with the same card
+var offsets = map[TypeEnum][]board.BoardPosition{
+	LINEAR_CONT: {
+		{PosX: 1, PosY: 0}, {PosX: -1, PosY: 0}, {PosX: 0, PosY: 1}, {PosX: 0, PosY: -1},
+	},
+	LINEAR_SPA: {
+		{PosX: 2, PosY: 0}, {PosX: -2, PosY: 0}, {PosX: 0, PosY: 2}, {PosX: 0, PosY: -2},
+	},
+	DIAGONAL_CONT: {
+		{PosX: 1, PosY: 1}, {PosX: -1, PosY: -1}, {PosX: 1, PosY: -1}, {PosX: -1, PosY: 1},
+	},
+	DIAGONAL_SPA: {
+		{PosX: 2, PosY: 2}, {PosX: -2, PosY: -2}, {PosX: 2, PosY: -2}, {PosX: -2, PosY: 2},
+	},
+	L_LEFT: {
+		{PosX: -2, PosY: -1}, {PosX: 2, PosY: 1}, {PosX: 1, PosY: -2}, {PosX: -1, PosY: 2},
+	},
+	L_RIGHT: {
+		{PosX: 2, PosY: -1}, {PosX: -2, PosY: 1}, {PosX: 1, PosY: 2}, {PosX: -1, PosY: -2},
+	},
+}
+
+// Destinations returns every position the box at from can be swapped with
+// using a card of the given type on a board of the given size
+func Destinations(cardType TypeEnum, from board.BoardPosition, boardSize int) []board.BoardPosition {
+	destinations := []board.BoardPosition{}
+
+	if cardType == LINEAR_LAT {
+		// The box moves to any end of its row or column
+		last := boardSize - 1
+		for _, to := range []board.BoardPosition{
+			{PosX: 0, PosY: from.PosY},
+			{PosX: last, PosY: from.PosY},
+			{PosX: from.PosX, PosY: 0},
+			{PosX: from.PosX, PosY: last},
+		} {
+			if to != from && !contains(destinations, to) {
+				destinations = append(destinations, to)
+			}
+		}
+		return destinations
+	}
+
+	for _, offset := range offsets[cardType] {
+		to := board.BoardPosition{PosX: from.PosX + offset.PosX, PosY: from.PosY + offset.PosY}
+		if inside(to, boardSize) {
+			destinations = append(destinations, to)
+		}
+	}
+	return destinations
+}
+
+// IsValidMovement reports whether a card of the given type can swap the
+// boxes at from and to
+func IsValidMovement(cardType TypeEnum, from, to board.BoardPosition, boardSize int) bool {
+	if !inside(from, boardSize) {
+		return false
+	}
+	return contains(Destinations(cardType, from, boardSize), to)
+}
+
+func inside(pos board.BoardPosition, boardSize int) bool {
+	return pos.PosX >= 0 && pos.PosX < boardSize && pos.PosY >= 0 && pos.PosY < boardSize
+}
+
+func contains(positions []board.BoardPosition, pos board.BoardPosition) bool {
+	for _, p := range positions {
+		if p == pos {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package movementCard_test
+
+import (
+	"testing"
+
+	"github.com/NachoGz/switcher-backend-go/internal/board"
+	"github.com/NachoGz/switcher-backend-go/internal/movementCard"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDestinations_Corner(t *testing.T) {
+	corner := board.BoardPosition{PosX: 0, PosY: 0}
+
+	assert.ElementsMatch(t, []board.BoardPosition{
+		{PosX: 1, PosY: 0}, {PosX: 0, PosY: 1},
+	}, movementCard.Destinations(movementCard.LINEAR_CONT, corner, 6))
+
+	assert.ElementsMatch(t, []board.BoardPosition{
+		{PosX: 2, PosY: 2},
+	}, movementCard.Destinations(movementCard.DIAGONAL_SPA, corner, 6))
+
+	assert.ElementsMatch(t, []board.BoardPosition{
+		{PosX: 5, PosY: 0}, {PosX: 0, PosY: 5},
+	}, movementCard.Destinations(movementCard.LINEAR_LAT, corner, 6))
+}
+
+func TestDestinations_LShapes(t *testing.T) {
+	center := board.BoardPosition{PosX: 2, PosY: 2}
+
+	assert.ElementsMatch(t, []board.BoardPosition{
+		{PosX: 0, PosY: 1}, {PosX: 4, PosY: 3}, {PosX: 3, PosY: 0}, {PosX: 1, PosY: 4},
+	}, movementCard.Destinations(movementCard.L_LEFT, center, 6))
+
+	assert.ElementsMatch(t, []board.BoardPosition{
+		{PosX: 4, PosY: 1}, {PosX: 0, PosY: 3}, {PosX: 3, PosY: 4}, {PosX: 1, PosY: 0},
+	}, movementCard.Destinations(movementCard.L_RIGHT, center, 6))
+}
+
+func TestDestinations_Reversible(t *testing.T) {
+	// Every movement except the lateral one can be undone with the same card
+	for _, cardType := range movementCard.GetAllCardTypes() {
+		if cardType == movementCard.LINEAR_LAT {
+			continue
+		}
+		for y := 0; y < 6; y++ {
+			for x := 0; x < 6; x++ {
+				from := board.BoardPosition{PosX: x, PosY: y}
+				for _, to := range movementCard.Destinations(cardType, from, 6) {
+					assert.True(t, movementCard.IsValidMovement(cardType, to, from, 6), "%s %v %v", cardType, from, to)
+				}
+			}
+		}
+	}
+}
+
+func TestIsValidMovement(t *testing.T) {
+	from := board.BoardPosition{PosX: 1, PosY: 1}
+
+	assert.True(t, movementCard.IsValidMovement(movementCard.DIAGONAL_CONT, from, board.BoardPosition{PosX: 2, PosY: 2}, 6))
+	assert.False(t, movementCard.IsValidMovement(movementCard.DIAGONAL_CONT, from, board.BoardPosition{PosX: 2, PosY: 1}, 6))
+	assert.True(t, movementCard.IsValidMovement(movementCard.LINEAR_SPA, from, board.BoardPosition{PosX: 3, PosY: 1}, 6))
+
+	// Positions outside the board are never valid
+	assert.False(t, movementCard.IsValidMovement(movementCard.LINEAR_CONT, board.BoardPosition{PosX: 5, PosY: 0}, board.BoardPosition{PosX: 6, PosY: 0}, 6))
+	assert.False(t, movementCard.IsValidMovement(movementCard.LINEAR_CONT, board.BoardPosition{PosX: 4, PosY: 4}, board.BoardPosition{PosX: 5, PosY: 4}, 5))
+}
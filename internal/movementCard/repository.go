@@ -37,3 +37,23 @@ func (r *PostgresMovementCardRepository) AssignMovementCard(ctx context.Context,
 func (r *PostgresMovementCardRepository) MarkCardInPlayerHand(ctx context.Context, cardID uuid.UUID) error {
 	return r.queries.MarkCardInPlayerHand(ctx, cardID)
 }
+
+// GetMovementCardsByGame fetches every movement card of a game, dealt or not
+func (r *PostgresMovementCardRepository) GetMovementCardsByGame(ctx context.Context, gameID uuid.UUID) ([]database.MovementCard, error) {
+	return r.queries.GetMovementCardsByGame(ctx, gameID)
+}
+
+// GetMovementCardsByPlayer fetches the hand of a player
+func (r *PostgresMovementCardRepository) GetMovementCardsByPlayer(ctx context.Context, playerID uuid.UUID) ([]database.MovementCard, error) {
+	return r.queries.GetMovementCardsByPlayer(ctx, uuid.NullUUID{UUID: playerID, Valid: true})
+}
+
+// MarkCardUsed marks a card of the hand as used in the current turn
+func (r *PostgresMovementCardRepository) MarkCardUsed(ctx context.Context, cardID uuid.UUID) error {
+	return r.queries.MarkCardUsed(ctx, cardID)
+}
+
+// DiscardMovementCard takes the card out of the hand and puts it at the bottom of the deck
+func (r *PostgresMovementCardRepository) DiscardMovementCard(ctx context.Context, params database.DiscardMovementCardParams) error {
+	return r.queries.DiscardMovementCard(ctx, params)
+}
@@ -4,10 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"math/rand/v2"
 
 	"github.com/NachoGz/switcher-backend-go/internal/database"
 	"github.com/NachoGz/switcher-backend-go/internal/player"
+	"github.com/NachoGz/switcher-backend-go/internal/ruleSet"
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
 	"github.com/google/uuid"
 )
 
@@ -28,39 +29,18 @@ func NewService(
 	}
 }
 
-func (s *Service) CreateMovementCardDeck(ctx context.Context, gameID uuid.UUID) error {
-	// Create a list with the types of movement cards
-	typesList := make([]TypeEnum, 0, 40)
-	for i := 0; i < 6; i++ {
-		typesList = append(typesList, DIAGONAL_CONT)
-	}
-
-	for i := 0; i < 6; i++ {
-		typesList = append(typesList, DIAGONAL_SPA)
-	}
-
-	for i := 0; i < 6; i++ {
-		typesList = append(typesList, L_RIGHT)
-	}
-
-	for i := 0; i < 6; i++ {
-		typesList = append(typesList, L_LEFT)
-	}
-
-	for i := 0; i < 5; i++ {
-		typesList = append(typesList, LINEAR_LAT)
-	}
-
-	for i := 0; i < 5; i++ {
-		typesList = append(typesList, LINEAR_CONT)
-	}
-
-	for i := 0; i < 6; i++ {
-		typesList = append(typesList, LINEAR_SPA)
+func (s *Service) CreateMovementCardDeck(ctx context.Context, gameID uuid.UUID, rules ruleSet.RuleSet, rng utils.Randomizer) error {
+	// Create a list with the types of movement cards, as many of each as the
+	// rules say
+	typesList := []TypeEnum{}
+	for _, cardType := range GetAllCardTypes() {
+		for i := 0; i < rules.MovementDeck[string(cardType)]; i++ {
+			typesList = append(typesList, cardType)
+		}
 	}
 
 	// Shuffle the list
-	rand.Shuffle(len(typesList), func(i, j int) {
+	rng.Shuffle(len(typesList), func(i, j int) {
 		typesList[i], typesList[j] = typesList[j], typesList[i]
 	})
 
@@ -72,14 +52,14 @@ func (s *Service) CreateMovementCardDeck(ctx context.Context, gameID uuid.UUID)
 			Used:        false,
 			GameID:      gameID,
 			Type:        string(cardType),
-			Position:    sql.NullInt32{Int32: int32(i)},
+			Position:    sql.NullInt32{Int32: int32(i), Valid: true},
 		})
 		if err != nil {
 			return fmt.Errorf("failed to create movement card: %w", err)
 		}
 	}
 
-	// assign 3 to each player
+	// assign a hand to each player
 	players, err := s.playerRepo.GetPlayersInGame(ctx, gameID)
 	if err != nil {
 		return fmt.Errorf("failed to get players: %w", err)
@@ -92,17 +72,17 @@ func (s *Service) CreateMovementCardDeck(ctx context.Context, gameID uuid.UUID)
 		}
 
 		// Check if there are enough cards in deck
-		if len(movDeck) < 3 {
+		if len(movDeck) < rules.HandSize {
 			return fmt.Errorf("not enough cards in deck to assign to player %s", player.ID)
 		}
 
 		// Shuffle the deck
-		rand.Shuffle(len(movDeck), func(i, j int) {
+		rng.Shuffle(len(movDeck), func(i, j int) {
 			movDeck[i], movDeck[j] = movDeck[j], movDeck[i]
 		})
 
-		// Take the first three cards
-		assignedMovCards := movDeck[:3]
+		// Take the first cards
+		assignedMovCards := movDeck[:rules.HandSize]
 
 		for _, card := range assignedMovCards {
 			err := s.movementCardRepo.AssignMovementCard(context.Background(), database.AssignMovementCardParams{
@@ -0,0 +1,130 @@
+package openapi
+
+import (
+	"net/http"
+	"strings"
+)
+
+const OPENAPI_VERSION = "3.0.3"
+
+const JSON_CONTENT_TYPE = "application/json"
+
+// Responses maps the status codes of an operation to a value of the body
+// sent with them. A nil value means the response has no body
+type Responses map[int]any
+
+// Param is a query parameter of an operation
+type Param struct {
+	Name        string
+	Description string
+	// JSON type of the value: string, integer or boolean
+	Type string
+}
+
+// Operation describes a route of the API. Request and the responses are
+// example values, their schemas are generated from their types
+type Operation struct {
+	Method    string
+	Path      string
+	ID        string
+	Summary   string
+	Tag       string
+	Query     []Param
+	Request   any
+	Responses Responses
+	// Priority operations are matched before the rest, for patterns that
+	// would clash with others in a single ServeMux
+	Priority bool
+}
+
+// Pattern is the pattern the operation is registered with in a ServeMux
+func (op Operation) Pattern() string {
+	return op.Method + " " + op.Path
+}
+
+// pathParams lists the {name} segments of the path
+func (op Operation) pathParams() []string {
+	var params []string
+	for _, segment := range strings.Split(op.Path, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			params = append(params, strings.Trim(segment, "{}"))
+		}
+	}
+	return params
+}
+
+// Document is an OpenAPI 3 document
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// PathItem holds the operations of a path by lowercase method
+type PathItem map[string]*OperationDoc
+
+type OperationDoc struct {
+	OperationID string                 `json:"operationId"`
+	Summary     string                 `json:"summary,omitempty"`
+	Tags        []string               `json:"tags,omitempty"`
+	Parameters  []ParameterDoc         `json:"parameters,omitempty"`
+	RequestBody *RequestBodyDoc        `json:"requestBody,omitempty"`
+	Responses   map[string]ResponseDoc `json:"responses"`
+}
+
+type ParameterDoc struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Required    bool    `json:"required"`
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBodyDoc struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type ResponseDoc struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// Schema is the subset of the OpenAPI schema object the generator produces.
+// AdditionalProperties is either false or a *Schema
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties any                `json:"additionalProperties,omitempty"`
+}
+
+// response documents a status code of an operation
+func response(status int, schema *Schema) ResponseDoc {
+	doc := ResponseDoc{Description: http.StatusText(status)}
+	if schema != nil {
+		doc.Content = map[string]MediaType{JSON_CONTENT_TYPE: {Schema: schema}}
+	}
+	return doc
+}
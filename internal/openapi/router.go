@@ -0,0 +1,164 @@
+package openapi
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
+)
+
+// Router registers the routes of the API and documents them, so the spec
+// can't drift from what is served
+type Router struct {
+	info       Info
+	mux        *http.ServeMux
+	priority   *http.ServeMux
+	operations []Operation
+	// Wraps the handler of every route registered afterwards
+	middleware []func(http.Handler) http.Handler
+
+	specOnce sync.Once
+	spec     []byte
+}
+
+func NewRouter(info Info) *Router {
+	return &Router{
+		info:     info,
+		mux:      http.NewServeMux(),
+		priority: http.NewServeMux(),
+	}
+}
+
+// Use adds a middleware run inside the route, once the mux has matched the
+// request and set its path values. It only wraps routes registered later
+func (r *Router) Use(middleware func(http.Handler) http.Handler) {
+	r.middleware = append(r.middleware, middleware)
+}
+
+// HandleFunc registers the handler of an operation
+func (r *Router) HandleFunc(op Operation, handler http.HandlerFunc) {
+	if op.Priority {
+		r.priority.Handle(op.Pattern(), r.wrap(handler))
+	} else {
+		r.mux.Handle(op.Pattern(), r.wrap(handler))
+	}
+	r.operations = append(r.operations, op)
+}
+
+// HandleUndocumentedFunc registers a route left out of the document, such as
+// websocket upgrades
+func (r *Router) HandleUndocumentedFunc(pattern string, handler http.HandlerFunc) {
+	r.mux.Handle(pattern, r.wrap(handler))
+}
+
+// wrap applies the middleware, the first one added being the outermost
+func (r *Router) wrap(handler http.Handler) http.Handler {
+	for i := len(r.middleware) - 1; i >= 0; i-- {
+		handler = r.middleware[i](handler)
+	}
+	return handler
+}
+
+func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if _, pattern := r.priority.Handler(req); pattern != "" {
+		r.priority.ServeHTTP(w, req)
+		return
+	}
+	r.mux.ServeHTTP(w, req)
+}
+
+// Document builds the OpenAPI document of the registered operations
+func (r *Router) Document() *Document {
+	return NewDocument(r.info, r.operations...)
+}
+
+// HandleSpec serves the document as JSON. It's built on the first request,
+// once every route is registered
+func (r *Router) HandleSpec(w http.ResponseWriter, req *http.Request) {
+	r.specOnce.Do(func() {
+		spec, err := json.Marshal(r.Document())
+		if err != nil {
+			slog.ErrorContext(req.Context(), "Error marshalling OpenAPI document", "error", err)
+			return
+		}
+		r.spec = spec
+	})
+	if r.spec == nil {
+		http.Error(w, "Couldn't build the OpenAPI document", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", JSON_CONTENT_TYPE)
+	w.WriteHeader(http.StatusOK)
+	w.Write(r.spec)
+}
+
+// NewDocument documents the operations. Every operation answers errors with
+// a problem
+func NewDocument(info Info, operations ...Operation) *Document {
+	g := newGenerator()
+	problem := g.bodySchema(utils.Problem{})
+
+	doc := &Document{
+		OpenAPI: OPENAPI_VERSION,
+		Info:    info,
+		Paths:   map[string]PathItem{},
+	}
+	for _, op := range operations {
+		opDoc := &OperationDoc{
+			OperationID: op.ID,
+			Summary:     op.Summary,
+			Responses: map[string]ResponseDoc{
+				"default": {
+					Description: "Error",
+					Content:     map[string]MediaType{utils.PROBLEM_CONTENT_TYPE: {Schema: problem}},
+				},
+			},
+		}
+		if op.Tag != "" {
+			opDoc.Tags = []string{op.Tag}
+		}
+
+		for _, name := range op.pathParams() {
+			schema := &Schema{Type: "string"}
+			if strings.HasSuffix(name, "ID") {
+				schema.Format = "uuid"
+			}
+			opDoc.Parameters = append(opDoc.Parameters, ParameterDoc{Name: name, In: "path", Required: true, Schema: schema})
+		}
+		for _, param := range op.Query {
+			opDoc.Parameters = append(opDoc.Parameters, ParameterDoc{
+				Name:        param.Name,
+				In:          "query",
+				Description: param.Description,
+				Schema:      &Schema{Type: param.Type},
+			})
+		}
+
+		if op.Request != nil {
+			opDoc.RequestBody = &RequestBodyDoc{
+				Required: true,
+				Content:  map[string]MediaType{JSON_CONTENT_TYPE: {Schema: g.bodySchema(op.Request)}},
+			}
+		}
+		for status, body := range op.Responses {
+			var schema *Schema
+			if body != nil {
+				schema = g.bodySchema(body)
+			}
+			opDoc.Responses[strconv.Itoa(status)] = response(status, schema)
+		}
+
+		if doc.Paths[op.Path] == nil {
+			doc.Paths[op.Path] = PathItem{}
+		}
+		doc.Paths[op.Path][strings.ToLower(op.Method)] = opDoc
+	}
+	doc.Components.Schemas = g.schemas
+
+	return doc
+}
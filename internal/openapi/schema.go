@@ -0,0 +1,204 @@
+package openapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+const componentsPrefix = "#/components/schemas/"
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	uuidType          = reflect.TypeOf(uuid.UUID{})
+	nullUUIDType      = reflect.TypeOf(uuid.NullUUID{})
+	rawMessageType    = reflect.TypeOf(json.RawMessage{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// generator builds schemas from Go types the way encoding/json marshals
+// them. Named structs become components
+type generator struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+func newGenerator() *generator {
+	return &generator{
+		schemas: map[string]*Schema{},
+		names:   map[reflect.Type]string{},
+	}
+}
+
+// SchemaGenerator builds the schemas of values for documents other than the
+// OpenAPI one. Named structs are collected as components
+type SchemaGenerator struct {
+	g *generator
+}
+
+func NewSchemaGenerator() *SchemaGenerator {
+	return &SchemaGenerator{g: newGenerator()}
+}
+
+// Schema is the schema of the value, referencing the components
+func (s *SchemaGenerator) Schema(value any) *Schema {
+	return s.g.bodySchema(value)
+}
+
+// Components are the named schemas referenced so far
+func (s *SchemaGenerator) Components() map[string]*Schema {
+	return s.g.schemas
+}
+
+// bodySchema is the schema of a request or response body. A pointer to the
+// body is not nullable, it's how handlers pass their values around
+func (g *generator) bodySchema(value any) *Schema {
+	t := reflect.TypeOf(value)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return g.schemaOf(t)
+}
+
+func (g *generator) schemaOf(t reflect.Type) *Schema {
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case uuidType:
+		return &Schema{Type: "string", Format: "uuid"}
+	case nullUUIDType:
+		return &Schema{Type: "string", Format: "uuid", Nullable: true}
+	case rawMessageType:
+		return &Schema{}
+	}
+
+	if t.Kind() == reflect.Pointer {
+		return nullable(g.schemaOf(t.Elem()))
+	}
+	if t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType) {
+		return &Schema{}
+	}
+	if t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType) {
+		return &Schema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		// Nil slices are marshalled as null
+		return &Schema{Type: "array", Items: g.schemaOf(t.Elem()), Nullable: true}
+	case reflect.Array:
+		return &Schema{Type: "array", Items: g.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schemaOf(t.Elem()), Nullable: true}
+	case reflect.Struct:
+		return g.structSchema(t)
+	default:
+		return &Schema{}
+	}
+}
+
+// structSchema adds a named struct to the components and references it.
+// Anonymous structs are inlined
+func (g *generator) structSchema(t reflect.Type) *Schema {
+	if t.Name() == "" {
+		return g.objectSchema(t)
+	}
+
+	name, ok := g.names[t]
+	if !ok {
+		name = g.componentName(t)
+		g.names[t] = name
+		// Reserve the name before building the fields, so recursive types
+		// reference themselves
+		g.schemas[name] = &Schema{}
+		*g.schemas[name] = *g.objectSchema(t)
+	}
+	return &Schema{Ref: componentsPrefix + name}
+}
+
+// componentName is the exported name of the type, prefixed with its package
+// when another type already took it
+func (g *generator) componentName(t reflect.Type) string {
+	name := capitalize(t.Name())
+	if _, taken := g.schemas[name]; !taken {
+		return name
+	}
+	pkg := t.PkgPath()
+	return capitalize(pkg[strings.LastIndex(pkg, "/")+1:]) + name
+}
+
+func (g *generator) objectSchema(t reflect.Type) *Schema {
+	schema := &Schema{
+		Type:                 "object",
+		Properties:           map[string]*Schema{},
+		AdditionalProperties: false,
+	}
+	g.addFields(schema, t)
+	return schema
+}
+
+func (g *generator) addFields(schema *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		// Untagged embedded structs are flattened like encoding/json does
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			g.addFields(schema, field.Type)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		schema.Properties[name] = g.schemaOf(field.Type)
+		if !strings.Contains(opts, "omitempty") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+// nullable allows null besides the schema. References can't have siblings,
+// so they are wrapped
+func nullable(schema *Schema) *Schema {
+	if schema.Ref != "" {
+		return &Schema{AllOf: []*Schema{schema}, Nullable: true}
+	}
+	if schema.Type == "" && schema.AllOf == nil {
+		return schema
+	}
+	schema.Nullable = true
+	return schema
+}
+
+func capitalize(name string) string {
+	runes := []rune(name)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
@@ -0,0 +1,196 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// operation finds the documented operation of a method and path, such as
+// ("GET", "/games/{gameID}")
+func (d *Document) operation(method string, path string) (*OperationDoc, error) {
+	opDoc, ok := d.Paths[path][strings.ToLower(method)]
+	if !ok {
+		return nil, fmt.Errorf("%s %s is not documented", method, path)
+	}
+	return opDoc, nil
+}
+
+// ValidateRequest checks a request body against its operation. Missing
+// fields are allowed, since handlers read them as zero values
+func (d *Document) ValidateRequest(method string, path string, body []byte) error {
+	opDoc, err := d.operation(method, path)
+	if err != nil {
+		return err
+	}
+	if opDoc.RequestBody == nil {
+		if len(bytes.TrimSpace(body)) > 0 {
+			return fmt.Errorf("%s %s doesn't take a body", method, path)
+		}
+		return nil
+	}
+	return d.validateBody(opDoc.RequestBody.Content[JSON_CONTENT_TYPE].Schema, body, true)
+}
+
+// ValidateResponse checks the status, content type and body of a response
+// against its operation
+func (d *Document) ValidateResponse(method string, path string, status int, contentType string, body []byte) error {
+	opDoc, err := d.operation(method, path)
+	if err != nil {
+		return err
+	}
+	responseDoc, ok := opDoc.Responses[strconv.Itoa(status)]
+	if !ok {
+		if status < 400 {
+			return fmt.Errorf("%s %s doesn't document status %d", method, path, status)
+		}
+		responseDoc = opDoc.Responses["default"]
+	}
+
+	if responseDoc.Content == nil {
+		if len(bytes.TrimSpace(body)) > 0 {
+			return fmt.Errorf("status %d of %s %s has no body", status, method, path)
+		}
+		return nil
+	}
+	mediaType, ok := responseDoc.Content[contentType]
+	if !ok {
+		return fmt.Errorf("status %d of %s %s isn't sent as %q", status, method, path, contentType)
+	}
+	return d.validateBody(mediaType.Schema, body, false)
+}
+
+func (d *Document) validateBody(schema *Schema, body []byte, request bool) error {
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return fmt.Errorf("invalid JSON body: %w", err)
+	}
+	v := validator{doc: d, request: request}
+	return v.validate("$", schema, value)
+}
+
+type validator struct {
+	doc     *Document
+	request bool
+}
+
+func (v validator) validate(path string, schema *Schema, value any) error {
+	if schema.Ref != "" {
+		resolved, ok := v.doc.Components.Schemas[strings.TrimPrefix(schema.Ref, componentsPrefix)]
+		if !ok {
+			return fmt.Errorf("%s: unknown schema %s", path, schema.Ref)
+		}
+		return v.validate(path, resolved, value)
+	}
+
+	if value == nil {
+		if schema.Nullable || (schema.Type == "" && schema.AllOf == nil) {
+			return nil
+		}
+		return fmt.Errorf("%s: can't be null", path)
+	}
+	for _, sub := range schema.AllOf {
+		if err := v.validate(path, sub, value); err != nil {
+			return err
+		}
+	}
+
+	switch schema.Type {
+	case "object":
+		return v.validateObject(path, schema, value)
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			return fmt.Errorf("%s: expected an array", path)
+		}
+		for i, item := range items {
+			if err := v.validate(fmt.Sprintf("%s[%d]", path, i), schema.Items, item); err != nil {
+				return err
+			}
+		}
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%s: expected a string", path)
+		}
+		return validateFormat(path, schema.Format, s)
+	case "integer":
+		n, ok := value.(float64)
+		if !ok || n != math.Trunc(n) {
+			return fmt.Errorf("%s: expected an integer", path)
+		}
+	case "number":
+		if _, ok := value.(float64); !ok {
+			return fmt.Errorf("%s: expected a number", path)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s: expected a boolean", path)
+		}
+	}
+	return nil
+}
+
+func (v validator) validateObject(path string, schema *Schema, value any) error {
+	object, ok := value.(map[string]any)
+	if !ok {
+		return fmt.Errorf("%s: expected an object", path)
+	}
+
+	if !v.request {
+		for _, name := range schema.Required {
+			if _, ok := object[name]; !ok {
+				return fmt.Errorf("%s: missing %q", path, name)
+			}
+		}
+	}
+
+	// Sorted so the first error is always the same
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fieldPath := path + "." + name
+		if property, ok := schema.Properties[name]; ok {
+			if err := v.validate(fieldPath, property, object[name]); err != nil {
+				return err
+			}
+			continue
+		}
+		switch additional := schema.AdditionalProperties.(type) {
+		case *Schema:
+			if err := v.validate(fieldPath, additional, object[name]); err != nil {
+				return err
+			}
+		case bool:
+			if !additional {
+				return fmt.Errorf("%s: unknown field", fieldPath)
+			}
+		}
+	}
+	return nil
+}
+
+func validateFormat(path string, format string, value string) error {
+	switch format {
+	case "uuid":
+		if _, err := uuid.Parse(value); err != nil {
+			return fmt.Errorf("%s: expected a UUID", path)
+		}
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339Nano, value); err != nil {
+			return fmt.Errorf("%s: expected a date-time", path)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package openapi_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/NachoGz/switcher-backend-go/internal/openapi"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testItem struct {
+	ID        uuid.UUID  `json:"id"`
+	Name      string     `json:"name"`
+	Count     int        `json:"count"`
+	Parent    *testItem  `json:"parent"`
+	CreatedAt time.Time  `json:"created_at"`
+	Tags      []string   `json:"tags,omitempty"`
+	Owner     *uuid.UUID `json:"owner,omitempty"`
+}
+
+type testRequest struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+var testDoc = openapi.NewDocument(openapi.Info{Title: "Test", Version: "1"},
+	openapi.Operation{
+		Method: http.MethodPost, Path: "/items", ID: "createItem",
+		Request:   testRequest{},
+		Responses: openapi.Responses{http.StatusCreated: testItem{}},
+	},
+	openapi.Operation{
+		Method: http.MethodDelete, Path: "/items/{itemID}", ID: "deleteItem",
+		Responses: openapi.Responses{http.StatusNoContent: nil},
+	},
+)
+
+func TestNewDocument(t *testing.T) {
+	item := testDoc.Components.Schemas["TestItem"]
+	require.NotNil(t, item)
+	assert.Equal(t, []string{"id", "name", "count", "parent", "created_at"}, item.Required)
+	assert.Equal(t, "uuid", item.Properties["id"].Format)
+	assert.Equal(t, "date-time", item.Properties["created_at"].Format)
+
+	// Recursive types reference their own component
+	assert.True(t, item.Properties["parent"].Nullable)
+	assert.Equal(t, "#/components/schemas/TestItem", item.Properties["parent"].AllOf[0].Ref)
+
+	deleteDoc := testDoc.Paths["/items/{itemID}"]["delete"]
+	require.Len(t, deleteDoc.Parameters, 1)
+	assert.Equal(t, "itemID", deleteDoc.Parameters[0].Name)
+	assert.Equal(t, "path", deleteDoc.Parameters[0].In)
+	assert.Nil(t, deleteDoc.Responses["204"].Content)
+}
+
+func TestValidateRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr bool
+	}{
+		{"valid", `{"name": "a", "count": 1}`, false},
+		{"missing fields", `{}`, false},
+		{"wrong type", `{"count": "1"}`, true},
+		{"not an integer", `{"count": 1.5}`, true},
+		{"unknown field", `{"nmae": "a"}`, true},
+		{"invalid JSON", `{`, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := testDoc.ValidateRequest(http.MethodPost, "/items", []byte(tc.body))
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateResponse(t *testing.T) {
+	id := uuid.NewString()
+	valid := `{"id": "` + id + `", "name": "a", "count": 1, "parent": null, "created_at": "2024-01-02T03:04:05Z"}`
+
+	assert.NoError(t, testDoc.ValidateResponse(http.MethodPost, "/items", http.StatusCreated, "application/json", []byte(valid)))
+
+	// Required fields must be present in responses
+	err := testDoc.ValidateResponse(http.MethodPost, "/items", http.StatusCreated, "application/json",
+		[]byte(`{"id": "`+id+`", "name": "a", "count": 1, "parent": null}`))
+	assert.ErrorContains(t, err, "created_at")
+
+	err = testDoc.ValidateResponse(http.MethodPost, "/items", http.StatusCreated, "application/json",
+		[]byte(`{"id": "nope", "name": "a", "count": 1, "parent": null, "created_at": "2024-01-02T03:04:05Z"}`))
+	assert.ErrorContains(t, err, "UUID")
+
+	// Undocumented success statuses and bodies of empty responses are drift
+	assert.Error(t, testDoc.ValidateResponse(http.MethodPost, "/items", http.StatusOK, "application/json", []byte(valid)))
+	assert.Error(t, testDoc.ValidateResponse(http.MethodDelete, "/items/{itemID}", http.StatusNoContent, "", []byte(`{}`)))
+
+	// Errors are problems
+	problem := `{"type": "about:blank", "title": "Not Found", "status": 404, "code": "NOT_FOUND", "detail": "x", "error": "x"}`
+	assert.NoError(t, testDoc.ValidateResponse(http.MethodDelete, "/items/{itemID}", http.StatusNotFound, "application/problem+json", []byte(problem)))
+	assert.Error(t, testDoc.ValidateResponse(http.MethodDelete, "/items/{itemID}", http.StatusNotFound, "application/json", []byte(problem)))
+}
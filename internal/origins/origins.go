@@ -0,0 +1,113 @@
+package origins
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ANY is the pattern allowing every origin
+const ANY = "*"
+
+var ErrInvalidOrigin = errors.New("invalid origin")
+
+// AllowList is the set of origins allowed to call the server from a
+// browser. Patterns are "*", an origin like https://example.com or a
+// wildcard subdomain like https://*.example.com, which doesn't match
+// example.com itself
+type AllowList struct {
+	any       bool
+	exact     map[string]bool
+	wildcards []wildcard
+}
+
+// wildcard matches the subdomains of a domain, at any depth
+type wildcard struct {
+	scheme string
+	// Domain with a leading dot, like .example.com
+	suffix string
+	port   string
+}
+
+// NewAllowList parses the patterns, reporting every invalid one
+func NewAllowList(patterns []string) (*AllowList, error) {
+	list := &AllowList{exact: map[string]bool{}}
+
+	var errs []error
+	for _, pattern := range patterns {
+		if pattern == ANY {
+			list.any = true
+			continue
+		}
+
+		scheme, host, port, err := parse(pattern)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%w %q: must be a scheme and host, like https://example.com", ErrInvalidOrigin, pattern))
+			continue
+		}
+
+		domain, isWildcard := strings.CutPrefix(host, "*.")
+		if !isWildcard && !strings.Contains(host, "*") {
+			list.exact[origin(scheme, host, port)] = true
+			continue
+		}
+		// Only a leading wildcard is supported, and *.com would allow every
+		// site of a top level domain
+		if !isWildcard || !strings.Contains(domain, ".") || strings.Contains(domain, "*") {
+			errs = append(errs, fmt.Errorf("%w %q: wildcards must be followed by a domain, like https://*.example.com", ErrInvalidOrigin, pattern))
+			continue
+		}
+		list.wildcards = append(list.wildcards, wildcard{scheme: scheme, suffix: "." + domain, port: port})
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return list, nil
+}
+
+// AllowsAny reports whether the list has the "*" pattern
+func (l *AllowList) AllowsAny() bool {
+	return l.any
+}
+
+// Allowed reports whether an Origin header matches the list
+func (l *AllowList) Allowed(value string) bool {
+	if l.any {
+		return true
+	}
+
+	scheme, host, port, err := parse(value)
+	if err != nil || strings.Contains(host, "*") {
+		return false
+	}
+	if l.exact[origin(scheme, host, port)] {
+		return true
+	}
+	for _, w := range l.wildcards {
+		if scheme == w.scheme && port == w.port && len(host) > len(w.suffix) && strings.HasSuffix(host, w.suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// parse splits an origin, lowercasing the scheme and host as browsers do
+func parse(value string) (scheme, host, port string, err error) {
+	u, err := url.Parse(value)
+	if err != nil {
+		return "", "", "", err
+	}
+	if u.Scheme == "" || u.Host == "" || u.User != nil || (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" {
+		return "", "", "", ErrInvalidOrigin
+	}
+	return strings.ToLower(u.Scheme), strings.ToLower(u.Hostname()), u.Port(), nil
+}
+
+func origin(scheme, host, port string) string {
+	if port == "" {
+		return scheme + "://" + host
+	}
+	return scheme + "://" + host + ":" + port
+}
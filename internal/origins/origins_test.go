@@ -0,0 +1,67 @@
+package origins_test
+
+import (
+	"testing"
+
+	"github.com/NachoGz/switcher-backend-go/internal/origins"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllowList(t *testing.T) {
+	list, err := origins.NewAllowList([]string{
+		"https://switcher.example",
+		"http://localhost:5173",
+		"https://*.preview.example",
+	})
+	require.NoError(t, err)
+	assert.False(t, list.AllowsAny())
+
+	tests := []struct {
+		origin  string
+		allowed bool
+	}{
+		{"https://switcher.example", true},
+		{"HTTPS://Switcher.Example", true},
+		{"http://switcher.example", false},
+		{"https://switcher.example:8443", false},
+		{"https://evil.switcher.example", false},
+		{"https://switcher.example.evil", false},
+		{"http://localhost:5173", true},
+		{"http://localhost:3000", false},
+		{"https://pr-12.preview.example", true},
+		{"https://a.b.preview.example", true},
+		{"https://preview.example", false},
+		{"https://evilpreview.example", false},
+		{"http://pr-12.preview.example", false},
+		{"https://pr-12.preview.example:8443", false},
+		{"https://*.preview.example", false},
+		{"null", false},
+		{"", false},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.allowed, list.Allowed(test.origin), test.origin)
+	}
+}
+
+func TestAllowList_Any(t *testing.T) {
+	list, err := origins.NewAllowList([]string{origins.ANY})
+	require.NoError(t, err)
+	assert.True(t, list.AllowsAny())
+	assert.True(t, list.Allowed("https://anything.example"))
+}
+
+func TestNewAllowList_Invalid(t *testing.T) {
+	for _, pattern := range []string{
+		"switcher.example",
+		"https://switcher.example/app",
+		"https://user@switcher.example",
+		"https://*.com",
+		"https://*.*.example",
+		"https://api.*.example",
+	} {
+		_, err := origins.NewAllowList([]string{"https://ok.example", pattern})
+		assert.ErrorIs(t, err, origins.ErrInvalidOrigin, pattern)
+	}
+}
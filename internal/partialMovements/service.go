@@ -2,10 +2,10 @@ package partialMovements
 
 import (
 	"context"
-	"database/sql"
 
 	"github.com/NachoGz/switcher-backend-go/internal/board"
 	"github.com/NachoGz/switcher-backend-go/internal/database"
+	"github.com/NachoGz/switcher-backend-go/internal/gameEvent"
 	"github.com/NachoGz/switcher-backend-go/internal/movementCard"
 	"github.com/google/uuid"
 )
@@ -15,21 +15,25 @@ type Service struct {
 	partialMovRepo   PartialMovementRepository
 	boardRepo        board.BoardRepository
 	movementCardRepo movementCard.MovementCardRepository
+	gameEventService gameEvent.GameEventService
 }
 
 // NewService creates a new partial movements servzicez
 func NewService(partialMovRepo PartialMovementRepository, boardRepo board.BoardRepository,
-	movementCardRepo movementCard.MovementCardRepository) PartialMovementService {
+	movementCardRepo movementCard.MovementCardRepository, gameEventService gameEvent.GameEventService) PartialMovementService {
 	return &Service{
 		partialMovRepo:   partialMovRepo,
 		boardRepo:        boardRepo,
 		movementCardRepo: movementCardRepo,
+		gameEventService: gameEventService,
 	}
 }
 
 var _ PartialMovementService = (*Service)(nil)
 
-func (s *Service) RevertPartialMovements(ctx context.Context, db *sql.DB, gameID uuid.UUID, playerID uuid.UUID) error {
+// RevertPartialMovements undoes the movements of the current turn of a player,
+// newest first, and gives the cards back to their hand
+func (s *Service) RevertPartialMovements(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID) error {
 	partialMovements, err := s.partialMovRepo.GetPartialMovementsByPlayer(ctx, database.GetPartialMovementsByPlayerParams{
 		GameID:   gameID,
 		PlayerID: playerID,
@@ -56,6 +60,15 @@ func (s *Service) RevertPartialMovements(ctx context.Context, db *sql.DB, gameID
 		if err = s.partialMovRepo.UndoMovementByID(ctx, movement.ID); err != nil {
 			return err
 		}
+
+		// The partial movement is gone, keep it in the game log
+		if _, err = s.gameEventService.Record(ctx, gameID, playerID, gameEvent.MOVEMENT_UNDONE, gameEvent.MovementPayload{
+			MovementCardID: movement.MovementCardID,
+			From:           posFrom,
+			To:             posTo,
+		}); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -4,17 +4,21 @@ import (
 	"context"
 
 	"github.com/NachoGz/switcher-backend-go/internal/database"
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
 	"github.com/google/uuid"
 )
 
 type PlayerService interface {
 	CreatePlayer(ctx context.Context, playerData Player) (*Player, error)
 	DBToModel(ctx context.Context, dbPlayer database.Player) Player
-	AssignRandomTurns(ctx context.Context, players []Player) (uuid.UUID, error)
+	AssignRandomTurns(ctx context.Context, players []Player, rng utils.Randomizer) (uuid.UUID, error)
 	CountPlayers(ctx context.Context, gameID uuid.UUID) (int, error)
 	GetPlayerByID(ctx context.Context, gameID uuid.UUID, playerID uuid.UUID) (Player, error)
 	GetPlayersInGame(ctx context.Context, gameID uuid.UUID) ([]Player, error)
 	GetWinner(ctx context.Context, id uuid.UUID) (*Player, error)
+	SetWinner(ctx context.Context, playerID uuid.UUID) error
+	SetReady(ctx context.Context, playerID uuid.UUID, ready bool) error
+	DeletePlayer(ctx context.Context, playerID uuid.UUID, gameID uuid.UUID) error
 }
 
 type PlayerRepository interface {
@@ -24,4 +28,7 @@ type PlayerRepository interface {
 	GetPlayerByID(ctx context.Context, params database.GetPlayerByIDParams) (database.Player, error)
 	GetPlayersInGame(ctx context.Context, gameID uuid.UUID) ([]database.Player, error)
 	GetWinner(ctx context.Context, id uuid.UUID) (database.Player, error)
+	SetWinner(ctx context.Context, id uuid.UUID) error
+	SetPlayerReady(ctx context.Context, params database.SetPlayerReadyParams) error
+	DeletePlayer(ctx context.Context, params database.DeletePlayerParams) error
 }
@@ -45,3 +45,18 @@ func (m *MockPlayerRepository) GetWinner(ctx context.Context, gameID uuid.UUID)
 	args := m.Called(ctx, gameID)
 	return args.Get(0).(database.Player), args.Error(1)
 }
+
+func (m *MockPlayerRepository) SetWinner(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockPlayerRepository) SetPlayerReady(ctx context.Context, params database.SetPlayerReadyParams) error {
+	args := m.Called(ctx, params)
+	return args.Error(0)
+}
+
+func (m *MockPlayerRepository) DeletePlayer(ctx context.Context, params database.DeletePlayerParams) error {
+	args := m.Called(ctx, params)
+	return args.Error(0)
+}
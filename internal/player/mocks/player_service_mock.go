@@ -5,6 +5,7 @@ import (
 
 	"github.com/NachoGz/switcher-backend-go/internal/database"
 	"github.com/NachoGz/switcher-backend-go/internal/player"
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 )
@@ -31,8 +32,8 @@ func (m *MockPlayerService) GetPlayersInGame(ctx context.Context, gameID uuid.UU
 	return args.Get(0).([]player.Player), args.Error(1)
 }
 
-func (m *MockPlayerService) AssignRandomTurns(ctx context.Context, players []player.Player) (uuid.UUID, error) {
-	args := m.Called(ctx, players)
+func (m *MockPlayerService) AssignRandomTurns(ctx context.Context, players []player.Player, rng utils.Randomizer) (uuid.UUID, error) {
+	args := m.Called(ctx, players, rng)
 	return args.Get(0).(uuid.UUID), args.Error(1)
 }
 
@@ -51,3 +52,18 @@ func (m *MockPlayerService) GetWinner(ctx context.Context, gameID uuid.UUID) (*p
 	args := m.Called(ctx, gameID)
 	return args.Get(0).(*player.Player), args.Error(1)
 }
+
+func (m *MockPlayerService) SetWinner(ctx context.Context, playerID uuid.UUID) error {
+	args := m.Called(ctx, playerID)
+	return args.Error(0)
+}
+
+func (m *MockPlayerService) SetReady(ctx context.Context, playerID uuid.UUID, ready bool) error {
+	args := m.Called(ctx, playerID, ready)
+	return args.Error(0)
+}
+
+func (m *MockPlayerService) DeletePlayer(ctx context.Context, playerID uuid.UUID, gameID uuid.UUID) error {
+	args := m.Called(ctx, playerID, gameID)
+	return args.Error(0)
+}
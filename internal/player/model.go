@@ -15,6 +15,11 @@ type Player struct {
 	GameStateID uuid.UUID `json:"game_state_id"`
 	Host        bool      `json:"host"`
 	Winner      bool      `json:"winner"`
+	Bot         bool      `json:"bot"`
+	BotLevel    string    `json:"bot_level,omitempty"`
+	Ready       bool      `json:"ready"`
+	// Set when the player is a registered user, nil for guests
+	UserID *uuid.UUID `json:"user_id,omitempty"`
 }
 
 // turnEnum
@@ -37,5 +42,25 @@ func (s *Service) DBToModel(ctx context.Context, dbPlayer database.Player) Playe
 		GameStateID: dbPlayer.GameStateID,
 		Host:        dbPlayer.Host,
 		Winner:      dbPlayer.Winner,
+		Bot:         dbPlayer.Bot,
+		BotLevel:    dbPlayer.BotLevel.String,
+		Ready:       dbPlayer.Ready,
+		UserID:      userID(dbPlayer.UserID),
 	}
 }
+
+// userID converts a nullable database user ID
+func userID(id uuid.NullUUID) *uuid.UUID {
+	if !id.Valid {
+		return nil
+	}
+	return &id.UUID
+}
+
+// NullUserID converts a model user ID to a nullable database one
+func NullUserID(id *uuid.UUID) uuid.NullUUID {
+	if id == nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: *id, Valid: true}
+}
@@ -47,3 +47,18 @@ func (r *PostgresPlayerRepository) GetPlayerByID(ctx context.Context, params dat
 func (r *PostgresPlayerRepository) GetWinner(ctx context.Context, id uuid.UUID) (database.Player, error) {
 	return r.queries.GetWinner(ctx, id)
 }
+
+// SetWinner marks the player as the winner of their game
+func (r *PostgresPlayerRepository) SetWinner(ctx context.Context, id uuid.UUID) error {
+	return r.queries.SetWinner(ctx, id)
+}
+
+// SetPlayerReady sets whether the player is ready to start the game
+func (r *PostgresPlayerRepository) SetPlayerReady(ctx context.Context, params database.SetPlayerReadyParams) error {
+	return r.queries.SetPlayerReady(ctx, params)
+}
+
+// DeletePlayer removes a player from its game
+func (r *PostgresPlayerRepository) DeletePlayer(ctx context.Context, params database.DeletePlayerParams) error {
+	return r.queries.DeletePlayer(ctx, params)
+}
@@ -5,9 +5,9 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
-	"math/rand"
 
 	"github.com/NachoGz/switcher-backend-go/internal/database"
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
 	"github.com/google/uuid"
 )
 
@@ -33,6 +33,9 @@ func (s *Service) CreatePlayer(ctx context.Context, playerData Player) (*Player,
 		GameID:      playerData.GameID,
 		GameStateID: playerData.GameStateID,
 		Host:        playerData.Host,
+		Bot:         playerData.Bot,
+		BotLevel:    sql.NullString{String: playerData.BotLevel, Valid: playerData.BotLevel != ""},
+		UserID:      NullUserID(playerData.UserID),
 	})
 	if err != nil {
 		return nil, err
@@ -58,7 +61,7 @@ func (s *Service) GetPlayersInGame(ctx context.Context, gameID uuid.UUID) ([]Pla
 }
 
 // Assign randomly the turns for the players in the game and returrns the id of the first player
-func (s *Service) AssignRandomTurns(ctx context.Context, players []Player) (uuid.UUID, error) {
+func (s *Service) AssignRandomTurns(ctx context.Context, players []Player, rng utils.Randomizer) (uuid.UUID, error) {
 	n := len(players)
 	if n == 0 {
 		return uuid.Nil, errors.New("there are no players")
@@ -70,7 +73,7 @@ func (s *Service) AssignRandomTurns(ctx context.Context, players []Player) (uuid
 	}
 
 	// Shuffle to randomize order
-	rand.Shuffle(n, func(i, j int) {
+	rng.Shuffle(n, func(i, j int) {
 		randomTurns[i], randomTurns[j] = randomTurns[j], randomTurns[i]
 	})
 
@@ -95,7 +98,7 @@ func (s *Service) AssignRandomTurns(ctx context.Context, players []Player) (uuid
 		// Assign turn
 		if err := s.playerRepo.AssignTurnPlayer(context.Background(), database.AssignTurnPlayerParams{
 			ID:   player.ID,
-			Turn: sql.NullString{String: string(turnEnumVal)},
+			Turn: sql.NullString{String: string(turnEnumVal), Valid: true},
 		}); err != nil {
 			return uuid.Nil, err
 		}
@@ -145,3 +148,24 @@ func (s *Service) GetWinner(ctx context.Context, id uuid.UUID) (*Player, error)
 	winner := s.DBToModel(ctx, dbWinner)
 	return &winner, nil
 }
+
+// SetWinner marks the player as the winner of its game
+func (s *Service) SetWinner(ctx context.Context, playerID uuid.UUID) error {
+	return s.playerRepo.SetWinner(ctx, playerID)
+}
+
+// SetReady sets whether the player is ready to start the game
+func (s *Service) SetReady(ctx context.Context, playerID uuid.UUID, ready bool) error {
+	return s.playerRepo.SetPlayerReady(ctx, database.SetPlayerReadyParams{
+		ID:    playerID,
+		Ready: ready,
+	})
+}
+
+// DeletePlayer removes a player from the given game
+func (s *Service) DeletePlayer(ctx context.Context, playerID uuid.UUID, gameID uuid.UUID) error {
+	return s.playerRepo.DeletePlayer(ctx, database.DeletePlayerParams{
+		ID:     playerID,
+		GameID: gameID,
+	})
+}
@@ -0,0 +1,47 @@
+package rating
+
+import "math"
+
+// Update returns the new ratings of the participants of a game, in the same
+// order. A game between n players is scored as n-1 duels per player: the
+// winner beats everyone and the rest draw among themselves. The change is
+// scaled down by n-1 so that a game moves ratings as much as a single duel
+func Update(participants []Participant) []int {
+	ratings := make([]int, len(participants))
+	if len(participants) < 2 {
+		for i, p := range participants {
+			ratings[i] = p.Rating
+		}
+		return ratings
+	}
+
+	for i, p := range participants {
+		delta := 0.0
+		for j, opponent := range participants {
+			if i == j {
+				continue
+			}
+			delta += score(p, opponent) - expected(p.Rating, opponent.Rating)
+		}
+		ratings[i] = p.Rating + int(math.Round(K_FACTOR*delta/float64(len(participants)-1)))
+	}
+	return ratings
+}
+
+// score is the result of a duel for p: 1 for a win, 0 for a loss and 0.5
+// for a draw
+func score(p, opponent Participant) float64 {
+	switch {
+	case p.Winner && !opponent.Winner:
+		return 1
+	case opponent.Winner && !p.Winner:
+		return 0
+	default:
+		return 0.5
+	}
+}
+
+// expected is the probability of a player rated r beating one rated opponent
+func expected(r, opponent int) float64 {
+	return 1 / (1 + math.Pow(10, float64(opponent-r)/400))
+}
@@ -0,0 +1,63 @@
+package rating_test
+
+import (
+	"testing"
+
+	"github.com/NachoGz/switcher-backend-go/internal/rating"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdate_Duel(t *testing.T) {
+	ratings := rating.Update([]rating.Participant{
+		{Rating: 1500, Winner: true},
+		{Rating: 1500},
+	})
+
+	// Even players exchange half of the K factor
+	assert.Equal(t, []int{1516, 1484}, ratings)
+}
+
+func TestUpdate_Upset(t *testing.T) {
+	favourite := rating.Update([]rating.Participant{
+		{Rating: 1800, Winner: true},
+		{Rating: 1400},
+	})
+	upset := rating.Update([]rating.Participant{
+		{Rating: 1800},
+		{Rating: 1400, Winner: true},
+	})
+
+	// Beating a stronger player is worth more than beating a weaker one
+	assert.Less(t, favourite[0]-1800, upset[1]-1400)
+	assert.Greater(t, upset[1]-1400, rating.K_FACTOR/2)
+}
+
+func TestUpdate_FourPlayers(t *testing.T) {
+	participants := []rating.Participant{
+		{Rating: 1500},
+		{Rating: 1600, Winner: true},
+		{Rating: 1500},
+		{Rating: 1400},
+	}
+
+	ratings := rating.Update(participants)
+
+	assert.Greater(t, ratings[1], 1600)
+	assert.LessOrEqual(t, ratings[1]-1600, rating.K_FACTOR)
+
+	// The losers draw among themselves, so the strongest of them loses the most
+	assert.Less(t, ratings[0], 1500)
+	assert.Less(t, ratings[2], 1500)
+	assert.Less(t, ratings[0]-1500, ratings[3]-1400)
+
+	// Points are exchanged, never created
+	total := 0
+	for i, r := range ratings {
+		total += r - participants[i].Rating
+	}
+	assert.InDelta(t, 0, total, 2)
+}
+
+func TestUpdate_SinglePlayer(t *testing.T) {
+	assert.Equal(t, []int{1500}, rating.Update([]rating.Participant{{Rating: 1500, Winner: true}}))
+}
@@ -0,0 +1,12 @@
+package rating
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type RatingService interface {
+	RateGame(ctx context.Context, gameID uuid.UUID) error
+	GetRating(ctx context.Context, userID *uuid.UUID) (int, error)
+}
@@ -0,0 +1,22 @@
+package rating_mock
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRatingService struct {
+	mock.Mock
+}
+
+func (m *MockRatingService) RateGame(ctx context.Context, gameID uuid.UUID) error {
+	args := m.Called(ctx, gameID)
+	return args.Error(0)
+}
+
+func (m *MockRatingService) GetRating(ctx context.Context, userID *uuid.UUID) (int, error) {
+	args := m.Called(ctx, userID)
+	return args.Int(0), args.Error(1)
+}
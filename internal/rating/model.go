@@ -0,0 +1,15 @@
+package rating
+
+const (
+	// Rating of new users and of guests, who aren't rated
+	DEFAULT_RATING = 1500
+
+	// Most points a player can win or lose in a single game
+	K_FACTOR = 32
+)
+
+// Participant is a rated player of a finished game
+type Participant struct {
+	Rating int
+	Winner bool
+}
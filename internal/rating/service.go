@@ -0,0 +1,102 @@
+package rating
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"github.com/NachoGz/switcher-backend-go/internal/database"
+	"github.com/NachoGz/switcher-backend-go/internal/logging"
+	"github.com/NachoGz/switcher-backend-go/internal/player"
+	"github.com/NachoGz/switcher-backend-go/internal/user"
+	"github.com/google/uuid"
+)
+
+// Service keeps the ratings of registered users up to date
+type Service struct {
+	playerService player.PlayerService
+	userService   user.UserService
+	db            *sql.DB
+}
+
+// NewService creates a new rating service
+func NewService(playerService player.PlayerService, userService user.UserService, db *sql.DB) *Service {
+	return &Service{
+		playerService: playerService,
+		userService:   userService,
+		db:            db,
+	}
+}
+
+// Ensure Service implements RatingService
+var _ RatingService = (*Service)(nil)
+
+// HandleGameFinished rates a game once it has a winner. It is meant to be
+// registered with gameplay.GameplayService.OnGameFinished
+func (s *Service) HandleGameFinished(ctx context.Context, gameID uuid.UUID, winnerID uuid.UUID) {
+	if err := s.RateGame(ctx, gameID); err != nil {
+		slog.ErrorContext(ctx, "Error rating game", logging.GameID(gameID), "error", err)
+	}
+}
+
+// RateGame updates the ratings of the registered users of a finished game.
+// Only games between at least two people are rated, bots are left out.
+// Guests count as opponents with the default rating. The ratings are
+// adjusted by the points won or lost, so games finishing at the same time
+// don't overwrite each other's
+func (s *Service) RateGame(ctx context.Context, gameID uuid.UUID) error {
+	players, err := s.playerService.GetPlayersInGame(ctx, gameID)
+	if err != nil {
+		return fmt.Errorf("error fetching players: %w", err)
+	}
+
+	humans := []player.Player{}
+	registered := false
+	for _, p := range players {
+		if p.Bot {
+			continue
+		}
+		humans = append(humans, p)
+		registered = registered || p.UserID != nil
+	}
+	if len(humans) < 2 || !registered {
+		return nil
+	}
+
+	participants := make([]Participant, len(humans))
+	for i, p := range humans {
+		current, err := s.GetRating(ctx, p.UserID)
+		if err != nil {
+			return err
+		}
+		participants[i] = Participant{Rating: current, Winner: p.Winner}
+	}
+
+	// Everyone in the game is rated or nobody is
+	return database.InTx(ctx, s.db, func(ctx context.Context) error {
+		for i, updated := range Update(participants) {
+			if humans[i].UserID == nil {
+				continue
+			}
+			delta := updated - participants[i].Rating
+			if err := s.userService.AdjustRating(ctx, *humans[i].UserID, delta); err != nil {
+				return fmt.Errorf("error updating rating: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// GetRating returns the rating of a user, or the default one for guests
+func (s *Service) GetRating(ctx context.Context, userID *uuid.UUID) (int, error) {
+	if userID == nil {
+		return DEFAULT_RATING, nil
+	}
+
+	u, err := s.userService.GetUserByID(ctx, *userID)
+	if err != nil {
+		return 0, fmt.Errorf("error fetching user: %w", err)
+	}
+	return u.Rating, nil
+}
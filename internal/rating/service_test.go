@@ -0,0 +1,99 @@
+package rating_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/NachoGz/switcher-backend-go/internal/database/databasetest"
+	"github.com/NachoGz/switcher-backend-go/internal/player"
+	player_mock "github.com/NachoGz/switcher-backend-go/internal/player/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/rating"
+	"github.com/NachoGz/switcher-backend-go/internal/user"
+	user_mock "github.com/NachoGz/switcher-backend-go/internal/user/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRateGame(t *testing.T) {
+	mockPlayerService := new(player_mock.MockPlayerService)
+	mockUserService := new(user_mock.MockUserService)
+	db, txs := databasetest.NewDB(t)
+	service := rating.NewService(mockPlayerService, mockUserService, db)
+
+	gameID := uuid.New()
+	winnerID := uuid.New()
+
+	// A registered winner, a guest and a bot, which isn't rated
+	mockPlayerService.On("GetPlayersInGame", mock.Anything, gameID).
+		Return([]player.Player{
+			{ID: uuid.New(), Name: "alice", Winner: true, UserID: &winnerID},
+			{ID: uuid.New(), Name: "Guest"},
+			{ID: uuid.New(), Name: "Bot 1", Bot: true},
+		}, nil)
+	mockUserService.On("GetUserByID", mock.Anything, winnerID).
+		Return(&user.User{ID: winnerID, Rating: 1500}, nil)
+	mockUserService.On("AdjustRating", mock.Anything, winnerID, 16).
+		Return(nil)
+
+	err := service.RateGame(context.Background(), gameID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, txs.Committed())
+	mockUserService.AssertExpectations(t)
+}
+
+func TestRateGame_AgainstBotsOnly(t *testing.T) {
+	mockPlayerService := new(player_mock.MockPlayerService)
+	mockUserService := new(user_mock.MockUserService)
+	db, txs := databasetest.NewDB(t)
+	service := rating.NewService(mockPlayerService, mockUserService, db)
+
+	gameID := uuid.New()
+	userID := uuid.New()
+
+	mockPlayerService.On("GetPlayersInGame", mock.Anything, gameID).
+		Return([]player.Player{
+			{ID: uuid.New(), Name: "alice", Winner: true, UserID: &userID},
+			{ID: uuid.New(), Name: "Bot 1", Bot: true},
+		}, nil)
+
+	err := service.RateGame(context.Background(), gameID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, txs.Begun())
+	mockUserService.AssertNotCalled(t, "AdjustRating")
+}
+
+func TestRateGame_UpdateFails(t *testing.T) {
+	mockPlayerService := new(player_mock.MockPlayerService)
+	mockUserService := new(user_mock.MockUserService)
+	db, txs := databasetest.NewDB(t)
+	service := rating.NewService(mockPlayerService, mockUserService, db)
+
+	gameID := uuid.New()
+	winnerID := uuid.New()
+	loserID := uuid.New()
+
+	mockPlayerService.On("GetPlayersInGame", mock.Anything, gameID).
+		Return([]player.Player{
+			{ID: uuid.New(), Name: "alice", Winner: true, UserID: &winnerID},
+			{ID: uuid.New(), Name: "bob", UserID: &loserID},
+		}, nil)
+	mockUserService.On("GetUserByID", mock.Anything, winnerID).
+		Return(&user.User{ID: winnerID, Rating: 1500}, nil)
+	mockUserService.On("GetUserByID", mock.Anything, loserID).
+		Return(&user.User{ID: loserID, Rating: 1500}, nil)
+	mockUserService.On("AdjustRating", mock.Anything, winnerID, 16).
+		Return(nil)
+	mockUserService.On("AdjustRating", mock.Anything, loserID, -16).
+		Return(errors.New("database error"))
+
+	err := service.RateGame(context.Background(), gameID)
+
+	// The winner's new rating is rolled back along with the loser's
+	assert.ErrorContains(t, err, "database error")
+	assert.Equal(t, 0, txs.Committed())
+	assert.Equal(t, 1, txs.RolledBack())
+}
@@ -0,0 +1,216 @@
+package ruleSet
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
+)
+
+const (
+	CLASSIC = "classic"
+	QUICK   = "quick"
+	BLITZ   = "blitz"
+)
+
+// Players dealt from the same decks, used to check the decks are big enough
+const MAX_PLAYERS = 4
+
+// Bounds in seconds of the time a player has to play a turn
+const (
+	MIN_TURN_DURATION     = 10
+	MAX_TURN_DURATION     = 600
+	DEFAULT_TURN_DURATION = 120
+)
+
+// Seconds per turn of the classic rules, set at startup
+var classicTurnDuration = DEFAULT_TURN_DURATION
+
+// SetClassicTurnDuration changes the time per turn of the classic rules,
+// which games get unless they choose another preset or override it
+func SetClassicTurnDuration(seconds int) error {
+	if seconds < MIN_TURN_DURATION || seconds > MAX_TURN_DURATION {
+		return fmt.Errorf("turn duration must be between %d and %d seconds", MIN_TURN_DURATION, MAX_TURN_DURATION)
+	}
+	classicTurnDuration = seconds
+	return nil
+}
+
+var (
+	ErrUnknownPreset = utils.NewDomainError(utils.ErrInvalidInput, "UNKNOWN_RULE_SET", "unknown rule set")
+	ErrInvalidRules  = utils.NewDomainError(utils.ErrInvalidInput, "INVALID_RULES", "invalid rules")
+)
+
+// RuleSet holds the rules a game is played with. It is chosen when the game
+// is created and stored with it
+type RuleSet struct {
+	Name      string `json:"name"`
+	BoardSize int    `json:"board_size"`
+	// Number of box colors, taken in order from red, green, blue and yellow
+	Colors    int `json:"colors"`
+	HandSize  int `json:"hand_size"`
+	ShowLimit int `json:"show_limit"`
+	// Amount of movement cards of each type in the deck
+	MovementDeck map[string]int `json:"movement_deck"`
+	// Figure cards split between the players
+	HardFigures int `json:"hard_figures"`
+	EasyFigures int `json:"easy_figures"`
+	// Seconds a player has to play their turn before it passes to the next
+	// player
+	TurnDuration    int  `json:"turn_duration"`
+	BlockingEnabled bool `json:"blocking_enabled"`
+}
+
+// Classic are the rules of the board game
+func Classic() RuleSet {
+	return RuleSet{
+		Name:      CLASSIC,
+		BoardSize: 6,
+		Colors:    4,
+		HandSize:  3,
+		ShowLimit: 3,
+		MovementDeck: map[string]int{
+			"diagonal_cont": 6,
+			"diagonal_spa":  6,
+			"l_right":       6,
+			"l_left":        6,
+			"linear_lat":    5,
+			"linear_cont":   5,
+			"linear_esp":    6,
+		},
+		HardFigures:     36,
+		EasyFigures:     14,
+		TurnDuration:    classicTurnDuration,
+		BlockingEnabled: true,
+	}
+}
+
+// Quick keeps the classic board with fewer figures to complete
+func Quick() RuleSet {
+	rules := Classic()
+	rules.Name = QUICK
+	rules.HardFigures = 16
+	rules.EasyFigures = 8
+	rules.TurnDuration = 60
+	return rules
+}
+
+// Blitz is played on a smaller board with short turns and no blocking
+func Blitz() RuleSet {
+	rules := Classic()
+	rules.Name = BLITZ
+	rules.BoardSize = 5
+	rules.ShowLimit = 2
+	rules.HardFigures = 8
+	rules.EasyFigures = 8
+	rules.TurnDuration = 30
+	rules.BlockingEnabled = false
+	return rules
+}
+
+// Preset returns the named rule set
+func Preset(name string) (RuleSet, error) {
+	switch name {
+	case CLASSIC, "":
+		return Classic(), nil
+	case QUICK:
+		return Quick(), nil
+	case BLITZ:
+		return Blitz(), nil
+	default:
+		return RuleSet{}, fmt.Errorf("%w: %s", ErrUnknownPreset, name)
+	}
+}
+
+// Resolve starts from the named preset and applies the given overrides, a
+// partial RuleSet in JSON
+func Resolve(name string, overrides json.RawMessage) (RuleSet, error) {
+	rules, err := Preset(name)
+	if err != nil {
+		return RuleSet{}, err
+	}
+
+	if len(overrides) > 0 {
+		// A deck in the overrides replaces the preset deck instead of merging
+		if err := unmarshalOver(&rules, overrides); err != nil {
+			return RuleSet{}, fmt.Errorf("%w: %v", ErrInvalidRules, err)
+		}
+	}
+
+	if err := rules.Validate(); err != nil {
+		return RuleSet{}, err
+	}
+	return rules, nil
+}
+
+// FromJSON decodes the rules stored with a game. Missing fields keep their
+// classic value, so games created before a rule existed play as before
+func FromJSON(data []byte) (RuleSet, error) {
+	rules := Classic()
+	if len(data) == 0 {
+		return rules, nil
+	}
+	if err := unmarshalOver(&rules, data); err != nil {
+		return RuleSet{}, err
+	}
+	return rules, nil
+}
+
+func unmarshalOver(rules *RuleSet, data []byte) error {
+	var partial struct {
+		MovementDeck map[string]int `json:"movement_deck"`
+	}
+	if err := json.Unmarshal(data, &partial); err != nil {
+		return err
+	}
+
+	deck := rules.MovementDeck
+	if err := json.Unmarshal(data, rules); err != nil {
+		return err
+	}
+	if partial.MovementDeck != nil {
+		rules.MovementDeck = partial.MovementDeck
+	} else {
+		rules.MovementDeck = deck
+	}
+	return nil
+}
+
+// Validate checks the rules can be played
+func (r RuleSet) Validate() error {
+	if r.BoardSize < 4 || r.BoardSize > 10 {
+		return fmt.Errorf("%w: board size must be between 4 and 10", ErrInvalidRules)
+	}
+	if r.Colors < 2 || r.Colors > 4 {
+		return fmt.Errorf("%w: colors must be between 2 and 4", ErrInvalidRules)
+	}
+	if r.HandSize < 1 || r.HandSize > 5 {
+		return fmt.Errorf("%w: hand size must be between 1 and 5", ErrInvalidRules)
+	}
+	if r.ShowLimit < 1 || r.ShowLimit > 5 {
+		return fmt.Errorf("%w: show limit must be between 1 and 5", ErrInvalidRules)
+	}
+
+	classicDeck := Classic().MovementDeck
+	deckSize := 0
+	for cardType, amount := range r.MovementDeck {
+		if _, ok := classicDeck[cardType]; !ok {
+			return fmt.Errorf("%w: unknown movement card %s", ErrInvalidRules, cardType)
+		}
+		if amount < 0 {
+			return fmt.Errorf("%w: negative amount of %s cards", ErrInvalidRules, cardType)
+		}
+		deckSize += amount
+	}
+	if deckSize < r.HandSize*MAX_PLAYERS {
+		return fmt.Errorf("%w: the movement deck needs at least %d cards", ErrInvalidRules, r.HandSize*MAX_PLAYERS)
+	}
+
+	if r.HardFigures < 0 || r.EasyFigures < 0 || r.HardFigures+r.EasyFigures < MAX_PLAYERS {
+		return fmt.Errorf("%w: there must be at least %d figure cards", ErrInvalidRules, MAX_PLAYERS)
+	}
+	if r.TurnDuration < MIN_TURN_DURATION || r.TurnDuration > MAX_TURN_DURATION {
+		return fmt.Errorf("%w: turn duration must be between %d and %d seconds", ErrInvalidRules, MIN_TURN_DURATION, MAX_TURN_DURATION)
+	}
+	return nil
+}
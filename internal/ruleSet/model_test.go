@@ -0,0 +1,80 @@
+package ruleSet_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/NachoGz/switcher-backend-go/internal/ruleSet"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPresetsAreValid(t *testing.T) {
+	for _, name := range []string{ruleSet.CLASSIC, ruleSet.QUICK, ruleSet.BLITZ} {
+		rules, err := ruleSet.Preset(name)
+		assert.NoError(t, err)
+		assert.Equal(t, name, rules.Name)
+		assert.NoError(t, rules.Validate(), name)
+	}
+}
+
+func TestResolve_Overrides(t *testing.T) {
+	rules, err := ruleSet.Resolve(ruleSet.QUICK, json.RawMessage(`{"hand_size": 2, "blocking_enabled": false}`))
+	assert.NoError(t, err)
+
+	expected := ruleSet.Quick()
+	expected.HandSize = 2
+	expected.BlockingEnabled = false
+	assert.Equal(t, expected, rules)
+}
+
+func TestResolve_DeckReplacesPresetDeck(t *testing.T) {
+	rules, err := ruleSet.Resolve("", json.RawMessage(`{"movement_deck": {"linear_cont": 20}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"linear_cont": 20}, rules.MovementDeck)
+
+	// The presets are not modified
+	assert.Len(t, ruleSet.Classic().MovementDeck, 7)
+}
+
+func TestResolve_Invalid(t *testing.T) {
+	_, err := ruleSet.Resolve("chess", nil)
+	assert.ErrorIs(t, err, ruleSet.ErrUnknownPreset)
+
+	_, err = ruleSet.Resolve(ruleSet.CLASSIC, json.RawMessage(`{"movement_deck": {"linear_cont": 3}}`))
+	assert.ErrorIs(t, err, ruleSet.ErrInvalidRules)
+
+	_, err = ruleSet.Resolve(ruleSet.CLASSIC, json.RawMessage(`{"colors": 7}`))
+	assert.ErrorIs(t, err, ruleSet.ErrInvalidRules)
+
+	_, err = ruleSet.Resolve(ruleSet.CLASSIC, json.RawMessage(`not json`))
+	assert.ErrorIs(t, err, ruleSet.ErrInvalidRules)
+}
+
+func TestFromJSON_DefaultsToClassic(t *testing.T) {
+	rules, err := ruleSet.FromJSON([]byte(`{}`))
+	assert.NoError(t, err)
+	assert.Equal(t, ruleSet.Classic(), rules)
+
+	stored, _ := json.Marshal(ruleSet.Blitz())
+	rules, err = ruleSet.FromJSON(stored)
+	assert.NoError(t, err)
+	assert.Equal(t, ruleSet.Blitz(), rules)
+}
+
+func TestSetClassicTurnDuration(t *testing.T) {
+	defer ruleSet.SetClassicTurnDuration(ruleSet.DEFAULT_TURN_DURATION)
+
+	assert.NoError(t, ruleSet.SetClassicTurnDuration(90))
+	assert.Equal(t, 90, ruleSet.Classic().TurnDuration)
+
+	// Games stored without a turn duration play with the configured one
+	rules, err := ruleSet.FromJSON([]byte(`{"name": "classic"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, 90, rules.TurnDuration)
+
+	// The other presets keep their own
+	assert.Equal(t, 30, ruleSet.Blitz().TurnDuration)
+
+	assert.Error(t, ruleSet.SetClassicTurnDuration(ruleSet.MAX_TURN_DURATION+1))
+	assert.Equal(t, 90, ruleSet.Classic().TurnDuration)
+}
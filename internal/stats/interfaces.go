@@ -0,0 +1,23 @@
+package stats
+
+import (
+	"context"
+
+	"github.com/NachoGz/switcher-backend-go/internal/database"
+	"github.com/google/uuid"
+)
+
+type StatsService interface {
+	RecordMatch(ctx context.Context, gameID uuid.UUID) error
+	GetPlayerStats(ctx context.Context, player string) (*PlayerStats, error)
+	GetLeaderboard(ctx context.Context, size int) ([]LeaderboardEntry, error)
+}
+
+type StatsRepository interface {
+	CreateMatch(ctx context.Context, result database.CreateMatchResultParams, participants []database.CreateMatchParticipantParams) error
+	GetPlayerStatsByUser(ctx context.Context, userID uuid.UUID) (database.GetPlayerStatsByUserRow, error)
+	GetPlayerStatsByName(ctx context.Context, name string) (database.GetPlayerStatsByNameRow, error)
+	GetMatchHistoryByUser(ctx context.Context, params database.GetMatchHistoryByUserParams) ([]database.GetMatchHistoryByUserRow, error)
+	GetMatchHistoryByName(ctx context.Context, params database.GetMatchHistoryByNameParams) ([]database.GetMatchHistoryByNameRow, error)
+	GetLeaderboard(ctx context.Context, limit int32) ([]database.GetLeaderboardRow, error)
+}
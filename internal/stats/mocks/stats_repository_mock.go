@@ -0,0 +1,43 @@
+package stats_mock
+
+import (
+	"context"
+
+	"github.com/NachoGz/switcher-backend-go/internal/database"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockStatsRepository struct {
+	mock.Mock
+}
+
+func (m *MockStatsRepository) CreateMatch(ctx context.Context, result database.CreateMatchResultParams, participants []database.CreateMatchParticipantParams) error {
+	args := m.Called(ctx, result, participants)
+	return args.Error(0)
+}
+
+func (m *MockStatsRepository) GetPlayerStatsByUser(ctx context.Context, userID uuid.UUID) (database.GetPlayerStatsByUserRow, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(database.GetPlayerStatsByUserRow), args.Error(1)
+}
+
+func (m *MockStatsRepository) GetPlayerStatsByName(ctx context.Context, name string) (database.GetPlayerStatsByNameRow, error) {
+	args := m.Called(ctx, name)
+	return args.Get(0).(database.GetPlayerStatsByNameRow), args.Error(1)
+}
+
+func (m *MockStatsRepository) GetMatchHistoryByUser(ctx context.Context, params database.GetMatchHistoryByUserParams) ([]database.GetMatchHistoryByUserRow, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).([]database.GetMatchHistoryByUserRow), args.Error(1)
+}
+
+func (m *MockStatsRepository) GetMatchHistoryByName(ctx context.Context, params database.GetMatchHistoryByNameParams) ([]database.GetMatchHistoryByNameRow, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).([]database.GetMatchHistoryByNameRow), args.Error(1)
+}
+
+func (m *MockStatsRepository) GetLeaderboard(ctx context.Context, limit int32) ([]database.GetLeaderboardRow, error) {
+	args := m.Called(ctx, limit)
+	return args.Get(0).([]database.GetLeaderboardRow), args.Error(1)
+}
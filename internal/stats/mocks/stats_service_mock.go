@@ -0,0 +1,34 @@
+package stats_mock
+
+import (
+	"context"
+
+	"github.com/NachoGz/switcher-backend-go/internal/stats"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockStatsService struct {
+	mock.Mock
+}
+
+func (m *MockStatsService) RecordMatch(ctx context.Context, gameID uuid.UUID) error {
+	args := m.Called(ctx, gameID)
+	return args.Error(0)
+}
+
+func (m *MockStatsService) GetPlayerStats(ctx context.Context, player string) (*stats.PlayerStats, error) {
+	args := m.Called(ctx, player)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*stats.PlayerStats), args.Error(1)
+}
+
+func (m *MockStatsService) GetLeaderboard(ctx context.Context, size int) ([]stats.LeaderboardEntry, error) {
+	args := m.Called(ctx, size)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]stats.LeaderboardEntry), args.Error(1)
+}
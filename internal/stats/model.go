@@ -0,0 +1,65 @@
+package stats
+
+import (
+	"time"
+
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
+	"github.com/google/uuid"
+)
+
+const (
+	// Number of recent matches returned with the stats of a player
+	HISTORY_LIMIT = 20
+
+	DEFAULT_LEADERBOARD_SIZE = 10
+	MAX_LEADERBOARD_SIZE     = 100
+)
+
+var (
+	ErrPlayerNotFound = utils.NewDomainError(utils.ErrNotFound, "STATS_NOT_FOUND", "no finished matches found for player")
+)
+
+type PlayerStats struct {
+	Player             string         `json:"player"`
+	UserID             *uuid.UUID     `json:"user_id,omitempty"`
+	Games              int            `json:"games"`
+	Wins               int            `json:"wins"`
+	WinRate            float64        `json:"win_rate"`
+	FiguresCompleted   int            `json:"figures_completed"`
+	Blocks             int            `json:"blocks"`
+	Turns              int            `json:"turns"`
+	AvgDurationSeconds float64        `json:"avg_duration_seconds"`
+	Matches            []MatchSummary `json:"matches"`
+}
+
+// MatchSummary is a finished match as seen by one of its participants
+type MatchSummary struct {
+	GameID           uuid.UUID `json:"game_id"`
+	GameName         string    `json:"game_name"`
+	PlayerCount      int       `json:"player_count"`
+	WinnerName       string    `json:"winner_name"`
+	Turns            int       `json:"turns"`
+	DurationSeconds  int       `json:"duration_seconds"`
+	FinishedAt       time.Time `json:"finished_at"`
+	Won              bool      `json:"won"`
+	FiguresCompleted int       `json:"figures_completed"`
+	Blocks           int       `json:"blocks"`
+}
+
+type LeaderboardEntry struct {
+	Rank             int       `json:"rank"`
+	UserID           uuid.UUID `json:"user_id"`
+	Username         string    `json:"username"`
+	Games            int       `json:"games"`
+	Wins             int       `json:"wins"`
+	WinRate          float64   `json:"win_rate"`
+	FiguresCompleted int       `json:"figures_completed"`
+}
+
+// winRate returns the share of games won, 0 when no game was played
+func winRate(wins, games int64) float64 {
+	if games == 0 {
+		return 0
+	}
+	return float64(wins) / float64(games)
+}
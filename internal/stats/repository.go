@@ -0,0 +1,66 @@
+package stats
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/NachoGz/switcher-backend-go/internal/database"
+	"github.com/google/uuid"
+)
+
+// PostgresStatsRepository implements StatsRepository for Postgres
+type PostgresStatsRepository struct {
+	db      *sql.DB
+	queries *database.Queries
+}
+
+// NewStatsRepository creates a new stats repository
+func NewStatsRepository(queries *database.Queries, db *sql.DB) StatsRepository {
+	return &PostgresStatsRepository{
+		db:      db,
+		queries: queries,
+	}
+}
+
+// CreateMatch stores the result of a match along with its participants
+func (r *PostgresStatsRepository) CreateMatch(ctx context.Context, result database.CreateMatchResultParams, participants []database.CreateMatchParticipantParams) error {
+	return database.InTx(ctx, r.db, func(ctx context.Context) error {
+		match, err := r.queries.CreateMatchResult(ctx, result)
+		if err != nil {
+			return err
+		}
+
+		for _, participant := range participants {
+			participant.MatchID = match.ID
+			if err := r.queries.CreateMatchParticipant(ctx, participant); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetPlayerStatsByUser aggregates the matches of a registered user
+func (r *PostgresStatsRepository) GetPlayerStatsByUser(ctx context.Context, userID uuid.UUID) (database.GetPlayerStatsByUserRow, error) {
+	return r.queries.GetPlayerStatsByUser(ctx, uuid.NullUUID{UUID: userID, Valid: true})
+}
+
+// GetPlayerStatsByName aggregates the matches played under a name
+func (r *PostgresStatsRepository) GetPlayerStatsByName(ctx context.Context, name string) (database.GetPlayerStatsByNameRow, error) {
+	return r.queries.GetPlayerStatsByName(ctx, name)
+}
+
+// GetMatchHistoryByUser fetches the latest matches of a registered user
+func (r *PostgresStatsRepository) GetMatchHistoryByUser(ctx context.Context, params database.GetMatchHistoryByUserParams) ([]database.GetMatchHistoryByUserRow, error) {
+	return r.queries.GetMatchHistoryByUser(ctx, params)
+}
+
+// GetMatchHistoryByName fetches the latest matches played under a name
+func (r *PostgresStatsRepository) GetMatchHistoryByName(ctx context.Context, params database.GetMatchHistoryByNameParams) ([]database.GetMatchHistoryByNameRow, error) {
+	return r.queries.GetMatchHistoryByName(ctx, params)
+}
+
+// GetLeaderboard ranks registered users by their wins
+func (r *PostgresStatsRepository) GetLeaderboard(ctx context.Context, limit int32) ([]database.GetLeaderboardRow, error) {
+	return r.queries.GetLeaderboard(ctx, limit)
+}
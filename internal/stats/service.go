@@ -0,0 +1,245 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/NachoGz/switcher-backend-go/internal/database"
+	"github.com/NachoGz/switcher-backend-go/internal/game"
+	"github.com/NachoGz/switcher-backend-go/internal/gameEvent"
+	"github.com/NachoGz/switcher-backend-go/internal/logging"
+	"github.com/NachoGz/switcher-backend-go/internal/player"
+	"github.com/NachoGz/switcher-backend-go/internal/user"
+	"github.com/google/uuid"
+)
+
+// Service records finished matches and computes player statistics from them
+type Service struct {
+	statsRepo        StatsRepository
+	gameService      game.GameService
+	playerService    player.PlayerService
+	gameEventService gameEvent.GameEventService
+	userService      user.UserService
+}
+
+// NewService creates a new stats service
+func NewService(
+	statsRepo StatsRepository,
+	gameService game.GameService,
+	playerService player.PlayerService,
+	gameEventService gameEvent.GameEventService,
+	userService user.UserService,
+) *Service {
+	return &Service{
+		statsRepo:        statsRepo,
+		gameService:      gameService,
+		playerService:    playerService,
+		gameEventService: gameEventService,
+		userService:      userService,
+	}
+}
+
+// Ensure Service implements StatsService
+var _ StatsService = (*Service)(nil)
+
+// HandleGameFinished records the result of a game once it has a winner. It
+// is meant to be registered with gameplay.GameplayService.OnGameFinished
+func (s *Service) HandleGameFinished(ctx context.Context, gameID uuid.UUID, winnerID uuid.UUID) {
+	if err := s.RecordMatch(ctx, gameID); err != nil {
+		slog.ErrorContext(ctx, "Error recording game result", logging.GameID(gameID), "error", err)
+	}
+}
+
+// RecordMatch stores the result of a finished game. The turns, figures and
+// blocks are counted from the event log of the game
+func (s *Service) RecordMatch(ctx context.Context, gameID uuid.UUID) error {
+	currentGame, err := s.gameService.GetGameByID(ctx, gameID)
+	if err != nil {
+		return fmt.Errorf("error fetching game: %w", err)
+	}
+
+	players, err := s.playerService.GetPlayersInGame(ctx, gameID)
+	if err != nil {
+		return fmt.Errorf("error fetching players: %w", err)
+	}
+
+	events, err := s.gameEventService.GetEvents(ctx, gameID)
+	if err != nil {
+		return fmt.Errorf("error fetching events: %w", err)
+	}
+
+	turns := 1
+	figures := map[uuid.UUID]int32{}
+	blocks := map[uuid.UUID]int32{}
+	var startedAt, finishedAt time.Time
+	for _, event := range events {
+		switch event.Type {
+		case gameEvent.GAME_STARTED:
+			startedAt = event.CreatedAt
+		case gameEvent.TURN_ENDED:
+			turns++
+		case gameEvent.FIGURE_PLAYED:
+			figures[event.PlayerID]++
+		case gameEvent.FIGURE_BLOCKED:
+			blocks[event.PlayerID]++
+		}
+		finishedAt = event.CreatedAt
+	}
+	if startedAt.IsZero() {
+		startedAt = finishedAt
+	}
+
+	winnerName := ""
+	participants := make([]database.CreateMatchParticipantParams, 0, len(players))
+	for _, p := range players {
+		if p.Winner {
+			winnerName = p.Name
+		}
+		participants = append(participants, database.CreateMatchParticipantParams{
+			ID:               uuid.New(),
+			PlayerID:         p.ID,
+			UserID:           player.NullUserID(p.UserID),
+			Name:             p.Name,
+			Bot:              p.Bot,
+			Winner:           p.Winner,
+			FiguresCompleted: figures[p.ID],
+			Blocks:           blocks[p.ID],
+		})
+	}
+
+	err = s.statsRepo.CreateMatch(ctx, database.CreateMatchResultParams{
+		ID:              uuid.New(),
+		GameID:          gameID,
+		GameName:        currentGame.Name,
+		PlayerCount:     int32(len(players)),
+		WinnerName:      winnerName,
+		Turns:           int32(turns),
+		DurationSeconds: int32(finishedAt.Sub(startedAt).Seconds()),
+		StartedAt:       startedAt,
+	}, participants)
+	if err != nil {
+		return fmt.Errorf("error storing match result: %w", err)
+	}
+	return nil
+}
+
+// GetPlayerStats returns the stats and latest matches of a player. The player
+// is either the ID of a registered user or a name used in past games
+func (s *Service) GetPlayerStats(ctx context.Context, playerKey string) (*PlayerStats, error) {
+	userID, err := uuid.Parse(playerKey)
+	if err != nil {
+		return s.getStatsByName(ctx, playerKey)
+	}
+
+	u, err := s.userService.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, ErrPlayerNotFound
+	}
+
+	row, err := s.statsRepo.GetPlayerStatsByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching stats: %w", err)
+	}
+	if row.Games == 0 {
+		return nil, ErrPlayerNotFound
+	}
+
+	history, err := s.statsRepo.GetMatchHistoryByUser(ctx, database.GetMatchHistoryByUserParams{
+		UserID: uuid.NullUUID{UUID: userID, Valid: true},
+		Limit:  HISTORY_LIMIT,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching match history: %w", err)
+	}
+
+	stats := toPlayerStats(u.Username, row, history)
+	stats.UserID = &u.ID
+	return stats, nil
+}
+
+func (s *Service) getStatsByName(ctx context.Context, name string) (*PlayerStats, error) {
+	row, err := s.statsRepo.GetPlayerStatsByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching stats: %w", err)
+	}
+	if row.Games == 0 {
+		return nil, ErrPlayerNotFound
+	}
+
+	history, err := s.statsRepo.GetMatchHistoryByName(ctx, database.GetMatchHistoryByNameParams{
+		Lower: name,
+		Limit: HISTORY_LIMIT,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching match history: %w", err)
+	}
+
+	rows := make([]database.GetMatchHistoryByUserRow, len(history))
+	for i, match := range history {
+		rows[i] = database.GetMatchHistoryByUserRow(match)
+	}
+	return toPlayerStats(name, database.GetPlayerStatsByUserRow(row), rows), nil
+}
+
+// toPlayerStats builds the stats of a player from its aggregates and history
+func toPlayerStats(name string, row database.GetPlayerStatsByUserRow, history []database.GetMatchHistoryByUserRow) *PlayerStats {
+	stats := &PlayerStats{
+		Player:             name,
+		Games:              int(row.Games),
+		Wins:               int(row.Wins),
+		WinRate:            winRate(row.Wins, row.Games),
+		FiguresCompleted:   int(row.FiguresCompleted),
+		Blocks:             int(row.Blocks),
+		Turns:              int(row.Turns),
+		AvgDurationSeconds: row.AvgDurationSeconds,
+		Matches:            []MatchSummary{},
+	}
+
+	for _, match := range history {
+		stats.Matches = append(stats.Matches, MatchSummary{
+			GameID:           match.GameID,
+			GameName:         match.GameName,
+			PlayerCount:      int(match.PlayerCount),
+			WinnerName:       match.WinnerName,
+			Turns:            int(match.Turns),
+			DurationSeconds:  int(match.DurationSeconds),
+			FinishedAt:       match.FinishedAt,
+			Won:              match.Winner,
+			FiguresCompleted: int(match.FiguresCompleted),
+			Blocks:           int(match.Blocks),
+		})
+	}
+	return stats
+}
+
+// GetLeaderboard ranks registered users by their wins. Guests aren't ranked
+// as their names aren't unique
+func (s *Service) GetLeaderboard(ctx context.Context, size int) ([]LeaderboardEntry, error) {
+	if size <= 0 {
+		size = DEFAULT_LEADERBOARD_SIZE
+	}
+	if size > MAX_LEADERBOARD_SIZE {
+		size = MAX_LEADERBOARD_SIZE
+	}
+
+	rows, err := s.statsRepo.GetLeaderboard(ctx, int32(size))
+	if err != nil {
+		return nil, fmt.Errorf("error fetching leaderboard: %w", err)
+	}
+
+	entries := make([]LeaderboardEntry, 0, len(rows))
+	for i, row := range rows {
+		entries = append(entries, LeaderboardEntry{
+			Rank:             i + 1,
+			UserID:           row.UserID,
+			Username:         row.Username,
+			Games:            int(row.Games),
+			Wins:             int(row.Wins),
+			WinRate:          winRate(row.Wins, row.Games),
+			FiguresCompleted: int(row.FiguresCompleted),
+		})
+	}
+	return entries, nil
+}
@@ -0,0 +1,190 @@
+package stats_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NachoGz/switcher-backend-go/internal/database"
+	"github.com/NachoGz/switcher-backend-go/internal/game"
+	game_mock "github.com/NachoGz/switcher-backend-go/internal/game/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/gameEvent"
+	gameEvent_mock "github.com/NachoGz/switcher-backend-go/internal/gameEvent/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/player"
+	player_mock "github.com/NachoGz/switcher-backend-go/internal/player/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/stats"
+	stats_mock "github.com/NachoGz/switcher-backend-go/internal/stats/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/user"
+	user_mock "github.com/NachoGz/switcher-backend-go/internal/user/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordMatch(t *testing.T) {
+	// Create mocks
+	mockStatsRepo := new(stats_mock.MockStatsRepository)
+	mockGameService := new(game_mock.MockGameService)
+	mockPlayerService := new(player_mock.MockPlayerService)
+	mockGameEventService := new(gameEvent_mock.MockGameEventService)
+	mockUserService := new(user_mock.MockUserService)
+
+	// Create the service with all the mocks
+	service := stats.NewService(mockStatsRepo, mockGameService, mockPlayerService, mockGameEventService, mockUserService)
+
+	gameID := uuid.New()
+	userID := uuid.New()
+	winner := player.Player{ID: uuid.New(), Name: "Alice", Winner: true, UserID: &userID}
+	loser := player.Player{ID: uuid.New(), Name: "Bot 1", Bot: true}
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	mockGameService.On("GetGameByID", mock.Anything, gameID).
+		Return(&game.Game{ID: gameID, Name: "Friday game"}, nil)
+	mockPlayerService.On("GetPlayersInGame", mock.Anything, gameID).
+		Return([]player.Player{winner, loser}, nil)
+	mockGameEventService.On("GetEvents", mock.Anything, gameID).
+		Return([]gameEvent.GameEvent{
+			{Type: gameEvent.GAME_STARTED, CreatedAt: start},
+			{Type: gameEvent.FIGURE_PLAYED, PlayerID: winner.ID, CreatedAt: start.Add(time.Minute)},
+			{Type: gameEvent.TURN_ENDED, PlayerID: winner.ID, CreatedAt: start.Add(2 * time.Minute)},
+			{Type: gameEvent.FIGURE_BLOCKED, PlayerID: loser.ID, CreatedAt: start.Add(3 * time.Minute)},
+			{Type: gameEvent.TURN_ENDED, PlayerID: loser.ID, CreatedAt: start.Add(4 * time.Minute)},
+			{Type: gameEvent.FIGURE_PLAYED, PlayerID: winner.ID, CreatedAt: start.Add(5 * time.Minute)},
+			{Type: gameEvent.GAME_WON, PlayerID: winner.ID, CreatedAt: start.Add(5 * time.Minute)},
+		}, nil)
+
+	mockStatsRepo.On("CreateMatch", mock.Anything,
+		mock.MatchedBy(func(result database.CreateMatchResultParams) bool {
+			return result.GameID == gameID &&
+				result.GameName == "Friday game" &&
+				result.PlayerCount == 2 &&
+				result.WinnerName == "Alice" &&
+				result.Turns == 3 &&
+				result.DurationSeconds == 300 &&
+				result.StartedAt.Equal(start)
+		}),
+		mock.MatchedBy(func(participants []database.CreateMatchParticipantParams) bool {
+			return len(participants) == 2 &&
+				participants[0].PlayerID == winner.ID &&
+				participants[0].UserID == uuid.NullUUID{UUID: userID, Valid: true} &&
+				participants[0].Winner &&
+				participants[0].FiguresCompleted == 2 &&
+				participants[0].Blocks == 0 &&
+				participants[1].Bot &&
+				!participants[1].UserID.Valid &&
+				participants[1].Blocks == 1
+		}),
+	).Return(nil)
+
+	err := service.RecordMatch(context.Background(), gameID)
+
+	assert.NoError(t, err)
+	mockStatsRepo.AssertExpectations(t)
+}
+
+func TestGetPlayerStats_ByUser(t *testing.T) {
+	// Create mocks
+	mockStatsRepo := new(stats_mock.MockStatsRepository)
+	mockGameService := new(game_mock.MockGameService)
+	mockPlayerService := new(player_mock.MockPlayerService)
+	mockGameEventService := new(gameEvent_mock.MockGameEventService)
+	mockUserService := new(user_mock.MockUserService)
+
+	// Create the service with all the mocks
+	service := stats.NewService(mockStatsRepo, mockGameService, mockPlayerService, mockGameEventService, mockUserService)
+
+	userID := uuid.New()
+	finishedAt := time.Now()
+
+	mockUserService.On("GetUserByID", mock.Anything, userID).
+		Return(&user.User{ID: userID, Username: "alice"}, nil)
+	mockStatsRepo.On("GetPlayerStatsByUser", mock.Anything, userID).
+		Return(database.GetPlayerStatsByUserRow{Games: 4, Wins: 1, FiguresCompleted: 9, Blocks: 2, Turns: 40, AvgDurationSeconds: 600}, nil)
+	mockStatsRepo.On("GetMatchHistoryByUser", mock.Anything, database.GetMatchHistoryByUserParams{
+		UserID: uuid.NullUUID{UUID: userID, Valid: true},
+		Limit:  stats.HISTORY_LIMIT,
+	}).Return([]database.GetMatchHistoryByUserRow{
+		{GameID: uuid.New(), GameName: "Friday game", PlayerCount: 3, WinnerName: "alice", Winner: true, FinishedAt: finishedAt},
+	}, nil)
+
+	playerStats, err := service.GetPlayerStats(context.Background(), userID.String())
+
+	require.NoError(t, err)
+	assert.Equal(t, "alice", playerStats.Player)
+	assert.Equal(t, &userID, playerStats.UserID)
+	assert.Equal(t, 4, playerStats.Games)
+	assert.Equal(t, 0.25, playerStats.WinRate)
+	require.Len(t, playerStats.Matches, 1)
+	assert.True(t, playerStats.Matches[0].Won)
+}
+
+func TestGetPlayerStats_ByName(t *testing.T) {
+	// Create mocks
+	mockStatsRepo := new(stats_mock.MockStatsRepository)
+	mockGameService := new(game_mock.MockGameService)
+	mockPlayerService := new(player_mock.MockPlayerService)
+	mockGameEventService := new(gameEvent_mock.MockGameEventService)
+	mockUserService := new(user_mock.MockUserService)
+
+	// Create the service with all the mocks
+	service := stats.NewService(mockStatsRepo, mockGameService, mockPlayerService, mockGameEventService, mockUserService)
+
+	mockStatsRepo.On("GetPlayerStatsByName", mock.Anything, "Guest").
+		Return(database.GetPlayerStatsByNameRow{Games: 2, Wins: 2}, nil)
+	mockStatsRepo.On("GetMatchHistoryByName", mock.Anything, mock.Anything).
+		Return([]database.GetMatchHistoryByNameRow{}, nil)
+
+	playerStats, err := service.GetPlayerStats(context.Background(), "Guest")
+
+	require.NoError(t, err)
+	assert.Nil(t, playerStats.UserID)
+	assert.Equal(t, 1.0, playerStats.WinRate)
+	assert.Empty(t, playerStats.Matches)
+	mockUserService.AssertNotCalled(t, "GetUserByID")
+}
+
+func TestGetPlayerStats_NoMatches(t *testing.T) {
+	// Create mocks
+	mockStatsRepo := new(stats_mock.MockStatsRepository)
+	mockGameService := new(game_mock.MockGameService)
+	mockPlayerService := new(player_mock.MockPlayerService)
+	mockGameEventService := new(gameEvent_mock.MockGameEventService)
+	mockUserService := new(user_mock.MockUserService)
+
+	// Create the service with all the mocks
+	service := stats.NewService(mockStatsRepo, mockGameService, mockPlayerService, mockGameEventService, mockUserService)
+
+	mockStatsRepo.On("GetPlayerStatsByName", mock.Anything, "Nobody").
+		Return(database.GetPlayerStatsByNameRow{}, nil)
+
+	_, err := service.GetPlayerStats(context.Background(), "Nobody")
+
+	assert.ErrorIs(t, err, stats.ErrPlayerNotFound)
+}
+
+func TestGetLeaderboard(t *testing.T) {
+	// Create mocks
+	mockStatsRepo := new(stats_mock.MockStatsRepository)
+	mockGameService := new(game_mock.MockGameService)
+	mockPlayerService := new(player_mock.MockPlayerService)
+	mockGameEventService := new(gameEvent_mock.MockGameEventService)
+	mockUserService := new(user_mock.MockUserService)
+
+	// Create the service with all the mocks
+	service := stats.NewService(mockStatsRepo, mockGameService, mockPlayerService, mockGameEventService, mockUserService)
+
+	mockStatsRepo.On("GetLeaderboard", mock.Anything, int32(stats.MAX_LEADERBOARD_SIZE)).
+		Return([]database.GetLeaderboardRow{
+			{UserID: uuid.New(), Username: "alice", Games: 4, Wins: 3},
+			{UserID: uuid.New(), Username: "bob", Games: 2, Wins: 1},
+		}, nil)
+
+	entries, err := service.GetLeaderboard(context.Background(), 1000)
+
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, 1, entries[0].Rank)
+	assert.Equal(t, 0.75, entries[0].WinRate)
+	assert.Equal(t, 2, entries[1].Rank)
+}
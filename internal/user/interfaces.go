@@ -0,0 +1,29 @@
+package user
+
+import (
+	"context"
+
+	"github.com/NachoGz/switcher-backend-go/internal/database"
+	"github.com/google/uuid"
+)
+
+type UserService interface {
+	Register(ctx context.Context, username string, password string) (*User, *Session, error)
+	Login(ctx context.Context, username string, password string) (*User, *Session, error)
+	Logout(ctx context.Context, token string) error
+	Authenticate(ctx context.Context, token string) (*User, error)
+	GetUserByID(ctx context.Context, id uuid.UUID) (*User, error)
+	AdjustRating(ctx context.Context, id uuid.UUID, delta int) error
+	DeleteExpiredSessions(ctx context.Context) (int, error)
+}
+
+type UserRepository interface {
+	CreateUser(ctx context.Context, params database.CreateUserParams) (database.User, error)
+	GetUserByID(ctx context.Context, id uuid.UUID) (database.User, error)
+	GetUserByUsername(ctx context.Context, username string) (database.User, error)
+	CreateSession(ctx context.Context, params database.CreateSessionParams) (database.Session, error)
+	GetSessionByTokenHash(ctx context.Context, tokenHash string) (database.Session, error)
+	DeleteSession(ctx context.Context, tokenHash string) error
+	DeleteExpiredSessions(ctx context.Context) (int64, error)
+	UpdateUserRating(ctx context.Context, params database.UpdateUserRatingParams) error
+}
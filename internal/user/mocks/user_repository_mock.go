@@ -0,0 +1,53 @@
+package user_mock
+
+import (
+	"context"
+
+	"github.com/NachoGz/switcher-backend-go/internal/database"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) CreateUser(ctx context.Context, params database.CreateUserParams) (database.User, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).(database.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetUserByID(ctx context.Context, id uuid.UUID) (database.User, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(database.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetUserByUsername(ctx context.Context, username string) (database.User, error) {
+	args := m.Called(ctx, username)
+	return args.Get(0).(database.User), args.Error(1)
+}
+
+func (m *MockUserRepository) CreateSession(ctx context.Context, params database.CreateSessionParams) (database.Session, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).(database.Session), args.Error(1)
+}
+
+func (m *MockUserRepository) GetSessionByTokenHash(ctx context.Context, tokenHash string) (database.Session, error) {
+	args := m.Called(ctx, tokenHash)
+	return args.Get(0).(database.Session), args.Error(1)
+}
+
+func (m *MockUserRepository) DeleteSession(ctx context.Context, tokenHash string) error {
+	args := m.Called(ctx, tokenHash)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) DeleteExpiredSessions(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateUserRating(ctx context.Context, params database.UpdateUserRatingParams) error {
+	args := m.Called(ctx, params)
+	return args.Error(0)
+}
@@ -0,0 +1,60 @@
+package user_mock
+
+import (
+	"context"
+
+	"github.com/NachoGz/switcher-backend-go/internal/user"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockUserService struct {
+	mock.Mock
+}
+
+func (m *MockUserService) Register(ctx context.Context, username string, password string) (*user.User, *user.Session, error) {
+	args := m.Called(ctx, username, password)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*user.User), args.Get(1).(*user.Session), args.Error(2)
+}
+
+func (m *MockUserService) Login(ctx context.Context, username string, password string) (*user.User, *user.Session, error) {
+	args := m.Called(ctx, username, password)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*user.User), args.Get(1).(*user.Session), args.Error(2)
+}
+
+func (m *MockUserService) Logout(ctx context.Context, token string) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *MockUserService) Authenticate(ctx context.Context, token string) (*user.User, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.User), args.Error(1)
+}
+
+func (m *MockUserService) GetUserByID(ctx context.Context, id uuid.UUID) (*user.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.User), args.Error(1)
+}
+
+func (m *MockUserService) AdjustRating(ctx context.Context, id uuid.UUID, delta int) error {
+	args := m.Called(ctx, id, delta)
+	return args.Error(0)
+}
+
+func (m *MockUserService) DeleteExpiredSessions(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
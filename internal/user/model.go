@@ -0,0 +1,69 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/NachoGz/switcher-backend-go/internal/database"
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
+	"github.com/google/uuid"
+)
+
+// How long a session token stays valid after logging in
+const SESSION_DURATION = 30 * 24 * time.Hour
+
+var (
+	ErrMissingCredentials = utils.NewDomainError(utils.ErrInvalidInput, "MISSING_CREDENTIALS", "username and password are required")
+	ErrUsernameTaken      = utils.NewDomainError(utils.ErrConflict, "USERNAME_TAKEN", "username is already taken")
+	ErrInvalidCredentials = utils.NewDomainError(utils.ErrUnauthorized, "INVALID_CREDENTIALS", "invalid username or password")
+	ErrInvalidSession     = utils.NewDomainError(utils.ErrUnauthorized, "INVALID_SESSION", "invalid or expired session")
+)
+
+type User struct {
+	ID         uuid.UUID `json:"id"`
+	Username   string    `json:"username"`
+	Rating     int       `json:"rating"`
+	RatedGames int       `json:"rated_games"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Session is handed to the client after registering or logging in. The
+// token is only known by the client, the database keeps its hash
+type Session struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying the authenticated user
+func NewContext(ctx context.Context, u *User) context.Context {
+	return context.WithValue(ctx, contextKey{}, u)
+}
+
+// FromContext returns the authenticated user of a request, if any. Guests
+// have no user
+func FromContext(ctx context.Context) (*User, bool) {
+	u, ok := ctx.Value(contextKey{}).(*User)
+	return u, ok && u != nil
+}
+
+// IDFromContext returns the ID of the authenticated user, or nil for guests
+func IDFromContext(ctx context.Context) *uuid.UUID {
+	u, ok := FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	return &u.ID
+}
+
+// DBToModel converts a database user to a model user
+func (s *Service) DBToModel(dbUser database.User) User {
+	return User{
+		ID:         dbUser.ID,
+		Username:   dbUser.Username,
+		Rating:     int(dbUser.Rating),
+		RatedGames: int(dbUser.RatedGames),
+		CreatedAt:  dbUser.CreatedAt,
+	}
+}
@@ -0,0 +1,60 @@
+package user
+
+import (
+	"context"
+
+	"github.com/NachoGz/switcher-backend-go/internal/database"
+	"github.com/google/uuid"
+)
+
+// PostgresUserRepository implements UserRepository for Postgres
+type PostgresUserRepository struct {
+	queries *database.Queries
+}
+
+// NewUserRepository creates a new user repository
+func NewUserRepository(queries *database.Queries) UserRepository {
+	return &PostgresUserRepository{
+		queries: queries,
+	}
+}
+
+// CreateUser creates a new user
+func (r *PostgresUserRepository) CreateUser(ctx context.Context, params database.CreateUserParams) (database.User, error) {
+	return r.queries.CreateUser(ctx, params)
+}
+
+// GetUserByID fetches a user by its ID
+func (r *PostgresUserRepository) GetUserByID(ctx context.Context, id uuid.UUID) (database.User, error) {
+	return r.queries.GetUserByID(ctx, id)
+}
+
+// GetUserByUsername fetches a user by its username, ignoring case
+func (r *PostgresUserRepository) GetUserByUsername(ctx context.Context, username string) (database.User, error) {
+	return r.queries.GetUserByUsername(ctx, username)
+}
+
+// CreateSession stores a new session of a user
+func (r *PostgresUserRepository) CreateSession(ctx context.Context, params database.CreateSessionParams) (database.Session, error) {
+	return r.queries.CreateSession(ctx, params)
+}
+
+// GetSessionByTokenHash fetches a session that hasn't expired
+func (r *PostgresUserRepository) GetSessionByTokenHash(ctx context.Context, tokenHash string) (database.Session, error) {
+	return r.queries.GetSessionByTokenHash(ctx, tokenHash)
+}
+
+// DeleteSession deletes a session
+func (r *PostgresUserRepository) DeleteSession(ctx context.Context, tokenHash string) error {
+	return r.queries.DeleteSession(ctx, tokenHash)
+}
+
+// DeleteExpiredSessions deletes every session past its expiry
+func (r *PostgresUserRepository) DeleteExpiredSessions(ctx context.Context) (int64, error) {
+	return r.queries.DeleteExpiredSessions(ctx)
+}
+
+// UpdateUserRating adds the points of a rated game to the rating of a user
+func (r *PostgresUserRepository) UpdateUserRating(ctx context.Context, params database.UpdateUserRatingParams) error {
+	return r.queries.UpdateUserRating(ctx, params)
+}
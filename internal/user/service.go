@@ -0,0 +1,191 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/NachoGz/switcher-backend-go/internal/database"
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Service handles user accounts and their sessions
+type Service struct {
+	userRepo UserRepository
+}
+
+// NewService creates a new user service
+func NewService(userRepo UserRepository) *Service {
+	return &Service{
+		userRepo: userRepo,
+	}
+}
+
+// Ensure Service implements UserService
+var _ UserService = (*Service)(nil)
+
+// Register creates a new user and logs it in
+func (s *Service) Register(ctx context.Context, username string, password string) (*User, *Session, error) {
+	username = strings.TrimSpace(username)
+	if username == "" || password == "" {
+		return nil, nil, ErrMissingCredentials
+	}
+
+	_, err := s.userRepo.GetUserByUsername(ctx, username)
+	if err == nil {
+		return nil, nil, ErrUsernameTaken
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, nil, fmt.Errorf("error fetching user: %w", err)
+	}
+
+	passwordHash, err := utils.HashPassword(password)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error hashing password: %w", err)
+	}
+
+	dbUser, err := s.userRepo.CreateUser(ctx, database.CreateUserParams{
+		ID:           uuid.New(),
+		Username:     username,
+		PasswordHash: passwordHash,
+	})
+	if err != nil {
+		// Someone else took the username in the meantime
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return nil, nil, ErrUsernameTaken
+		}
+		return nil, nil, fmt.Errorf("error creating user: %w", err)
+	}
+
+	session, err := s.createSession(ctx, dbUser.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user := s.DBToModel(dbUser)
+	return &user, session, nil
+}
+
+// Login checks the credentials of a user and starts a new session
+func (s *Service) Login(ctx context.Context, username string, password string) (*User, *Session, error) {
+	username = strings.TrimSpace(username)
+	if username == "" || password == "" {
+		return nil, nil, ErrMissingCredentials
+	}
+
+	dbUser, err := s.userRepo.GetUserByUsername(ctx, username)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("error fetching user: %w", err)
+	}
+
+	if err := utils.CheckPasswordHash(dbUser.PasswordHash, password); err != nil {
+		return nil, nil, ErrInvalidCredentials
+	}
+
+	session, err := s.createSession(ctx, dbUser.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user := s.DBToModel(dbUser)
+	return &user, session, nil
+}
+
+// Logout ends the session of a token
+func (s *Service) Logout(ctx context.Context, token string) error {
+	if err := s.userRepo.DeleteSession(ctx, hashToken(token)); err != nil {
+		return fmt.Errorf("error deleting session: %w", err)
+	}
+	return nil
+}
+
+// Authenticate returns the user owning a session token
+func (s *Service) Authenticate(ctx context.Context, token string) (*User, error) {
+	if token == "" {
+		return nil, ErrInvalidSession
+	}
+
+	session, err := s.userRepo.GetSessionByTokenHash(ctx, hashToken(token))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrInvalidSession
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error fetching session: %w", err)
+	}
+
+	return s.GetUserByID(ctx, session.UserID)
+}
+
+// GetUserByID gets a user by its ID
+func (s *Service) GetUserByID(ctx context.Context, id uuid.UUID) (*User, error) {
+	dbUser, err := s.userRepo.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	user := s.DBToModel(dbUser)
+	return &user, nil
+}
+
+// AdjustRating adds the points won or lost in a rated game to the rating of
+// a user
+func (s *Service) AdjustRating(ctx context.Context, id uuid.UUID, delta int) error {
+	return s.userRepo.UpdateUserRating(ctx, database.UpdateUserRatingParams{
+		Delta: int32(delta),
+		ID:    id,
+	})
+}
+
+// DeleteExpiredSessions deletes the sessions nobody can log in with anymore
+// and returns how many there were
+func (s *Service) DeleteExpiredSessions(ctx context.Context) (int, error) {
+	deleted, err := s.userRepo.DeleteExpiredSessions(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("error deleting expired sessions: %w", err)
+	}
+	return int(deleted), nil
+}
+
+// createSession stores a new session for a user and returns its token
+func (s *Service) createSession(ctx context.Context, userID uuid.UUID) (*Session, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, fmt.Errorf("error generating session token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	dbSession, err := s.userRepo.CreateSession(ctx, database.CreateSessionParams{
+		ID:        uuid.New(),
+		UserID:    userID,
+		TokenHash: hashToken(token),
+		ExpiresAt: time.Now().Add(SESSION_DURATION),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating session: %w", err)
+	}
+
+	return &Session{
+		Token:     token,
+		ExpiresAt: dbSession.ExpiresAt,
+	}, nil
+}
+
+// hashToken hashes a session token before it's stored or looked up, so a
+// leaked database can't be used to impersonate users
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
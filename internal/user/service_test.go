@@ -0,0 +1,153 @@
+package user_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NachoGz/switcher-backend-go/internal/database"
+	"github.com/NachoGz/switcher-backend-go/internal/user"
+	user_mock "github.com/NachoGz/switcher-backend-go/internal/user/mocks"
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegister(t *testing.T) {
+	mockUserRepo := new(user_mock.MockUserRepository)
+	service := user.NewService(mockUserRepo)
+
+	userID := uuid.New()
+
+	mockUserRepo.On("GetUserByUsername", mock.Anything, "alice").
+		Return(database.User{}, sql.ErrNoRows)
+
+	// The password is stored hashed
+	mockUserRepo.On("CreateUser", mock.Anything, mock.MatchedBy(func(params database.CreateUserParams) bool {
+		return params.Username == "alice" && utils.CheckPasswordHash(params.PasswordHash, "secret") == nil
+	})).Return(database.User{ID: userID, Username: "alice", PasswordHash: "hash"}, nil)
+
+	// So is the session token
+	var tokenHash string
+	mockUserRepo.On("CreateSession", mock.Anything, mock.MatchedBy(func(params database.CreateSessionParams) bool {
+		tokenHash = params.TokenHash
+		return params.UserID == userID && params.ExpiresAt.After(time.Now())
+	})).Return(database.Session{UserID: userID, ExpiresAt: time.Now().Add(user.SESSION_DURATION)}, nil)
+
+	account, session, err := service.Register(context.Background(), " alice ", "secret")
+
+	require.NoError(t, err)
+	assert.Equal(t, userID, account.ID)
+	assert.Equal(t, "alice", account.Username)
+	assert.NotEmpty(t, session.Token)
+	assert.NotEqual(t, session.Token, tokenHash)
+
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestRegister_UsernameTaken(t *testing.T) {
+	mockUserRepo := new(user_mock.MockUserRepository)
+	service := user.NewService(mockUserRepo)
+
+	mockUserRepo.On("GetUserByUsername", mock.Anything, "alice").
+		Return(database.User{ID: uuid.New(), Username: "Alice"}, nil)
+
+	_, _, err := service.Register(context.Background(), "alice", "secret")
+
+	assert.ErrorIs(t, err, user.ErrUsernameTaken)
+	mockUserRepo.AssertNotCalled(t, "CreateUser")
+}
+
+func TestRegister_UsernameTakenMeanwhile(t *testing.T) {
+	mockUserRepo := new(user_mock.MockUserRepository)
+	service := user.NewService(mockUserRepo)
+
+	// Someone registers "Alice" between the lookup and the insert, and the
+	// case insensitive index rejects it
+	mockUserRepo.On("GetUserByUsername", mock.Anything, "alice").Return(database.User{}, sql.ErrNoRows)
+	mockUserRepo.On("CreateUser", mock.Anything, mock.Anything).
+		Return(database.User{}, &pq.Error{Code: "23505", Constraint: "users_username_lower_idx"})
+
+	_, _, err := service.Register(context.Background(), "alice", "secret")
+
+	assert.ErrorIs(t, err, user.ErrUsernameTaken)
+	mockUserRepo.AssertNotCalled(t, "CreateSession")
+}
+
+func TestLogin_InvalidCredentials(t *testing.T) {
+	mockUserRepo := new(user_mock.MockUserRepository)
+	service := user.NewService(mockUserRepo)
+
+	hash, err := utils.HashPassword("secret")
+	require.NoError(t, err)
+
+	mockUserRepo.On("GetUserByUsername", mock.Anything, "alice").
+		Return(database.User{ID: uuid.New(), Username: "alice", PasswordHash: hash}, nil)
+	mockUserRepo.On("GetUserByUsername", mock.Anything, "bob").
+		Return(database.User{}, sql.ErrNoRows)
+
+	_, _, err = service.Login(context.Background(), "alice", "wrong")
+	assert.ErrorIs(t, err, user.ErrInvalidCredentials)
+
+	// Unknown users get the same error
+	_, _, err = service.Login(context.Background(), "bob", "secret")
+	assert.ErrorIs(t, err, user.ErrInvalidCredentials)
+
+	mockUserRepo.AssertNotCalled(t, "CreateSession")
+}
+
+func TestAuthenticate(t *testing.T) {
+	mockUserRepo := new(user_mock.MockUserRepository)
+	service := user.NewService(mockUserRepo)
+
+	userID := uuid.New()
+
+	// Log in to get a token
+	hash, err := utils.HashPassword("secret")
+	require.NoError(t, err)
+	mockUserRepo.On("GetUserByUsername", mock.Anything, "alice").
+		Return(database.User{ID: userID, Username: "alice", PasswordHash: hash}, nil)
+
+	var tokenHash string
+	mockUserRepo.On("CreateSession", mock.Anything, mock.MatchedBy(func(params database.CreateSessionParams) bool {
+		tokenHash = params.TokenHash
+		return true
+	})).Return(database.Session{UserID: userID}, nil)
+
+	_, session, err := service.Login(context.Background(), "alice", "secret")
+	require.NoError(t, err)
+
+	// The token is looked up by its hash
+	mockUserRepo.On("GetSessionByTokenHash", mock.Anything, tokenHash).
+		Return(database.Session{UserID: userID}, nil)
+	mockUserRepo.On("GetSessionByTokenHash", mock.Anything, mock.Anything).
+		Return(database.Session{}, sql.ErrNoRows)
+	mockUserRepo.On("GetUserByID", mock.Anything, userID).
+		Return(database.User{ID: userID, Username: "alice"}, nil)
+
+	account, err := service.Authenticate(context.Background(), session.Token)
+	require.NoError(t, err)
+	assert.Equal(t, userID, account.ID)
+
+	_, err = service.Authenticate(context.Background(), "forged")
+	assert.ErrorIs(t, err, user.ErrInvalidSession)
+}
+
+func TestDeleteExpiredSessions(t *testing.T) {
+	mockUserRepo := new(user_mock.MockUserRepository)
+	service := user.NewService(mockUserRepo)
+
+	mockUserRepo.On("DeleteExpiredSessions", mock.Anything).Return(int64(2), nil).Once()
+	deleted, err := service.DeleteExpiredSessions(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+
+	mockUserRepo.On("DeleteExpiredSessions", mock.Anything).Return(int64(0), errors.New("database error")).Once()
+	_, err = service.DeleteExpiredSessions(context.Background())
+	assert.ErrorContains(t, err, "database error")
+}
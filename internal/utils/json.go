@@ -2,34 +2,38 @@ package utils
 
 import (
 	"encoding/json"
-	"log"
+	"log/slog"
 	"net/http"
 )
 
-func RespondWithError(w http.ResponseWriter, code int, msg string, err error) {
-	if err != nil {
-		log.Println(err)
-	}
-	if code > 499 {
-		log.Printf("Responding with 5XX error: %s", msg)
-	}
-	type errorResponse struct {
-		Error string `json:"error"`
-	}
-	RespondWithJSON(w, code, errorResponse{
-		Error: msg,
-	})
+func RespondWithError(w http.ResponseWriter, r *http.Request, code int, msg string, err error) {
+	logError(r, code, msg, err)
+	RespondWithProblem(w, problemFromStatus(code, msg))
 }
 
 func RespondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	dat, err := json.Marshal(payload)
 	if err != nil {
-		log.Printf("Error marshalling JSON: %s", err)
+		slog.Error("Error marshalling JSON", "error", err)
 		w.WriteHeader(500)
 		return
 	}
 	w.WriteHeader(code)
 	w.Write(dat)
-	log.Println(string(dat))
+}
+
+// logError logs why a request failed. Server errors are logged as errors,
+// the client's own mistakes only when debugging
+func logError(r *http.Request, code int, msg string, err error) {
+	level := slog.LevelDebug
+	if code > 499 {
+		level = slog.LevelError
+	}
+
+	attrs := []slog.Attr{slog.Int("status", code)}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	slog.LogAttrs(r.Context(), level, msg, attrs...)
 }
@@ -0,0 +1,151 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+const PROBLEM_CONTENT_TYPE = "application/problem+json"
+
+// kind is a category of domain errors. Every error of a kind is answered
+// with the same HTTP status
+type kind struct {
+	status int
+	code   string
+	title  string
+}
+
+func (k *kind) Error() string {
+	return k.title
+}
+
+// Kinds of domain errors. Services wrap them with NewDomainError, handlers
+// only need RespondWithDomainError to answer with the right status
+var (
+	ErrInvalidInput = error(&kind{http.StatusBadRequest, "INVALID_INPUT", "invalid input"})
+	ErrUnauthorized = error(&kind{http.StatusUnauthorized, "UNAUTHORIZED", "unauthorized"})
+	ErrForbidden    = error(&kind{http.StatusForbidden, "FORBIDDEN", "forbidden"})
+	ErrNotYourTurn  = error(&kind{http.StatusForbidden, "NOT_YOUR_TURN", "not your turn"})
+	ErrNotFound     = error(&kind{http.StatusNotFound, "NOT_FOUND", "not found"})
+	ErrConflict     = error(&kind{http.StatusConflict, "CONFLICT", "conflict"})
+	ErrGameFull     = error(&kind{http.StatusConflict, "GAME_FULL", "game full"})
+	ErrInvalidMove  = error(&kind{http.StatusUnprocessableEntity, "INVALID_MOVE", "invalid move"})
+	ErrRejected     = error(&kind{http.StatusUnprocessableEntity, "REJECTED", "rejected"})
+	ErrRateLimited  = error(&kind{http.StatusTooManyRequests, "RATE_LIMITED", "rate limited"})
+
+	kinds = []error{
+		ErrInvalidInput, ErrUnauthorized, ErrForbidden, ErrNotYourTurn, ErrNotFound,
+		ErrConflict, ErrGameFull, ErrInvalidMove, ErrRejected, ErrRateLimited,
+	}
+	errInternal = &kind{http.StatusInternalServerError, "INTERNAL_ERROR", "internal error"}
+)
+
+// DomainError is an error of a kind with a stable code clients can switch on
+type DomainError struct {
+	Kind    error
+	Code    string
+	Message string
+}
+
+func NewDomainError(kind error, code string, message string) *DomainError {
+	return &DomainError{
+		Kind:    kind,
+		Code:    code,
+		Message: message,
+	}
+}
+
+func (e *DomainError) Error() string {
+	return e.Message
+}
+
+func (e *DomainError) Unwrap() error {
+	return e.Kind
+}
+
+// Problem is an RFC 7807 problem details body. Error repeats the detail for
+// clients that still read the old {"error": ...} body
+type Problem struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail"`
+	Code   string `json:"code"`
+	Error  string `json:"error"`
+	// Errors lists the invalid fields of a request
+	Errors any `json:"errors,omitempty"`
+}
+
+// detailedError is an error with more to tell the client, like the fields
+// that failed validation
+type detailedError interface {
+	Details() any
+}
+
+func newProblem(k *kind, code string, detail string) Problem {
+	return Problem{
+		Type:   "/problems/" + strings.ReplaceAll(strings.ToLower(code), "_", "-"),
+		Title:  http.StatusText(k.status),
+		Status: k.status,
+		Detail: detail,
+		Code:   code,
+		Error:  detail,
+	}
+}
+
+// ProblemFromError maps an error to its problem. Errors that aren't domain
+// errors are internal, their detail is the fallback so nothing leaks
+func ProblemFromError(err error, fallback string) Problem {
+	var k *kind
+	if !errors.As(err, &k) {
+		return newProblem(errInternal, errInternal.code, fallback)
+	}
+
+	problem := newProblem(k, k.code, err.Error())
+	var domainErr *DomainError
+	if errors.As(err, &domainErr) {
+		problem = newProblem(k, domainErr.Code, domainErr.Message)
+	}
+	var detailed detailedError
+	if errors.As(err, &detailed) {
+		problem.Errors = detailed.Details()
+	}
+	return problem
+}
+
+// problemFromStatus builds the problem of an error raised by the handler
+// itself, using the code of the first kind with that status
+func problemFromStatus(status int, msg string) Problem {
+	for _, err := range kinds {
+		if k := err.(*kind); k.status == status {
+			return newProblem(k, k.code, msg)
+		}
+	}
+	if status > 499 {
+		return newProblem(errInternal, errInternal.code, msg)
+	}
+	return newProblem(&kind{status: status}, "ERROR", msg)
+}
+
+// RespondWithDomainError answers with the status and code of a service
+// error. fallback is the detail sent when the error is internal
+func RespondWithDomainError(w http.ResponseWriter, r *http.Request, err error, fallback string) {
+	problem := ProblemFromError(err, fallback)
+	logError(r, problem.Status, problem.Detail, err)
+	RespondWithProblem(w, problem)
+}
+
+func RespondWithProblem(w http.ResponseWriter, problem Problem) {
+	w.Header().Set("Content-Type", PROBLEM_CONTENT_TYPE)
+	dat, err := json.Marshal(problem)
+	if err != nil {
+		slog.Error("Error marshalling JSON", "error", err)
+		w.WriteHeader(500)
+		return
+	}
+	w.WriteHeader(problem.Status)
+	w.Write(dat)
+}
@@ -0,0 +1,73 @@
+package utils_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var errTestNotFound = utils.NewDomainError(utils.ErrNotFound, "THING_NOT_FOUND", "thing not found")
+
+func TestProblemFromError(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+		detail string
+	}{
+		{"domain error", errTestNotFound, http.StatusNotFound, "THING_NOT_FOUND", "thing not found"},
+		{"wrapped domain error", fmt.Errorf("error fetching thing: %w", errTestNotFound), http.StatusNotFound, "THING_NOT_FOUND", "thing not found"},
+		{"bare kind", utils.ErrGameFull, http.StatusConflict, "GAME_FULL", "game full"},
+		{"unknown error", errors.New("connection refused"), http.StatusInternalServerError, "INTERNAL_ERROR", "fallback"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			problem := utils.ProblemFromError(tc.err, "fallback")
+
+			assert.Equal(t, tc.status, problem.Status)
+			assert.Equal(t, tc.code, problem.Code)
+			assert.Equal(t, tc.detail, problem.Detail)
+			assert.Equal(t, tc.detail, problem.Error)
+			assert.Equal(t, http.StatusText(tc.status), problem.Title)
+		})
+	}
+}
+
+func TestDomainErrorIsKind(t *testing.T) {
+	assert.ErrorIs(t, errTestNotFound, utils.ErrNotFound)
+	assert.NotErrorIs(t, errTestNotFound, utils.ErrForbidden)
+}
+
+func TestRespondWithDomainError(t *testing.T) {
+	rr := httptest.NewRecorder()
+
+	utils.RespondWithDomainError(rr, httptest.NewRequest(http.MethodGet, "/", nil), errTestNotFound, "fallback")
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, utils.PROBLEM_CONTENT_TYPE, rr.Header().Get("Content-Type"))
+
+	var problem utils.Problem
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &problem))
+	assert.Equal(t, "/problems/thing-not-found", problem.Type)
+	assert.Equal(t, "THING_NOT_FOUND", problem.Code)
+}
+
+func TestRespondWithError(t *testing.T) {
+	rr := httptest.NewRecorder()
+
+	utils.RespondWithError(rr, httptest.NewRequest(http.MethodPost, "/", nil), http.StatusBadRequest, "Invalid request body", nil)
+
+	var problem utils.Problem
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &problem))
+	assert.Equal(t, http.StatusBadRequest, problem.Status)
+	assert.Equal(t, "INVALID_INPUT", problem.Code)
+	assert.Equal(t, "Invalid request body", problem.Error)
+}
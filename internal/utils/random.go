@@ -0,0 +1,25 @@
+package utils
+
+import "math/rand/v2"
+
+// Randomizer is the source of randomness used to set up a game. Two
+// randomizers created with the same seed produce the same sequence, so a
+// game can be reproduced from its seed
+type Randomizer interface {
+	IntN(n int) int
+	Shuffle(n int, swap func(i, j int))
+}
+
+// NewRandomizer creates a deterministic randomizer from a seed
+func NewRandomizer(seed int64) Randomizer {
+	return rand.New(rand.NewPCG(uint64(seed), uint64(seed)>>32))
+}
+
+// NewSeed returns a random non zero seed for a new game
+func NewSeed() int64 {
+	for {
+		if seed := rand.Int64(); seed != 0 {
+			return seed
+		}
+	}
+}
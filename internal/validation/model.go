@@ -0,0 +1,62 @@
+package validation
+
+import (
+	"strings"
+
+	"github.com/NachoGz/switcher-backend-go/internal/ruleSet"
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
+)
+
+// Limits of the request fields
+const (
+	MIN_GAME_NAME_LENGTH   = 3
+	MAX_GAME_NAME_LENGTH   = 32
+	MIN_PLAYERS            = 2
+	MAX_PLAYERS            = ruleSet.MAX_PLAYERS
+	MAX_PLAYER_NAME_LENGTH = 20
+	MIN_USERNAME_LENGTH    = 3
+	MIN_PASSWORD_LENGTH    = 4
+	MAX_PASSWORD_LENGTH    = 64
+	// Accounts outlive games, so they need longer passwords
+	MIN_ACCOUNT_PASSWORD_LENGTH = 8
+	// bcrypt refuses longer passwords
+	MAX_PASSWORD_BYTES = 72
+)
+
+// Codes of the field errors
+const (
+	REQUIRED      = "REQUIRED"
+	TOO_SHORT     = "TOO_SHORT"
+	TOO_LONG      = "TOO_LONG"
+	OUT_OF_RANGE  = "OUT_OF_RANGE"
+	INVALID_CHARS = "INVALID_CHARS"
+)
+
+var ErrInvalidRequest = utils.NewDomainError(utils.ErrInvalidInput, "VALIDATION_FAILED", "the request has invalid fields")
+
+// FieldError is a problem with one field of a request
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Errors lists every invalid field of a request
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, fieldErr := range e {
+		messages[i] = fieldErr.Field + " " + fieldErr.Message
+	}
+	return strings.Join(messages, ", ")
+}
+
+func (e Errors) Unwrap() error {
+	return ErrInvalidRequest
+}
+
+// Details is added to the problem sent to the client
+func (e Errors) Details() any {
+	return []FieldError(e)
+}
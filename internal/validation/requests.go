@@ -0,0 +1,76 @@
+package validation
+
+import (
+	"regexp"
+
+	"github.com/NachoGz/switcher-backend-go/internal/game"
+	"github.com/NachoGz/switcher-backend-go/internal/player"
+)
+
+var playerNameChars = Matches(regexp.MustCompile(`^[\p{L}\p{N} _-]+$`), "letters, numbers, spaces, _ and -")
+
+// Rules shared by the requests
+var (
+	gameNameRules = []Rule[string]{
+		Required,
+		Length(MIN_GAME_NAME_LENGTH, MAX_GAME_NAME_LENGTH),
+	}
+	playerNameRules = []Rule[string]{
+		Required,
+		Length(1, MAX_PLAYER_NAME_LENGTH),
+		playerNameChars,
+	}
+	passwordRules = []Rule[string]{
+		Length(MIN_PASSWORD_LENGTH, MAX_PASSWORD_LENGTH),
+		MaxBytes(MAX_PASSWORD_BYTES),
+	}
+	// Usernames are the default player name of their users
+	usernameRules = []Rule[string]{
+		Required,
+		Length(MIN_USERNAME_LENGTH, MAX_PLAYER_NAME_LENGTH),
+		playerNameChars,
+	}
+	accountPasswordRules = []Rule[string]{
+		Required,
+		Length(MIN_ACCOUNT_PASSWORD_LENGTH, MAX_PASSWORD_LENGTH),
+		MaxBytes(MAX_PASSWORD_BYTES),
+	}
+)
+
+// CreateGame validates a new game and its host. An empty password makes the
+// game public
+func CreateGame(newGame game.Game, host player.Player) error {
+	return Validate(
+		Field("game.name", newGame.Name, gameNameRules...),
+		Field("game.max_players", newGame.MaxPlayers, Between(MIN_PLAYERS, MAX_PLAYERS)),
+		Field("game.min_players", newGame.MinPlayers,
+			Between(MIN_PLAYERS, MAX_PLAYERS), AtMost(newGame.MaxPlayers, "game.max_players")),
+		Field("game.password", newGame.Password, Optional(passwordRules...)),
+		Field("player.name", host.Name, playerNameRules...),
+	)
+}
+
+// JoinGame validates a player joining a game. The password is only limited
+// in size, a wrong one is rejected when it's checked
+func JoinGame(playerName string, password *string) error {
+	return Validate(
+		Field("player_name", playerName, playerNameRules...),
+		Field("password", password, Optional(Length(1, MAX_PASSWORD_LENGTH), MaxBytes(MAX_PASSWORD_BYTES))),
+	)
+}
+
+// JoinQueue validates the name of a player entering matchmaking. A missing
+// name is reported by the matchmaking service
+func JoinQueue(playerName string) error {
+	return Validate(
+		Field("player_name", playerName, Length(0, MAX_PLAYER_NAME_LENGTH), playerNameChars),
+	)
+}
+
+// Register validates the credentials of a new user
+func Register(username string, password string) error {
+	return Validate(
+		Field("username", username, usernameRules...),
+		Field("password", password, accountPasswordRules...),
+	)
+}
@@ -0,0 +1,120 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Rule checks a value and describes the problem if it's invalid
+type Rule[T any] func(value T) *FieldError
+
+// Check validates one field of a request
+type Check func() *FieldError
+
+// Field checks a value against its rules, stopping at the first one broken
+func Field[T any](name string, value T, rules ...Rule[T]) Check {
+	return func() *FieldError {
+		for _, rule := range rules {
+			if fieldErr := rule(value); fieldErr != nil {
+				fieldErr.Field = name
+				return fieldErr
+			}
+		}
+		return nil
+	}
+}
+
+// Validate runs every check and returns the errors of all invalid fields
+func Validate(checks ...Check) error {
+	var errs Errors
+	for _, check := range checks {
+		if fieldErr := check(); fieldErr != nil {
+			errs = append(errs, *fieldErr)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// Required rejects empty or blank strings
+func Required(value string) *FieldError {
+	if strings.TrimSpace(value) == "" {
+		return &FieldError{Code: REQUIRED, Message: "is required"}
+	}
+	return nil
+}
+
+// Length limits the characters of a string, ignoring surrounding spaces
+func Length(min int, max int) Rule[string] {
+	return func(value string) *FieldError {
+		length := utf8.RuneCountInString(strings.TrimSpace(value))
+		if length < min {
+			return &FieldError{Code: TOO_SHORT, Message: fmt.Sprintf("must be at least %d characters", min)}
+		}
+		if length > max {
+			return &FieldError{Code: TOO_LONG, Message: fmt.Sprintf("must be at most %d characters", max)}
+		}
+		return nil
+	}
+}
+
+// MaxBytes limits the encoded size of a string
+func MaxBytes(max int) Rule[string] {
+	return func(value string) *FieldError {
+		if len(value) > max {
+			return &FieldError{Code: TOO_LONG, Message: fmt.Sprintf("must be at most %d bytes", max)}
+		}
+		return nil
+	}
+}
+
+// Matches only allows strings made of the characters of pattern. Empty
+// strings are left to Required
+func Matches(pattern *regexp.Regexp, allowed string) Rule[string] {
+	return func(value string) *FieldError {
+		if value != "" && !pattern.MatchString(value) {
+			return &FieldError{Code: INVALID_CHARS, Message: "may only contain " + allowed}
+		}
+		return nil
+	}
+}
+
+// Between limits a number to a closed range
+func Between(min int, max int) Rule[int] {
+	return func(value int) *FieldError {
+		if value < min || value > max {
+			return &FieldError{Code: OUT_OF_RANGE, Message: fmt.Sprintf("must be between %d and %d", min, max)}
+		}
+		return nil
+	}
+}
+
+// AtMost keeps a number from going over another field
+func AtMost(limit int, other string) Rule[int] {
+	return func(value int) *FieldError {
+		if value > limit {
+			return &FieldError{Code: OUT_OF_RANGE, Message: "must not be greater than " + other}
+		}
+		return nil
+	}
+}
+
+// Optional applies the rules only when the value is set and not empty
+func Optional[T comparable](rules ...Rule[T]) Rule[*T] {
+	return func(value *T) *FieldError {
+		var zero T
+		if value == nil || *value == zero {
+			return nil
+		}
+		for _, rule := range rules {
+			if fieldErr := rule(*value); fieldErr != nil {
+				return fieldErr
+			}
+		}
+		return nil
+	}
+}
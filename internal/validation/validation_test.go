@@ -0,0 +1,155 @@
+package validation_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/NachoGz/switcher-backend-go/internal/game"
+	"github.com/NachoGz/switcher-backend-go/internal/player"
+	"github.com/NachoGz/switcher-backend-go/internal/utils"
+	"github.com/NachoGz/switcher-backend-go/internal/validation"
+	"github.com/stretchr/testify/assert"
+)
+
+func fieldErrors(t *testing.T, err error) map[string]string {
+	t.Helper()
+	var errs validation.Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected validation errors, got %v", err)
+	}
+	codes := make(map[string]string, len(errs))
+	for _, fieldErr := range errs {
+		codes[fieldErr.Field] = fieldErr.Code
+	}
+	return codes
+}
+
+func TestCreateGame(t *testing.T) {
+	password := "secret"
+	validGame := game.Game{Name: "Test Game", MaxPlayers: 4, MinPlayers: 2, Password: &password}
+	validHost := player.Player{Name: "Player_1"}
+
+	assert.NoError(t, validation.CreateGame(validGame, validHost))
+
+	// Games without password are public
+	publicGame := validGame
+	publicGame.Password = nil
+	assert.NoError(t, validation.CreateGame(publicGame, validHost))
+}
+
+func TestCreateGame_InvalidFields(t *testing.T) {
+	shortPassword := "abc"
+	longPassword := strings.Repeat("ñ", validation.MAX_PASSWORD_LENGTH)
+
+	tests := []struct {
+		name     string
+		game     game.Game
+		host     player.Player
+		expected map[string]string
+	}{
+		{
+			name:     "missing names",
+			game:     game.Game{Name: "   ", MaxPlayers: 4, MinPlayers: 2},
+			host:     player.Player{},
+			expected: map[string]string{"game.name": validation.REQUIRED, "player.name": validation.REQUIRED},
+		},
+		{
+			name:     "long game name",
+			game:     game.Game{Name: strings.Repeat("a", validation.MAX_GAME_NAME_LENGTH+1), MaxPlayers: 4, MinPlayers: 2},
+			host:     player.Player{Name: "Player1"},
+			expected: map[string]string{"game.name": validation.TOO_LONG},
+		},
+		{
+			name:     "players out of range",
+			game:     game.Game{Name: "Test Game", MaxPlayers: 10, MinPlayers: 1},
+			host:     player.Player{Name: "Player1"},
+			expected: map[string]string{"game.max_players": validation.OUT_OF_RANGE, "game.min_players": validation.OUT_OF_RANGE},
+		},
+		{
+			name:     "min players over max players",
+			game:     game.Game{Name: "Test Game", MaxPlayers: 3, MinPlayers: 4},
+			host:     player.Player{Name: "Player1"},
+			expected: map[string]string{"game.min_players": validation.OUT_OF_RANGE},
+		},
+		{
+			name:     "short password",
+			game:     game.Game{Name: "Test Game", MaxPlayers: 4, MinPlayers: 2, Password: &shortPassword},
+			host:     player.Player{Name: "Player1"},
+			expected: map[string]string{"game.password": validation.TOO_SHORT},
+		},
+		{
+			name:     "password over the bcrypt limit",
+			game:     game.Game{Name: "Test Game", MaxPlayers: 4, MinPlayers: 2, Password: &longPassword},
+			host:     player.Player{Name: "Player1"},
+			expected: map[string]string{"game.password": validation.TOO_LONG},
+		},
+		{
+			name:     "player name charset",
+			game:     game.Game{Name: "Test Game", MaxPlayers: 4, MinPlayers: 2},
+			host:     player.Player{Name: "<b>Player</b>"},
+			expected: map[string]string{"player.name": validation.INVALID_CHARS},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validation.CreateGame(tt.game, tt.host)
+
+			assert.Equal(t, tt.expected, fieldErrors(t, err))
+		})
+	}
+}
+
+func TestJoinGame(t *testing.T) {
+	assert.NoError(t, validation.JoinGame("José 2", nil))
+
+	err := validation.JoinGame("", nil)
+	assert.Equal(t, map[string]string{"player_name": validation.REQUIRED}, fieldErrors(t, err))
+}
+
+func TestJoinQueue(t *testing.T) {
+	// Missing names are left to the matchmaking service
+	assert.NoError(t, validation.JoinQueue(""))
+
+	err := validation.JoinQueue("Alice!")
+	assert.Equal(t, map[string]string{"player_name": validation.INVALID_CHARS}, fieldErrors(t, err))
+}
+
+func TestRegister(t *testing.T) {
+	assert.NoError(t, validation.Register("alice_2", "correct horse"))
+
+	tests := []struct {
+		name     string
+		username string
+		password string
+		expected map[string]string
+	}{
+		{"missing credentials", "", "", map[string]string{"username": validation.REQUIRED, "password": validation.REQUIRED}},
+		{"short credentials", "al", "secret", map[string]string{"username": validation.TOO_SHORT, "password": validation.TOO_SHORT}},
+		{"username longer than a player name", strings.Repeat("a", validation.MAX_PLAYER_NAME_LENGTH+1), "correct horse", map[string]string{"username": validation.TOO_LONG}},
+		{"username charset", "alice@example.com", "correct horse", map[string]string{"username": validation.INVALID_CHARS}},
+		{"password over the bcrypt limit", "alice", strings.Repeat("ñ", validation.MAX_PASSWORD_LENGTH), map[string]string{"password": validation.TOO_LONG}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validation.Register(tt.username, tt.password)
+
+			assert.Equal(t, tt.expected, fieldErrors(t, err))
+		})
+	}
+}
+
+func TestErrorsProblem(t *testing.T) {
+	err := validation.CreateGame(game.Game{MaxPlayers: 4, MinPlayers: 2}, player.Player{Name: "Player1"})
+
+	assert.ErrorIs(t, err, utils.ErrInvalidInput)
+
+	problem := utils.ProblemFromError(err, "Invalid game")
+	assert.Equal(t, 400, problem.Status)
+	assert.Equal(t, "VALIDATION_FAILED", problem.Code)
+	assert.Equal(t, []validation.FieldError{
+		{Field: "game.name", Code: validation.REQUIRED, Message: "is required"},
+	}, problem.Errors)
+}
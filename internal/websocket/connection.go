@@ -2,32 +2,101 @@ package websocket
 
 import (
 	"encoding/json"
-	"log"
+	"errors"
+	"log/slog"
 	"net/http"
 	"time"
 
+	"github.com/NachoGz/switcher-backend-go/internal/origins"
 	"github.com/gorilla/websocket"
 )
 
-const (
+// Limits of the websocket connections
+type Limits struct {
 	// Time allowed to write a message to the peer
-	writeWait = 10 * time.Second
+	WriteWait time.Duration
+	// Time allowed to read the next pong message from the peer. Pings are
+	// sent every 9/10 of it
+	PongWait time.Duration
+	// Maximum message size allowed from peer
+	MaxMessageSize int64
+	// Sizes in bytes of the I/O buffers of each connection
+	ReadBufferSize  int
+	WriteBufferSize int
+	// Messages queued for a client before it's dropped as too slow
+	SendBufferSize int
+}
 
-	// Time allowed to read the next pong message from the peer
-	pongWait = 60 * time.Second
+// DefaultLimits are the limits used unless others are set
+func DefaultLimits() Limits {
+	return Limits{
+		WriteWait:       10 * time.Second,
+		PongWait:        60 * time.Second,
+		MaxMessageSize:  512,
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		SendBufferSize:  256,
+	}
+}
 
-	// Send pings to peer with this period (must be less than pongWait)
-	pingPeriod = (pongWait * 9) / 10
+// Validate checks every limit is positive
+func (l Limits) Validate() error {
+	if l.WriteWait <= 0 || l.PongWait <= 0 {
+		return errors.New("websocket wait times must be positive")
+	}
+	if l.MaxMessageSize <= 0 || l.ReadBufferSize <= 0 || l.WriteBufferSize <= 0 || l.SendBufferSize <= 0 {
+		return errors.New("websocket sizes must be positive")
+	}
+	return nil
+}
 
-	// Maximum message size allowed from peer
-	maxMessageSize = 512
+var (
+	limits   = DefaultLimits()
+	upgrader = newUpgrader(limits)
+	// Origins of the browsers allowed to connect, any until set
+	allowedOrigins, _ = origins.NewAllowList([]string{origins.ANY})
 )
 
-var upgrader = websocket.Upgrader{
-	ReadBufferSize:  1024,
-	WriteBufferSize: 1024,
-	// Allow all origins for development
-	CheckOrigin: func(r *http.Request) bool { return true },
+// SetLimits replaces the limits of the connections opened afterwards. It's
+// meant to be called once at startup, before any connection is opened
+func SetLimits(l Limits) {
+	limits = l
+	upgrader = newUpgrader(l)
+}
+
+// SetAllowedOrigins replaces the origins allowed to open connections from a
+// browser. Like SetLimits, it's meant to be called once at startup
+func SetAllowedOrigins(allowed *origins.AllowList) {
+	allowedOrigins = allowed
+}
+
+// NewSendBuffer makes the channel of the messages queued for a client
+func NewSendBuffer() chan []byte {
+	return make(chan []byte, limits.SendBufferSize)
+}
+
+func newUpgrader(l Limits) websocket.Upgrader {
+	return websocket.Upgrader{
+		ReadBufferSize:  l.ReadBufferSize,
+		WriteBufferSize: l.WriteBufferSize,
+		CheckOrigin:     checkOrigin,
+	}
+}
+
+// checkOrigin stops other sites from opening connections with the cookies of
+// their visitors. Clients that aren't browsers don't send an Origin
+func checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" || allowedOrigins.Allowed(origin) {
+		return true
+	}
+	slog.WarnContext(r.Context(), "Websocket origin not allowed", "origin", origin)
+	return false
+}
+
+// pingPeriod is how often pings are sent, which must be less than PongWait
+func (l Limits) pingPeriod() time.Duration {
+	return (l.PongWait * 9) / 10
 }
 
 // Connection represents a websocket connection
@@ -52,7 +121,7 @@ func (c *Connection) ReadMessage() (int, []byte, error) {
 
 // WriteMessage writes a message to the websocket connection
 func (c *Connection) WriteMessage(messageType int, data []byte) error {
-	c.ws.SetWriteDeadline(time.Now().Add(writeWait))
+	c.ws.SetWriteDeadline(time.Now().Add(limits.WriteWait))
 	return c.ws.WriteMessage(messageType, data)
 }
 
@@ -68,7 +137,8 @@ func (c *Client) ReadPump() {
 		c.Conn.Close()
 	}()
 
-	c.Conn.ws.SetReadLimit(maxMessageSize)
+	pongWait := limits.PongWait
+	c.Conn.ws.SetReadLimit(limits.MaxMessageSize)
 	c.Conn.ws.SetReadDeadline(time.Now().Add(pongWait))
 	c.Conn.ws.SetPongHandler(func(string) error {
 		c.Conn.ws.SetReadDeadline(time.Now().Add(pongWait))
@@ -79,36 +149,43 @@ func (c *Client) ReadPump() {
 		_, message, err := c.Conn.ReadMessage()
 		if err != nil {
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway) {
-				log.Printf("error: %v", err)
+				slog.WarnContext(c.Context(), "Websocket closed unexpectedly", "error", err)
 			}
 			break
 		}
 
-		var msg map[string]interface{}
+		// Spectators are read-only
+		if c.Spectator {
+			continue
+		}
+
+		var msg struct {
+			Type    string          `json:"type"`
+			Payload json.RawMessage `json:"payload"`
+		}
 		if err := json.Unmarshal(message, &msg); err != nil {
-			log.Printf("Invalid message format: %v", err)
+			slog.DebugContext(c.Context(), "Invalid websocket message", "error", err)
 			continue
 		}
 
-		if eventType, ok := msg["type"].(string); ok {
-			switch eventType {
-			case "GAMES_LIST_UPDATE":
-				log.Println("There was an update in the games list")
-			default:
-				log.Printf("Unknown event type: %s", eventType)
-			}
+		// Commands are handled by the server and not echoed to the room
+		if c.Server.DispatchCommand(c, msg.Type, msg.Payload) {
+			continue
 		}
 
+		slog.DebugContext(c.Context(), "Relaying websocket message", "type", msg.Type)
+
 		c.Server.BroadcastMessage(&BroadcastMessage{
 			GameID:  c.GameID,
 			Message: message,
+			Private: true,
 		})
 	}
 }
 
 // WritePump pumps messages from the server to the websocket connection
 func (c *Client) WritePump() {
-	ticker := time.NewTicker(pingPeriod)
+	ticker := time.NewTicker(limits.pingPeriod())
 	defer func() {
 		ticker.Stop()
 		c.Conn.Close()
@@ -119,7 +196,11 @@ func (c *Client) WritePump() {
 		case message, ok := <-c.Send:
 			if !ok {
 				// The server closed the channel
-				c.Conn.WriteMessage(websocket.CloseMessage, []byte{})
+				closeMessage := []byte{}
+				if c.closeMessage != nil {
+					closeMessage = c.closeMessage
+				}
+				c.Conn.WriteMessage(websocket.CloseMessage, closeMessage)
 				return
 			}
 
@@ -0,0 +1,64 @@
+package websocket_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/NachoGz/switcher-backend-go/internal/origins"
+	"github.com/NachoGz/switcher-backend-go/internal/websocket"
+	gorilla "github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConnection_CheckOrigin(t *testing.T) {
+	allowed, err := origins.NewAllowList([]string{"https://switcher.example", "https://*.preview.switcher.example"})
+	require.NoError(t, err)
+	websocket.SetAllowedOrigins(allowed)
+	t.Cleanup(func() {
+		anyOrigin, _ := origins.NewAllowList([]string{origins.ANY})
+		websocket.SetAllowedOrigins(anyOrigin)
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.NewConnection(w, r)
+		if err == nil {
+			conn.Close()
+		}
+	}))
+	defer server.Close()
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	tests := []struct {
+		origin  string
+		allowed bool
+	}{
+		{"https://switcher.example", true},
+		{"https://pr-3.preview.switcher.example", true},
+		{"https://evil.example", false},
+		{"https://preview.switcher.example", false},
+		// Clients that aren't browsers
+		{"", true},
+	}
+
+	for _, test := range tests {
+		header := http.Header{}
+		if test.origin != "" {
+			header.Set("Origin", test.origin)
+		}
+
+		conn, resp, err := gorilla.DefaultDialer.Dial(url, header)
+		if test.allowed {
+			if assert.NoError(t, err, test.origin) {
+				conn.Close()
+			}
+		} else {
+			assert.ErrorIs(t, err, gorilla.ErrBadHandshake, test.origin)
+			if assert.NotNil(t, resp, test.origin) {
+				assert.Equal(t, http.StatusForbidden, resp.StatusCode, test.origin)
+			}
+		}
+	}
+}
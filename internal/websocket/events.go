@@ -0,0 +1,85 @@
+package websocket
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Types of the messages sent through the websockets. Their payloads are
+// documented in the AsyncAPI document
+const (
+	// Game room
+	MOVEMENT_PLAYED = "MOVEMENT_PLAYED"
+	FIGURE_PLAYED   = "FIGURE_PLAYED"
+	FIGURE_BLOCKED  = "FIGURE_BLOCKED"
+	TURN_ENDED      = "TURN_ENDED"
+	GAME_WON        = "GAME_WON"
+	GAME_ABANDONED  = "GAME_ABANDONED"
+	GAME_STARTED    = "GAME_STARTED"
+	LOBBY_STATE     = "LOBBY_STATE"
+	PLAYER_KICKED   = "PLAYER_KICKED"
+	CHAT_MESSAGE    = "CHAT_MESSAGE"
+	CHAT_HISTORY    = "CHAT_HISTORY"
+	CHAT_ERROR      = "CHAT_ERROR"
+
+	// Lobby room
+	GAME_ADDED       = "GAME_ADDED"
+	GAME_UPDATED     = "GAME_UPDATED"
+	GAME_REMOVED     = "GAME_REMOVED"
+	GAMES_SNAPSHOT   = "GAMES_SNAPSHOT"
+	GAME_INFO_UPDATE = "GAME_INFO_UPDATE"
+
+	// Matchmaking ticket room
+	MATCHMAKING_STATUS = "MATCHMAKING_STATUS"
+)
+
+// events is the catalog of the message types the server sends. Their
+// payloads are documented by handlers.Events, which tests keep in sync
+var events = map[string]bool{
+	MOVEMENT_PLAYED:    true,
+	FIGURE_PLAYED:      true,
+	FIGURE_BLOCKED:     true,
+	TURN_ENDED:         true,
+	GAME_WON:           true,
+	GAME_ABANDONED:     true,
+	GAME_STARTED:       true,
+	LOBBY_STATE:        true,
+	PLAYER_KICKED:      true,
+	CHAT_MESSAGE:       true,
+	CHAT_HISTORY:       true,
+	CHAT_ERROR:         true,
+	GAME_ADDED:         true,
+	GAME_UPDATED:       true,
+	GAME_REMOVED:       true,
+	GAMES_SNAPSHOT:     true,
+	GAME_INFO_UPDATE:   true,
+	MATCHMAKING_STATUS: true,
+}
+
+// Events lists the types of the messages the server sends
+func Events() []string {
+	names := make([]string, 0, len(events))
+	for name := range events {
+		names = append(names, name)
+	}
+	return names
+}
+
+// IsEvent reports whether the message type is in the catalog, including the
+// ones scoped to a game with GameScoped
+func IsEvent(messageType string) bool {
+	if prefix, name, ok := strings.Cut(messageType, ":"); ok {
+		if _, err := uuid.Parse(prefix); err != nil {
+			return false
+		}
+		messageType = name
+	}
+	return events[messageType]
+}
+
+// GameScoped is the type of a lobby room message about a game, like
+// "<game id>:GAME_STARTED". Older clients listen for these
+func GameScoped(gameID uuid.UUID, messageType string) string {
+	return gameID.String() + ":" + messageType
+}
@@ -0,0 +1,210 @@
+package websocket_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"maps"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/NachoGz/switcher-backend-go/internal/websocket"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsEvent(t *testing.T) {
+	assert.True(t, websocket.IsEvent(websocket.MOVEMENT_PLAYED))
+	assert.True(t, websocket.IsEvent(websocket.GameScoped(uuid.New(), websocket.GAME_STARTED)))
+	assert.False(t, websocket.IsEvent("NOT_AN_EVENT"))
+	assert.False(t, websocket.IsEvent(websocket.GameScoped(uuid.New(), "NOT_AN_EVENT")))
+	assert.False(t, websocket.IsEvent("not-a-uuid:"+websocket.GAME_STARTED))
+}
+
+// hubSends maps the hub methods that send messages to the index of their
+// message type argument
+var hubSends = map[string]int{
+	"BroadcastToGame":   1,
+	"BroadcastPrivate":  1,
+	"BroadcastToTicket": 1,
+	"BroadcastEvent":    1,
+	"SendToClient":      1,
+	"RegisterCommand":   0,
+}
+
+// sourceFile is a file of the module outside the tests
+type sourceFile struct {
+	dir  string
+	file *ast.File
+}
+
+// TestMessageTypesAreCatalogued fails when code outside the tests sends a
+// message type that isn't in the catalog, builds one by hand or passes one
+// the test can't follow back to the catalog
+func TestMessageTypesAreCatalogued(t *testing.T) {
+	fset := token.NewFileSet()
+	files := []sourceFile{}
+	err := filepath.WalkDir("../..", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && (d.Name() == "mocks" || d.Name() == "database") {
+			return filepath.SkipDir
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return err
+		}
+		files = append(files, sourceFile{dir: filepath.Dir(path), file: file})
+		return nil
+	})
+	assert.NoError(t, err)
+
+	checker := &typeChecker{t: t, fset: fset, constants: constants(files)}
+	sends := senders(files)
+	for _, source := range files {
+		eachSend(source.file, sends, func(caller *ast.FuncDecl, arg ast.Expr) {
+			// Forwarded types are checked where the forwarding function is called
+			if _, ok := forwardedParam(caller, arg); ok {
+				return
+			}
+			checker.check(source.dir, arg)
+		})
+	}
+}
+
+// constants maps the constants of every package, keyed by its directory
+// and their name, to their value
+func constants(files []sourceFile) map[string]ast.Expr {
+	values := map[string]ast.Expr{}
+	for _, source := range files {
+		for _, decl := range source.file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.CONST {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				value := spec.(*ast.ValueSpec)
+				for i, name := range value.Names {
+					if i < len(value.Values) {
+						values[source.dir+"."+name.Name] = value.Values[i]
+					}
+				}
+			}
+		}
+	}
+	return values
+}
+
+// senders adds to hubSends the functions that pass one of their parameters
+// on as the type of a message
+func senders(files []sourceFile) map[string]int {
+	sends := maps.Clone(hubSends)
+	for changed := true; changed; {
+		changed = false
+		for _, source := range files {
+			eachSend(source.file, sends, func(caller *ast.FuncDecl, arg ast.Expr) {
+				index, ok := forwardedParam(caller, arg)
+				if _, known := sends[caller.Name.Name]; ok && !known {
+					sends[caller.Name.Name] = index
+					changed = true
+				}
+			})
+		}
+	}
+	return sends
+}
+
+// eachSend calls fn with the message type of every call to a sender, along
+// with the function making the call
+func eachSend(file *ast.File, sends map[string]int, fn func(caller *ast.FuncDecl, arg ast.Expr)) {
+	for _, decl := range file.Decls {
+		caller, _ := decl.(*ast.FuncDecl)
+		ast.Inspect(decl, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			var name string
+			switch fun := call.Fun.(type) {
+			case *ast.SelectorExpr:
+				name = fun.Sel.Name
+			case *ast.Ident:
+				name = fun.Name
+			}
+			index, ok := sends[name]
+			if ok && len(call.Args) > index {
+				fn(caller, call.Args[index])
+			}
+			return true
+		})
+	}
+}
+
+// forwardedParam returns the index of the parameter of caller passed as arg
+func forwardedParam(caller *ast.FuncDecl, arg ast.Expr) (int, bool) {
+	ident, ok := arg.(*ast.Ident)
+	if !ok || caller == nil {
+		return 0, false
+	}
+	index := 0
+	for _, field := range caller.Type.Params.List {
+		for _, name := range field.Names {
+			if name.Name == ident.Name {
+				return index, true
+			}
+			index++
+		}
+	}
+	return 0, false
+}
+
+// typeChecker follows message types back to the catalog
+type typeChecker struct {
+	t         *testing.T
+	fset      *token.FileSet
+	constants map[string]ast.Expr
+}
+
+// check fails unless arg is a message type of the catalog, as seen from the
+// package in dir
+func (c *typeChecker) check(dir string, arg ast.Expr) {
+	c.t.Helper()
+
+	switch arg := arg.(type) {
+	case *ast.BasicLit:
+		value, _ := strconv.Unquote(arg.Value)
+		assert.True(c.t, websocket.IsEvent(value), "%s: message type %q is not in the catalog", c.fset.Position(arg.Pos()), value)
+	case *ast.CallExpr:
+		// Types scoped to a game go through GameScoped
+		if selector, ok := arg.Fun.(*ast.SelectorExpr); !ok || selector.Sel.Name != "GameScoped" {
+			c.t.Errorf("%s: message type built without websocket.GameScoped", c.fset.Position(arg.Pos()))
+			return
+		}
+		c.check(dir, arg.Args[1])
+	case *ast.SelectorExpr:
+		// Constants of the websocket package are the catalog itself
+		if pkg, ok := arg.X.(*ast.Ident); !ok || pkg.Name != "websocket" {
+			c.t.Errorf("%s: message type is not a constant of the websocket package", c.fset.Position(arg.Pos()))
+			return
+		}
+		assert.True(c.t, websocket.IsEvent(arg.Sel.Name), "%s: websocket.%s is not in the catalog", c.fset.Position(arg.Pos()), arg.Sel.Name)
+	case *ast.Ident:
+		// Constants of the same package, which alias the catalog's
+		value, ok := c.constants[dir+"."+arg.Name]
+		if !ok {
+			c.t.Errorf("%s: %s is not a constant", c.fset.Position(arg.Pos()), arg.Name)
+			return
+		}
+		c.check(dir, value)
+	default:
+		c.t.Errorf("%s: can't tell whether the message type is in the catalog", c.fset.Position(arg.Pos()))
+	}
+}
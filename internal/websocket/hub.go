@@ -1,11 +1,27 @@
 package websocket
 
 import (
+	"context"
 	"encoding/json"
-	"log"
+	"fmt"
+	"log/slog"
 	"sync"
+	"sync/atomic"
 
+	"github.com/NachoGz/switcher-backend-go/internal/logging"
+	"github.com/NachoGz/switcher-backend-go/internal/metrics"
 	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// Close code sent to a player removed from a game by its host
+	CLOSE_KICKED = 4001
+	// Close code sent to a spectator when the game already has as many as
+	// it allows
+	CLOSE_SPECTATORS_FULL = 4002
+	// Close code sent to a player that left the game
+	CLOSE_LEFT = 4003
 )
 
 type Client struct {
@@ -14,6 +30,42 @@ type Client struct {
 	Send     chan []byte
 	GameID   uuid.UUID
 	PlayerID uuid.UUID
+	// Spectators only receive public messages and can't send any
+	Spectator bool
+	// Most spectators the game allows, checked when a spectator registers.
+	// Nil means there is no limit
+	MaxSpectators *int
+	// Matchmaking ticket followed by the client instead of a game
+	TicketID uuid.UUID
+
+	// Close frame sent when the server drops the client, set by the hub
+	closeMessage []byte
+}
+
+// Context carries the game and player of the client, so what is logged
+// while serving it is tied to them
+func (c *Client) Context() context.Context {
+	ctx := logging.WithGameID(context.Background(), c.GameID)
+	if c.PlayerID != uuid.Nil {
+		ctx = logging.WithPlayerID(ctx, c.PlayerID)
+	}
+	return ctx
+}
+
+// room returns the room the client belongs to
+func (c *Client) room() room {
+	if c.TicketID != uuid.Nil {
+		return room{ticket: true, id: c.TicketID}
+	}
+	return room{id: c.GameID}
+}
+
+// room identifies the clients receiving the same broadcasts. Games and
+// matchmaking tickets have rooms apart, so no ticket is taken for a game.
+// The lobby is the room of the nil game
+type room struct {
+	ticket bool
+	id     uuid.UUID
 }
 
 // Message represents a structured message for WebSocket communication
@@ -25,8 +77,8 @@ type Message struct {
 
 // Hub maintains the set of active clients and broadcasts messages
 type Hub struct {
-	// Registered clients by game ID
-	clients map[uuid.UUID]map[*Client]bool
+	// Registered clients by room
+	clients map[room]map[*Client]bool
 
 	// Register requests from clients
 	Register chan *Client
@@ -37,23 +89,64 @@ type Hub struct {
 	// Broadcast message to specific game
 	broadcast chan *BroadcastMessage
 
+	// Senders waiting for the main loop to take their broadcast
+	pending atomic.Int64
+
+	// Messages addressed to a single client
+	direct chan *directMessage
+
+	// Requests to drop the connections of a player
+	disconnect chan *disconnectRequest
+
+	// Health checks, answered by closing the channel
+	ping chan chan struct{}
+
 	// Mutex to protect concurrent access to the clients map
 	mu sync.Mutex
+
+	// Handlers for the commands clients can send, by message type
+	commands map[string]CommandHandler
+
+	// Hooks run after a client is registered or unregistered
+	onRegister   []func(client *Client)
+	onUnregister []func(client *Client)
+}
+
+// CommandHandler handles a command sent by a client through its websocket
+type CommandHandler func(client *Client, payload json.RawMessage)
+
+type directMessage struct {
+	client  *Client
+	message []byte
+}
+
+type disconnectRequest struct {
+	gameID       uuid.UUID
+	playerID     uuid.UUID
+	closeMessage []byte
 }
 
 // BroadcastMessage contains the message data and target game
 type BroadcastMessage struct {
-	GameID  uuid.UUID
-	Message []byte
+	GameID uuid.UUID
+	// Sends the message to the room of a matchmaking ticket instead
+	TicketID uuid.UUID
+	Message  []byte
+	// Private messages are only delivered to players, never to spectators
+	Private bool
 }
 
 // NewServer creates a new Hub instance
 func NewHub() *Hub {
 	return &Hub{
-		clients:    make(map[uuid.UUID]map[*Client]bool),
+		clients:    make(map[room]map[*Client]bool),
 		Register:   make(chan *Client),
 		unregister: make(chan *Client),
 		broadcast:  make(chan *BroadcastMessage),
+		direct:     make(chan *directMessage),
+		disconnect: make(chan *disconnectRequest),
+		ping:       make(chan chan struct{}),
+		commands:   make(map[string]CommandHandler),
 	}
 }
 
@@ -62,51 +155,149 @@ func (h *Hub) Run() {
 	for {
 		select {
 		case client := <-h.Register:
+			room := client.room()
 			h.mu.Lock()
-			if _, ok := h.clients[client.GameID]; !ok {
-				h.clients[client.GameID] = make(map[*Client]bool)
+			// Checked here so spectators connecting at once can't exceed it
+			if client.Spectator && client.MaxSpectators != nil && h.countSpectators(room) >= *client.MaxSpectators {
+				h.mu.Unlock()
+				slog.InfoContext(client.Context(), "Spectator rejected, the game is full")
+				client.closeMessage = websocket.FormatCloseMessage(CLOSE_SPECTATORS_FULL, "spectating is not available for this game")
+				close(client.Send)
+				continue
+			}
+			if _, ok := h.clients[room]; !ok {
+				h.clients[room] = make(map[*Client]bool)
 			}
-			h.clients[client.GameID][client] = true
+			h.clients[room][client] = true
+			hooks := h.hooks(room, h.onRegister)
+			clients := len(h.clients[room])
 			h.mu.Unlock()
-			log.Printf("Client registered for game %s, total clients: %d",
-				client.GameID, len(h.clients[client.GameID]))
+			slog.DebugContext(client.Context(), "Client registered", "clients", clients)
+
+			for _, hook := range hooks {
+				go hook(client)
+			}
 
 		case client := <-h.unregister:
 			h.mu.Lock()
-			if _, ok := h.clients[client.GameID]; ok {
-				if _, ok := h.clients[client.GameID][client]; ok {
-					delete(h.clients[client.GameID], client)
-					close(client.Send)
-					log.Printf("Client unregistered from game %s", client.GameID)
-
-					// If no clients left in the game, clean up
-					if len(h.clients[client.GameID]) == 0 {
-						delete(h.clients, client.GameID)
-						log.Printf("No clients left in game %s, removing game", client.GameID)
-					}
+			h.removeClient(client)
+			h.mu.Unlock()
+
+		case request := <-h.disconnect:
+			h.mu.Lock()
+			for client := range h.clients[room{id: request.gameID}] {
+				if client.Spectator || client.PlayerID != request.playerID {
+					continue
 				}
+				// The write pump sends the close frame once Send is closed
+				client.closeMessage = request.closeMessage
+				h.removeClient(client)
 			}
 			h.mu.Unlock()
 
 		case message := <-h.broadcast:
 			h.mu.Lock()
-			if clients, ok := h.clients[message.GameID]; ok {
+			target := room{id: message.GameID}
+			if message.TicketID != uuid.Nil {
+				target = room{ticket: true, id: message.TicketID}
+			}
+			if clients, ok := h.clients[target]; ok {
 				for client := range clients {
+					if message.Private && client.Spectator {
+						continue
+					}
 					select {
 					case client.Send <- message.Message:
 					default:
-						close(client.Send)
-						delete(h.clients[message.GameID], client)
+						metrics.WebsocketDroppedMessages.Inc("broadcast")
+						slog.WarnContext(client.Context(), "Dropping client, its send buffer is full")
+						h.removeClient(client)
 					}
 				}
 			}
 			h.mu.Unlock()
+
+		case pong := <-h.ping:
+			close(pong)
+
+		case message := <-h.direct:
+			h.mu.Lock()
+			// The client may have disconnected in the meantime
+			if _, ok := h.clients[message.client.room()][message.client]; ok {
+				select {
+				case message.client.Send <- message.message:
+				default:
+					metrics.WebsocketDroppedMessages.Inc("direct")
+					slog.WarnContext(message.client.Context(), "Dropping direct message, client send buffer is full")
+				}
+			}
+			h.mu.Unlock()
 		}
 	}
 }
 
+// removeClient drops a client from its room. The caller must hold the lock
+func (h *Hub) removeClient(client *Client) {
+	room := client.room()
+	if _, ok := h.clients[room]; !ok {
+		return
+	}
+	if _, ok := h.clients[room][client]; !ok {
+		return
+	}
+
+	delete(h.clients[room], client)
+	close(client.Send)
+	slog.DebugContext(client.Context(), "Client unregistered")
+
+	for _, hook := range h.hooks(room, h.onUnregister) {
+		go hook(client)
+	}
+
+	// If no clients left in the room, clean up
+	if len(h.clients[room]) == 0 {
+		delete(h.clients, room)
+		slog.DebugContext(client.Context(), "No clients left, removing the room")
+	}
+}
+
+// hooks returns the hooks to run for a client of the given room. They are
+// about games, so ticket rooms run none
+func (h *Hub) hooks(room room, hooks []func(client *Client)) []func(client *Client) {
+	if room.ticket {
+		return nil
+	}
+	return hooks
+}
+
 // BroadcastToGame sends a JSON message to all clients in a specific game
 func (h *Hub) BroadcastToGame(gameID uuid.UUID, messageType string, payload interface{}) {
+	h.broadcastJSON(gameID, messageType, payload, false)
+}
+
+// BroadcastToTicket sends a JSON message to the clients following a matchmaking ticket
+func (h *Hub) BroadcastToTicket(ticketID uuid.UUID, messageType string, payload interface{}) {
+	jsonData, err := json.Marshal(Message{
+		Type:    messageType,
+		Payload: payload,
+	})
+	if err != nil {
+		slog.Error("Error marshaling message to JSON", "ticket_id", ticketID, "type", messageType, "error", err)
+		return
+	}
+
+	h.BroadcastMessage(&BroadcastMessage{
+		TicketID: ticketID,
+		Message:  jsonData,
+	})
+}
+
+// BroadcastPrivate sends a JSON message to the players of a game, skipping spectators
+func (h *Hub) BroadcastPrivate(gameID uuid.UUID, messageType string, payload interface{}) {
+	h.broadcastJSON(gameID, messageType, payload, true)
+}
+
+func (h *Hub) broadcastJSON(gameID uuid.UUID, messageType string, payload interface{}, private bool) {
 	// Create the message structure
 	message := Message{
 		Type:    messageType,
@@ -117,17 +308,77 @@ func (h *Hub) BroadcastToGame(gameID uuid.UUID, messageType string, payload inte
 	// Marshal to JSON
 	jsonData, err := json.Marshal(message)
 	if err != nil {
-		log.Printf("Error marshaling message to JSON: %v", err)
+		slog.Error("Error marshaling message to JSON", logging.GameID(gameID), "type", messageType, "error", err)
 		return
 	}
 
-	log.Printf("Broadcasting to game %s: %s", gameID, string(jsonData))
+	// Payloads aren't logged, they may hold what players shouldn't see
+	slog.Debug("Broadcasting message", logging.GameID(gameID), "type", messageType, "private", private)
 
 	// Send through the broadcast channel
-	h.broadcast <- &BroadcastMessage{
+	h.BroadcastMessage(&BroadcastMessage{
 		GameID:  gameID,
 		Message: jsonData,
+		Private: private,
+	})
+}
+
+// SendToClient sends a JSON message to a single client
+func (h *Hub) SendToClient(client *Client, messageType string, payload interface{}) {
+	message := Message{
+		Type:    messageType,
+		Payload: payload,
+	}
+	if client.TicketID == uuid.Nil {
+		message.GameID = client.GameID.String()
+	}
+
+	jsonData, err := json.Marshal(message)
+	if err != nil {
+		slog.ErrorContext(client.Context(), "Error marshaling message to JSON", "type", messageType, "error", err)
+		return
 	}
+
+	h.direct <- &directMessage{
+		client:  client,
+		message: jsonData,
+	}
+}
+
+// RegisterCommand sets the handler for the commands of the given type sent by clients
+func (h *Hub) RegisterCommand(messageType string, handler CommandHandler) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.commands[messageType] = handler
+}
+
+// DispatchCommand runs the handler registered for the message type, if any,
+// and reports whether the command was handled
+func (h *Hub) DispatchCommand(client *Client, messageType string, payload json.RawMessage) bool {
+	h.mu.Lock()
+	handler, ok := h.commands[messageType]
+	h.mu.Unlock()
+
+	if !ok {
+		return false
+	}
+
+	handler(client, payload)
+	return true
+}
+
+// OnRegister adds a hook that runs after a client joins a game room
+func (h *Hub) OnRegister(hook func(client *Client)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.onRegister = append(h.onRegister, hook)
+}
+
+// OnUnregister adds a hook that runs after a client leaves a game room
+func (h *Hub) OnUnregister(hook func(client *Client)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.onUnregister = append(h.onUnregister, hook)
 }
 
 // BroadcastEvent sends a simple event message (no payload) to all clients in a game
@@ -140,12 +391,47 @@ func (h *Hub) GetClientsInGame(gameID uuid.UUID) int {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
-	if clients, ok := h.clients[gameID]; ok {
+	if clients, ok := h.clients[room{id: gameID}]; ok {
 		return len(clients)
 	}
 	return 0
 }
 
+// ClientsByGame returns the number of clients connected to each game.
+// Clients following a matchmaking ticket aren't counted
+func (h *Hub) ClientsByGame() map[uuid.UUID]int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	counts := make(map[uuid.UUID]int, len(h.clients))
+	for room, clients := range h.clients {
+		if room.ticket {
+			continue
+		}
+		counts[room.id] = len(clients)
+	}
+	return counts
+}
+
+// GetSpectatorsInGame returns the number of spectators watching a specific game
+func (h *Hub) GetSpectatorsInGame(gameID uuid.UUID) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.countSpectators(room{id: gameID})
+}
+
+// countSpectators counts the spectators of a room. The caller must hold the lock
+func (h *Hub) countSpectators(room room) int {
+	spectators := 0
+	for client := range h.clients[room] {
+		if client.Spectator {
+			spectators++
+		}
+	}
+	return spectators
+}
+
 // RegisterClient registers a client with the hub
 func (h *Hub) RegisterClient(client *Client) {
 	h.Register <- client
@@ -158,5 +444,35 @@ func (h *Hub) UnregisterClient(client *Client) {
 
 // BroadcastMessage sends a message through the broadcast channel
 func (h *Hub) BroadcastMessage(message *BroadcastMessage) {
+	h.pending.Add(1)
+	defer h.pending.Add(-1)
 	h.broadcast <- message
 }
+
+// QueueDepth returns the number of broadcasts waiting for the main loop
+func (h *Hub) QueueDepth() int {
+	return int(h.pending.Load())
+}
+
+// Ping fails when the main loop isn't running or is too busy to answer
+// before ctx is done
+func (h *Hub) Ping(ctx context.Context) error {
+	pong := make(chan struct{})
+	select {
+	case h.ping <- pong:
+	case <-ctx.Done():
+		return fmt.Errorf("hub loop is not running: %w", ctx.Err())
+	}
+	<-pong
+	return nil
+}
+
+// DisconnectPlayer closes the connections of a player to a game with the
+// given close code and reason
+func (h *Hub) DisconnectPlayer(gameID uuid.UUID, playerID uuid.UUID, code int, reason string) {
+	h.disconnect <- &disconnectRequest{
+		gameID:       gameID,
+		playerID:     playerID,
+		closeMessage: websocket.FormatCloseMessage(code, reason),
+	}
+}
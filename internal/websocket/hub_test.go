@@ -0,0 +1,189 @@
+package websocket_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NachoGz/switcher-backend-go/internal/websocket"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func receive(t *testing.T, client *websocket.Client) []byte {
+	t.Helper()
+	select {
+	case msg := <-client.Send:
+		return msg
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for message")
+		return nil
+	}
+}
+
+func TestHub_SpectatorsOnlyReceivePublicMessages(t *testing.T) {
+	hub := websocket.NewHub()
+	go hub.Run()
+
+	gameID := uuid.New()
+	playerClient := &websocket.Client{Server: hub, Send: make(chan []byte, 4), GameID: gameID, PlayerID: uuid.New()}
+	spectatorClient := &websocket.Client{Server: hub, Send: make(chan []byte, 4), GameID: gameID, Spectator: true}
+
+	hub.RegisterClient(playerClient)
+	hub.RegisterClient(spectatorClient)
+
+	assert.Eventually(t, func() bool {
+		return hub.GetClientsInGame(gameID) == 2
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, 1, hub.GetSpectatorsInGame(gameID))
+
+	// Private messages only reach players
+	hub.BroadcastPrivate(gameID, "PRIVATE_EVENT", nil)
+	assert.Contains(t, string(receive(t, playerClient)), "PRIVATE_EVENT")
+
+	// Public messages reach everyone
+	hub.BroadcastEvent(gameID, "PUBLIC_EVENT")
+	assert.Contains(t, string(receive(t, playerClient)), "PUBLIC_EVENT")
+	assert.Contains(t, string(receive(t, spectatorClient)), "PUBLIC_EVENT")
+
+	assert.Empty(t, spectatorClient.Send)
+}
+
+func TestHub_DisconnectPlayer(t *testing.T) {
+	hub := websocket.NewHub()
+	go hub.Run()
+
+	gameID := uuid.New()
+	playerID := uuid.New()
+	kickedClient := &websocket.Client{Server: hub, Send: make(chan []byte, 4), GameID: gameID, PlayerID: playerID}
+	otherClient := &websocket.Client{Server: hub, Send: make(chan []byte, 4), GameID: gameID, PlayerID: uuid.New()}
+
+	hub.RegisterClient(kickedClient)
+	hub.RegisterClient(otherClient)
+
+	assert.Eventually(t, func() bool {
+		return hub.GetClientsInGame(gameID) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	hub.DisconnectPlayer(gameID, playerID, websocket.CLOSE_KICKED, "kicked by the host")
+
+	// The send channel of the kicked player is closed, the rest stay
+	select {
+	case _, ok := <-kickedClient.Send:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the client to be dropped")
+	}
+	assert.Equal(t, 1, hub.GetClientsInGame(gameID))
+
+	hub.BroadcastEvent(gameID, "PUBLIC_EVENT")
+	assert.Contains(t, string(receive(t, otherClient)), "PUBLIC_EVENT")
+}
+
+func TestHub_SpectatorLimit(t *testing.T) {
+	hub := websocket.NewHub()
+	go hub.Run()
+
+	gameID := uuid.New()
+	maxSpectators := 2
+	spectators := make([]*websocket.Client, 5)
+	for i := range spectators {
+		spectators[i] = &websocket.Client{Server: hub, Send: make(chan []byte, 4), GameID: gameID, Spectator: true, MaxSpectators: &maxSpectators}
+	}
+
+	// Connections arriving at once can't get past the limit
+	var wg sync.WaitGroup
+	for _, spectator := range spectators {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			hub.RegisterClient(spectator)
+		}()
+	}
+	wg.Wait()
+	assert.NoError(t, hub.Ping(context.Background()))
+	assert.Equal(t, maxSpectators, hub.GetSpectatorsInGame(gameID))
+
+	rejected := 0
+	for _, spectator := range spectators {
+		select {
+		case _, ok := <-spectator.Send:
+			assert.False(t, ok)
+			rejected++
+		default:
+		}
+	}
+	assert.Equal(t, len(spectators)-maxSpectators, rejected)
+
+	// Players don't count against it
+	player := &websocket.Client{Server: hub, Send: make(chan []byte, 4), GameID: gameID, PlayerID: uuid.New()}
+	hub.RegisterClient(player)
+	assert.NoError(t, hub.Ping(context.Background()))
+	assert.Equal(t, maxSpectators+1, hub.GetClientsInGame(gameID))
+}
+
+func TestHub_DropsSlowClients(t *testing.T) {
+	hub := websocket.NewHub()
+	go hub.Run()
+
+	unregistered := make(chan *websocket.Client, 1)
+	hub.OnUnregister(func(client *websocket.Client) { unregistered <- client })
+
+	// A client whose send buffer is always full
+	gameID := uuid.New()
+	slowClient := &websocket.Client{Server: hub, Send: make(chan []byte), GameID: gameID, PlayerID: uuid.New()}
+	hub.RegisterClient(slowClient)
+
+	hub.BroadcastEvent(gameID, "PUBLIC_EVENT")
+	assert.NoError(t, hub.Ping(context.Background()))
+
+	// It is dropped like any other client leaving
+	_, ok := <-slowClient.Send
+	assert.False(t, ok)
+	assert.Equal(t, slowClient, <-unregistered)
+	assert.Equal(t, 0, hub.GetClientsInGame(gameID))
+	assert.NotContains(t, hub.ClientsByGame(), gameID)
+}
+
+func TestHub_TicketRoomsAreNotGames(t *testing.T) {
+	hub := websocket.NewHub()
+	go hub.Run()
+
+	registered := make(chan *websocket.Client, 4)
+	hub.OnRegister(func(client *websocket.Client) { registered <- client })
+
+	// A game and a ticket that happen to share an ID
+	id := uuid.New()
+	gameClient := &websocket.Client{Server: hub, Send: make(chan []byte, 4), GameID: id, PlayerID: uuid.New()}
+	ticketClient := &websocket.Client{Server: hub, Send: make(chan []byte, 4), TicketID: id, Spectator: true}
+
+	hub.RegisterClient(gameClient)
+	hub.RegisterClient(ticketClient)
+	assert.NoError(t, hub.Ping(context.Background()))
+
+	// Only the game client counts and runs the hooks
+	assert.Equal(t, 1, hub.GetClientsInGame(id))
+	assert.Equal(t, map[uuid.UUID]int{id: 1}, hub.ClientsByGame())
+	assert.Equal(t, gameClient, <-registered)
+	assert.Empty(t, registered)
+
+	hub.BroadcastEvent(id, "GAME_EVENT")
+	hub.BroadcastToTicket(id, "TICKET_EVENT", nil)
+	assert.Contains(t, string(receive(t, gameClient)), "GAME_EVENT")
+	assert.Contains(t, string(receive(t, ticketClient)), "TICKET_EVENT")
+	assert.Empty(t, gameClient.Send)
+	assert.Empty(t, ticketClient.Send)
+}
+
+func TestHub_Ping(t *testing.T) {
+	hub := websocket.NewHub()
+
+	// Nothing answers until the loop runs
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.Error(t, hub.Ping(ctx))
+
+	go hub.Run()
+	assert.NoError(t, hub.Ping(context.Background()))
+}
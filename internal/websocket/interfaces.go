@@ -1,15 +1,25 @@
 package websocket
 
-import "github.com/google/uuid"
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
 
 // WebSocketHub defines methods for broadcasting and managing connections.
 type WebSocketHub interface {
 	BroadcastToGame(gameID uuid.UUID, messageType string, payload interface{})
+	BroadcastPrivate(gameID uuid.UUID, messageType string, payload interface{})
+	BroadcastToTicket(ticketID uuid.UUID, messageType string, payload interface{})
 	BroadcastEvent(gameID uuid.UUID, eventType string)
 	GetClientsInGame(gameID uuid.UUID) int
+	GetSpectatorsInGame(gameID uuid.UUID) int
 	RegisterClient(client *Client)
 	UnregisterClient(client *Client)
 	BroadcastMessage(message *BroadcastMessage)
+	SendToClient(client *Client, messageType string, payload interface{})
+	DispatchCommand(client *Client, messageType string, payload json.RawMessage) bool
+	DisconnectPlayer(gameID uuid.UUID, playerID uuid.UUID, code int, reason string)
 }
 
 // Ensure Hub implements WebSocketHub
@@ -1,6 +1,9 @@
 package websocket_mock
 
 import (
+	"encoding/json"
+	"fmt"
+
 	"github.com/NachoGz/switcher-backend-go/internal/websocket"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
@@ -10,11 +13,31 @@ type MockWebSocketHub struct {
 	mock.Mock
 }
 
+// checkEvent fails the test of any code sending a message that isn't in the
+// catalog, the same way unexpected calls do
+func checkEvent(messageType string) {
+	if !websocket.IsEvent(messageType) {
+		panic(fmt.Sprintf("websocket message type %q is not in the catalog", messageType))
+	}
+}
+
 func (m *MockWebSocketHub) BroadcastToGame(gameID uuid.UUID, messageType string, payload interface{}) {
+	checkEvent(messageType)
 	m.Called(gameID, messageType, payload)
 }
 
+func (m *MockWebSocketHub) BroadcastPrivate(gameID uuid.UUID, messageType string, payload interface{}) {
+	checkEvent(messageType)
+	m.Called(gameID, messageType, payload)
+}
+
+func (m *MockWebSocketHub) BroadcastToTicket(ticketID uuid.UUID, messageType string, payload interface{}) {
+	checkEvent(messageType)
+	m.Called(ticketID, messageType, payload)
+}
+
 func (m *MockWebSocketHub) BroadcastEvent(gameID uuid.UUID, eventType string) {
+	checkEvent(eventType)
 	m.Called(gameID, eventType)
 }
 
@@ -23,6 +46,11 @@ func (m *MockWebSocketHub) GetClientsInGame(gameID uuid.UUID) int {
 	return args.Int(0)
 }
 
+func (m *MockWebSocketHub) GetSpectatorsInGame(gameID uuid.UUID) int {
+	args := m.Called(gameID)
+	return args.Int(0)
+}
+
 func (m *MockWebSocketHub) RegisterClient(client *websocket.Client) {
 	m.Called(client)
 }
@@ -34,3 +62,17 @@ func (m *MockWebSocketHub) UnregisterClient(client *websocket.Client) {
 func (m *MockWebSocketHub) BroadcastMessage(message *websocket.BroadcastMessage) {
 	m.Called(message)
 }
+
+func (m *MockWebSocketHub) SendToClient(client *websocket.Client, messageType string, payload interface{}) {
+	checkEvent(messageType)
+	m.Called(client, messageType, payload)
+}
+
+func (m *MockWebSocketHub) DispatchCommand(client *websocket.Client, messageType string, payload json.RawMessage) bool {
+	args := m.Called(client, messageType, payload)
+	return args.Bool(0)
+}
+
+func (m *MockWebSocketHub) DisconnectPlayer(gameID uuid.UUID, playerID uuid.UUID, code int, reason string) {
+	m.Called(gameID, playerID, code, reason)
+}
@@ -24,3 +24,10 @@ WHERE game_id = $1 AND pos_x = $2 AND pos_y = $3;
 UPDATE boxes
 SET color = $2
 WHERE id = $1;
+
+
+-- name: GetBoxesByGame :many
+SELECT *
+FROM boxes
+WHERE game_id = $1
+ORDER BY pos_y, pos_x;
\ No newline at end of file
@@ -0,0 +1,13 @@
+-- name: CreateChatMessage :one
+INSERT INTO
+	chat_messages (id, game_id, player_id, player_name, content, system)
+VALUES
+	($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetRecentChatMessages :many
+SELECT *
+FROM chat_messages
+WHERE game_id = $1
+ORDER BY created_at DESC
+LIMIT $2;
@@ -1,7 +1,36 @@
 -- name: CreateFigureCard :one
 INSERT INTO
-	figure_cards (id, show, player_id, game_id, type, blocked, soft_blocked, difficulty)
+	figure_cards (id, show, player_id, game_id, type, blocked, soft_blocked, difficulty, position)
 VALUES
-	($1, $2, $3, $4, $5, $6 , $7, $8)
+	($1, $2, $3, $4, $5, $6 , $7, $8, $9)
 RETURNING *;
 
+-- name: GetShownFigureCardsByGame :many
+SELECT *
+FROM figure_cards
+WHERE game_id = $1 AND show = true;
+
+-- name: GetFigureCardsByGame :many
+SELECT *
+FROM figure_cards
+WHERE game_id = $1;
+
+-- name: GetFigureCardsByPlayer :many
+SELECT *
+FROM figure_cards
+WHERE player_id = $1
+ORDER BY position;
+
+-- name: ShowFigureCard :exec
+UPDATE figure_cards
+SET show = true
+WHERE id = $1;
+
+-- name: DeleteFigureCard :exec
+DELETE FROM figure_cards
+WHERE id = $1;
+
+-- name: SetFigureCardBlocked :exec
+UPDATE figure_cards
+SET blocked = $2
+WHERE id = $1;
@@ -0,0 +1,10 @@
+-- name: CreateGameBan :exec
+INSERT INTO game_bans (id, game_id, name, user_id)
+VALUES ($1, $2, $3, $4);
+
+-- name: IsPlayerBanned :one
+SELECT EXISTS (
+	SELECT 1
+	FROM game_bans
+	WHERE game_id = $1 AND (LOWER(name) = LOWER($2) OR user_id = $3)
+);
@@ -0,0 +1,31 @@
+-- name: CreateGameEvent :one
+WITH
+	next_seq AS (
+		INSERT INTO
+			game_event_counters (game_id, seq)
+		VALUES
+			($2, 1)
+		ON CONFLICT (game_id) DO UPDATE
+		SET
+			seq = game_event_counters.seq + 1
+		RETURNING
+			seq
+	)
+INSERT INTO
+	game_events (id, game_id, seq, type, player_id, payload)
+SELECT
+	$1,
+	$2,
+	next_seq.seq,
+	$3,
+	$4,
+	$5
+FROM
+	next_seq
+RETURNING *;
+
+-- name: GetGameEvents :many
+SELECT *
+FROM game_events
+WHERE game_id = $1
+ORDER BY seq;
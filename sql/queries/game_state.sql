@@ -8,6 +8,11 @@ UPDATE game_state
 SET state=$2
 WHERE game_id=$1;
 
+-- name: TransitionGameState :execrows
+UPDATE game_state
+SET state=sqlc.arg('to_state')
+WHERE game_id=sqlc.arg('game_id') AND state=sqlc.arg('from_state');
+
 -- name: UpdateCurrentPlayer :exec
 UPDATE game_state
 SET current_player_id=$2
@@ -16,4 +21,14 @@ WHERE game_id=$1;
 -- name: GetGameStateByGameID :one
 SELECT *
 FROM game_state
-WHERE game_id=$1;
\ No newline at end of file
+WHERE game_id=$1;
+
+-- name: UpdateForbiddenColor :exec
+UPDATE game_state
+SET forbidden_color=$2
+WHERE game_id=$1;
+
+-- name: CountGamesByState :many
+SELECT state, COUNT(*) AS games
+FROM game_state
+GROUP BY state;
@@ -1,13 +1,59 @@
 -- name: CreateGame :one
-INSERT INTO games (id, name, max_players, min_players, is_private, password)
-VALUES ($1, $2, $3, $4, $5, $6)
+INSERT INTO games (id, name, max_players, min_players, is_private, password, max_spectators, seed, rules)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 RETURNING *;
 
--- name: GetAvailableGames :many
-SELECT games.*
-FROM games
-JOIN game_state ON games.id = game_state.game_id
-WHERE game_state.state = 'waiting';
+-- name: SearchGames :many
+WITH lobby AS (
+	SELECT games.*, COUNT(players.id)::INTEGER AS players_count
+	FROM games
+	JOIN game_state ON games.id = game_state.game_id
+	LEFT JOIN players ON games.id = players.game_id
+	WHERE game_state.state = 'waiting'
+	GROUP BY games.id
+)
+SELECT *
+FROM lobby
+WHERE (sqlc.narg('name')::TEXT IS NULL OR name ILIKE '%' || sqlc.narg('name')::TEXT || '%')
+	AND (sqlc.narg('is_private')::BOOLEAN IS NULL OR is_private = sqlc.narg('is_private')::BOOLEAN)
+	AND (sqlc.narg('players_count')::INTEGER IS NULL OR players_count = sqlc.narg('players_count')::INTEGER)
+	AND max_players - players_count >= sqlc.arg('min_free_slots')::INTEGER
+	AND (sqlc.narg('min_players')::INTEGER IS NULL OR min_players >= sqlc.narg('min_players')::INTEGER)
+	AND (sqlc.narg('max_players')::INTEGER IS NULL OR max_players <= sqlc.narg('max_players')::INTEGER)
+	AND (sqlc.narg('cursor_id')::UUID IS NULL OR CASE sqlc.arg('sort')::TEXT
+		WHEN 'oldest' THEN (created_at, id) > (sqlc.narg('cursor_time')::TIMESTAMP, sqlc.narg('cursor_id')::UUID)
+		WHEN 'name' THEN (LOWER(name), id) > (sqlc.narg('cursor_name')::TEXT, sqlc.narg('cursor_id')::UUID)
+		WHEN 'players' THEN (players_count, id) < (sqlc.narg('cursor_count')::INTEGER, sqlc.narg('cursor_id')::UUID)
+		WHEN 'free_slots' THEN (max_players - players_count, id) < (sqlc.narg('cursor_count')::INTEGER, sqlc.narg('cursor_id')::UUID)
+		ELSE (created_at, id) < (sqlc.narg('cursor_time')::TIMESTAMP, sqlc.narg('cursor_id')::UUID)
+	END)
+ORDER BY
+	CASE WHEN sqlc.arg('sort')::TEXT = 'oldest' THEN created_at END ASC,
+	CASE WHEN sqlc.arg('sort')::TEXT = 'name' THEN LOWER(name) END ASC,
+	CASE WHEN sqlc.arg('sort')::TEXT = 'players' THEN players_count END DESC,
+	CASE WHEN sqlc.arg('sort')::TEXT = 'free_slots' THEN max_players - players_count END DESC,
+	CASE WHEN sqlc.arg('sort')::TEXT = 'newest' THEN created_at END DESC,
+	CASE WHEN sqlc.arg('sort')::TEXT IN ('oldest', 'name') THEN id END ASC,
+	CASE WHEN sqlc.arg('sort')::TEXT NOT IN ('oldest', 'name') THEN id END DESC
+LIMIT sqlc.arg('page_size') OFFSET sqlc.arg('page_offset');
+
+-- name: CountSearchGames :one
+WITH lobby AS (
+	SELECT games.*, COUNT(players.id)::INTEGER AS players_count
+	FROM games
+	JOIN game_state ON games.id = game_state.game_id
+	LEFT JOIN players ON games.id = players.game_id
+	WHERE game_state.state = 'waiting'
+	GROUP BY games.id
+)
+SELECT COUNT(*)
+FROM lobby
+WHERE (sqlc.narg('name')::TEXT IS NULL OR name ILIKE '%' || sqlc.narg('name')::TEXT || '%')
+	AND (sqlc.narg('is_private')::BOOLEAN IS NULL OR is_private = sqlc.narg('is_private')::BOOLEAN)
+	AND (sqlc.narg('players_count')::INTEGER IS NULL OR players_count = sqlc.narg('players_count')::INTEGER)
+	AND max_players - players_count >= sqlc.arg('min_free_slots')::INTEGER
+	AND (sqlc.narg('min_players')::INTEGER IS NULL OR min_players >= sqlc.narg('min_players')::INTEGER)
+	AND (sqlc.narg('max_players')::INTEGER IS NULL OR max_players <= sqlc.narg('max_players')::INTEGER);
 
 -- name: GetGameById :one
 SELECT *
@@ -17,4 +63,36 @@ WHERE id = $1;
 -- name: DeleteGame :exec
 DELETE 
 FROM games 
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1;
+-- name: GetGameByJoinCode :one
+SELECT *
+FROM games
+WHERE join_code = $1;
+
+-- name: SetGameJoinCode :exec
+UPDATE games
+SET join_code = $2, updated_at = NOW()
+WHERE id = $1;
+
+-- name: TouchGame :exec
+UPDATE games
+SET last_activity_at = $2
+WHERE id = $1;
+
+-- name: ListInactiveGames :many
+-- Moves, chat messages and players joining count as activity, along with
+-- what was recorded with TouchGame
+SELECT id, state, last_activity_at
+FROM (
+	SELECT games.id, game_state.state, GREATEST(
+		games.last_activity_at,
+		(SELECT MAX(created_at) FROM game_events WHERE game_events.game_id = games.id),
+		(SELECT MAX(created_at) FROM chat_messages WHERE chat_messages.game_id = games.id),
+		(SELECT MAX(created_at) FROM players WHERE players.game_id = games.id)
+	)::TIMESTAMP AS last_activity_at
+	FROM games
+	JOIN game_state ON games.id = game_state.game_id
+	WHERE games.last_activity_at < $1
+) AS activity
+WHERE last_activity_at < $1
+ORDER BY last_activity_at;
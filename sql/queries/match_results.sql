@@ -0,0 +1,63 @@
+-- name: CreateMatchResult :one
+INSERT INTO match_results (id, game_id, game_name, player_count, winner_name, turns, duration_seconds, started_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: CreateMatchParticipant :exec
+INSERT INTO match_participants (id, match_id, player_id, user_id, name, bot, winner, figures_completed, blocks)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+
+-- name: GetPlayerStatsByUser :one
+SELECT
+	COUNT(*) AS games,
+	COUNT(*) FILTER (WHERE p.winner) AS wins,
+	COALESCE(SUM(p.figures_completed), 0)::INTEGER AS figures_completed,
+	COALESCE(SUM(p.blocks), 0)::INTEGER AS blocks,
+	COALESCE(SUM(m.turns), 0)::INTEGER AS turns,
+	COALESCE(AVG(m.duration_seconds), 0)::FLOAT AS avg_duration_seconds
+FROM match_participants p
+JOIN match_results m ON m.id = p.match_id
+WHERE p.user_id = $1;
+
+-- name: GetPlayerStatsByName :one
+SELECT
+	COUNT(*) AS games,
+	COUNT(*) FILTER (WHERE p.winner) AS wins,
+	COALESCE(SUM(p.figures_completed), 0)::INTEGER AS figures_completed,
+	COALESCE(SUM(p.blocks), 0)::INTEGER AS blocks,
+	COALESCE(SUM(m.turns), 0)::INTEGER AS turns,
+	COALESCE(AVG(m.duration_seconds), 0)::FLOAT AS avg_duration_seconds
+FROM match_participants p
+JOIN match_results m ON m.id = p.match_id
+WHERE LOWER(p.name) = LOWER($1);
+
+-- name: GetMatchHistoryByUser :many
+SELECT m.game_id, m.game_name, m.player_count, m.winner_name, m.turns, m.duration_seconds, m.finished_at,
+	p.winner, p.figures_completed, p.blocks
+FROM match_participants p
+JOIN match_results m ON m.id = p.match_id
+WHERE p.user_id = $1
+ORDER BY m.finished_at DESC
+LIMIT $2;
+
+-- name: GetMatchHistoryByName :many
+SELECT m.game_id, m.game_name, m.player_count, m.winner_name, m.turns, m.duration_seconds, m.finished_at,
+	p.winner, p.figures_completed, p.blocks
+FROM match_participants p
+JOIN match_results m ON m.id = p.match_id
+WHERE LOWER(p.name) = LOWER($1)
+ORDER BY m.finished_at DESC
+LIMIT $2;
+
+-- name: GetLeaderboard :many
+SELECT
+	u.id AS user_id,
+	u.username,
+	COUNT(*) AS games,
+	COUNT(*) FILTER (WHERE p.winner) AS wins,
+	COALESCE(SUM(p.figures_completed), 0)::INTEGER AS figures_completed
+FROM match_participants p
+JOIN users u ON u.id = p.user_id
+GROUP BY u.id, u.username
+ORDER BY wins DESC, games ASC, u.username
+LIMIT $1;
@@ -24,7 +24,8 @@ RETURNING *;
 -- name: GetMovementCardDeck :many
 SELECT *
 FROM movement_cards
-WHERE game_id = $1 AND player_id IS NULL;
+WHERE game_id = $1 AND player_id IS NULL
+ORDER BY position;
 
 -- name: AssignMovementCard :exec
 UPDATE movement_cards
@@ -34,4 +35,30 @@ WHERE id=$1;
 -- name: MarkCardInPlayerHand :exec
 UPDATE movement_cards
 SET used = false
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1;
+
+-- name: GetMovementCardsByGame :many
+SELECT *
+FROM movement_cards
+WHERE game_id = $1
+ORDER BY position;
+
+-- name: GetMovementCardsByPlayer :many
+SELECT *
+FROM movement_cards
+WHERE player_id = $1
+ORDER BY position;
+
+-- name: MarkCardUsed :exec
+UPDATE movement_cards
+SET used = true
+WHERE id = $1;
+
+-- name: DiscardMovementCard :exec
+UPDATE movement_cards
+SET player_id = NULL, used = false, position = (
+	SELECT COALESCE(MAX(position), 0) + 1
+	FROM movement_cards
+	WHERE game_id = $2
+)
+WHERE id = $1;
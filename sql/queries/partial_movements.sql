@@ -18,7 +18,8 @@ WHERE id in (SELECT id from last_movement);
 -- name: GetPartialMovementsByPlayer :many
 SELECT * 
 FROM partial_movements
-WHERE game_id = $1 AND player_id = $2;
+WHERE game_id = $1 AND player_id = $2
+ORDER BY created_at DESC;
 
 -- name: UndoMovementByID :exec
 DELETE FROM partial_movements
@@ -1,6 +1,6 @@
 -- name: CreatePlayer :one
-INSERT INTO players (id, name, turn, game_id, game_state_id, host)
-VALUES ($1, $2, $3, $4, $5, $6)
+INSERT INTO players (id, name, turn, game_id, game_state_id, host, bot, bot_level, user_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 RETURNING *;
 
 -- name: CountPlayers :one
@@ -9,7 +9,8 @@ SELECT COUNT(*) FROM players WHERE game_id = $1;
 -- name: GetPlayersInGame :many
 SELECT *
 FROM players
-WHERE game_id=$1;
+WHERE game_id=$1
+ORDER BY created_at, id;
 
 -- name: AssignTurnPlayer :exec
 UPDATE players
@@ -24,4 +25,19 @@ WHERE game_id=$1 AND id=$2;
 -- name: GetWinner :one
 SELECT *
 FROM players
-WHERE game_id = $1 AND winner = true limit 1;
\ No newline at end of file
+WHERE game_id = $1 AND winner = true limit 1;
+
+-- name: SetWinner :exec
+UPDATE players
+SET winner = true, updated_at = NOW()
+WHERE id = $1;
+
+-- name: SetPlayerReady :exec
+UPDATE players
+SET ready = $2, updated_at = NOW()
+WHERE id = $1;
+
+-- name: DeletePlayer :exec
+DELETE
+FROM players
+WHERE id = $1 AND game_id = $2;
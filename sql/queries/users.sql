@@ -0,0 +1,37 @@
+-- name: CreateUser :one
+INSERT INTO users (id, username, password_hash)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetUserByID :one
+SELECT *
+FROM users
+WHERE id = $1;
+
+-- name: GetUserByUsername :one
+SELECT *
+FROM users
+WHERE LOWER(username) = LOWER($1);
+
+-- name: CreateSession :one
+INSERT INTO sessions (id, user_id, token_hash, expires_at)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetSessionByTokenHash :one
+SELECT *
+FROM sessions
+WHERE token_hash = $1 AND expires_at > NOW();
+
+-- name: DeleteSession :exec
+DELETE FROM sessions
+WHERE token_hash = $1;
+
+-- name: DeleteExpiredSessions :execrows
+DELETE FROM sessions
+WHERE expires_at <= NOW();
+
+-- name: UpdateUserRating :exec
+UPDATE users
+SET rating = rating + sqlc.arg('delta'), rated_games = rated_games + 1, updated_at = NOW()
+WHERE id = sqlc.arg('id');
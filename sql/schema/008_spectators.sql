@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE games
+ADD COLUMN max_spectators INT DEFAULT NULL;
+
+-- +goose Down
+ALTER TABLE games
+DROP COLUMN IF EXISTS max_spectators;
@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE
+	chat_messages (
+		id UUID PRIMARY KEY,
+		game_id UUID references games (id) ON DELETE CASCADE NOT NULL,
+		player_id UUID references players (id) ON DELETE SET NULL,
+		player_name VARCHAR(255) NOT NULL,
+		content TEXT NOT NULL,
+		system BOOLEAN NOT NULL DEFAULT FALSE,
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);
+
+CREATE INDEX chat_messages_game_id_created_at_idx ON chat_messages (game_id, created_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS chat_messages;
@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE
+	game_events (
+		id UUID PRIMARY KEY,
+		game_id UUID references games (id) ON DELETE CASCADE NOT NULL,
+		seq INTEGER NOT NULL,
+		type VARCHAR(64) NOT NULL,
+		player_id UUID DEFAULT NULL,
+		payload JSONB NOT NULL DEFAULT '{}',
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE (game_id, seq)
+	);
+
+-- +goose Down
+DROP TABLE IF EXISTS game_events;
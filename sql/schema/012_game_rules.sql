@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE games
+ADD COLUMN rules JSONB NOT NULL DEFAULT '{}';
+
+-- +goose Down
+ALTER TABLE games
+DROP COLUMN IF EXISTS rules;
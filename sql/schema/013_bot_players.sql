@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE players
+ADD COLUMN bot BOOLEAN NOT NULL DEFAULT FALSE,
+ADD COLUMN bot_level VARCHAR(16) DEFAULT NULL;
+
+-- +goose Down
+ALTER TABLE players
+DROP COLUMN IF EXISTS bot_level,
+DROP COLUMN IF EXISTS bot;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE figure_cards
+ADD COLUMN position INTEGER;
+
+-- +goose Down
+ALTER TABLE figure_cards
+DROP COLUMN IF EXISTS position;
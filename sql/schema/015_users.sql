@@ -0,0 +1,29 @@
+-- +goose Up
+CREATE TABLE
+	users (
+		id UUID PRIMARY KEY,
+		username VARCHAR(32) NOT NULL UNIQUE,
+		password_hash TEXT NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);
+
+CREATE TABLE
+	sessions (
+		id UUID PRIMARY KEY,
+		user_id UUID references users (id) ON DELETE CASCADE NOT NULL,
+		token_hash VARCHAR(64) NOT NULL UNIQUE,
+		expires_at TIMESTAMP NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);
+
+ALTER TABLE players
+ADD COLUMN user_id UUID references users (id) ON DELETE SET NULL DEFAULT NULL;
+
+-- +goose Down
+ALTER TABLE players
+DROP COLUMN IF EXISTS user_id;
+
+DROP TABLE IF EXISTS sessions;
+
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,36 @@
+-- +goose Up
+-- Results outlive their games, so they don't reference the games table
+CREATE TABLE
+	match_results (
+		id UUID PRIMARY KEY,
+		game_id UUID NOT NULL UNIQUE,
+		game_name VARCHAR(255) NOT NULL,
+		player_count INTEGER NOT NULL,
+		winner_name VARCHAR(255) NOT NULL,
+		turns INTEGER NOT NULL,
+		duration_seconds INTEGER NOT NULL,
+		started_at TIMESTAMP NOT NULL,
+		finished_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);
+
+CREATE TABLE
+	match_participants (
+		id UUID PRIMARY KEY,
+		match_id UUID references match_results (id) ON DELETE CASCADE NOT NULL,
+		player_id UUID NOT NULL,
+		user_id UUID references users (id) ON DELETE SET NULL DEFAULT NULL,
+		name VARCHAR(255) NOT NULL,
+		bot BOOLEAN NOT NULL DEFAULT FALSE,
+		winner BOOLEAN NOT NULL DEFAULT FALSE,
+		figures_completed INTEGER NOT NULL DEFAULT 0,
+		blocks INTEGER NOT NULL DEFAULT 0
+	);
+
+CREATE INDEX match_participants_user_id_idx ON match_participants (user_id);
+
+CREATE INDEX match_participants_name_idx ON match_participants (LOWER(name));
+
+-- +goose Down
+DROP TABLE IF EXISTS match_participants;
+
+DROP TABLE IF EXISTS match_results;
@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE users
+ADD COLUMN rating INTEGER NOT NULL DEFAULT 1500,
+ADD COLUMN rated_games INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE users
+DROP COLUMN IF EXISTS rated_games,
+DROP COLUMN IF EXISTS rating;
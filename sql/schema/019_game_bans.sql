@@ -0,0 +1,14 @@
+-- +goose Up
+CREATE TABLE
+	game_bans (
+		id UUID PRIMARY KEY,
+		game_id UUID references games (id) ON DELETE CASCADE NOT NULL,
+		name VARCHAR(255) NOT NULL,
+		user_id UUID references users (id) ON DELETE CASCADE DEFAULT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);
+
+CREATE INDEX game_bans_game_id_idx ON game_bans (game_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS game_bans;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE games
+ADD COLUMN join_code VARCHAR(8) UNIQUE DEFAULT NULL;
+
+-- +goose Down
+ALTER TABLE games
+DROP COLUMN IF EXISTS join_code;
@@ -0,0 +1,17 @@
+-- +goose Up
+CREATE INDEX players_game_id_idx ON players (game_id);
+
+CREATE INDEX game_state_state_idx ON game_state (state);
+
+CREATE INDEX games_created_at_id_idx ON games (created_at, id);
+
+CREATE INDEX games_name_id_idx ON games (LOWER(name), id);
+
+-- +goose Down
+DROP INDEX IF EXISTS games_name_id_idx;
+
+DROP INDEX IF EXISTS games_created_at_id_idx;
+
+DROP INDEX IF EXISTS game_state_state_idx;
+
+DROP INDEX IF EXISTS players_game_id_idx;
@@ -0,0 +1,11 @@
+-- +goose Up
+ALTER TABLE games
+ADD COLUMN last_activity_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+CREATE INDEX games_last_activity_at_idx ON games (last_activity_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS games_last_activity_at_idx;
+
+ALTER TABLE games
+DROP COLUMN IF EXISTS last_activity_at;
@@ -0,0 +1,21 @@
+-- +goose Up
+-- Last seq given to the events of each game. Taking the next one locks the
+-- row, so events recorded at the same time get consecutive numbers
+CREATE TABLE
+	game_event_counters (
+		game_id UUID PRIMARY KEY references games (id) ON DELETE CASCADE,
+		seq INTEGER NOT NULL
+	);
+
+INSERT INTO
+	game_event_counters (game_id, seq)
+SELECT
+	game_id,
+	MAX(seq)
+FROM
+	game_events
+GROUP BY
+	game_id;
+
+-- +goose Down
+DROP TABLE IF EXISTS game_event_counters;
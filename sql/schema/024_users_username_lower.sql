@@ -0,0 +1,14 @@
+-- +goose Up
+-- Usernames are looked up ignoring case, so they have to be unique ignoring
+-- case too. Otherwise "Alice" and "alice" could both sign up and only one of
+-- them could ever log in
+ALTER TABLE users
+DROP CONSTRAINT IF EXISTS users_username_key;
+
+CREATE UNIQUE INDEX users_username_lower_idx ON users (LOWER(username));
+
+-- +goose Down
+DROP INDEX IF EXISTS users_username_lower_idx;
+
+ALTER TABLE users
+ADD CONSTRAINT users_username_key UNIQUE (username);
@@ -0,0 +1,8 @@
+// Package schema embeds the goose migrations, so the server can tell
+// whether the database is up to date
+package schema
+
+import "embed"
+
+//go:embed *.sql
+var Migrations embed.FS